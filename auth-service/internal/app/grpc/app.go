@@ -3,6 +3,7 @@ package grpcapp
 import (
 	"auth-service/internal/config"
 	authgrpc "auth-service/internal/web/grpc/auth"
+	rolegrpc "auth-service/internal/web/grpc/role"
 	"auth-service/internal/web/middleware"
 	"fmt"
 	"log/slog"
@@ -20,13 +21,14 @@ type App struct {
 }
 
 func NewApp(log *slog.Logger, authService authgrpc.AuthService, userService authgrpc.UserService, tokenService authgrpc.TokenService,
-	cfg *config.Config, validate *validator.Validate, interceptor *middleware.AuthInterceptor) *App {
+	roleService rolegrpc.RoleService, cfg *config.Config, validate *validator.Validate, interceptor *middleware.AuthInterceptor) *App {
 	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
 		recovery.UnaryServerInterceptor(),
 		interceptor.UnaryInterceptor(),
 	),
 	)
 	authgrpc.Register(gRPCServer, authService, userService, tokenService, validate)
+	rolegrpc.Register(gRPCServer, roleService)
 	return &App{
 		log:        log,
 		gRPCServer: gRPCServer,
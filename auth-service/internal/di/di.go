@@ -9,8 +9,10 @@ import (
 	"auth-service/internal/storage/sqlite"
 	appstorage "auth-service/internal/storage/sqlite/app"
 	jwtstorage "auth-service/internal/storage/sqlite/jwt"
+	rolestorage "auth-service/internal/storage/sqlite/role"
 	userstorage "auth-service/internal/storage/sqlite/user"
 	web "auth-service/internal/web/grpc/auth"
+	rolegrpc "auth-service/internal/web/grpc/role"
 	"auth-service/internal/web/middleware"
 	"database/sql"
 	"log/slog"
@@ -52,6 +54,7 @@ var Module = fx.Options(
 		fx.Annotate(appstorage.NewStorage, fx.As(new(auth.AppStorage))),
 		fx.Annotate(userstorage.NewStorage, fx.As(new(auth.UserStorage))),
 		fx.Annotate(jwtstorage.NewStorage, fx.As(new(auth.JWTStorage))),
+		fx.Annotate(rolestorage.NewStorage, fx.As(new(auth.RoleStorage))),
 
 		fx.Annotate(jwt.NewJWTProvider, fx.As(new(auth.JWTProvider))),
 
@@ -62,6 +65,7 @@ var Module = fx.Options(
 		fx.Annotate(auth.NewAuth, fx.As(new(web.AuthService))),
 		fx.Annotate(auth.NewAuth, fx.As(new(web.TokenService))),
 		fx.Annotate(auth.NewAuth, fx.As(new(web.UserService))),
+		fx.Annotate(auth.NewAuth, fx.As(new(rolegrpc.RoleService))),
 		grpcapp.NewApp,
 	),
 	fx.Invoke(func(lc fx.Lifecycle, storage *sql.DB, log *slog.Logger) {
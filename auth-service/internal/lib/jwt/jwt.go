@@ -3,6 +3,7 @@ package jwt
 import (
 	"auth-service/internal/config"
 	"auth-service/internal/model"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -27,10 +28,11 @@ func NewJWTProvider(cfg *config.Config) *iwtProvider {
 	}
 }
 
-func (p *iwtProvider) generateToken(user, app uuid.UUID, duration time.Duration) (string, error) {
+func (p *iwtProvider) generateToken(user, app uuid.UUID, roles []string, duration time.Duration) (string, error) {
 	claims := model.CustomClaims{
 		UserID: user,
 		AppID:  app,
+		Roles:  roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    Issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -43,23 +45,23 @@ func (p *iwtProvider) generateToken(user, app uuid.UUID, duration time.Duration)
 	return token.SignedString(p.secretKey)
 }
 
-func (p *iwtProvider) NewAccessToken(user, app uuid.UUID) (string, error) {
-	return p.generateToken(user, app, AccessTokenTTL)
+func (p *iwtProvider) NewAccessToken(user, app uuid.UUID, roles []string) (string, error) {
+	return p.generateToken(user, app, roles, AccessTokenTTL)
 }
 
 func (p *iwtProvider) NewRefreshToken(user, app uuid.UUID) (string, error) {
-	return p.generateToken(user, app, RefreshTokenTTL)
+	return p.generateToken(user, app, nil, RefreshTokenTTL)
 }
 
 func (p *iwtProvider) ValidateToken(tokenString string) (*model.CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &model.CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
 		return p.secretKey, nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidToken, err)
 	}
 	claims, ok := token.Claims.(*model.CustomClaims)
 	if !ok || !token.Valid {
@@ -26,7 +26,31 @@ func TestJWTProvider(t *testing.T) {
 		ID:   uuid.New(),
 		Name: "Test App",
 	}
-	accessToken, err := provider.NewAccessToken(user.ID, app.ID)
+	accessToken, err := provider.NewAccessToken(user.ID, app.ID, []string{model.RoleCustomer})
 	require.NoError(t, err)
 	require.NotEmpty(t, accessToken)
 }
+
+func TestJWTProvider_ValidateToken(t *testing.T) {
+	cfg := config.Config{
+		JWTSecretKey: "my_secret_key",
+	}
+	provider := jwt.NewJWTProvider(&cfg)
+	userID := uuid.New()
+	appID := uuid.New()
+
+	roles := []string{model.RoleManager, model.RoleCashier}
+
+	accessToken, err := provider.NewAccessToken(userID, appID, roles)
+	require.NoError(t, err)
+
+	claims, err := provider.ValidateToken(accessToken)
+	require.NoError(t, err)
+	require.Equal(t, userID, claims.UserID)
+	require.Equal(t, appID, claims.AppID)
+	require.Equal(t, roles, claims.Roles)
+
+	other := jwt.NewJWTProvider(&config.Config{JWTSecretKey: "other_key"})
+	_, err = other.ValidateToken(accessToken)
+	require.ErrorIs(t, err, model.ErrInvalidToken)
+}
@@ -10,4 +10,6 @@ var (
 	ErrAppNotFound  = errors.New("app not found")
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrInvalidToken = errors.New("invalid token")
+	ErrRoleNotFound = errors.New("role not found")
+	ErrPermissionDenied = errors.New("permission denied")
 )
@@ -18,5 +18,6 @@ type Session struct {
 type CustomClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	AppID  uuid.UUID `json:"add_id"`
+	Roles  []string  `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,23 @@
+package model
+
+const (
+	RoleAdmin    = "admin"
+	RoleManager  = "manager"
+	RoleCashier  = "cashier"
+	RoleCustomer = "customer"
+)
+
+// DefaultRole назначается каждому новому пользователю при регистрации.
+const DefaultRole = RoleCustomer
+
+const (
+	PermProductWrite   = "product:write"
+	PermPriceUpdate    = "product:price:update"
+	PermStockUpdate    = "product:stock:update"
+	PermCategoryWrite  = "category:write"
+	PermCategoryDelete = "category:delete"
+	PermSupplierWrite  = "supplier:write"
+	PermClientWrite    = "client:write"
+	PermClientDelete   = "client:delete"
+	PermRoleAssign     = "role:assign"
+)
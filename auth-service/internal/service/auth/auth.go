@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,11 +21,12 @@ type auth struct {
 	storageU    UserStorage
 	storageA    AppStorage
 	storageJ    JWTStorage
+	storageR    RoleStorage
 	jwtProvider JWTProvider
 }
 
 type UserStorage interface {
-	SaveUser(ctx context.Context, user model.Users) error
+	SaveUser(ctx context.Context, user model.Users, role string) error
 	GetUserByEmail(ctx context.Context, email string) (model.Users, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (model.Users, error)
 	UpdateUserPassword(ctx context.Context, email string, newPassword []byte) error
@@ -40,18 +42,25 @@ type JWTStorage interface {
 	UpdateSession(ctx context.Context, oldToken, newToken string, duration time.Time) error
 }
 
+type RoleStorage interface {
+	AssignRole(ctx context.Context, userID uuid.UUID, role string) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
 type JWTProvider interface {
-	NewAccessToken(user, app uuid.UUID) (string, error)
+	NewAccessToken(user, app uuid.UUID, roles []string) (string, error)
 	NewRefreshToken(user, app uuid.UUID) (string, error)
 	ValidateToken(tokenString string) (*model.CustomClaims, error)
 }
 
-func NewAuth(log *slog.Logger, storageU UserStorage, storageA AppStorage, storageJ JWTStorage, jwtProvider JWTProvider) *auth {
+func NewAuth(log *slog.Logger, storageU UserStorage, storageA AppStorage, storageJ JWTStorage, storageR RoleStorage, jwtProvider JWTProvider) *auth {
 	return &auth{
 		log:         log,
 		storageU:    storageU,
 		storageA:    storageA,
 		storageJ:    storageJ,
+		storageR:    storageR,
 		jwtProvider: jwtProvider,
 	}
 }
@@ -84,7 +93,13 @@ func (a *auth) Login(ctx context.Context, email, password, appID string) (access
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	accessToken, err = a.jwtProvider.NewAccessToken(user.ID, app.ID)
+	roles, err := a.storageR.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		a.log.Error("failed to get user roles", sl.Err(err))
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	accessToken, err = a.jwtProvider.NewAccessToken(user.ID, app.ID, roles)
 	if err != nil {
 		a.log.Error("failed to generate access token", sl.Err(err))
 		return "", "", fmt.Errorf("%s: %w", op, err)
@@ -127,7 +142,7 @@ func (a *auth) RegisterNewUser(ctx context.Context, email, password, name, surna
 		PhoneNumber: phoneNumber,
 		Password:    passHash,
 	}
-	err = a.storageU.SaveUser(ctx, user)
+	err = a.storageU.SaveUser(ctx, user, model.DefaultRole)
 	if err != nil {
 		log.Error("Failed to save user", slog.Any("error", err))
 		return "", err
@@ -136,9 +151,79 @@ func (a *auth) RegisterNewUser(ctx context.Context, email, password, name, surna
 }
 
 func (a *auth) IsAdmin(ctx context.Context, userID uuid.UUID) (isAdmin bool, err error) {
+	return a.HasRole(ctx, userID, model.RoleAdmin)
+}
+
+func (a *auth) HasRole(ctx context.Context, userID uuid.UUID, role string) (bool, error) {
+	const op = "Auth.HasRole"
+	log := a.log.With(slog.String("operation", op), slog.String("userID", userID.String()), slog.String("role", role))
+
+	if _, err := a.storageU.GetUserByID(ctx, userID); err != nil {
+		log.Info("user not found", sl.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	roles, err := a.storageR.GetUserRoles(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user roles", sl.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, r := range roles {
+		if r == role {
+			return true, nil
+		}
+	}
 	return false, nil
 }
 
+func (a *auth) GetRoles(ctx context.Context, userID uuid.UUID) (roles, permissions []string, err error) {
+	const op = "Auth.GetRoles"
+	log := a.log.With(slog.String("operation", op), slog.String("userID", userID.String()))
+
+	if _, err := a.storageU.GetUserByID(ctx, userID); err != nil {
+		log.Info("user not found", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	roles, err = a.storageR.GetUserRoles(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user roles", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	permissions, err = a.storageR.GetUserPermissions(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user permissions", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return roles, permissions, nil
+}
+
+// AssignRole назначает пользователю роль. Назначать роли может только
+// пользователь с правом role:assign, по умолчанию — администратор.
+func (a *auth) AssignRole(ctx context.Context, actorID, userID uuid.UUID, role string) error {
+	const op = "Auth.AssignRole"
+	log := a.log.With(slog.String("operation", op), slog.String("actorID", actorID.String()),
+		slog.String("userID", userID.String()), slog.String("role", role))
+
+	permissions, err := a.storageR.GetUserPermissions(ctx, actorID)
+	if err != nil {
+		log.Error("failed to get actor permissions", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !slices.Contains(permissions, model.PermRoleAssign) {
+		log.Warn("role assignment denied")
+		return fmt.Errorf("%s: %w", op, model.ErrPermissionDenied)
+	}
+	if _, err := a.storageU.GetUserByID(ctx, userID); err != nil {
+		log.Info("user not found", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := a.storageR.AssignRole(ctx, userID, role); err != nil {
+		log.Error("failed to assign role", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("Role assigned")
+	return nil
+}
+
 func (a *auth) ChangePassword(ctx context.Context, email, oldPassword, newPassword string) error {
 	const op = "Auth.ChangePassword"
 	log := a.log.With(slog.String("operation", op), slog.String("email", email))
@@ -193,8 +278,13 @@ func (a *auth) RefreshToken(ctx context.Context, refreshToken, appId string) (st
 		log.Info("Session does not belong to the specified app", slog.String("appID", appId))
 		return "", "", fmt.Errorf("%s: %w", op, model.ErrInvalidCredentials)
 	}
+	roles, err := a.storageR.GetUserRoles(ctx, sessionHash.UserID)
+	if err != nil {
+		log.Error("Failed to get user roles", slog.Any("error", err))
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
 	// Генерируем новый access и refresh токены
-	newAccessToken, err := a.jwtProvider.NewAccessToken(sessionHash.UserID, sessionHash.AppID)
+	newAccessToken, err := a.jwtProvider.NewAccessToken(sessionHash.UserID, sessionHash.AppID, roles)
 	if err != nil {
 		log.Error("Failed to generate new access token", slog.Any("error", err))
 		return "", "", fmt.Errorf("%s: %w", op, err)
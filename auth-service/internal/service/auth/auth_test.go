@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"auth-service/internal/model"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUsers struct {
+	UserStorage
+	users map[uuid.UUID]bool
+}
+
+func (f fakeUsers) GetUserByID(_ context.Context, id uuid.UUID) (model.Users, error) {
+	if !f.users[id] {
+		return model.Users{}, model.ErrUserNotFound
+	}
+	return model.Users{ID: id}, nil
+}
+
+// fakeRoles хранит роли в памяти; права ролей заданы как в миграциях.
+type fakeRoles struct {
+	roles map[uuid.UUID][]string
+}
+
+var rolePermissions = map[string][]string{
+	model.RoleAdmin:   {model.PermProductWrite, model.PermRoleAssign},
+	model.RoleManager: {model.PermProductWrite},
+}
+
+func (f *fakeRoles) AssignRole(_ context.Context, userID uuid.UUID, role string) error {
+	if role != model.RoleAdmin && role != model.RoleManager && role != model.RoleCustomer {
+		return model.ErrRoleNotFound
+	}
+	f.roles[userID] = append(f.roles[userID], role)
+	return nil
+}
+
+func (f *fakeRoles) GetUserRoles(_ context.Context, userID uuid.UUID) ([]string, error) {
+	return f.roles[userID], nil
+}
+
+func (f *fakeRoles) GetUserPermissions(_ context.Context, userID uuid.UUID) ([]string, error) {
+	var perms []string
+	for _, r := range f.roles[userID] {
+		perms = append(perms, rolePermissions[r]...)
+	}
+	return perms, nil
+}
+
+func TestAssignRole(t *testing.T) {
+	admin, manager, customer := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name    string
+		actor   uuid.UUID
+		user    uuid.UUID
+		role    string
+		wantErr error
+	}{
+		{name: "admin grants manager", actor: admin, user: customer, role: model.RoleManager},
+		{name: "manager cannot grant", actor: manager, user: customer, role: model.RoleManager, wantErr: model.ErrPermissionDenied},
+		{name: "customer cannot grant itself", actor: customer, user: customer, role: model.RoleAdmin, wantErr: model.ErrPermissionDenied},
+		{name: "unknown user", actor: admin, user: uuid.New(), role: model.RoleManager, wantErr: model.ErrUserNotFound},
+		{name: "unknown role", actor: admin, user: customer, role: "owner", wantErr: model.ErrRoleNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := &fakeRoles{roles: map[uuid.UUID][]string{
+				admin:    {model.RoleAdmin},
+				manager:  {model.RoleManager},
+				customer: {model.RoleCustomer},
+			}}
+			users := fakeUsers{users: map[uuid.UUID]bool{admin: true, manager: true, customer: true}}
+			a := NewAuth(slog.New(slog.NewTextHandler(io.Discard, nil)), users, nil, nil, roles, nil)
+
+			err := a.AssignRole(context.Background(), tt.actor, tt.user, tt.role)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.NotContains(t, roles.roles[tt.user], tt.role)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, roles.roles[tt.user], tt.role)
+		})
+	}
+}
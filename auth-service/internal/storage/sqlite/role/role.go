@@ -0,0 +1,68 @@
+package rolestorage
+
+import (
+	"auth-service/internal/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type storage struct {
+	db *sql.DB
+}
+
+func NewStorage(db *sql.DB) *storage {
+	return &storage{db: db}
+}
+
+func (s *storage) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	const op = "storage.sqlite.AssignRole"
+	query := `INSERT INTO user_roles (user_id, role) VALUES (?, ?) ON CONFLICT DO NOTHING`
+	_, err := s.db.ExecContext(ctx, query, userID.String(), role)
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return fmt.Errorf("%s: %w", op, model.ErrRoleNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *storage) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	const op = "storage.sqlite.GetUserRoles"
+	query := `SELECT role FROM user_roles WHERE user_id = ? ORDER BY role`
+	return s.selectNames(ctx, op, query, userID.String())
+}
+
+func (s *storage) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	const op = "storage.sqlite.GetUserPermissions"
+	query := `SELECT DISTINCT rp.permission FROM role_permissions rp
+	JOIN user_roles ur ON ur.role = rp.role
+	WHERE ur.user_id = ?
+	ORDER BY rp.permission`
+	return s.selectNames(ctx, op, query, userID.String())
+}
+
+func (s *storage) selectNames(ctx context.Context, op, query string, args ...any) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return names, nil
+}
@@ -19,11 +19,23 @@ type storage struct {
 func NewStorage(db *sql.DB) *storage {
 	return &storage{db: db}
 }
-func (s *storage) SaveUser(ctx context.Context, user model.Users) error {
+
+// SaveUser сохраняет пользователя и назначает ему роль role в одной
+// транзакции, чтобы не оставалось пользователей без роли.
+func (s *storage) SaveUser(ctx context.Context, user model.Users, role string) (err error) {
 	const op = "storage.sqlite.SaveUser"
-	query := `INSERT INTO users (id, mail, hash_password, name, surname, phone_number) VALUES (?, ?, ?, ?, ?, ?)`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	_, err := s.db.ExecContext(ctx, query, user.ID.String(), user.Mail, user.Password, user.Name, user.Surname, user.PhoneNumber)
+	query := `INSERT INTO users (id, mail, hash_password, name, surname, phone_number) VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, query, user.ID.String(), user.Mail, user.Password, user.Name, user.Surname, user.PhoneNumber)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 			return fmt.Errorf("%s: %w", op, model.ErrUserExists)
@@ -31,8 +43,21 @@ func (s *storage) SaveUser(ctx context.Context, user model.Users) error {
 
 		return fmt.Errorf("%s: %w", op, err)
 	}
+
+	roleQuery := `INSERT INTO user_roles (user_id, role) VALUES (?, ?)`
+	if _, err = tx.ExecContext(ctx, roleQuery, user.ID.String(), role); err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return fmt.Errorf("%s: %w", op, model.ErrRoleNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	return nil
 }
+
 func (s *storage) GetUserByEmail(ctx context.Context, email string) (model.Users, error) {
 	const op = "storage.sqlite.GetUserByEmail"
 	var user model.Users
@@ -135,8 +135,16 @@ func (s *serverAPI) IsAdmin(ctx context.Context, req *pb.IsAdminRequest) (*pb.Is
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	isAdmin, err := s.token.IsAdmin(ctx, uuid.MustParse(req.GetUserId()))
+	userID, err := uuid.Parse(req.GetUserId())
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	isAdmin, err := s.token.IsAdmin(ctx, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	return &pb.IsAdminResponse{
@@ -209,7 +217,7 @@ func (s *serverAPI) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.
 	}
 	claims, err :=s.token.ValidateToken(ctx, req.GetToken())
 	if err != nil {
-		if errors.Is(err, model.ErrInvalidCredentials) {
+		if errors.Is(err, model.ErrInvalidCredentials) || errors.Is(err, model.ErrInvalidToken) {
 			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
 		return nil, status.Error(codes.Internal, "internal error")
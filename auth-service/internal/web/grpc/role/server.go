@@ -0,0 +1,96 @@
+package role
+
+import (
+	"auth-service/internal/model"
+	rolespb "auth-service/internal/pb/roles"
+	"auth-service/internal/web/middleware"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type serverAPI struct {
+	rolespb.UnimplementedRolesServer
+	role RoleService
+}
+
+type RoleService interface {
+	HasRole(ctx context.Context, userID uuid.UUID, role string) (bool, error)
+	GetRoles(ctx context.Context, userID uuid.UUID) (roles, permissions []string, err error)
+	AssignRole(ctx context.Context, actorID, userID uuid.UUID, role string) error
+}
+
+func Register(gRPC *grpc.Server, role RoleService) {
+	rolespb.RegisterRolesServer(gRPC, &serverAPI{role: role})
+}
+
+func (s *serverAPI) HasRole(ctx context.Context, req *rolespb.HasRoleRequest) (*rolespb.HasRoleResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	hasRole, err := s.role.HasRole(ctx, userID, req.GetRole())
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &rolespb.HasRoleResponse{HasRole: hasRole}, nil
+}
+
+func (s *serverAPI) GetRoles(ctx context.Context, req *rolespb.GetRolesRequest) (*rolespb.GetRolesResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	roles, permissions, err := s.role.GetRoles(ctx, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &rolespb.GetRolesResponse{
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// AssignRole назначает роль пользователю от имени владельца токена запроса.
+func (s *serverAPI) AssignRole(ctx context.Context, req *rolespb.AssignRoleRequest) (*rolespb.AssignRoleResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	err = s.role.AssignRole(ctx, claims.UserID, userID, req.GetRole())
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		case errors.Is(err, model.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, model.ErrRoleNotFound):
+			return nil, status.Error(codes.InvalidArgument, "unknown role")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &rolespb.AssignRoleResponse{}, nil
+}
@@ -14,6 +14,15 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+type claimsKey struct{}
+
+// ClaimsFromContext возвращает данные токена, с которым пришёл запрос к
+// закрытому методу.
+func ClaimsFromContext(ctx context.Context) (*model.CustomClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*model.CustomClaims)
+	return claims, ok
+}
+
 type AuthInterceptor struct {
 	jwtProvider JWTProvider
 	service     AuthService
@@ -73,9 +82,13 @@ func (i *AuthInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 			if err := grpc.SetHeader(ctx, header); err != nil {
 				log.Printf("failed to set header: %v", err)
 			}
-			return handler(ctx, req)
+			claims, err := i.jwtProvider.ValidateToken(newAccessToken)
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, "invalid access token")
+			}
+			return handler(context.WithValue(ctx, claimsKey{}, claims), req)
 		}
-		return handler(ctx, req)
+		return handler(context.WithValue(ctx, claimsKey{}, token), req)
 	}
 }
 
@@ -0,0 +1,75 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS roles (
+    name TEXT PRIMARY KEY,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name TEXT PRIMARY KEY,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission),
+
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user_id ON user_roles(user_id);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Полный доступ'),
+    ('manager', 'Управление каталогом и ценами'),
+    ('cashier', 'Продажи и остатки'),
+    ('customer', 'Покупатель')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('product:write', 'Создание и удаление товаров'),
+    ('product:price:update', 'Изменение цен'),
+    ('product:stock:update', 'Изменение остатков'),
+    ('category:write', 'Создание и изменение категорий'),
+    ('category:delete', 'Удаление категорий'),
+    ('supplier:write', 'Управление поставщиками'),
+    ('client:write', 'Изменение клиентов'),
+    ('client:delete', 'Удаление клиентов')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+SELECT 'admin', name FROM permissions
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('manager', 'product:write'),
+    ('manager', 'product:price:update'),
+    ('manager', 'product:stock:update'),
+    ('manager', 'category:write'),
+    ('manager', 'supplier:write'),
+    ('manager', 'client:write'),
+    ('cashier', 'product:stock:update'),
+    ('cashier', 'client:write')
+ON CONFLICT DO NOTHING;
+
+-- существующие пользователи получают роль покупателя
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'customer' FROM users
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
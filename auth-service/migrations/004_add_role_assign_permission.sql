@@ -0,0 +1,13 @@
+-- +goose Up
+-- назначать роли через Roles/AssignRole может только администратор
+INSERT INTO permissions (name, description) VALUES
+    ('role:assign', 'Назначение ролей пользователям')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'role:assign')
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DELETE FROM role_permissions WHERE permission = 'role:assign';
+DELETE FROM permissions WHERE name = 'role:assign';
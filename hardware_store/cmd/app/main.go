@@ -11,6 +11,9 @@ import (
 // @description REST API для магазина бытовой техники
 // @host localhost:8081
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	fx.New(
 		di.Module,
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/payment"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Заглушка платёжного шлюза для локальной разработки. Настраивается
+// переменными окружения:
+//
+//	PAYMENT_STUB_ADDRESS    адрес, на котором слушает заглушка
+//	PAYMENT_STUB_PUBLIC_URL адрес заглушки для ссылок на страницу оплаты
+//	PAYMENT_WEBHOOK_URL     куда отправлять уведомления о платежах
+//	PAYMENT_WEBHOOK_SECRET  секрет подписи уведомлений, как payment.webhook_secret приложения
+func main() {
+	log := logger.NewLog(getenv("ENV", "local"))
+
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if secret == "" {
+		log.Error("PAYMENT_WEBHOOK_SECRET environment variable is not set")
+		os.Exit(1)
+	}
+	address := getenv("PAYMENT_STUB_ADDRESS", "0.0.0.0:8090")
+	stub := payment.NewStubServer(secret,
+		getenv("PAYMENT_STUB_PUBLIC_URL", "http://localhost:8090"),
+		getenv("PAYMENT_WEBHOOK_URL", "http://app-main:8081/api/v1/payments/webhook"),
+		log)
+
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           stub,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		log.Info("Payment stub started", slog.String("address", address))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Payment stub failed", logger.Err(err))
+			os.Exit(1)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdown); err != nil {
+		log.Error("Failed to stop payment stub", logger.Err(err))
+	}
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -15,45 +16,48 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/addresses": {
-            "post": {
-                "description": "Создаёт новый адрес в системе на основе переданных данных",
-                "consumes": [
-                    "application/json"
+        "/cart": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает корзину клиента, привязанного к пользователю из токена, создавая её при первом обращении",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "addresses"
+                    "carts"
                 ],
-                "summary": "Создать новый адрес",
-                "parameters": [
-                    {
-                        "description": "Данные адреса для создания",
-                        "name": "address",
-                        "in": "body",
-                        "required": true,
+                "summary": "Получить свою корзину",
+                "responses": {
+                    "200": {
+                        "description": "Корзина клиента",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Адрес успешно создан",
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "У пользователя нет клиента",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при сохранении адреса",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -61,87 +65,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/addresses/{id}": {
-            "get": {
-                "description": "Возвращает полные данные адреса по уникальному идентификатору",
+        "/cart/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Вызывается сразу после входа через auth-service: переносит позиции анонимной корзины в корзину клиента, привязанного к пользователю из токена. Анонимная корзина удаляется",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "addresses"
+                    "carts"
                 ],
-                "summary": "Получить адрес",
+                "summary": "Слить анонимную корзину со своей корзиной после входа",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "UUID адреса",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Токен анонимной корзины",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartMergeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Адрес успешно получен",
+                        "description": "Корзина клиента после слияния",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID",
+                        "description": "Ошибки валидации",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Адрес не найден",
+                        "description": "У пользователя нет клиента или корзина не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении адреса",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет адрес по уникальному идентификатору UUID",
-                "tags": [
-                    "addresses"
+            }
+        },
+        "/carts": {
+            "post": {
+                "description": "Создаёт корзину без клиента. Возвращённый token нужен для всех операций с корзиной",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Удалить адрес",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "UUID адреса",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "carts"
                 ],
+                "summary": "Создать анонимную корзину",
                 "responses": {
-                    "204": {
-                        "description": "Адрес успешно удалён"
-                    },
-                    "400": {
-                        "description": "Невалидный формат UUID",
-                        "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Адрес не найден",
+                    "201": {
+                        "description": "Корзина создана",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при удалении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -149,36 +160,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
+        "/carts/{token}": {
             "get": {
-                "description": "Возвращает список всех категорий в системе",
+                "description": "Возвращает корзину с текущими ценами и остатками товаров",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
+                ],
+                "summary": "Получить корзину",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен корзины",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить список категорий",
                 "responses": {
                     "200": {
-                        "description": "Список категорий успешно получен",
+                        "description": "Корзина",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
-                            }
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена или истекла",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении списка",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/carts/{token}/checkout": {
             "post": {
-                "description": "Создаёт новую категорию в системе на основе переданных данных",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт заказ из корзины клиента и удаляет корзину в одной транзакции. Анонимную корзину нужно предварительно слить с корзиной клиента. Покупатель может оформить только корзину своего клиента. Без warehouse_id товары списываются со склада по умолчанию. Тело с промокодами и складом необязательно",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,35 +216,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Создать новую категорию",
+                "summary": "Оформить заказ по корзине",
                 "parameters": [
                     {
-                        "description": "Данные категории для создания",
-                        "name": "category",
+                        "type": "string",
+                        "description": "Токен корзины",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Промокоды и склад",
+                        "name": "checkout",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartCheckoutRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Категория успешно создана",
+                    "201": {
+                        "description": "Заказ оформлен",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderResponse"
                         }
                     },
                     "400": {
-                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                        "description": "Корзина пуста, анонимна, товара недостаточно или промокод недействителен",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или корзина чужого клиента",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина, склад или продукт не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Лимит использований промокода исчерпан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при сохранении категории",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -222,55 +282,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{id}": {
-            "get": {
-                "description": "Возвращает полные данные категории по уникальному идентификатору",
+        "/carts/{token}/items": {
+            "post": {
+                "description": "Добавляет товар в корзину. Если товар уже есть, количество увеличивается. Итоговое количество не может превышать остаток",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Получить категорию",
+                "summary": "Добавить товар в корзину",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "UUID категории",
-                        "name": "id",
+                        "description": "Токен корзины",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Товар и количество",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartItemRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно получена",
+                        "description": "Корзина после изменения",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID",
+                        "description": "Ошибки валидации или недостаточный остаток",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Категория не найдена",
+                        "description": "Корзина или продукт не найдены",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении категории",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Обновляет данные категории по уникальному идентификатору",
+            }
+        },
+        "/carts/{token}/items/{product_id}": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -278,49 +350,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Обновить категорию",
+                "summary": "Изменить количество товара в корзине",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен корзины",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID категории",
-                        "name": "id",
+                        "description": "UUID продукта",
+                        "name": "product_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Обновлённые данные категории",
-                        "name": "category",
+                        "description": "Новое количество",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartItemQuantityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно обновлена",
+                        "description": "Корзина после изменения",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный UUID или ошибки валидации данных",
+                        "description": "Ошибки валидации или недостаточный остаток",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Категория не найдена",
+                        "description": "Корзина или позиция не найдены",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -328,24 +407,36 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет категорию по уникальному идентификатору UUID",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Удалить категорию",
+                "summary": "Удалить товар из корзины",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен корзины",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID категории",
-                        "name": "id",
+                        "description": "UUID продукта",
+                        "name": "product_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Категория успешно удалена"
+                    "200": {
+                        "description": "Корзина после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
+                        }
                     },
                     "400": {
                         "description": "Невалидный формат UUID",
@@ -354,13 +445,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Категория не найдена",
+                        "description": "Корзина или позиция не найдены",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при удалении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -368,31 +459,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients": {
+        "/categories": {
             "get": {
-                "description": "Возвращает список всех клиентов в системе",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает страницу категорий, упорядоченных по названию",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "categories"
+                ],
+                "summary": "Получить список категорий",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить список всех клиентов",
                 "responses": {
                     "200": {
-                        "description": "Список клиентов",
+                        "description": "Список категорий успешно получен",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
-                            }
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Внутренняя ошибка сервера при получении списка",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -400,7 +506,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создаёт нового клиента вместе с адресом",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт новую категорию в системе на основе переданных данных",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,35 +519,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "categories"
                 ],
-                "summary": "Создание клиента",
+                "summary": "Создать новую категорию",
                 "parameters": [
                     {
-                        "description": "Данные клиента",
-                        "name": "client",
+                        "description": "Данные категории для создания",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Клиент успешно создан",
+                    "200": {
+                        "description": "Категория успешно создана",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный запрос",
+                        "description": "Ошибки валидации полей или некорректный формат запроса",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Внутренняя ошибка сервера при сохранении категории",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -444,40 +567,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/search": {
+        "/categories/{id}": {
             "get": {
-                "description": "Возвращает информацию о клиенте по имени и фамилии",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает полные данные категории по уникальному идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "categories"
                 ],
-                "summary": "Получить клиента",
+                "summary": "Получить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Имя клиента",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фамилия клиента",
-                        "name": "surname",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "UUID категории",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно",
+                        "description": "Категория успешно получена",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
                         }
                     },
                     "400": {
@@ -487,23 +601,26 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Клиент не найден",
+                        "description": "Категория не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Внутренняя ошибка сервера при получении категории",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/clients/{id}": {
-            "put": {
-                "description": "Обновляет адрес клиента по его уникальному идентификатору",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет данные категории по уникальному идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -511,46 +628,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "categories"
                 ],
-                "summary": "Обновить клиента",
+                "summary": "Обновить категорию",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID клиента",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные адреса для обновления",
-                        "name": "address",
+                        "description": "Обновлённые данные категории",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Адрес успешно обновлён"
+                        "description": "Категория успешно обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
+                        }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID",
+                        "description": "Невалидный UUID или ошибки валидации данных",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Клиент не найден",
+                        "description": "Категория не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Внутренняя ошибка сервера при обновлении",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -558,22 +690,21 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет клиента по его уникальному идентификатору",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Удаляет категорию по уникальному идентификатору UUID",
                 "tags": [
-                    "clients"
+                    "categories"
                 ],
-                "summary": "Удаление клиента",
+                "summary": "Удалить категорию",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID клиента",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -581,7 +712,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content"
+                        "description": "Категория успешно удалена"
                     },
                     "400": {
                         "description": "Невалидный формат UUID",
@@ -589,14 +720,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Клиент не найден",
+                        "description": "Категория не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Внутренняя ошибка сервера при удалении",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -604,136 +747,200 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/{id}": {
-            "get": {
-                "description": "Возвращает бинарные данные изображения по уникальному идентификатору",
+        "/categories/{id}/reorder-policy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задаёт точку заказа и объём закупки по умолчанию для товаров категории, у которых они не заданы",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "replenishment"
                 ],
-                "summary": "Получить изображение по ID",
+                "summary": "Задать точку заказа категории",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID изображения",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Точка заказа",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бинарные данные изображения"
+                        "description": "Точка заказа изменена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyResponse"
+                        }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID изображения",
+                        "description": "Ошибки валидации",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Изображение не найдено",
+                        "description": "Категория не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении изображения",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет существующее изображение новыми бинарными данными",
+            }
+        },
+        "/clients": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает страницу клиентов в порядке регистрации",
                 "consumes": [
-                    "application/octet-stream"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "clients"
                 ],
-                "summary": "Обновить изображение",
+                "summary": "Получить список всех клиентов",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "UUID изображения",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Бинарные данные изображения",
-                        "name": "image",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Изображение успешно обновлено"
+                    "200": {
+                        "description": "Список клиентов",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ClientResponse"
+                        }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID или некорректные данные изображения",
+                        "description": "Некорректные параметры пагинации",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Изображение не найдено",
+                    "401": {
+                        "description": "Требуется авторизация",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Удаляет изображение по уникальному идентификатору UUID",
+            "post": {
+                "description": "Создаёт нового клиента вместе с адресом. Если запрос выполнен с токеном покупателя, клиент привязывается к его пользователю, у пользователя может быть только один клиент",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "images"
+                    "clients"
                 ],
-                "summary": "Удалить изображение",
+                "summary": "Создание клиента",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "UUID изображения",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные клиента",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Изображение успешно удалено"
+                    "201": {
+                        "description": "Клиент успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
+                        }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID изображения",
+                        "description": "Невалидный запрос",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Изображение не найдено",
+                    "409": {
+                        "description": "У пользователя уже есть клиент",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при удалении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -741,72 +948,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/clients/search": {
             "get": {
-                "description": "Возвращает список всех продуктов в системе",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о клиенте по имени и фамилии",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "clients"
                 ],
-                "summary": "Получить список продуктов",
-                "responses": {
+                "summary": "Получить клиента",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя клиента",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фамилия клиента",
+                        "name": "surname",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "Список продуктов успешно получен",
+                        "description": "Успешно",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера при получении списка",
+                    "400": {
+                        "description": "Невалидный формат UUID",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Создаёт новый продукт в системе на основе переданных данных",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Создать новый продукт",
-                "parameters": [
-                    {
-                        "description": "Данные продукта для создания",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Продукт успешно создан",
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                    "404": {
+                        "description": "Клиент не найден",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при сохранении продукта",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -814,32 +1022,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Возвращает полные данные продукта по уникальному идентификатору",
+        "/clients/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет адрес клиента по его уникальному идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "clients"
                 ],
-                "summary": "Получить продукт",
+                "summary": "Обновить клиента",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные адреса для обновления",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Продукт успешно получен",
-                        "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
-                        }
+                        "description": "Адрес успешно обновлён"
                     },
                     "400": {
                         "description": "Невалидный формат UUID",
@@ -847,14 +1069,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Продукт не найден",
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении продукта",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -862,16 +1096,27 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет продукт по уникальному идентификатору UUID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет клиента по его уникальному идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "products"
+                    "clients"
                 ],
-                "summary": "Удалить продукт",
+                "summary": "Удаление клиента",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -879,7 +1124,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Продукт успешно удалён"
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Невалидный формат UUID",
@@ -887,14 +1132,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Продукт не найден",
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при удалении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -902,21 +1159,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/image": {
+        "/clients/{id}/cart": {
             "get": {
-                "description": "Возвращает изображение продукта по его уникальному идентификатору",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает корзину клиента, создавая её при первом обращении. Покупателю доступна только корзина своего клиента",
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "carts"
                 ],
-                "summary": "Получить изображение продукта",
+                "summary": "Получить корзину клиента",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -924,74 +1186,114 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бинарные данные изображения"
+                        "description": "Корзина клиента",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
+                        }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID продукта",
+                        "description": "Невалидный формат UUID",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или клиент чужой",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Изображение не найдено",
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении изображения",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/clients/{id}/cart/merge": {
             "post": {
-                "description": "Загружает новое изображение для указанного продукта",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переносит позиции анонимной корзины в корзину клиента. Анонимная корзина удаляется. Покупателю доступна только корзина своего клиента",
                 "consumes": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "carts"
                 ],
-                "summary": "Загрузить изображение для продукта",
+                "summary": "Слить анонимную корзину с корзиной клиента",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Бинарные данные изображения",
-                        "name": "image",
+                        "description": "Токен анонимной корзины",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartMergeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Изображение успешно загружено",
+                    "200": {
+                        "description": "Корзина клиента после слияния",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ImageResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CartResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID продукта или некорректные данные изображения",
+                        "description": "Ошибки валидации",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или клиент чужой",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Клиент или корзина не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при сохранении изображения",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -999,59 +1301,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/stock": {
-            "put": {
-                "description": "Обновляет доступное количество товара на складе для указанного продукта",
-                "consumes": [
-                    "application/json"
+        "/clients/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает заказы клиента в порядке оформления",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "orders"
                 ],
-                "summary": "Обновить количество товара на складе",
+                "summary": "Получить заказы клиента",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Новое количество товара",
-                        "name": "stock",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.UpdateStockCountRequest"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Количество товара успешно обновлено",
+                        "description": "Заказы клиента",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_OrderResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный формат запроса, отрицательное количество или недостаточный остаток",
+                        "description": "Невалидный UUID или параметры пагинации",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Продукт не найден",
+                    "401": {
+                        "description": "Требуется авторизация",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -1059,36 +1373,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/suppliers": {
+        "/currencies": {
             "get": {
-                "description": "Возвращает список всех поставщиков в системе",
+                "description": "Возвращает валюты, в которые можно пересчитывать цены, с правилами округления",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "currencies"
                 ],
-                "summary": "Получить список поставщиков",
+                "summary": "Получить список валют",
                 "responses": {
                     "200": {
-                        "description": "Список поставщиков успешно получен",
+                        "description": "Валюты",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                                "$ref": "#/definitions/hardware_store_internal_web_dto.CurrencyResponse"
                             }
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении списка",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создаёт нового поставщика вместе с его адресом в системе",
+            }
+        },
+        "/currencies/{code}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет название валюты и правила округления сумм после пересчёта в неё",
                 "consumes": [
                     "application/json"
                 ],
@@ -1096,35 +1417,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "currencies"
                 ],
-                "summary": "Создать нового поставщика",
+                "summary": "Добавить или изменить валюту",
                 "parameters": [
                     {
-                        "description": "Данные поставщика для создания",
-                        "name": "supplier",
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные валюты",
+                        "name": "currency",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierRequest"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CurrencyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Поставщик успешно создан",
+                    "200": {
+                        "description": "Валюта сохранена",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.CurrencyResponse"
                         }
                     },
                     "400": {
-                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                        "description": "Ошибки валидации или некорректный код валюты",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при сохранении поставщика",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
@@ -1132,55 +1473,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/suppliers/{id}": {
+        "/currencies/{code}/rates": {
             "get": {
-                "description": "Возвращает полные данные поставщика по уникальному идентификатору",
+                "description": "Возвращает курсы валюты к рублю от последнего к первому, при необходимости за период",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "currencies"
                 ],
-                "summary": "Получить поставщика",
+                "summary": "Получить историю курсов валюты",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "UUID поставщика",
-                        "name": "id",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217",
+                        "name": "code",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Начало периода",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Конец периода",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Поставщик успешно получен",
+                        "description": "Курсы валюты",
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ExchangeRateResponse"
                         }
                     },
                     "400": {
-                        "description": "Невалидный формат UUID",
+                        "description": "Некорректные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Поставщик не найден",
+                        "description": "Валюта не найдена",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера при получении поставщика",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/exchange-rates": {
             "put": {
-                "description": "Обновляет адрес поставщика по его уникальному идентификатору",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет курсы валют к рублю. Курс той же валюты на ту же дату заменяется. Пакет сохраняется целиком: при ошибке в любом курсе не сохраняется ни один",
                 "consumes": [
                     "application/json"
                 ],
@@ -1188,40 +1563,216 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "currencies"
                 ],
-                "summary": "Обновить адрес поставщика",
+                "summary": "Загрузить курсы валют",
+                "parameters": [
+                    {
+                        "description": "Курсы валют",
+                        "name": "rates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ExchangeRatesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Курсы сохранены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ExchangeRateImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, неизвестная валюта или курс базовой валюты",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загружает CSV со столбцами currency, date и rate; дата в формате YYYY-MM-DD или DD.MM.YYYY, разделитель — запятая или точка с запятой. Файл сохраняется целиком: при ошибке в любой строке не сохраняется ни один курс",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Загрузить курсы валют из файла",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл CSV с курсами",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Курсы сохранены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ExchangeRateImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Нет файла, ошибка в строке или неизвестная валюта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{id}": {
+            "get": {
+                "description": "Возвращает бинарные данные изображения по уникальному идентификатору",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Получить изображение по ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID поставщика",
+                        "description": "UUID изображения",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Бинарные данные изображения"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID изображения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Изображение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении изображения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "description": "Обновлённые данные адреса",
-                        "name": "address",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет существующее изображение новыми бинарными данными",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Обновить изображение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID изображения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Бинарные данные изображения",
+                        "name": "image",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                            "type": "string"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Адрес поставщика успешно обновлён"
+                    "204": {
+                        "description": "Изображение успешно обновлено"
                     },
                     "400": {
-                        "description": "Невалидный формат UUID или ошибки валидации данных",
+                        "description": "Невалидный формат UUID или некорректные данные изображения",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Поставщик не найден",
+                        "description": "Изображение не найдено",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
@@ -1235,16 +1786,21 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет поставщика по уникальному идентификатору UUID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет изображение по уникальному идентификатору UUID",
                 "tags": [
-                    "suppliers"
+                    "images"
                 ],
-                "summary": "Удалить поставщика",
+                "summary": "Удалить изображение",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID поставщика",
+                        "description": "UUID изображения",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1252,16 +1808,28 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Поставщик успешно удалён"
+                        "description": "Изображение успешно удалено"
                     },
                     "400": {
-                        "description": "Невалидный формат UUID",
+                        "description": "Невалидный формат UUID изображения",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Поставщик не найден",
+                        "description": "Изображение не найдено",
                         "schema": {
                             "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
                         }
@@ -1274,260 +1842,7485 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "hardware_store_internal_web_dto.AddressRequest": {
-            "description": "Запрос на создание нового адреса с информацией о стране, городе и улице",
-            "type": "object",
-            "required": [
-                "city",
-                "country",
-                "street"
-            ],
-            "properties": {
-                "city": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2,
-                    "example": "Москва"
-                },
-                "country": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2,
-                    "example": "Россия"
-                },
-                "street": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2,
-                    "example": "Технопарк, 15"
-                }
-            }
         },
-        "hardware_store_internal_web_dto.AddressResponse": {
-            "description": "Данные адреса включая информацию о местоположении",
-            "type": "object",
-            "properties": {
-                "address_id": {
+        "/orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проверяет клиента, фиксирует текущие цены товаров, применяет акции и промокоды и списывает остатки по всем позициям со склада заказа в одной транзакции. Если какого-то товара на складе не хватает или промокод недействителен, заказ не создаётся",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Оформить заказ",
+                "parameters": [
+                    {
+                        "description": "Клиент и позиции заказа",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ оформлен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, недостаточный остаток или недействительный промокод",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Клиент, склад или продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Лимит использований промокода исчерпан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заказ с позициями по его UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/invoice.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает PDF-счёт по заказу с реквизитами магазина, покупателем, позициями, НДС по ставкам и итогами. Счёт выпускается при первом запросе под следующим номером за год, дальше отдаётся сохранённым. Номер — в имени файла",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Счёт на оплату заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF-документ",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ или клиент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ отменён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все платежи заказа в порядке создания, включая отклонённые",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Получить платежи по заказу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Платежи заказа",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт в платёжном шлюзе платёж на сумму нового заказа и возвращает ссылку на страницу оплаты. Пока у заказа есть незавершённый платёж, возвращается он. Когда шлюз сообщит о списании денег, заказ станет оплаченным",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Создать платёж по заказу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Платёж создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID или нулевая сумма заказа",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ не ожидает оплаты",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка платёжного шлюза",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.BadGatewayErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/receipt.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает PDF-квитанцию по оплаченному заказу. Квитанции нумеруются отдельно от счетов; выпущенная квитанция отдаётся сохранённой",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Квитанция об оплате заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF-документ",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ или клиент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ ещё не оплачен или отменён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/transitions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переводит заказ в новый статус по таблице переходов. Отмена и возврат возвращают товары на склад; единицы, уже вернувшиеся по заявкам на возврат, повторно не приходуются. Недопустимый переход отклоняется с кодом illegal_transition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Сменить статус заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус заказа изменён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Принимает подписанное уведомление об изменении платежа. Подпись HMAC-SHA256 тела передаётся в заголовке X-Payment-Signature. Повторная доставка того же события не меняет данных. Когда деньги списаны, новый заказ становится оплаченным",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Уведомление платёжного шлюза",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Подпись тела уведомления",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Уведомление принято"
+                    },
+                    "400": {
+                        "description": "Неверная подпись или формат уведомления",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает платёж с текущим статусом и суммой возвратов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Получить платёж",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Платёж",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/capture": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подтверждает платёж, деньги по которому заблокированы, на всю сумму. Нужен, если отключено автоматическое списание. Заказ становится оплаченным",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Списать заблокированные деньги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Деньги списаны",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Деньги по платежу не заблокированы",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка платёжного шлюза",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.BadGatewayErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/refunds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает покупателю часть списанных денег или сумму проверенной заявки на возврат товара; заявка при этом закрывается возвратом денег. Общая сумма возвратов не может превышать сумму платежа. Повторный запрос с тем же ключом Idempotency-Key не возвращает деньги второй раз; для заявки на возврат без заголовка ключ выводится из заявки, иначе заголовок обязателен. Если шлюз не ответил, возврат завершит повтор запроса с тем же ключом, а другие возвраты по платежу до тех пор отклоняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Вернуть деньги по платежу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности возврата, до 128 байт",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Сумма или заявка на возврат",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Деньги возвращены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, нет ключа идемпотентности, ключ занят другим возвратом или сумма больше остатка",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж или заявка не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Деньги не списаны, заявка не проверена или предыдущий возврат не подтверждён шлюзом",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка платёжного шлюза",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.BadGatewayErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет цены всех товаров, подходящих под фильтр по категории, поставщику или диапазону цен, на процент или сумму с округлением до окончания в копейках. Цены меняются одной транзакцией и попадают в историю цен; если новая цена хотя бы одного товара не положительна, не меняется ни одна. С dry_run возвращает новые цены, не меняя их",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Массово изменить цены",
+                "parameters": [
+                    {
+                        "description": "Фильтр товаров и изменение цены",
+                        "name": "repricing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.RepricingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новые цены товаров",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.RepricingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, пустой фильтр или неположительная новая цена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Возвращает страницу товаров с фильтрацией и сортировкой. Без in_stock возвращаются только товары в наличии, in_stock=false снимает это ограничение",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить список продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID категории",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID склада, на котором есть товар",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена в рублях",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена в рублях",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Только товары в наличии",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока названия",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price",
+                            "name",
+                            "last_update_date"
+                        ],
+                        "type": "string",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Валюта, в которую пересчитываются цены; без параметра цены возвращаются в рублях (RUB)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Дата курса пересчёта, по умолчанию сегодня",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список продуктов успешно получен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса, неизвестная валюта или нет курса на дату",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении списка",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт новый продукт в системе на основе переданных данных. Цена задаётся в рублях (RUB): каталог, корзины и заказы считаются в рублях, другие валюты доступны только при выдаче через параметр currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Создать новый продукт",
+                "parameters": [
+                    {
+                        "description": "Данные продукта для создания",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Продукт успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Артикул уже занят другим товаром",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при сохранении продукта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает товары по тем же фильтрам, что и список, в CSV, XLSX или JSON Lines вместе с названиями категорий и поставщиков. Файл передаётся потоком без постраничной разбивки; если выгрузка прервалась после начала передачи, файл обрывается",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Выгрузить каталог",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Формат файла",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID категории",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID склада, на котором есть товар",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Только товары в наличии",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока названия",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price",
+                            "name",
+                            "last_update_date"
+                        ],
+                        "type": "string",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл каталога",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загружает CSV или XLSX с товарами и ставит его в очередь на обработку. Столбцы сопоставляются полям sku, name, category, supplier, price, available_stock по заголовкам или через mapping; категория и поставщик задаются UUID или названием. При dry_run строки только проверяются, при upsert товары с существующим артикулом обновляются",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Импортировать товары из файла",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл CSV или XLSX, первая строка — заголовок",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию определяется по расширению",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Только проверить строки",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Обновлять товары с существующим артикулом",
+                        "name": "upsert",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON-объект: заголовок столбца → поле товара",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Импорт поставлен в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Нет файла, неизвестный формат или некорректное сопоставление столбцов",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает статус импорта, счётчики созданных, обновлённых и ошибочных строк и отчёт об ошибках по строкам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить состояние импорта товаров",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID импорта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние импорта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Импорт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Возвращает полные данные продукта по уникальному идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Валюта, в которую пересчитывается цена; без параметра цена возвращается в рублях (RUB)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Дата курса пересчёта, по умолчанию сегодня",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт успешно получен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID, неизвестная валюта или нет курса на дату",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении продукта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Полностью заменяет артикул, название, категорию, цену, остаток и поставщика товара. Цена задаётся в рублях (RUB)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Обновить товар",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные товара",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, несуществующая категория или поставщик",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Артикул уже занят другим товаром",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет продукт по уникальному идентификатору UUID",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Продукт успешно удалён"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при удалении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет только переданные поля товара по семантике JSON merge patch (RFC 7396)",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Частично обновить товар",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля товара",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, несуществующая категория или поставщик",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/best-supplier": {
+            "get": {
+                "description": "Возвращает лучшее предложение по товару среди поставщиков, готовых продать нужное количество в указанной валюте. По умолчанию выбирается самое дешёвое, при prefer=lead_time — самое быстрое",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Выбрать поставщика товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Требуемое количество",
+                        "name": "quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "RUB",
+                        "description": "Валюта закупки",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный срок поставки в днях",
+                        "name": "max_lead_time_days",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price",
+                            "lead_time"
+                        ],
+                        "type": "string",
+                        "default": "price",
+                        "description": "Приоритет выбора",
+                        "name": "prefer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Лучшее предложение",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Нет подходящего предложения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/image": {
+            "get": {
+                "description": "Возвращает изображение продукта по его уникальному идентификатору",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Получить изображение продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Бинарные данные изображения"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID продукта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Изображение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении изображения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загружает новое изображение для указанного продукта",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Загрузить изображение для продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Бинарные данные изображения",
+                        "name": "image",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Изображение успешно загружено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID продукта или некорректные данные изображения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при сохранении изображения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает периоды действия цен товара от поздних к ранним, включая запланированные. По истории можно установить, какая цена действовала в момент покупки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить историю цен товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История цен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID или параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Планирует новую цену товара с момента valid_from в будущем. Когда момент наступит, фоновая задача применит цену и закроет действующую. Цена, уже запланированная на тот же момент, заменяется. Цена задаётся в рублях (RUB)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Запланировать смену цены",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая цена и момент её вступления в силу",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Цена запланирована",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или момент в прошлом",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/{price_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет запланированную цену, пока она не вступила в силу. Применённые цены остаются в истории",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Отменить запланированную цену",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID запланированной цены",
+                        "name": "price_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Цена отменена"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Запланированная цена не найдена или уже применена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reorder-policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает собственную точку заказа товара, точку заказа его категории и действующую",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Получить точку заказа товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Точка заказа",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductReorderPolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет точку заказа и объём закупки товара. Отсутствующие поля берутся из категории товара",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Задать точку заказа товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Точка заказа",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Точка заказа изменена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductReorderPolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reservations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удерживает количество товара на ограниченный срок. Зарезервированный товар нельзя продать, пока резерв не подтверждён или не освобождён. Истёкшие резервы освобождаются автоматически",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Зарезервировать товар",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры резерва",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Резерв создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или недостаточный свободный остаток",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Списывает amount единиц товара продажей со склада warehouse_id, без warehouse_id — со склада по умолчанию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Обновить количество товара на складе",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое количество товара",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UpdateStockCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество товара успешно обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат запроса, отрицательное количество или недостаточный остаток",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает журнал движений остатка товара в хронологическом порядке",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Получить историю движений товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История движений",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_StockMovementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный UUID или параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проводит приход, продажу, возврат, корректировку или списание и записывает его в журнал. Кассир может проводить только продажи и возвраты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Провести движение остатка",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Движение остатка",
+                        "name": "movement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.StockMovementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Движение проведено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.StockMovementResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или недостаточный остаток",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/suppliers": {
+            "get": {
+                "description": "Возвращает все предложения поставщиков по товару, сгруппированные по валюте и упорядоченные от самого дешёвого к самому дорогому",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить поставщиков товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Предложения поставщиков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/tax": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает действующую ставку налога товара и откуда она взята: собственная ставка товара, ставка категории или ставка по умолчанию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить ставку налога товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ставка налога",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductTaxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задаёт товару собственную ставку налога, которая действует вместо ставки его категории. Ставка применяется к заказам, оформленным после изменения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Задать ставку налога товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ставка налога в процентах",
+                        "name": "tax",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductTaxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ставка налога задана",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductTaxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или ставка вне диапазона 0–100",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает собственную ставку налога товара: для него снова действует ставка категории или ставка по умолчанию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Снять ставку налога товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Действующая ставка после снятия",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ProductTaxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает акции от новых к старым, включая завершённые",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Получить список акций",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Акции",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт правило скидки: процент, фиксированную сумму или «купи X — получи Y» на товары категории, поставщика или все товары, при необходимости по промокоду с лимитами использований и сроком действия",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Создать акцию",
+                "parameters": [
+                    {
+                        "description": "Условия акции",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Акция создана",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, параметры не соответствуют виду акции, категория или поставщик не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Промокод уже используется другой акцией",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/evaluate": {
+            "post": {
+                "description": "Подбирает к корзине или списку позиций действующие акции и введённые промокоды и возвращает скидки по каждой позиции. Цены берутся из текущих цен товаров. Промокоды не погашаются: это происходит при оформлении заказа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Рассчитать скидки",
+                "parameters": [
+                    {
+                        "description": "Корзина или позиции и промокоды",
+                        "name": "evaluation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionEvaluateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Скидки по позициям",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionEvaluationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или недействительный промокод",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина или продукт не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Лимит использований промокода исчерпан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает условия акции по её UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Получить акцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID акции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Акция",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Акция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет условия акции. Чтобы завершить акцию досрочно, передайте active=false. Оформленные заказы сохраняют полученные скидки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Изменить акцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID акции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Условия акции",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Акция изменена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, параметры не соответствуют виду акции, категория или поставщик не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Акция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Промокод уже используется другой акцией",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт черновик заказа поставщику с ожидаемыми ценами и количествами",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Создать заказ поставщику",
+                "parameters": [
+                    {
+                        "description": "Поставщик, склад приёмки и позиции",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ поставщику создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик, склад или продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заказ поставщику с позициями и приёмками",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Получить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ поставщику",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ поставщику не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/receipts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Приходует принятые позиции на склад заказа и записывает приёмку с расхождениями между ожидаемым и принятым количеством в одной транзакции. Заказ становится частично или полностью принятым",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Принять товар по заказу поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Принятые количества",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.GoodsReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Товар принят",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации, товар не из заказа или ничего не принято",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ поставщику не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ не ожидает поставки",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/transitions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отправляет, отменяет или закрывает частично принятый заказ поставщику. Недопустимый переход отклоняется с кодом illegal_transition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Сменить статус заказа поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус изменён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ поставщику не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Превращает резерв в продажу: снимает резерв и списывает остаток в журнал движений",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Подтвердить резерв",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID резерва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Резерв подтверждён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Резерв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Резерв уже закрыт или истёк",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает активный резерв и возвращает количество в свободный остаток",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Освободить резерв",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID резерва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Резерв освобождён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Резерв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Резерв уже закрыт или истёк",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заявки на возврат в порядке создания, при необходимости только по заказу или в одном статусе",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Получить заявки на возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заказа",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "requested",
+                            "approved",
+                            "rejected",
+                            "received",
+                            "inspected",
+                            "refunded",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Статус заявки",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявки на возврат",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Регистрирует возврат позиций доставленного заказа с причинами. Сумма к возврату — доля оплаченной суммы позиции после скидок и с налогом. Позицию нельзя вернуть больше, чем осталось незаявленным в других заявках",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Создать заявку на возврат",
+                "parameters": [
+                    {
+                        "description": "Заказ, склад приёмки и позиции",
+                        "name": "return",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заявка создана",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или позиция не из заказа",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ ещё не доставлен или уже возвращён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заявку на возврат с позициями и результатами осмотра",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Получить заявку на возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка на возврат",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/inspection": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает решение по каждой позиции полученной заявки и в одной транзакции проводит движения остатков: возврат на склад заявки, а при списании или отправке поставщику — сразу и расход. Заявка становится проверенной",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Записать результаты осмотра",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Решения по позициям",
+                        "name": "inspection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnInspectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Осмотр записан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или решения не по всем позициям",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар по заявке ещё не получен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/transitions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Одобряет, отклоняет или отменяет заявку и отмечает получение товара. Возврат денег закрывает заявку только через возврат по платежу. Недопустимый переход отклоняется с кодом illegal_transition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Сменить статус заявки на возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус изменён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает оповещения о товарах, остаток которых опустился ниже точки заказа, с рекомендуемым поставщиком и объёмом закупки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Получить оповещения о низком остатке",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "default": "open",
+                        "description": "Статус оповещения",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Оповещения",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_StockAlertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Пересчитывает остатки по журналу движений и возвращает товары с расхождениями. Пустой список означает, что остатки сходятся",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Сверить остатки с журналом",
+                "responses": {
+                    "200": {
+                        "description": "Товары с расхождениями",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/hardware_store_internal_web_dto.StockDiscrepancyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Атомарно списывает товар со склада-источника и зачисляет на склад-получатель. Общий остаток товара не меняется. Если на источнике не хватает товара, перемещение не проводится",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Переместить товар между складами",
+                "parameters": [
+                    {
+                        "description": "Перемещение",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.StockTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Перемещение проведено",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или недостаточный остаток на складе-источнике",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "description": "Возвращает страницу поставщиков, упорядоченных по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить список поставщиков",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список поставщиков успешно получен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении списка",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт нового поставщика вместе с его адресом в системе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Создать нового поставщика",
+                "parameters": [
+                    {
+                        "description": "Данные поставщика для создания",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Поставщик успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации полей или некорректный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при сохранении поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "description": "Возвращает полные данные поставщика по уникальному идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поставщик успешно получен",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при получении поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет адрес поставщика по его уникальному идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Обновить адрес поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновлённые данные адреса",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Адрес поставщика успешно обновлён"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID или ошибки валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при обновлении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет поставщика по уникальному идентификатору UUID",
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Удалить поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Поставщик успешно удалён"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера при удалении",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/products": {
+            "get": {
+                "description": "Возвращает страницу товаров поставщика с закупочными ценами, упорядоченных по названию товара",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить прайс-лист поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прайс-лист поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_SupplierProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID или параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/products/{product_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет товар в прайс-лист поставщика или заменяет его закупочную цену, срок поставки и минимальную партию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Задать условия поставки товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Условия поставки",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прайс-лист обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации или неизвестная валюта",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик или продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Артикул уже занят другим товаром поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ConflictErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет товар из прайс-листа поставщика",
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Убрать товар из прайс-листа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID продукта",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Товар убран из прайс-листа"
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товара нет в прайс-листе поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заказы поставщику в порядке создания",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Получить заказы поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказы поставщику",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный UUID или параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "description": "Возвращает страницу складов, упорядоченных по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Получить список складов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список складов",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт склад или торговый зал вместе с его адресом",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Создать склад",
+                "parameters": [
+                    {
+                        "description": "Данные склада",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.WarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Склад создан",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибки валидации",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется авторизация",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.UnauthorizedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ForbiddenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "get": {
+                "description": "Возвращает склад по его UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Получить склад",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID склада",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Склад",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Невалидный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.NotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/hardware_store_internal_web_dto.InternalErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "hardware_store_internal_web_dto.AddressRequest": {
+            "description": "Запрос на создание нового адреса с информацией о стране, городе и улице",
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "street"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Москва"
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Россия"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Технопарк, 15"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.AppliedDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "7599.00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SPRING10"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Весенняя скидка на инструмент"
+                },
+                "promotion_id": {
+                    "type": "string",
+                    "example": "d3eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.BadGatewayErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "payment provider error: unexpected status 503 Service Unavailable"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartCheckoutRequest": {
+            "type": "object",
+            "required": [
+                "promo_codes"
+            ],
+            "properties": {
+                "promo_codes": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SPRING10"
+                    ]
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartItemQuantityRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartItemResponse": {
+            "description": "Позиция корзины с текущей ценой и остатком товара",
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "available_stock": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "151980.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "75990.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartMergeRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CartResponse": {
+            "description": "Корзина с позициями и итоговой суммой по текущим ценам. token используется для доступа к корзине",
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "333e8400-e29b-41d4-a716-446655440001"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.CartItemResponse"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "151980.00"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CategoryRequest": {
+            "description": "Запрос на создание новой категории товаров. tax_rate — ставка налога в процентах для товаров категории; без неё действует ставка по умолчанию",
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Холодильники"
+                },
+                "tax_rate": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "example": "20"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CategoryResponse": {
+            "description": "Данные категории включая уникальный идентификатор и название",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Холодильники"
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "tax_rate": {
+                    "type": "string",
+                    "example": "20.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ClientRequest": {
+            "description": "Запрос на создание нового товара с категорией, ценой и информацией о поставщике",
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                },
+                "birthday": {
+                    "type": "string",
+                    "example": "1999-01-01"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Иван"
+                },
+                "surname": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Иванов"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ClientResponse": {
+            "description": "Данные клиента включая дату регистрации и ссылку на адрес",
+            "type": "object",
+            "properties": {
+                "address_uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "birthday": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "registration_date": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ConflictErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "illegal_transition"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "illegal order status transition: new -\u003e shipped"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CurrencyRequest": {
+            "description": "Название и правила округления сумм, пересчитанных в валюту. По умолчанию два знака после запятой и округление половины вверх",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "decimals": {
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 0,
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Доллар США"
+                },
+                "rounding": {
+                    "type": "string",
+                    "enum": [
+                        "half_up",
+                        "down",
+                        "up"
+                    ],
+                    "example": "half_up"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.CurrencyResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Доллар США"
+                },
+                "rounding": {
+                    "type": "string",
+                    "example": "half_up"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ExchangeRateImportResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ExchangeRateRequest": {
+            "description": "Сколько рублей стоит единица валюты начиная с effective_date",
+            "type": "object",
+            "required": [
+                "currency",
+                "effective_date",
+                "rate"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "effective_date": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "92.5031"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "effective_date": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "92.50310000"
+                },
+                "rate_id": {
+                    "type": "string",
+                    "example": "7a1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ExchangeRatesRequest": {
+            "type": "object",
+            "required": [
+                "rates"
+            ],
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ExchangeRateRequest"
+                    }
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ForbiddenErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "insufficient permissions"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.GoodsReceiptLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 18
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.GoodsReceiptLineResponse": {
+            "description": "discrepancy — разница между принятым и ожидаемым количеством: отрицательная при недопоставке, положительная при излишке",
+            "type": "object",
+            "properties": {
+                "discrepancy": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "expected_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.GoodsReceiptRequest": {
+            "description": "Фактически принятые количества. Нулевое количество фиксирует, что позиция не пришла",
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.GoodsReceiptLineRequest"
+                    }
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.GoodsReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.GoodsReceiptLineResponse"
+                    }
+                },
+                "receipt_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ImageResponse": {
+            "description": "Данные изображения включая уникальный идентификатор",
+            "type": "object",
+            "properties": {
+                "image_id": {
+                    "type": "string",
+                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.InternalErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.CategoryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ClientResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ClientResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ExchangeRateResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_OrderResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.OrderResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ProductPriceResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ProductResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ProductResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_ReturnResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_StockAlertResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.StockAlertResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.StockMovementResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_SupplierProductResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierProductResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_SupplierResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.SupplierResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ListResponse-hardware_store_internal_web_dto_WarehouseResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.WarehouseResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoi...Q.mD2c..."
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.NotFoundErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "client not found"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderItemResponse": {
+            "description": "Позиция заказа с ценой на момент оформления. line_total — сумма без скидки, total — со скидкой в ценах заказа; net, tax и gross — total без налога, налог и с налогом; returned_quantity — сколько единиц вернулось по заявкам на возврат",
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "15198.00"
+                },
+                "gross": {
+                    "type": "string",
+                    "example": "136782.00"
+                },
+                "item_id": {
+                    "type": "string",
+                    "example": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "151980.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "net": {
+                    "type": "string",
+                    "example": "113985.00"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "returned_quantity": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "22797.00"
+                },
+                "tax_rate": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "136782.00"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "75990.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderRequest": {
+            "description": "Клиент, позиции и промокоды заказа. Цены берутся из текущих цен товаров, скидки — из действующих акций. Без warehouse_id товары списываются со склада по умолчанию",
+            "type": "object",
+            "required": [
+                "client_id",
+                "items",
+                "promo_codes"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "333e8400-e29b-41d4-a716-446655440001"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.OrderItemRequest"
+                    }
+                },
+                "promo_codes": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SPRING10"
+                    ]
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderResponse": {
+            "description": "Заказ клиента с позициями, статусом и итоговой суммой. tax_mode — включали ли цены налог при оформлении (inclusive) или налог начислен сверху (exclusive); total — сумма к оплате с налогом, tax_lines — налог по ставкам",
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "333e8400-e29b-41d4-a716-446655440001"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "15198.00"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.OrderItemResponse"
+                    }
+                },
+                "net": {
+                    "type": "string",
+                    "example": "113985.00"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "new"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "22797.00"
+                },
+                "tax_lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.TaxLineResponse"
+                    }
+                },
+                "tax_mode": {
+                    "type": "string",
+                    "example": "inclusive"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "136782.00"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.OrderTransitionResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderTransitionRequest": {
+            "description": "Новый статус заказа. Допустимые переходы: new → paid|cancelled, paid → packed|cancelled, packed → shipped|cancelled, shipped → delivered, delivered → returned",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "paid",
+                        "packed",
+                        "shipped",
+                        "delivered",
+                        "cancelled",
+                        "returned"
+                    ],
+                    "example": "paid"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.OrderTransitionResponse": {
+            "description": "Запись истории статусов заказа",
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "new"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "paid"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PaymentRefundRequest": {
+            "description": "Сумма возврата или заявка на возврат товара. С return_id и без amount возвращается сумма заявки, а заявка закрывается возвратом денег",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "1500.00"
+                },
+                "return_id": {
+                    "type": "string",
+                    "example": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PaymentResponse": {
+            "description": "Платёж в платёжном шлюзе. confirmation_url — страница оплаты для покупателя, refunded — сумма, уже возвращённая покупателю",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "68391.00"
+                },
+                "confirmation_url": {
+                    "type": "string",
+                    "example": "http://localhost:8090/payments/fake_5f0c7a1e-7f3b-4a61-9d7e-2c1f1b9c0a11/pay"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string",
+                    "example": "fake_5f0c7a1e-7f3b-4a61-9d7e-2c1f1b9c0a11"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "http"
+                },
+                "refunded": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductImportResponse": {
+            "description": "Импорт обрабатывается в фоне: pending — в очереди, running — обрабатывается, completed — обработан (ошибки строк в errors), failed — файл не удалось обработать (причина в error)",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_rows": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "error": {
+                    "type": "string",
+                    "example": "missing columns: price"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ProductImportRowError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "catalog.csv"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "csv"
+                },
+                "import_id": {
+                    "type": "string",
+                    "example": "9b2e8400-e29b-41d4-a716-446655440000"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated_rows": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "upsert": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductImportRowError": {
+            "description": "row — номер строки в файле с единицы, включая заголовок; field отсутствует, если ошибка относится ко всей строке",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "category"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "category not found"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductLocationResponse": {
+            "description": "Часть общего остатка available_stock, находящаяся на конкретном складе",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Основной склад"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductPatchRequest": {
+            "description": "Частичное обновление товара (JSON merge patch): переданные поля заменяются, null в category_id или supplier_id отвязывает категорию или поставщика",
+            "type": "object",
+            "properties": {
+                "available_stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "72990.00"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductPriceRequest": {
+            "description": "Цена в рублях начнёт действовать с valid_from. Цена, уже запланированная на тот же момент, заменяется",
+            "type": "object",
+            "required": [
+                "price",
+                "valid_from"
+            ],
+            "properties": {
+                "price": {
+                    "type": "string",
+                    "example": "69990.00"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Осенняя распродажа"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2026-11-01T00:00:00+03:00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductPriceResponse": {
+            "description": "У текущей цены valid_to отсутствует. Запланированная цена отмечена scheduled и ещё не применена",
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "69990.00"
+                },
+                "price_id": {
+                    "type": "string",
+                    "example": "8b1e8400-e29b-41d4-a716-446655440000"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Осенняя распродажа"
+                },
+                "scheduled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductReorderPolicyResponse": {
+            "description": "Собственная политика товара, политика его категории и действующая политика, по которой проверяется остаток",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyResponse"
+                },
+                "effective": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyResponse"
+                },
+                "product": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.ReorderPolicyResponse"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductRequest": {
+            "description": "Запрос на обновление количества товара на складе",
+            "type": "object",
+            "required": [
+                "available_stock",
+                "category_id",
+                "name",
+                "price",
+                "supplier_id"
+            ],
+            "properties": {
+                "available_stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 15
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "75990.00"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "RB38A7861B1"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductResponse": {
+            "description": "Полная информация о товаре включая цену, остатки и информацию о поставщике",
+            "type": "object",
+            "required": [
+                "available_stock",
+                "last_update_date",
+                "name",
+                "price",
+                "product_id"
+            ],
+            "properties": {
+                "available_stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "category": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ProductLocationResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "price": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "reserved_stock": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "RB38A7861B1"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductTaxRequest": {
+            "description": "Ставка в процентах, которая действует для товара вместо ставки его категории",
+            "type": "object",
+            "required": [
+                "tax_rate"
+            ],
+            "properties": {
+                "tax_rate": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "example": "10"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ProductTaxResponse": {
+            "description": "tax_rate — действующая ставка, source — откуда она взята: product, category или default. override и category_rate — ставки товара и категории, если заданы",
+            "type": "object",
+            "properties": {
+                "category_rate": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "override": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "product"
+                },
+                "tax_rate": {
+                    "type": "string",
+                    "example": "10.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PromotionEvaluateRequest": {
+            "description": "Позиции берутся из корзины cart_token или из items. Клиент нужен для проверки лимитов промокодов на клиента; у корзины клиента он берётся из корзины",
+            "type": "object",
+            "required": [
+                "promo_codes"
+            ],
+            "properties": {
+                "cart_token": {
+                    "type": "string",
+                    "example": "7Jx0c2VjcmV0LXRva2Vu"
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "333e8400-e29b-41d4-a716-446655440001"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.OrderItemRequest"
+                    }
+                },
+                "promo_codes": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SPRING10"
+                    ]
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PromotionEvaluationResponse": {
+            "description": "Скидки каждой позиции и итог по каждой применённой акции",
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "7599.00"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.PromotionLineResponse"
+                    }
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.AppliedDiscountResponse"
+                    }
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "75990.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "68391.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PromotionLineResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "7599.00"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.AppliedDiscountResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "75990.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "68391.00"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "75990.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PromotionRequest": {
+            "description": "Вид kind задаёт обязательные параметры: percent — процент скидки с позиции, fixed — сумма скидки на все подходящие позиции заказа, buy_x_get_y — из каждых buy_quantity+get_quantity единиц товара get_quantity бесплатны. Без category_id и supplier_id акция действует на все товары. Акция с code применяется только по промокоду. Акции применяются по убыванию priority; акция с stackable=false не складывается с другими скидками на ту же позицию",
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "SPRING10"
+                },
+                "get_quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed",
+                        "buy_x_get_y"
+                    ],
+                    "example": "percent"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Весенняя скидка на инструмент"
+                },
+                "per_client_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percent": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "example": "10"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2026-03-01T00:00:00+03:00"
+                },
+                "valid_to": {
+                    "type": "string",
+                    "example": "2026-06-01T00:00:00+03:00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SPRING10"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "get_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Весенняя скидка на инструмент"
+                },
+                "per_client_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "promotion_id": {
+                    "type": "string",
+                    "example": "d3eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PurchaseOrderLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "expected_price": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "52000.00"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PurchaseOrderLineResponse": {
+            "type": "object",
+            "properties": {
+                "expected_price": {
+                    "type": "string",
+                    "example": "52000.00"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "1040000.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "outstanding": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PurchaseOrderRequest": {
+            "description": "Черновик заказа поставщику. Без warehouse_id товар принимается на склад по умолчанию",
+            "type": "object",
+            "required": [
+                "lines",
+                "supplier_id"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderLineRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Поставка к сезону"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PurchaseOrderResponse": {
+            "description": "Заказ поставщику с позициями, ожидаемой суммой и историей приёмок",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.PurchaseOrderLineResponse"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Поставка к сезону"
+                },
+                "purchase_order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.GoodsReceiptResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "draft"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "1040000.00"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.PurchaseOrderTransitionRequest": {
+            "description": "Ручные переходы: draft → sent|cancelled, sent → cancelled, partially_received → received (закрыть недопоставку)",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "sent",
+                        "partially_received",
+                        "received",
+                        "cancelled"
+                    ],
+                    "example": "sent"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReorderPolicyRequest": {
+            "description": "Остаток ниже reorder_point вызывает оповещение с рекомендацией заказать reorder_quantity единиц. Отсутствующее поле у товара берётся из категории",
+            "type": "object",
+            "properties": {
+                "reorder_point": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReorderPolicyResponse": {
+            "type": "object",
+            "properties": {
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.RepricingItemResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "new_price": {
+                    "type": "string",
+                    "example": "81690.00"
+                },
+                "old_price": {
+                    "type": "string",
+                    "example": "75990.00"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.RepricingRequest": {
+            "description": "Меняет цены товаров категории, поставщика или диапазона цен на процент percent или на сумму amount — задаётся одно из двух, отрицательное значение снижает цену. ending округляет новую цену до ближайшей с таким числом копеек",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "-100.00"
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ending": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0,
+                    "example": 90
+                },
+                "max_price": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "50000.00"
+                },
+                "min_price": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "1000.00"
+                },
+                "percent": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "example": "7.5"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Повышение цен поставщиком"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.RepricingResponse": {
+            "description": "matched — число товаров под фильтром, items — товары, цена которых изменилась или изменится. При dry_run цены не меняются",
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.RepricingItemResponse"
+                    }
+                },
+                "matched": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReservationRequest": {
+            "description": "Количество, склад и срок резерва. Без ttl_seconds используется срок из конфигурации, без warehouse_id при подтверждении товар списывается со склада по умолчанию",
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "cart q1w2e3r4"
+                },
+                "ttl_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "example": 900
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReservationResponse": {
+            "description": "Резерв удерживает количество товара до expires_at, пока не будет подтверждён или освобождён",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "cart q1w2e3r4"
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnInspectionLineRequest": {
+            "type": "object",
+            "required": [
+                "item_id",
+                "outcome"
+            ],
+            "properties": {
+                "item_id": {
+                    "type": "string",
+                    "example": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "restock",
+                        "write_off",
+                        "send_to_supplier"
+                    ],
+                    "example": "restock"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnInspectionRequest": {
+            "description": "Решение по каждой позиции заявки: restock — вернуть в продажу, write_off — списать, send_to_supplier — отправить поставщику",
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnInspectionLineRequest"
+                    }
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnLineRequest": {
+            "type": "object",
+            "required": [
+                "item_id",
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Не включается компрессор"
+                },
+                "item_id": {
+                    "type": "string",
+                    "example": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "defective",
+                        "damaged",
+                        "wrong_item",
+                        "not_as_described",
+                        "changed_mind",
+                        "other"
+                    ],
+                    "example": "defective"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnLineResponse": {
+            "description": "outcome заполняется после осмотра, refund — сумма к возврату за позицию",
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Не включается компрессор"
+                },
+                "item_id": {
+                    "type": "string",
+                    "example": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "send_to_supplier"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "defective"
+                },
+                "refund": {
+                    "type": "string",
+                    "example": "68391.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnRequest": {
+            "description": "Позиции доставленного заказа с количеством и причиной возврата. Без warehouse_id товар принимается на склад по умолчанию",
+            "type": "object",
+            "required": [
+                "lines",
+                "order_id"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnLineRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Покупатель принёс товар в магазин"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnResponse": {
+            "description": "Заявка на возврат с позициями, суммой к возврату и складом приёмки",
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "333e8400-e29b-41d4-a716-446655440001"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hardware_store_internal_web_dto.ReturnLineResponse"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Покупатель принёс товар в магазин"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "refund": {
+                    "type": "string",
+                    "example": "68391.00"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "return_id": {
+                    "type": "string",
+                    "example": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.ReturnTransitionRequest": {
+            "description": "Ручные переходы: requested → approved|rejected|cancelled, approved → received|cancelled. В refunded заявку переводит только возврат денег по платежу",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "requested",
+                        "approved",
+                        "rejected",
+                        "received",
+                        "inspected",
+                        "cancelled"
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.StockAlertResponse": {
+            "description": "Товар, остаток которого опустился ниже точки заказа, и рекомендуемая закупка у его поставщика",
+            "type": "object",
+            "properties": {
+                "alert_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "notified_at": {
+                    "type": "string"
+                },
+                "product_id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
                 },
-                "city": {
+                "product_name": {
                     "type": "string",
-                    "example": "Москва"
+                    "example": "Холодильник Samsung RB38A7861B1"
                 },
-                "country": {
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string",
-                    "example": "Россия"
+                    "example": "open"
                 },
-                "street": {
+                "stock": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "suggested_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "supplier_id": {
                     "type": "string",
-                    "example": "Технопарк, 15"
-                }
-            }
-        },
-        "hardware_store_internal_web_dto.CategoryRequest": {
-            "description": "Запрос на создание новой категории товаров",
-            "type": "object",
-            "required": [
-                "category"
-            ],
-            "properties": {
-                "category": {
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "supplier_name": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2,
-                    "example": "Холодильники"
+                    "example": "ООО 'ТехноСнаб'"
                 }
             }
         },
-        "hardware_store_internal_web_dto.CategoryResponse": {
-            "description": "Данные категории включая уникальный идентификатор и название",
+        "hardware_store_internal_web_dto.StockDiscrepancyResponse": {
+            "description": "Товар, у которого остаток не совпадает с суммой движений по журналу",
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string",
-                    "example": "Холодильники"
+                "available_stock": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "category_id": {
+                "ledger_stock": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "product_id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
                 }
             }
         },
-        "hardware_store_internal_web_dto.ClientRequest": {
-            "description": "Запрос на создание нового товара с категорией, ценой и информацией о поставщике",
+        "hardware_store_internal_web_dto.StockMovementRequest": {
+            "description": "Движение остатка товара. Для adjustment quantity задаётся со знаком, для остальных типов — положительное количество. Без warehouse_id движение проводится по складу по умолчанию",
             "type": "object",
             "required": [
-                "name",
-                "surname"
+                "quantity",
+                "type"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "birthday": {
+                "reason": {
                     "type": "string",
-                    "example": "1999-01-01"
+                    "maxLength": 255,
+                    "example": "Поставка по накладной №123"
                 },
-                "gender": {
+                "type": {
                     "type": "string",
                     "enum": [
-                        "male",
-                        "female"
+                        "receipt",
+                        "sale",
+                        "return",
+                        "adjustment",
+                        "write_off",
+                        "supplier_return"
                     ],
-                    "example": "male"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2,
-                    "example": "Иван"
+                    "example": "receipt"
                 },
-                "surname": {
+                "warehouse_id": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2,
-                    "example": "Иванов"
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "hardware_store_internal_web_dto.ClientResponse": {
-            "description": "Данные клиента включая дату регистрации и ссылку на адрес",
+        "hardware_store_internal_web_dto.StockMovementResponse": {
+            "description": "Движение остатка: quantity со знаком, actor_id — пользователь, проводивший движение",
             "type": "object",
             "properties": {
-                "address_uuid": {
+                "actor_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "birthday": {
+                "created_at": {
                     "type": "string"
                 },
-                "client_id": {
+                "movement_id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
                 },
-                "gender": {
-                    "type": "string"
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
                 },
-                "name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "example": -2
                 },
-                "registration_date": {
+                "reason": {
                     "type": "string"
                 },
-                "surname": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "sale"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "hardware_store_internal_web_dto.ImageResponse": {
-            "description": "Данные изображения включая уникальный идентификатор",
+        "hardware_store_internal_web_dto.StockMovementResultResponse": {
+            "description": "Проведённое движение и остаток товара после него",
             "type": "object",
             "properties": {
-                "image_id": {
-                    "type": "string",
-                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+                "available_stock": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "movement": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.StockMovementResponse"
                 }
             }
         },
-        "hardware_store_internal_web_dto.InternalErrorResponse": {
+        "hardware_store_internal_web_dto.StockTransferRequest": {
+            "description": "Перемещение части остатка товара с одного склада на другой. Общий остаток товара не меняется",
             "type": "object",
+            "required": [
+                "from_warehouse_id",
+                "product_id",
+                "quantity",
+                "to_warehouse_id"
+            ],
             "properties": {
-                "error": {
+                "from_warehouse_id": {
                     "type": "string",
-                    "example": "internal server error"
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to_warehouse_id": {
+                    "type": "string",
+                    "example": "7a2e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "hardware_store_internal_web_dto.NotFoundErrorResponse": {
+        "hardware_store_internal_web_dto.StockTransferResponse": {
+            "description": "Проведённое перемещение: actor_id — пользователь, проводивший перемещение",
             "type": "object",
             "properties": {
-                "error": {
+                "actor_id": {
                     "type": "string",
-                    "example": "client not found"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to_warehouse_id": {
+                    "type": "string",
+                    "example": "7a2e8400-e29b-41d4-a716-446655440000"
+                },
+                "transfer_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
                 }
             }
         },
-        "hardware_store_internal_web_dto.ProductRequest": {
-            "description": "Запрос на обновление количества товара на складе",
+        "hardware_store_internal_web_dto.SupplierProductRequest": {
+            "description": "Позиция прайс-листа: артикул поставщика, закупочная цена, срок поставки и минимальная партия. Без валюты цена считается в RUB",
             "type": "object",
-            "required": [
-                "available_stock",
-                "category_id",
-                "name",
-                "price",
-                "supplier_id"
-            ],
             "properties": {
-                "available_stock": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "lead_time_days": {
                     "type": "integer",
                     "minimum": 0,
-                    "example": 15
+                    "example": 7
                 },
-                "category_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "min_order_quantity": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "name": {
+                "purchase_price": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2,
-                    "example": "Холодильник Samsung RB38A7861B1"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 75990
+                    "minLength": 0,
+                    "example": "48500.00"
                 },
-                "supplier_id": {
+                "supplier_sku": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "maxLength": 64,
+                    "example": "TS-RB38-001"
                 }
             }
         },
-        "hardware_store_internal_web_dto.ProductResponse": {
-            "description": "Полная информация о товаре включая цену, остатки и информацию о поставщике",
+        "hardware_store_internal_web_dto.SupplierProductResponse": {
             "type": "object",
-            "required": [
-                "available_stock",
-                "last_update_date",
-                "name",
-                "price",
-                "product_id"
-            ],
             "properties": {
-                "available_stock": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "lead_time_days": {
                     "type": "integer",
-                    "minimum": 0
+                    "example": 7
                 },
-                "category": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "min_order_quantity": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "image": {
+                "product_id": {
                     "type": "string",
-                    "example": "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
                 },
-                "last_update_date": {
-                    "type": "string"
+                "product_name": {
+                    "type": "string",
+                    "example": "Холодильник Samsung RB38A7861B1"
                 },
-                "name": {
+                "purchase_price": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "48500.00"
                 },
-                "price": {
-                    "type": "number"
+                "supplier_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "product_id": {
+                "supplier_name": {
                     "type": "string",
-                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                    "example": "ООО 'ТехноСнаб'"
                 },
-                "supplier": {
+                "supplier_sku": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "TS-RB38-001"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -1576,6 +9369,37 @@ const docTemplate = `{
                 }
             }
         },
+        "hardware_store_internal_web_dto.TaxLineResponse": {
+            "description": "Сумма без налога, налог и сумма с налогом по позициям с одной ставкой",
+            "type": "object",
+            "properties": {
+                "gross": {
+                    "type": "string",
+                    "example": "136782.00"
+                },
+                "net": {
+                    "type": "string",
+                    "example": "113985.00"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "22797.00"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.UnauthorizedErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "missing access token"
+                }
+            }
+        },
         "hardware_store_internal_web_dto.UpdateStockCountRequest": {
             "type": "object",
             "required": [
@@ -1585,6 +9409,10 @@ const docTemplate = `{
                 "amount": {
                     "type": "integer",
                     "example": 5
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
@@ -1596,6 +9424,69 @@ const docTemplate = `{
                     "example": "invalid uuid format"
                 }
             }
+        },
+        "hardware_store_internal_web_dto.WarehouseRequest": {
+            "description": "Склад или торговый зал с адресом",
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/hardware_store_internal_web_dto.AddressRequest"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "warehouse",
+                        "showroom"
+                    ],
+                    "example": "warehouse"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Склад на Складской"
+                }
+            }
+        },
+        "hardware_store_internal_web_dto.WarehouseResponse": {
+            "description": "Место хранения товара. Склад по умолчанию принимает движения остатков без указанного склада",
+            "type": "object",
+            "properties": {
+                "address_uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "warehouse"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Основной склад"
+                },
+                "warehouse_id": {
+                    "type": "string",
+                    "example": "6f1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -1610,8 +9501,6 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "REST API для магазина бытовой техники",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
-	//LeftDelim:        "{{",
-	//RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"hardware_store/internal/config"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/service/cart"
+	"hardware_store/internal/service/imports"
+	"hardware_store/internal/service/payment"
+	"hardware_store/internal/service/product"
+	"hardware_store/internal/service/replenishment"
+	"log/slog"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// AddReservationSweeper периодически освобождает истёкшие резервы товаров.
+func AddReservationSweeper(lc fx.Lifecycle, products product.ProductService, cfg *config.Config, log *slog.Logger) {
+	runPeriodically(lc, cfg.Reservation.SweepInterval, func(ctx context.Context) {
+		n, err := products.ReleaseExpired(ctx)
+		if err != nil {
+			log.Error("Failed to release expired reservations", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Released expired reservations", slog.Int("count", n))
+		}
+	})
+}
+
+// AddCartCleanup периодически удаляет истёкшие корзины.
+func AddCartCleanup(lc fx.Lifecycle, carts cart.CartService, cfg *config.Config, log *slog.Logger) {
+	runPeriodically(lc, cfg.Cart.CleanupInterval, func(ctx context.Context) {
+		n, err := carts.PurgeExpired(ctx)
+		if err != nil {
+			log.Error("Failed to purge expired carts", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Purged expired carts", slog.Int("count", n))
+		}
+	})
+}
+
+// AddStockAlertScanner периодически сверяет остатки с точками заказа и
+// рассылает оповещения о низком остатке.
+func AddStockAlertScanner(lc fx.Lifecycle, alerts replenishment.ReplenishmentService, cfg *config.Config, log *slog.Logger) {
+	runPeriodically(lc, cfg.Alerts.ScanInterval, func(ctx context.Context) {
+		n, err := alerts.Scan(ctx)
+		if err != nil {
+			log.Error("Failed to scan stock levels", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Opened low stock alerts", slog.Int("count", n))
+		}
+	})
+}
+
+// AddImportWorker периодически забирает в обработку загруженные файлы
+// импорта товаров.
+func AddImportWorker(lc fx.Lifecycle, imports imports.ImportService, cfg *config.Config, log *slog.Logger) {
+	runPeriodically(lc, cfg.Import.PollInterval, func(ctx context.Context) {
+		n, err := imports.ProcessPending(ctx)
+		if err != nil {
+			log.Error("Failed to process product imports", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Processed product imports", slog.Int("count", n))
+		}
+	})
+}
+
+// AddPriceScheduler периодически применяет запланированные цены товаров,
+// срок которых наступил.
+func AddPriceScheduler(lc fx.Lifecycle, products product.ProductService, cfg *config.Config, log *slog.Logger) {
+	runPeriodically(lc, cfg.Pricing.ActivationInterval, func(ctx context.Context) {
+		n, err := products.ActivateScheduledPrices(ctx)
+		if err != nil {
+			log.Error("Failed to activate scheduled prices", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Activated scheduled prices", slog.Int("count", n))
+		}
+	})
+}
+
+// AddPaymentReconciler при запуске сверяет незавершённые платежи с
+// платёжным шлюзом, чтобы подхватить уведомления, пропущенные, пока
+// приложение не работало.
+func AddPaymentReconciler(lc fx.Lifecycle, payments payment.PaymentService, log *slog.Logger) {
+	runOnStart(lc, func(ctx context.Context) {
+		n, err := payments.Reconcile(ctx)
+		if err != nil {
+			log.Error("Failed to reconcile payments", logger.Err(err))
+		}
+		if n > 0 {
+			log.Info("Reconciled payments", slog.Int("count", n))
+		}
+	})
+}
+
+// runOnStart запускает fn один раз в фоне после старта приложения, чтобы не
+// задерживать запуск. При остановке прерывает fn и ждёт её завершения.
+func runOnStart(lc fx.Lifecycle, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// runPeriodically запускает fn раз в interval, пока работает приложение.
+// При остановке ждёт завершения текущего запуска.
+func runPeriodically(lc fx.Lifecycle, interval time.Duration, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						fn(ctx)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	pb "github.com/tambrama/protos/gen/go/sso"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	api     pb.AuthClient
+	timeout time.Duration
+}
+
+func NewClient(cfg *config.Config) (*Client, error) {
+	const op = "client.auth.NewClient"
+	conn, err := grpc.NewClient(cfg.Auth.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &Client{
+		conn:    conn,
+		api:     pb.NewAuthClient(conn),
+		timeout: cfg.Auth.Timeout,
+	}, nil
+}
+
+// Validate проверяет access-токен через RPC Validate.
+// Интерцептор auth-service требует тот же токен в метаданных authorization.
+func (c *Client) Validate(ctx context.Context, token string) (auth.Claims, error) {
+	const op = "client.auth.Validate"
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+	resp, err := c.api.Validate(ctx, &pb.ValidateRequest{Token: token})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.InvalidArgument:
+			return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+		default:
+			return auth.Claims{}, fmt.Errorf("%s: %w: %v", op, model.ErrAuthUnavailable, err)
+		}
+	}
+
+	userID, err := uuid.Parse(resp.GetUserId())
+	if err != nil {
+		return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+	}
+	appID, err := uuid.Parse(resp.GetAppId())
+	if err != nil {
+		return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+	}
+	// Validate не возвращает роли, поэтому они берутся из уже проверенного токена.
+	var tokenClaims auth.TokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &tokenClaims); err != nil {
+		return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+	}
+	return auth.Claims{UserID: userID, AppID: appID, Roles: tokenClaims.Roles}, nil
+}
+
+func AddClientLifecycle(lc fx.Lifecycle, c *Client) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return c.conn.Close()
+		},
+	})
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/tax"
 	"log"
 	"os"
 	"time"
@@ -12,6 +14,16 @@ type Config struct {
 	Env         string `yaml:"env" env-default:"development"`
 	DatabaseURL string `yaml:"database_url" env-required:"true"`
 	HTTPServer  `yaml:"http_server"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Pagination  PaginationConfig  `yaml:"pagination"`
+	Cart        CartConfig        `yaml:"cart"`
+	Reservation ReservationConfig `yaml:"reservation"`
+	Alerts      AlertsConfig      `yaml:"alerts"`
+	Import      ImportConfig      `yaml:"import"`
+	Pricing     PricingConfig     `yaml:"pricing"`
+	Tax         TaxConfig         `yaml:"tax"`
+	Invoice     InvoiceConfig     `yaml:"invoice"`
+	Payment     PaymentConfig     `yaml:"payment"`
 }
 
 type HTTPServer struct {
@@ -20,6 +32,113 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+// AuthConfig настройки подключения к auth-service.
+// PublicRoutes задаются как "METHOD /path" или просто "/path" (любой метод),
+// путь указывается в формате маршрутов gin, например "GET /api/v1/products/:id".
+type AuthConfig struct {
+	Address      string        `yaml:"address" env-default:"auth-service:9090"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"3s"`
+	JWTSecretKey string        `yaml:"jwt_secret_key"`
+	PublicRoutes []string      `yaml:"public_routes"`
+}
+
+// PaginationConfig CursorSecret подписывает курсоры страниц и должен
+// совпадать на всех экземплярах приложения за балансировщиком.
+type PaginationConfig struct {
+	CursorSecret string `yaml:"cursor_secret"`
+	DefaultLimit int    `yaml:"default_limit" env-default:"20"`
+	MaxLimit     int    `yaml:"max_limit" env-default:"100"`
+}
+
+// CartConfig TTL время неактивности, после которого корзина считается
+// истёкшей. Истёкшие корзины удаляются раз в CleanupInterval.
+type CartConfig struct {
+	TTL             time.Duration `yaml:"ttl" env-default:"72h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env-default:"1h"`
+}
+
+// ReservationConfig TTL срок жизни резерва товара по умолчанию. Истёкшие
+// резервы освобождаются раз в SweepInterval.
+type ReservationConfig struct {
+	TTL           time.Duration `yaml:"ttl" env-default:"15m"`
+	SweepInterval time.Duration `yaml:"sweep_interval" env-default:"1m"`
+}
+
+// AlertsConfig остатки сверяются с точками заказа раз в ScanInterval.
+// Notifiers перечисляет получателей оповещений: log, webhook и file.
+// Webhook получает оповещения POST-запросом на WebhookURL, file дописывает
+// их в FilePath по одному JSON-объекту на строку.
+type AlertsConfig struct {
+	ScanInterval   time.Duration `yaml:"scan_interval" env-default:"5m"`
+	Notifiers      []string      `yaml:"notifiers" env-default:"log"`
+	WebhookURL     string        `yaml:"webhook_url"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" env-default:"5s"`
+	FilePath       string        `yaml:"file_path"`
+}
+
+// ImportConfig загруженные файлы импорта товаров забираются в обработку раз
+// в PollInterval. Файлы больше MaxFileSize байт и с числом строк больше
+// MaxRows отклоняются.
+type ImportConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"5s"`
+	MaxFileSize  int64         `yaml:"max_file_size" env-default:"10485760"`
+	MaxRows      int           `yaml:"max_rows" env-default:"10000"`
+}
+
+// PricingConfig запланированные цены, срок которых наступил, применяются
+// раз в ActivationInterval.
+type PricingConfig struct {
+	ActivationInterval time.Duration `yaml:"activation_interval" env-default:"1m"`
+}
+
+// TaxConfig Mode — режим цен каталога: inclusive, если цены включают налог,
+// или exclusive, если налог начисляется сверху. DefaultRate — ставка в
+// процентах для товаров, у которых ставка не задана ни у товара, ни у
+// категории.
+type TaxConfig struct {
+	Mode        tax.Mode   `yaml:"mode" env-default:"inclusive"`
+	DefaultRate money.Rate `yaml:"default_rate" env-default:"0"`
+}
+
+// InvoiceConfig FontPath и BoldFontPath — шрифты TrueType с кириллицей,
+// которыми набираются PDF-счета и квитанции. Seller — реквизиты магазина в
+// шапке документов. TimeZone — часовой пояс магазина из базы IANA: по нему
+// определяются дата документа и год, с которого нумерация начинается
+// заново, независимо от часового пояса сервера.
+type InvoiceConfig struct {
+	FontPath     string       `yaml:"font_path" env-default:"/usr/share/fonts/dejavu/DejaVuSans.ttf"`
+	BoldFontPath string       `yaml:"bold_font_path" env-default:"/usr/share/fonts/dejavu/DejaVuSans-Bold.ttf"`
+	TimeZone     string       `yaml:"time_zone" env-default:"Europe/Moscow"`
+	Seller       SellerConfig `yaml:"seller"`
+}
+
+// SellerConfig реквизиты продавца. Пустые поля в документы не выводятся.
+type SellerConfig struct {
+	Name                 string `yaml:"name"`
+	INN                  string `yaml:"inn"`
+	KPP                  string `yaml:"kpp"`
+	Address              string `yaml:"address"`
+	Phone                string `yaml:"phone"`
+	Email                string `yaml:"email"`
+	Bank                 string `yaml:"bank"`
+	BIC                  string `yaml:"bic"`
+	Account              string `yaml:"account"`
+	CorrespondentAccount string `yaml:"correspondent_account"`
+}
+
+// PaymentConfig Provider — платёжный шлюз: fake хранит платежи в памяти и
+// оплачивает их сразу при создании, http работает со шлюзом по адресу
+// BaseURL, например с заглушкой cmd/paymentstub. WebhookSecret подписывает
+// уведомления шлюза. При AutoCapture деньги списываются сразу после оплаты,
+// иначе только блокируются до подтверждения.
+type PaymentConfig struct {
+	Provider      string        `yaml:"provider" env-default:"fake"`
+	BaseURL       string        `yaml:"base_url"`
+	WebhookSecret string        `yaml:"webhook_secret"`
+	Timeout       time.Duration `yaml:"timeout" env-default:"10s"`
+	AutoCapture   bool          `yaml:"auto_capture" env-default:"true"`
+}
+
 func NewConfig() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
@@ -35,5 +154,11 @@ func NewConfig() *Config {
 	if err != nil {
 		log.Fatalf("error reading config file: %s", err)
 	}
+	if !cfg.Tax.Mode.Valid() {
+		log.Fatalf("unknown tax mode %q", cfg.Tax.Mode)
+	}
+	if !tax.ValidRate(cfg.Tax.DefaultRate) {
+		log.Fatalf("tax default_rate %s is out of range", cfg.Tax.DefaultRate)
+	}
 	return &cfg
 }
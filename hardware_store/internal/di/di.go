@@ -2,38 +2,89 @@ package di
 
 import (
 	"hardware_store/internal/app"
+	authclient "hardware_store/internal/client/auth"
 	"hardware_store/internal/config"
 	"hardware_store/internal/logger"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/notifier"
+	paymentprovider "hardware_store/internal/payment"
 	"hardware_store/internal/server"
 	addressservice "hardware_store/internal/service/address"
+	cartservice "hardware_store/internal/service/cart"
 	categoryservice "hardware_store/internal/service/category"
 	clientservice "hardware_store/internal/service/client"
+	currencyservice "hardware_store/internal/service/currency"
 	imagesservice "hardware_store/internal/service/images"
+	importsservice "hardware_store/internal/service/imports"
+	invoiceservice "hardware_store/internal/service/invoice"
+	orderservice "hardware_store/internal/service/order"
+	paymentservice "hardware_store/internal/service/payment"
 	productservice "hardware_store/internal/service/product"
+	promotionservice "hardware_store/internal/service/promotion"
+	purchaseservice "hardware_store/internal/service/purchase"
+	replenishmentservice "hardware_store/internal/service/replenishment"
+	returnsservice "hardware_store/internal/service/returns"
+	stockservice "hardware_store/internal/service/stock"
 	supplierservice "hardware_store/internal/service/supplier"
+	warehouseservice "hardware_store/internal/service/warehouse"
 	"hardware_store/internal/storage/postgres"
 	"hardware_store/internal/storage/postgres/address"
+	"hardware_store/internal/storage/postgres/cart"
 	"hardware_store/internal/storage/postgres/category"
 	"hardware_store/internal/storage/postgres/client"
+	"hardware_store/internal/storage/postgres/currency"
 	"hardware_store/internal/storage/postgres/images"
+	"hardware_store/internal/storage/postgres/imports"
+	"hardware_store/internal/storage/postgres/invoice"
+	"hardware_store/internal/storage/postgres/order"
+	"hardware_store/internal/storage/postgres/payment"
 	"hardware_store/internal/storage/postgres/product"
+	"hardware_store/internal/storage/postgres/promotion"
+	"hardware_store/internal/storage/postgres/purchase"
+	"hardware_store/internal/storage/postgres/replenishment"
+	"hardware_store/internal/storage/postgres/reservation"
+	"hardware_store/internal/storage/postgres/returns"
+	"hardware_store/internal/storage/postgres/stock"
 	"hardware_store/internal/storage/postgres/supplier"
 	"hardware_store/internal/storage/postgres/tx"
+	"hardware_store/internal/storage/postgres/warehouse"
 	"hardware_store/internal/web"
+	carthandler "hardware_store/internal/web/handler/cart"
 	categoryhandler "hardware_store/internal/web/handler/category"
 	clienthandler "hardware_store/internal/web/handler/client"
+	currencyhandler "hardware_store/internal/web/handler/currency"
 	imageshandler "hardware_store/internal/web/handler/images"
+	importshandler "hardware_store/internal/web/handler/imports"
+	invoicehandler "hardware_store/internal/web/handler/invoice"
+	orderhandler "hardware_store/internal/web/handler/order"
+	paymenthandler "hardware_store/internal/web/handler/payment"
 	producthandler "hardware_store/internal/web/handler/product"
+	promotionhandler "hardware_store/internal/web/handler/promotion"
+	purchasehandler "hardware_store/internal/web/handler/purchase"
+	replenishmenthandler "hardware_store/internal/web/handler/replenishment"
+	reservationhandler "hardware_store/internal/web/handler/reservation"
+	returnshandler "hardware_store/internal/web/handler/returns"
+	stockhandler "hardware_store/internal/web/handler/stock"
 	supplierhandler "hardware_store/internal/web/handler/supplier"
+	warehousehandler "hardware_store/internal/web/handler/warehouse"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
 	"net/http"
+	"reflect"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/fx"
 )
 
+// NewValidator проверяет денежные суммы по числу копеек, чтобы к ним
+// применялись правила required, gt и gte.
 func NewValidator() *validator.Validate {
-	return validator.New()
+	v := validator.New()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(money.Money).Amount
+	}, money.Money{})
+	return v
 }
 func ProvideEnv(cfg *config.Config) string {
 	return cfg.Env
@@ -47,13 +98,29 @@ var Module = fx.Options(
 		postgres.NewDB,
 		tx.NewTxManager,
 		NewValidator,
+		fx.Annotate(authclient.NewClient, fx.As(fx.Self()), fx.As(new(middleware.TokenValidator))),
 		/////////////
 		fx.Annotate(client.NewClientRepository, fx.As(new(clientservice.ClientRepository))),
 		fx.Annotate(address.NewAddressRepository, fx.As(new(addressservice.AddressRepository))),
 		fx.Annotate(product.NewProductRepository, fx.As(new(productservice.ProductRepository))),
+		fx.Annotate(reservation.NewReservationRepository, fx.As(new(productservice.ReservationRepository))),
 		fx.Annotate(supplier.NewSupplierRepository, fx.As(new(supplierservice.SupplierRepository))),
 		fx.Annotate(images.NewImagesRepository, fx.As(new(imagesservice.ImagesRepository))),
 		fx.Annotate(category.NewCategoryRepository, fx.As(new(categoryservice.CategoryRepository))),
+		fx.Annotate(stock.NewStockRepository, fx.As(new(stockservice.StockRepository))),
+		fx.Annotate(order.NewOrderRepository, fx.As(new(orderservice.OrderRepository))),
+		fx.Annotate(cart.NewCartRepository, fx.As(new(cartservice.CartRepository))),
+		fx.Annotate(warehouse.NewWarehouseRepository, fx.As(new(warehouseservice.WarehouseRepository))),
+		fx.Annotate(replenishment.NewReplenishmentRepository, fx.As(new(replenishmentservice.ReplenishmentRepository))),
+		fx.Annotate(notifier.New, fx.As(new(replenishmentservice.Notifier))),
+		fx.Annotate(purchase.NewPurchaseRepository, fx.As(new(purchaseservice.PurchaseRepository))),
+		fx.Annotate(imports.NewImportRepository, fx.As(new(importsservice.ImportRepository))),
+		fx.Annotate(currency.NewCurrencyRepository, fx.As(new(currencyservice.CurrencyRepository))),
+		fx.Annotate(promotion.NewPromotionRepository, fx.As(new(promotionservice.PromotionRepository))),
+		fx.Annotate(invoice.NewInvoiceRepository, fx.As(new(invoiceservice.InvoiceRepository))),
+		fx.Annotate(returns.NewReturnRepository, fx.As(new(returnsservice.ReturnRepository))),
+		fx.Annotate(payment.NewPaymentRepository, fx.As(new(paymentservice.PaymentRepository))),
+		paymentprovider.New,
 		/////////////
 		fx.Annotate(
 			clientservice.NewClientService,
@@ -74,12 +141,63 @@ var Module = fx.Options(
 		fx.Annotate(categoryservice.NewCategoryService,
 			fx.As(new(categoryservice.CategoryService)),
 		),
+		fx.Annotate(stockservice.NewStockService,
+			fx.As(new(stockservice.StockService)),
+		),
+		fx.Annotate(orderservice.NewOrderService,
+			fx.As(new(orderservice.OrderService)),
+		),
+		fx.Annotate(cartservice.NewCartService,
+			fx.As(new(cartservice.CartService)),
+		),
+		fx.Annotate(warehouseservice.NewWarehouseService,
+			fx.As(new(warehouseservice.WarehouseService)),
+		),
+		fx.Annotate(replenishmentservice.NewReplenishmentService,
+			fx.As(new(replenishmentservice.ReplenishmentService)),
+		),
+		fx.Annotate(purchaseservice.NewPurchaseService,
+			fx.As(new(purchaseservice.PurchaseService)),
+		),
+		fx.Annotate(importsservice.NewImportService,
+			fx.As(new(importsservice.ImportService)),
+		),
+		fx.Annotate(currencyservice.NewCurrencyService,
+			fx.As(new(currencyservice.CurrencyService)),
+		),
+		fx.Annotate(promotionservice.NewPromotionService,
+			fx.As(new(promotionservice.PromotionService)),
+		),
+		fx.Annotate(invoiceservice.NewInvoiceService,
+			fx.As(new(invoiceservice.InvoiceService)),
+		),
+		fx.Annotate(returnsservice.NewReturnService,
+			fx.As(new(returnsservice.ReturnService)),
+		),
+		fx.Annotate(paymentservice.NewPaymentService,
+			fx.As(new(paymentservice.PaymentService)),
+		),
 		/////////////
 		clienthandler.NewClientHandler,
 		imageshandler.NewImageHandler,
 		producthandler.NewProductHandler,
 		categoryhandler.NewCategoryHandler,
 		supplierhandler.NewSupplierHandler,
+		stockhandler.NewStockHandler,
+		orderhandler.NewOrderHandler,
+		carthandler.NewCartHandler,
+		reservationhandler.NewReservationHandler,
+		warehousehandler.NewWarehouseHandler,
+		replenishmenthandler.NewReplenishmentHandler,
+		purchasehandler.NewPurchaseHandler,
+		importshandler.NewImportHandler,
+		currencyhandler.NewCurrencyHandler,
+		promotionhandler.NewPromotionHandler,
+		invoicehandler.NewInvoiceHandler,
+		returnshandler.NewReturnHandler,
+		paymenthandler.NewPaymentHandler,
+		middleware.NewAuthMiddleware,
+		pagination.NewPaginator,
 		////////////
 		web.NewRouter,
 		func(engine *gin.Engine) http.Handler {
@@ -88,5 +206,12 @@ var Module = fx.Options(
 		server.NewServer,
 	),
 	fx.Invoke(app.NewApp,
-		postgres.AddDBLifecycle),
+		postgres.AddDBLifecycle,
+		authclient.AddClientLifecycle,
+		app.AddCartCleanup,
+		app.AddReservationSweeper,
+		app.AddStockAlertScanner,
+		app.AddImportWorker,
+		app.AddPriceScheduler,
+		app.AddPaymentReconciler),
 )
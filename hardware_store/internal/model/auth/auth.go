@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleManager  = "manager"
+	RoleCashier  = "cashier"
+	RoleCustomer = "customer"
+)
+
+type Claims struct {
+	UserID uuid.UUID
+	AppID  uuid.UUID
+	Roles  []string
+}
+
+// HasAnyRole возвращает true, если у пользователя есть хотя бы одна из ролей.
+// Администратору доступно всё.
+func (c Claims) HasAnyRole(roles ...string) bool {
+	if slices.Contains(c.Roles, RoleAdmin) {
+		return true
+	}
+	for _, role := range roles {
+		if slices.Contains(c.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenClaims повторяет model.CustomClaims из auth-service,
+// app_id там сериализуется под ключом "add_id".
+type TokenClaims struct {
+	UserID uuid.UUID `json:"user_id"`
+	AppID  uuid.UUID `json:"add_id"`
+	Roles  []string  `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type claimsKey struct{}
+
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
@@ -0,0 +1,52 @@
+package cart
+
+import (
+	"hardware_store/internal/model/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cart корзина клиента или анонимная корзина. Анонимная корзина не привязана
+// к клиенту и доступна только по Token.
+type Cart struct {
+	CartID    uuid.UUID
+	ClientID  *uuid.UUID
+	Token     string
+	Items     []Item
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Item позиция корзины. Name, UnitPrice и AvailableStock читаются из товара
+// при каждой загрузке корзины, поэтому всегда актуальны. AvailableStock —
+// свободный остаток без учёта зарезервированного.
+type Item struct {
+	ProductID      uuid.UUID
+	Name           string
+	Quantity       int
+	UnitPrice      money.Money
+	AvailableStock int
+	AddedAt        time.Time
+}
+
+func (c Cart) Anonymous() bool {
+	return c.ClientID == nil
+}
+
+func (c Cart) Total() money.Money {
+	var total money.Money
+	for _, item := range c.Items {
+		total = total.Add(item.LineTotal())
+	}
+	return total
+}
+
+func (i Item) LineTotal() money.Money {
+	return i.UnitPrice.Mul(i.Quantity)
+}
+
+// Available сообщает, хватает ли остатка на всё количество позиции.
+func (i Item) Available() bool {
+	return i.Quantity <= i.AvailableStock
+}
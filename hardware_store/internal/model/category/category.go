@@ -1,10 +1,15 @@
 package category
 
 import (
+	"hardware_store/internal/model/money"
+
 	"github.com/google/uuid"
 )
 
+// Category TaxRate — ставка налога товаров категории; nil, если действует
+// ставка по умолчанию.
 type Category struct {
 	CategoryID uuid.UUID
 	Category   string
+	TaxRate    *money.Rate
 }
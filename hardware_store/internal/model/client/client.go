@@ -14,4 +14,7 @@ type Client struct {
 	Gender           string    `validate:"oneof=male female"`
 	RegistrationDate time.Time
 	AddressID        uuid.UUID
+	// UserID пользователь auth-service, которому принадлежит клиент.
+	// nil у клиентов, заведённых сотрудниками магазина.
+	UserID *uuid.UUID
 }
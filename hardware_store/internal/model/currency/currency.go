@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"errors"
+	"hardware_store/internal/model/money"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Base валюта, к которой приводятся курсы. Её курс всегда равен единице.
+const Base = money.DefaultCurrency
+
+type Rounding string
+
+const (
+	RoundHalfUp Rounding = "half_up"
+	RoundDown   Rounding = "down"
+	RoundUp     Rounding = "up"
+)
+
+func (r Rounding) Valid() bool {
+	switch r {
+	case RoundHalfUp, RoundDown, RoundUp:
+		return true
+	}
+	return false
+}
+
+var codePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Currency Decimals — сколько знаков после запятой остаётся у суммы после
+// пересчёта в эту валюту, Rounding — как отбрасываются остальные.
+type Currency struct {
+	Code     string
+	Name     string
+	Decimals int
+	Rounding Rounding
+}
+
+func (c Currency) Valid() bool {
+	return codePattern.MatchString(c.Code) && c.Name != "" && c.Decimals >= 0 && c.Decimals <= 2 && c.Rounding.Valid()
+}
+
+// Round округляет сумму в основных единицах валюты до Decimals знаков и
+// возвращает её в копейках.
+func (c Currency) Round(amount *big.Rat) int64 {
+	step := big.NewInt(1)
+	for i := c.Decimals; i < 2; i++ {
+		step.Mul(step, big.NewInt(10))
+	}
+	// Число шагов округления в сумме: amount * 100 / step.
+	steps := new(big.Rat).Mul(amount, new(big.Rat).SetFrac(big.NewInt(100), step))
+	q, r := new(big.Int).QuoRem(steps.Num(), steps.Denom(), new(big.Int))
+
+	var away bool
+	switch c.Rounding {
+	case RoundUp:
+		away = r.Sign() != 0
+	case RoundHalfUp:
+		away = new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(steps.Denom()) >= 0
+	}
+	if away {
+		if steps.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q.Mul(q, step).Int64()
+}
+
+// ExchangeRate Rate — сколько единиц базовой валюты стоит единица Currency.
+// Курс действует с EffectiveDate до следующего курса той же валюты.
+type ExchangeRate struct {
+	RateID        uuid.UUID
+	Currency      string
+	Rate          *big.Rat
+	EffectiveDate time.Time
+	CreatedAt     time.Time
+}
+
+// RateFilter ограничивает историю курсов датами действия, nil-поля не
+// ограничивают выборку.
+type RateFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+var ratePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,8})?$`)
+
+var errInvalidRate = errors.New("rate must be a positive decimal with at most 8 decimal places")
+
+// ParseRate разбирает курс в десятичной записи, например "92.5031".
+func ParseRate(s string) (*big.Rat, error) {
+	if !ratePattern.MatchString(s) {
+		return nil, errInvalidRate
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return nil, errInvalidRate
+	}
+	return r, nil
+}
+
+// FormatRate записывает курс с восемью знаками после точки, как он хранится.
+func FormatRate(r *big.Rat) string {
+	return r.FloatString(8)
+}
+
+// Convert пересчитывает сумму по курсам исходной валюты from и валюты to
+// к базовой и округляет по правилам to.
+func Convert(m money.Money, from, to *big.Rat, currency Currency) money.Money {
+	amount := new(big.Rat).SetFrac64(m.Amount, 100)
+	amount.Mul(amount, from)
+	amount.Quo(amount, to)
+	return money.New(currency.Round(amount), currency.Code)
+}
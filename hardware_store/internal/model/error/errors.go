@@ -6,4 +6,53 @@ var ErrImageNotFound = errors.New("image not found")
 
 var ErrProductNotFound error = errors.New("product not found")
 var ErrInsufficientStock = errors.New("insufficient stock")
-var ErrAmountIsNegative = errors.New("amount must be positive")
\ No newline at end of file
+var ErrAmountIsNegative = errors.New("amount must be positive")
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrSupplierNotFound = errors.New("supplier not found")
+var ErrInvalidToken = errors.New("invalid token")
+var ErrAuthUnavailable = errors.New("auth service unavailable")
+var ErrInvalidMovement = errors.New("invalid stock movement")
+var ErrClientNotFound = errors.New("client not found")
+var ErrClientExists = errors.New("client exists")
+var ErrAccessDenied = errors.New("access denied")
+var ErrOrderNotFound = errors.New("order not found")
+var ErrInvalidOrder = errors.New("invalid order")
+var ErrIllegalTransition = errors.New("illegal order status transition")
+var ErrCartNotFound = errors.New("cart not found")
+var ErrInvalidCart = errors.New("invalid cart")
+var ErrReservationNotFound = errors.New("reservation not found")
+var ErrReservationClosed = errors.New("reservation is no longer active")
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+var ErrInvalidTransfer = errors.New("invalid stock transfer")
+var ErrInvalidReorderPolicy = errors.New("invalid reorder policy")
+var ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+var ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+var ErrSupplierProductNotFound = errors.New("supplier product not found")
+var ErrInvalidSupplierProduct = errors.New("invalid supplier product")
+var ErrDuplicateSupplierSKU = errors.New("supplier sku already used")
+var ErrDuplicateProductSKU = errors.New("product sku already used")
+var ErrImportNotFound = errors.New("import not found")
+var ErrInvalidImport = errors.New("invalid import")
+var ErrCurrencyNotFound = errors.New("currency not found")
+var ErrInvalidCurrency = errors.New("invalid currency")
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+var ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+var ErrPriceChangeNotFound = errors.New("scheduled price change not found")
+var ErrInvalidPriceChange = errors.New("invalid price change")
+var ErrPromotionNotFound = errors.New("promotion not found")
+var ErrInvalidPromotion = errors.New("invalid promotion")
+var ErrDuplicatePromoCode = errors.New("promo code already used")
+var ErrInvalidPromoCode = errors.New("invalid promo code")
+var ErrPromoCodeExhausted = errors.New("promo code usage limit reached")
+var ErrInvalidTaxRate = errors.New("invalid tax rate")
+var ErrAddressNotFound = errors.New("address not found")
+var ErrInvoiceNotFound = errors.New("invoice not found")
+var ErrDuplicateInvoice = errors.New("invoice already issued")
+var ErrInvoiceNotIssuable = errors.New("document cannot be issued for order in this status")
+var ErrReturnNotFound = errors.New("return not found")
+var ErrInvalidReturn = errors.New("invalid return")
+var ErrPaymentNotFound = errors.New("payment not found")
+var ErrRefundNotFound = errors.New("refund not found")
+var ErrInvalidPayment = errors.New("invalid payment")
+var ErrInvalidWebhook = errors.New("invalid payment webhook")
+var ErrPaymentProvider = errors.New("payment provider error")
@@ -0,0 +1,149 @@
+package imports
+
+import (
+	"hardware_store/internal/model/money"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatXLSX
+}
+
+// Поля товара, в которые отображаются столбцы файла. Категория и поставщик
+// задаются UUID или названием.
+const (
+	FieldSKU      = "sku"
+	FieldName     = "name"
+	FieldCategory = "category"
+	FieldSupplier = "supplier"
+	FieldPrice    = "price"
+	FieldStock    = "available_stock"
+)
+
+// headerAliases заголовки столбцов, которые распознаются без явного
+// сопоставления. Сравнение идёт после NormalizeHeader.
+var headerAliases = map[string]string{
+	"sku":             FieldSKU,
+	"артикул":         FieldSKU,
+	"name":            FieldName,
+	"название":        FieldName,
+	"category":        FieldCategory,
+	"category_id":     FieldCategory,
+	"category_name":   FieldCategory,
+	"категория":       FieldCategory,
+	"supplier":        FieldSupplier,
+	"supplier_id":     FieldSupplier,
+	"supplier_name":   FieldSupplier,
+	"поставщик":       FieldSupplier,
+	"price":           FieldPrice,
+	"цена":            FieldPrice,
+	"available_stock": FieldStock,
+	"stock":           FieldStock,
+	"остаток":         FieldStock,
+}
+
+func ValidField(field string) bool {
+	switch field {
+	case FieldSKU, FieldName, FieldCategory, FieldSupplier, FieldPrice, FieldStock:
+		return true
+	}
+	return false
+}
+
+func NormalizeHeader(h string) string {
+	h = strings.TrimPrefix(h, "\ufeff")
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_")
+}
+
+// Columns сопоставляет столбцы заголовка полям товара: сначала по явному
+// mapping (заголовок → поле), затем по известным названиям. Столбцы без
+// сопоставления пропускаются.
+func Columns(header []string, mapping map[string]string) map[string]int {
+	explicit := make(map[string]string, len(mapping))
+	for h, field := range mapping {
+		explicit[NormalizeHeader(h)] = field
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		h = NormalizeHeader(h)
+		field, ok := explicit[h]
+		if !ok {
+			field, ok = headerAliases[h]
+		}
+		if _, taken := columns[field]; ok && !taken {
+			columns[field] = i
+		}
+	}
+	return columns
+}
+
+// Job фоновая загрузка товаров из файла. При DryRun строки только
+// проверяются, при Upsert товар с уже существующим артикулом обновляется,
+// иначе такая строка считается ошибкой.
+type Job struct {
+	ImportID    uuid.UUID
+	Status      Status
+	Format      Format
+	FileName    string
+	DryRun      bool
+	Upsert      bool
+	Mapping     map[string]string
+	TotalRows   int
+	CreatedRows int
+	UpdatedRows int
+	FailedRows  int
+	Error       string
+	Errors      []RowError
+	CreatedBy   uuid.UUID
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// RowError ошибка строки файла. Row — номер строки в файле с единицы,
+// включая заголовок; Field пуст, если ошибка относится к строке целиком.
+type RowError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+// Row строка файла после разбора и разрешения ссылок. AvailableStock равен
+// nil, если в файле нет столбца остатка: при обновлении остаток не меняется.
+type Row struct {
+	SKU            string      `validate:"omitempty,max=64"`
+	Name           string      `validate:"required,min=2,max=100"`
+	CategoryID     uuid.UUID   `validate:"required"`
+	Price          money.Money `validate:"required,gt=0"`
+	AvailableStock *int        `validate:"omitempty,gte=0"`
+	SupplierID     uuid.UUID   `validate:"required"`
+}
+
+// RowFields поля Row в терминах столбцов файла для отчёта об ошибках.
+var RowFields = map[string]string{
+	"SKU":            FieldSKU,
+	"Name":           FieldName,
+	"CategoryID":     FieldCategory,
+	"Price":          FieldPrice,
+	"AvailableStock": FieldStock,
+	"SupplierID":     FieldSupplier,
+}
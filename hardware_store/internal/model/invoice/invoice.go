@@ -0,0 +1,63 @@
+package invoice
+
+import (
+	"fmt"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/order"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindInvoice счёт на оплату заказа.
+	KindInvoice Kind = "invoice"
+	// KindReceipt квитанция об оплате заказа.
+	KindReceipt Kind = "receipt"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindInvoice || k == KindReceipt
+}
+
+// IssuableFor сообщает, можно ли выпустить документ по заказу в статусе s:
+// счёт — по любому неотменённому заказу, квитанцию — только по оплаченному.
+func (k Kind) IssuableFor(s order.Status) bool {
+	switch k {
+	case KindInvoice:
+		return s != order.StatusCancelled
+	case KindReceipt:
+		switch s {
+		case order.StatusPaid, order.StatusPacked, order.StatusShipped, order.StatusDelivered, order.StatusReturned:
+			return true
+		}
+	}
+	return false
+}
+
+// Invoice выпущенный документ по заказу. Номера идут подряд без пропусков
+// отдельно для каждого вида документа и года. Document — PDF, который
+// отдаётся при повторной печати без изменений.
+type Invoice struct {
+	InvoiceID uuid.UUID
+	OrderID   uuid.UUID
+	Kind      Kind
+	Year      int
+	Number    int
+	Total     money.Money
+	IssuedAt  time.Time
+	Document  []byte
+}
+
+// Code номер документа для печати: год и порядковый номер, например
+// 2026-000042.
+func (i Invoice) Code() string {
+	return fmt.Sprintf("%d-%06d", i.Year, i.Number)
+}
+
+// FileName имя PDF-файла документа.
+func (i Invoice) FileName() string {
+	return fmt.Sprintf("%s-%s.pdf", i.Kind, i.Code())
+}
@@ -0,0 +1,312 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency валюта, в которой хранятся цены каталога и заказов.
+const DefaultCurrency = "RUB"
+
+// scale число минимальных единиц в основной: копеек в рубле.
+const scale = 100
+
+var (
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Money денежная сумма в минимальных единицах валюты (копейках). Сумма без
+// валюты считается суммой в валюте второго операнда, поэтому нулевое
+// значение можно использовать как начальное при сложении.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Rate доля в сотых долях процента: 2000 — 20%.
+type Rate int64
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse разбирает десятичную запись вида "75990.00" или "-12.5". Знаков
+// после точки может быть больше двух, только если лишние знаки — нули.
+func Parse(s, currency string) (Money, error) {
+	amount, err := parseAmount(s)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func parseAmount(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !digits(whole) || !digits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q has more than 2 decimal places", ErrInvalidAmount, s)
+		}
+		frac = frac[:2]
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (1<<63-1)/scale-1 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	cents, _ := strconv.ParseInt(frac, 10, 64)
+	amount := units*scale + cents
+	if neg {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ParseRate разбирает долю в процентах с точностью до сотых: "20" — 2000,
+// "-5.5" — -550.
+func ParseRate(s string) (Rate, error) {
+	bp, err := parseAmount(s)
+	if err != nil {
+		return 0, err
+	}
+	return Rate(bp), nil
+}
+
+// String возвращает долю в процентах с двумя знаками после точки.
+func (r Rate) String() string {
+	return Money{Amount: int64(r)}.String()
+}
+
+// MarshalText записывает долю в процентах, как её принимает ParseRate.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText разбирает долю в процентах, например ставку из файла
+// настроек.
+func (r *Rate) UnmarshalText(text []byte) error {
+	rate, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String возвращает сумму с двумя знаками после точки без валюты.
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/scale, amount%scale)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add складывает суммы одной валюты. Сложение разных валют — ошибка
+// программы, поэтому вызывает панику; суммы из запросов и ответов внешних
+// систем нужно заранее проверить SameCurrency.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.common(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.common(o)}
+}
+
+// Mul умножает цену на количество.
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Cmp возвращает -1, 0 или 1, если m меньше, равна или больше o.
+func (m Money) Cmp(o Money) int {
+	m.common(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Percent возвращает долю rate от суммы, округлённую до копейки половиной
+// от нуля.
+func (m Money) Percent(rate Rate) Money {
+	return Money{Amount: divRound(m.Amount*int64(rate), 10000), Currency: m.Currency}
+}
+
+// Discount уменьшает сумму на долю rate.
+func (m Money) Discount(rate Rate) Money {
+	return m.Sub(m.Percent(rate))
+}
+
+// AddTax начисляет налог по ставке rate сверх суммы.
+func (m Money) AddTax(rate Rate) Money {
+	return m.Add(m.Percent(rate))
+}
+
+// IncludedTax выделяет налог по ставке rate из суммы, в которую он уже
+// включён: для 120.00 и 20% — 20.00.
+func (m Money) IncludedTax(rate Rate) Money {
+	return Money{Amount: divRound(m.Amount*int64(rate), 10000+int64(rate)), Currency: m.Currency}
+}
+
+// Sum складывает суммы одной валюты.
+func Sum(values ...Money) Money {
+	var total Money
+	for _, v := range values {
+		total = total.Add(v)
+	}
+	return total
+}
+
+// SameCurrency возвращает ErrCurrencyMismatch, если суммы нельзя сложить
+// или сравнить: обе с валютой и валюты разные.
+func (m Money) SameCurrency(o Money) error {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+func (m Money) common(o Money) string {
+	if err := m.SameCurrency(o); err != nil {
+		panic("money: " + err.Error())
+	}
+	if m.Currency == "" {
+		return o.Currency
+	}
+	return m.Currency
+}
+
+// divRound делит с округлением половины от нуля.
+func divRound(a, b int64) int64 {
+	q, r := a/b, a%b
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// MarshalJSON записывает сумму строкой, чтобы клиенты не теряли точность
+// при разборе в число с плавающей точкой.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON принимает сумму строкой или числом. Валюта не меняется.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(bytes.Trim(data, `"`))
+	if strings.ContainsAny(s, "eE") {
+		return fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	amount, err := parseAmount(s)
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+	return nil
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	amount, err := parseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+	return nil
+}
+
+// UnmarshalParam разбирает сумму из параметра запроса при привязке gin.
+func (m *Money) UnmarshalParam(param string) error {
+	return m.UnmarshalText([]byte(param))
+}
+
+// Scan читает NUMERIC, который драйвер передаёт текстом. Валюта, если не
+// задана, считается DefaultCurrency.
+func (m *Money) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	amount, err := parseAmount(s)
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	return nil
+}
+
+// Value передаёт сумму десятичной строкой, которую PostgreSQL приводит к
+// NUMERIC без потери точности.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
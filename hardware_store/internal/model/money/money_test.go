@@ -0,0 +1,264 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "75990.00", want: 7599000},
+		{in: "12.5", want: 1250},
+		{in: "7", want: 700},
+		{in: ".5", want: 50},
+		{in: "5.", want: 500},
+		{in: "+1.01", want: 101},
+		{in: "-0.5", want: -50},
+		{in: "-0.05", want: -5},
+		{in: " 3.10 ", want: 310},
+		{in: "1.500", want: 150},
+		{in: "1.005", wantErr: true},
+		{in: "0.001", wantErr: true},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "1,50", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "92233720368547758", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in, DefaultCurrency)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, New(tt.want, DefaultCurrency), got)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{-5, "-0.05"},
+		{-150, "-1.50"},
+		{7599000, "75990.00"},
+	}
+	for _, tt := range tests {
+		m := New(tt.amount, DefaultCurrency)
+		assert.Equal(t, tt.want, m.String())
+		back, err := Parse(m.String(), DefaultCurrency)
+		require.NoError(t, err)
+		assert.Equal(t, m, back)
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{10, 5, 2},
+		{4, 10, 0},
+		{5, 10, 1},
+		{15, 10, 2},
+		{14, 10, 1},
+		{-4, 10, 0},
+		{-5, 10, -1},
+		{-15, 10, -2},
+		{-14, 10, -1},
+		{0, 7, 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, divRound(tt.a, tt.b), "%d/%d", tt.a, tt.b)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   Rate
+		want   int64
+	}{
+		{name: "whole", amount: 10000, rate: 2000, want: 2000},
+		{name: "half kopeck rounds up", amount: 5, rate: 1000, want: 1},
+		{name: "below half rounds down", amount: 4, rate: 1000, want: 0},
+		{name: "negative rounds away from zero", amount: -5, rate: 1000, want: -1},
+		{name: "fractional rate", amount: 19999, rate: 1250, want: 2500},
+		{name: "zero rate", amount: 19999, rate: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.amount, DefaultCurrency)
+			assert.Equal(t, New(tt.want, DefaultCurrency), m.Percent(tt.rate))
+			assert.Equal(t, New(tt.amount-tt.want, DefaultCurrency), m.Discount(tt.rate))
+			assert.Equal(t, New(tt.amount+tt.want, DefaultCurrency), m.AddTax(tt.rate))
+		})
+	}
+}
+
+func TestIncludedTax(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   Rate
+		want   int64
+	}{
+		{name: "exact", amount: 12000, rate: 2000, want: 2000},
+		{name: "rounded", amount: 100, rate: 2000, want: 17},
+		{name: "reduced rate", amount: 11000, rate: 1000, want: 1000},
+		{name: "negative", amount: -100, rate: 2000, want: -17},
+		{name: "zero rate", amount: 12000, rate: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.amount, DefaultCurrency).IncludedTax(tt.rate)
+			assert.Equal(t, New(tt.want, DefaultCurrency), got)
+		})
+	}
+}
+
+func TestCommon(t *testing.T) {
+	tests := []struct {
+		name string
+		m, o string
+		want string
+	}{
+		{name: "same", m: "RUB", o: "RUB", want: "RUB"},
+		{name: "left empty", m: "", o: "USD", want: "USD"},
+		{name: "right empty", m: "USD", o: "", want: "USD"},
+		{name: "both empty", m: "", o: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, New(0, tt.m).SameCurrency(New(0, tt.o)))
+			assert.Equal(t, tt.want, New(0, tt.m).common(New(0, tt.o)))
+		})
+	}
+}
+
+func TestSameCurrencyMismatch(t *testing.T) {
+	err := New(100, "RUB").SameCurrency(New(100, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	assert.EqualError(t, err, "currency mismatch: RUB and USD")
+}
+
+func TestCurrencyMismatchPanics(t *testing.T) {
+	rub, usd := New(100, "RUB"), New(100, "USD")
+	assert.Panics(t, func() { rub.Add(usd) })
+	assert.Panics(t, func() { rub.Sub(usd) })
+	assert.Panics(t, func() { rub.Cmp(usd) })
+	assert.Panics(t, func() { Sum(rub, usd) })
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := New(1050, "RUB"), New(-300, "RUB")
+	assert.Equal(t, New(750, "RUB"), a.Add(b))
+	assert.Equal(t, New(1350, "RUB"), a.Sub(b))
+	assert.Equal(t, New(3150, "RUB"), a.Mul(3))
+	assert.Equal(t, New(750, "RUB"), Sum(a, b))
+	assert.Equal(t, Money{}, Sum())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(a))
+	assert.True(t, b.IsNegative())
+	assert.True(t, a.IsPositive())
+	assert.True(t, Money{}.IsZero())
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(New(-1250, DefaultCurrency))
+	require.NoError(t, err)
+	assert.Equal(t, `"-12.50"`, string(data))
+
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: `"12.50"`, want: 1250},
+		{in: `12.5`, want: 1250},
+		{in: `-0.5`, want: -50},
+		{in: `1e3`, wantErr: true},
+		{in: `"1.005"`, wantErr: true},
+		{in: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m := New(0, "USD")
+			err := json.Unmarshal([]byte(tt.in), &m)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, New(tt.want, "USD"), m, "currency is kept")
+		})
+	}
+
+	m := New(500, DefaultCurrency)
+	require.NoError(t, json.Unmarshal([]byte("null"), &m))
+	assert.Equal(t, New(500, DefaultCurrency), m, "null leaves the value")
+}
+
+func TestSQL(t *testing.T) {
+	v, err := New(-50, DefaultCurrency).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-0.50", v)
+
+	tests := []struct {
+		name    string
+		src     any
+		want    Money
+		wantErr bool
+	}{
+		{name: "string", src: "75990.00", want: New(7599000, DefaultCurrency)},
+		{name: "bytes", src: []byte("-0.50"), want: New(-50, DefaultCurrency)},
+		{name: "integer", src: int64(5), want: New(500, DefaultCurrency)},
+		{name: "null", src: nil, want: Money{}},
+		{name: "float", src: 1.5, wantErr: true},
+		{name: "too precise", src: "1.005", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m)
+		})
+	}
+
+	m := New(0, "USD")
+	require.NoError(t, m.Scan(v))
+	assert.Equal(t, New(-50, "USD"), m, "scan keeps a set currency")
+}
+
+func TestRate(t *testing.T) {
+	r, err := ParseRate("20")
+	require.NoError(t, err)
+	assert.Equal(t, Rate(2000), r)
+	assert.Equal(t, "20.00", r.String())
+
+	var back Rate
+	require.NoError(t, back.UnmarshalText([]byte("-5.5")))
+	assert.Equal(t, Rate(-550), back)
+	assert.Error(t, back.UnmarshalText([]byte("5.555")))
+}
@@ -0,0 +1,138 @@
+package order
+
+import (
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/tax"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusPaid      Status = "paid"
+	StatusPacked    Status = "packed"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+	StatusReturned  Status = "returned"
+)
+
+// transitions допустимые переходы между статусами. Отгрузка возможна только
+// после оплаты и упаковки, отменённый и возвращённый заказы конечные.
+var transitions = map[Status][]Status{
+	StatusNew:       {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusPacked, StatusCancelled},
+	StatusPacked:    {StatusShipped, StatusCancelled},
+	StatusShipped:   {StatusDelivered},
+	StatusDelivered: {StatusReturned},
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusNew, StatusPaid, StatusPacked, StatusShipped, StatusDelivered, StatusCancelled, StatusReturned:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// RestoresStock сообщает, возвращает ли переход в статус товары на склад.
+func (s Status) RestoresStock() bool {
+	return s == StatusCancelled || s == StatusReturned
+}
+
+// Order WarehouseID — склад, с которого списываются товары заказа и на
+// который они возвращаются при отмене; uuid.Nil при оформлении означает
+// склад по умолчанию. PromoCodes — промокоды, введённые при оформлении; в
+// сохранённом заказе не хранятся, скидки по ним записаны в позициях.
+// TaxMode — режим цен на момент оформления: включают ли цены позиций налог.
+// Total — сумма к оплате с налогом.
+type Order struct {
+	OrderID     uuid.UUID
+	ClientID    uuid.UUID
+	WarehouseID uuid.UUID
+	Status      Status
+	Items       []Item
+	PromoCodes  []string
+	TaxMode     tax.Mode
+	Total       money.Money
+	Transitions []Transition
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Item позиция заказа. Name и UnitPrice фиксируются на момент оформления
+// и не меняются при последующем изменении товара. Discount — скидка по
+// акциям на всю позицию, TaxRate — ставка налога товара при оформлении.
+// ReturnedQuantity — сколько единиц уже вернулось по заявкам на возврат.
+type Item struct {
+	ItemID           uuid.UUID
+	ProductID        uuid.UUID
+	Name             string
+	Quantity         int
+	UnitPrice        money.Money
+	Discount         money.Money
+	TaxRate          money.Rate
+	ReturnedQuantity int
+}
+
+// Transition запись о смене статуса заказа.
+type Transition struct {
+	TransitionID uuid.UUID
+	OrderID      uuid.UUID
+	From         Status
+	To           Status
+	ActorID      *uuid.UUID
+	CreatedAt    time.Time
+}
+
+func (i Item) LineTotal() money.Money {
+	return i.UnitPrice.Mul(i.Quantity)
+}
+
+// Remaining сколько единиц позиции ещё не вернулось.
+func (i Item) Remaining() int {
+	return max(i.Quantity-i.ReturnedQuantity, 0)
+}
+
+// Total сумма позиции после скидки.
+func (i Item) Total() money.Money {
+	return i.LineTotal().Sub(i.Discount)
+}
+
+// TaxLine разбивает сумму позиции после скидки на сумму без налога, налог
+// и сумму с налогом.
+func (o Order) TaxLine(item Item) tax.Line {
+	return tax.Split(item.Total(), item.TaxRate, o.TaxMode)
+}
+
+// TaxLines возвращает разбивку налога по каждой позиции в порядке позиций.
+func (o Order) TaxLines() []tax.Line {
+	lines := make([]tax.Line, 0, len(o.Items))
+	for _, item := range o.Items {
+		lines = append(lines, o.TaxLine(item))
+	}
+	return lines
+}
+
+// CalcTotal пересчитывает сумму заказа по позициям с учётом скидок и
+// налога, начисляемого сверх цен.
+func (o *Order) CalcTotal() {
+	o.Total = tax.Total(o.TaxLines()).Gross
+}
+
+// Discount общая скидка по позициям заказа.
+func (o Order) Discount() money.Money {
+	var discount money.Money
+	for _, item := range o.Items {
+		discount = discount.Add(item.Discount)
+	}
+	return discount
+}
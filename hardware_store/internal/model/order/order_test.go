@@ -0,0 +1,99 @@
+package order
+
+import (
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/tax"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rub(amount int64) money.Money {
+	return money.New(amount, money.DefaultCurrency)
+}
+
+func TestItemTotals(t *testing.T) {
+	tests := []struct {
+		name             string
+		item             Item
+		lineTotal, total int64
+		remaining        int
+	}{
+		{name: "plain", item: Item{Quantity: 3, UnitPrice: rub(1990)}, lineTotal: 5970, total: 5970, remaining: 3},
+		{name: "discounted", item: Item{Quantity: 2, UnitPrice: rub(1000), Discount: rub(150)}, lineTotal: 2000, total: 1850, remaining: 2},
+		{name: "partly returned", item: Item{Quantity: 5, UnitPrice: rub(100), ReturnedQuantity: 2}, lineTotal: 500, total: 500, remaining: 3},
+		{name: "fully returned", item: Item{Quantity: 1, UnitPrice: rub(100), ReturnedQuantity: 1}, lineTotal: 100, total: 100, remaining: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, rub(tt.lineTotal), tt.item.LineTotal())
+			assert.Equal(t, rub(tt.total), tt.item.Total())
+			assert.Equal(t, tt.remaining, tt.item.Remaining())
+		})
+	}
+}
+
+func TestCalcTotal(t *testing.T) {
+	items := []Item{
+		{Quantity: 2, UnitPrice: rub(10000), Discount: rub(2000), TaxRate: 2000},
+		{Quantity: 1, UnitPrice: rub(500), TaxRate: 0},
+	}
+	tests := []struct {
+		mode     tax.Mode
+		total    int64
+		discount int64
+	}{
+		{mode: tax.ModeExclusive, total: 22100, discount: 2000},
+		{mode: tax.ModeInclusive, total: 18500, discount: 2000},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			o := Order{TaxMode: tt.mode, Items: items}
+			o.CalcTotal()
+			assert.Equal(t, rub(tt.total), o.Total)
+			assert.Equal(t, rub(tt.discount), o.Discount())
+		})
+	}
+}
+
+func TestCanTransitionTo(t *testing.T) {
+	statuses := []Status{StatusNew, StatusPaid, StatusPacked, StatusShipped, StatusDelivered, StatusCancelled, StatusReturned}
+	allowed := map[Status][]Status{
+		StatusNew:       {StatusPaid, StatusCancelled},
+		StatusPaid:      {StatusPacked, StatusCancelled},
+		StatusPacked:    {StatusShipped, StatusCancelled},
+		StatusShipped:   {StatusDelivered},
+		StatusDelivered: {StatusReturned},
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				assert.Equal(t, slices.Contains(allowed[from], to), from.CanTransitionTo(to))
+			})
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		status        Status
+		valid         bool
+		restoresStock bool
+	}{
+		{status: StatusNew, valid: true},
+		{status: StatusPaid, valid: true},
+		{status: StatusPacked, valid: true},
+		{status: StatusShipped, valid: true},
+		{status: StatusDelivered, valid: true},
+		{status: StatusCancelled, valid: true, restoresStock: true},
+		{status: StatusReturned, valid: true, restoresStock: true},
+		{status: "lost"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.status.Valid())
+			assert.Equal(t, tt.restoresStock, tt.status.RestoresStock())
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package page
+
+import "github.com/google/uuid"
+
+// Cursor позиция в выборке: значение ключа сортировки последней строки
+// страницы и её UUID, который разрешает равенство ключей.
+type Cursor struct {
+	Key string
+	ID  uuid.UUID
+}
+
+// Request параметры страницы. Если задан After, Offset не используется.
+// Scope описывает выборку — маршрут, его параметры и фильтры, — под которую
+// выпускается курсор следующей страницы.
+type Request struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+	Scope  string
+}
@@ -0,0 +1,137 @@
+package payment
+
+import (
+	"hardware_store/internal/model/money"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	// StatusPending платёж создан и ждёт оплаты покупателем.
+	StatusPending Status = "pending"
+	// StatusAuthorized деньги заблокированы на счёте покупателя и ждут списания.
+	StatusAuthorized Status = "authorized"
+	// StatusCaptured деньги списаны, заказ оплачен.
+	StatusCaptured Status = "captured"
+	// StatusFailed оплата отклонена банком или шлюзом.
+	StatusFailed Status = "failed"
+	// StatusCancelled платёж отменён до списания денег.
+	StatusCancelled Status = "cancelled"
+)
+
+// transitions переходы, которые принимаются от шлюза. Уведомления могут
+// приходить не по порядку, поэтому переход назад молча пропускается.
+var transitions = map[Status][]Status{
+	StatusPending:    {StatusAuthorized, StatusCaptured, StatusFailed, StatusCancelled},
+	StatusAuthorized: {StatusCaptured, StatusFailed, StatusCancelled},
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusAuthorized, StatusCaptured, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// Open сообщает, может ли платёж ещё измениться без участия магазина. Такие
+// платежи сверяются со шлюзом при запуске приложения.
+func (s Status) Open() bool {
+	return s == StatusPending || s == StatusAuthorized
+}
+
+// Payment платёж по заказу в платёжном шлюзе Provider. ExternalID —
+// идентификатор платежа в шлюзе, ConfirmationURL — страница оплаты для
+// покупателя. Refunded — сумма, уже возвращённая покупателю.
+type Payment struct {
+	PaymentID       uuid.UUID
+	OrderID         uuid.UUID
+	Provider        string
+	ExternalID      string
+	Status          Status
+	Amount          money.Money
+	Refunded        money.Money
+	ConfirmationURL string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Refundable сумма, которую ещё можно вернуть покупателю.
+func (p Payment) Refundable() money.Money {
+	if p.Status != StatusCaptured {
+		return money.New(0, p.Amount.Currency)
+	}
+	return p.Amount.Sub(p.Refunded)
+}
+
+// Matches сообщает, описывает ли повторный запрос возврата с тем же ключом
+// этот возврат: совпадает заявка и, если сумма указана, сумма.
+func (r Refund) Matches(o Refund) bool {
+	if (r.ReturnID == nil) != (o.ReturnID == nil) || r.ReturnID != nil && *r.ReturnID != *o.ReturnID {
+		return false
+	}
+	if o.Amount.IsZero() {
+		return true
+	}
+	return r.Amount.SameCurrency(o.Amount) == nil && r.Amount.Cmp(o.Amount) == 0
+}
+
+// Request запрос на создание платежа в шлюзе. Key — ключ идемпотентности:
+// повторный запрос с тем же ключом возвращает уже созданный платёж. При
+// Capture деньги списываются сразу после оплаты, иначе только блокируются.
+type Request struct {
+	Key         string
+	OrderID     uuid.UUID
+	Amount      money.Money
+	Description string
+	Capture     bool
+}
+
+// State состояние платежа в шлюзе.
+type State struct {
+	ExternalID      string
+	Status          Status
+	Amount          money.Money
+	Refunded        money.Money
+	ConfirmationURL string
+}
+
+// Event уведомление шлюза об изменении платежа. EventID уникален в пределах
+// шлюза и позволяет не обрабатывать повторную доставку дважды.
+type Event struct {
+	EventID string
+	State
+}
+
+type RefundStatus string
+
+const (
+	// RefundPending возврат записан, но шлюз ещё не подтвердил его.
+	RefundPending RefundStatus = "pending"
+	// RefundSucceeded шлюз вернул деньги покупателю.
+	RefundSucceeded RefundStatus = "succeeded"
+)
+
+// Refund возврат денег по платежу. ReturnID задан, если деньги возвращаются
+// по заявке на возврат товара. Key — ключ идемпотентности, уникальный в
+// пределах платежа: повторный запрос с тем же ключом не возвращает деньги
+// второй раз. Возврат записывается до запроса к шлюзу и ждёт его
+// подтверждения в статусе RefundPending.
+type Refund struct {
+	RefundID  uuid.UUID
+	PaymentID uuid.UUID
+	ReturnID  *uuid.UUID
+	Key       string
+	Status    RefundStatus
+	Amount    money.Money
+	ActorID   *uuid.UUID
+	CreatedAt time.Time
+}
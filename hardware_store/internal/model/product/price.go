@@ -0,0 +1,27 @@
+package product
+
+import (
+	"hardware_store/internal/model/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Price период действия цены товара. У текущей цены ValidTo пуст.
+// Запланированная цена ждёт ValidFrom с пустым AppliedAt; история цен
+// показывает её вместе с действовавшими.
+type Price struct {
+	PriceID   uuid.UUID
+	ProductID uuid.UUID
+	Price     money.Money
+	ValidFrom time.Time
+	ValidTo   *time.Time
+	Reason    string
+	CreatedBy *uuid.UUID
+	CreatedAt time.Time
+	AppliedAt *time.Time
+}
+
+func (p Price) Scheduled() bool {
+	return p.AppliedAt == nil
+}
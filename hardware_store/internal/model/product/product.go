@@ -1,18 +1,110 @@
 package product
 
 import (
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/page"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Product AvailableStock — общий остаток по всем складам, ReservedStock — его
+// часть, удерживаемая активными резервами. Продать можно только FreeStock.
+// Locations раскладывает общий остаток по складам. SKU — артикул магазина,
+// пустой, если не задан.
 type Product struct {
 	ProductID      uuid.UUID
+	SKU            string
 	Name           string
 	CategoryID     uuid.UUID
-	Price          float64
+	Price          money.Money
 	AvailableStock int
+	ReservedStock  int
 	LastUpdateDate time.Time
 	SupplierID     uuid.UUID
 	ImageID        *uuid.UUID
+	Locations      []Location
+}
+
+// Location остаток товара на одном складе.
+type Location struct {
+	WarehouseID uuid.UUID
+	Name        string
+	Quantity    int
+}
+
+// ExportRow товар для выгрузки каталога вместе с названиями категории и
+// поставщика. Остатки по складам не выгружаются.
+type ExportRow struct {
+	Product
+	CategoryName string
+	SupplierName string
+}
+
+func (p Product) FreeStock() int {
+	return p.AvailableStock - p.ReservedStock
+}
+
+// Patch описывает частичное обновление товара: nil-поля не меняются,
+// uuid.Nil в CategoryID или SupplierID отвязывает категорию или поставщика.
+type Patch struct {
+	Name           *string
+	CategoryID     *uuid.UUID
+	Price          *money.Money
+	AvailableStock *int
+	SupplierID     *uuid.UUID
+}
+
+func (p *Product) Apply(patch Patch) {
+	if patch.Name != nil {
+		p.Name = *patch.Name
+	}
+	if patch.CategoryID != nil {
+		p.CategoryID = *patch.CategoryID
+	}
+	if patch.Price != nil {
+		p.Price = *patch.Price
+	}
+	if patch.AvailableStock != nil {
+		p.AvailableStock = *patch.AvailableStock
+	}
+	if patch.SupplierID != nil {
+		p.SupplierID = *patch.SupplierID
+	}
+}
+
+const (
+	SortByPrice          = "price"
+	SortByName           = "name"
+	SortByLastUpdateDate = "last_update_date"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Filter параметры выборки списка товаров. nil-поля не ограничивают выборку.
+// WarehouseID оставляет только товары, которые есть на этом складе.
+type Filter struct {
+	CategoryID  *uuid.UUID
+	SupplierID  *uuid.UUID
+	WarehouseID *uuid.UUID
+	MinPrice    *money.Money
+	MaxPrice    *money.Money
+	InStock     bool
+	Name        string
+	SortBy      string
+	Order       string
+	Page        page.Request
+}
+
+// SortKey значение ключа сортировки товара в виде, пригодном для курсора.
+func SortKey(p Product, sortBy string) string {
+	switch sortBy {
+	case SortByPrice:
+		return p.Price.String()
+	case SortByLastUpdateDate:
+		return p.LastUpdateDate.Format(time.RFC3339Nano)
+	default:
+		return p.Name
+	}
 }
@@ -0,0 +1,76 @@
+package product
+
+import (
+	"hardware_store/internal/model/money"
+
+	"github.com/google/uuid"
+)
+
+// Repricing массовое изменение цен товаров, подходящих под Filter: на долю
+// Percent или на сумму Amount, задаётся ровно одно из двух. Ending, если
+// задан, округляет новую цену до ближайшей с таким числом копеек: для 90 —
+// 123.45 превращается в 123.90. Учитываются только категория, поставщик и
+// диапазон цен фильтра. DryRun считает новые цены, не меняя их.
+type Repricing struct {
+	Filter  Filter
+	Percent *money.Rate
+	Amount  *money.Money
+	Ending  *int64
+	Reason  string
+	DryRun  bool
+}
+
+// RepricingItem новая цена одного товара.
+type RepricingItem struct {
+	ProductID uuid.UUID
+	Name      string
+	OldPrice  money.Money
+	NewPrice  money.Money
+}
+
+// RepricingResult Matched — число товаров под фильтром, Items — товары,
+// цена которых меняется.
+type RepricingResult struct {
+	DryRun  bool
+	Matched int
+	Items   []RepricingItem
+}
+
+// HasTarget сообщает, ограничен ли фильтр хотя бы одним условием. Без
+// условий изменение затронуло бы весь каталог.
+func (r Repricing) HasTarget() bool {
+	f := r.Filter
+	return f.CategoryID != nil || f.SupplierID != nil || f.MinPrice != nil || f.MaxPrice != nil
+}
+
+// NewPrice применяет изменение к цене.
+func (r Repricing) NewPrice(price money.Money) money.Money {
+	switch {
+	case r.Percent != nil:
+		price = price.Add(price.Percent(*r.Percent))
+	case r.Amount != nil:
+		price = price.Add(*r.Amount)
+	}
+	if r.Ending != nil {
+		price = roundToEnding(price, *r.Ending)
+	}
+	return price
+}
+
+// roundToEnding округляет неотрицательную сумму до ближайшей с копейками
+// ending. При равном удалении выбирается большая.
+func roundToEnding(m money.Money, ending int64) money.Money {
+	base := m.Amount - m.Amount%100 + ending
+	lower, upper := base, base
+	if base > m.Amount {
+		lower = base - 100
+	} else {
+		upper = base + 100
+	}
+	if m.Amount-lower < upper-m.Amount {
+		m.Amount = lower
+	} else {
+		m.Amount = upper
+	}
+	return m
+}
@@ -0,0 +1,69 @@
+package product
+
+import (
+	"hardware_store/internal/model/money"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundToEnding(t *testing.T) {
+	tests := []struct {
+		amount int64
+		ending int64
+		want   int64
+	}{
+		{amount: 12345, ending: 90, want: 12390},
+		{amount: 12339, ending: 90, want: 12290},
+		{amount: 12340, ending: 90, want: 12390},
+		{amount: 12390, ending: 90, want: 12390},
+		{amount: 12349, ending: 0, want: 12300},
+		{amount: 12350, ending: 0, want: 12400},
+		{amount: 12399, ending: 99, want: 12399},
+		{amount: 12348, ending: 99, want: 12299},
+		{amount: 30, ending: 90, want: -10},
+	}
+	for _, tt := range tests {
+		got := roundToEnding(money.New(tt.amount, "RUB"), tt.ending)
+		assert.Equal(t, money.New(tt.want, "RUB"), got, "%d to ending %d", tt.amount, tt.ending)
+	}
+}
+
+func TestRepricingNewPrice(t *testing.T) {
+	rate := func(r money.Rate) *money.Rate { return &r }
+	amount := func(a int64) *money.Money {
+		m := money.New(a, "RUB")
+		return &m
+	}
+	ending := func(e int64) *int64 { return &e }
+
+	tests := []struct {
+		name string
+		r    Repricing
+		want int64
+	}{
+		{name: "percent up", r: Repricing{Percent: rate(1000)}, want: 11000},
+		{name: "percent down", r: Repricing{Percent: rate(-1550)}, want: 8450},
+		{name: "percent rounds to kopeck", r: Repricing{Percent: rate(333)}, want: 10333},
+		{name: "amount", r: Repricing{Amount: amount(-1)}, want: 9999},
+		{name: "amount with ending", r: Repricing{Amount: amount(-1), Ending: ending(90)}, want: 9990},
+		{name: "percent with ending", r: Repricing{Percent: rate(500), Ending: ending(99)}, want: 10499},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, money.New(tt.want, "RUB"), tt.r.NewPrice(money.New(10000, "RUB")))
+		})
+	}
+}
+
+func TestRepricingHasTarget(t *testing.T) {
+	id := uuid.New()
+	price := money.New(100, "RUB")
+	assert.False(t, Repricing{}.HasTarget())
+	assert.False(t, Repricing{Filter: Filter{Name: "дрель"}}.HasTarget(), "name is not a repricing filter")
+	assert.True(t, Repricing{Filter: Filter{CategoryID: &id}}.HasTarget())
+	assert.True(t, Repricing{Filter: Filter{SupplierID: &id}}.HasTarget())
+	assert.True(t, Repricing{Filter: Filter{MinPrice: &price}}.HasTarget())
+	assert.True(t, Repricing{Filter: Filter{MaxPrice: &price}}.HasTarget())
+}
@@ -0,0 +1,167 @@
+package promotion
+
+import (
+	"bytes"
+	"hardware_store/internal/model/money"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Line позиция корзины или заказа, к которой применяются акции.
+type Line struct {
+	ProductID  uuid.UUID
+	Name       string
+	CategoryID uuid.UUID
+	SupplierID uuid.UUID
+	Quantity   int
+	UnitPrice  money.Money
+}
+
+func (l Line) Subtotal() money.Money {
+	return l.UnitPrice.Mul(l.Quantity)
+}
+
+// Basket позиции, к которым подбираются скидки. Codes — введённые
+// промокоды, ClientID нужен для лимитов на клиента.
+type Basket struct {
+	ClientID *uuid.UUID
+	Codes    []string
+	Lines    []Line
+}
+
+// Applied скидка одной акции.
+type Applied struct {
+	PromotionID uuid.UUID
+	Name        string
+	Code        string
+	Amount      money.Money
+}
+
+// LineResult позиция со скидками. Total — сумма позиции после скидок.
+type LineResult struct {
+	Line
+	Discounts []Applied
+	Discount  money.Money
+	Total     money.Money
+}
+
+// Evaluation результат подбора скидок: позиции со скидками и итог по
+// каждой применённой акции.
+type Evaluation struct {
+	Lines      []LineResult
+	Promotions []Applied
+	Subtotal   money.Money
+	Discount   money.Money
+	Total      money.Money
+}
+
+// Evaluate применяет акции к позициям по правилам Promotion. Акции должны
+// быть уже отобраны: действующие, с введённым кодом и неисчерпанными
+// лимитами. Скидка на позицию никогда не превышает её сумму.
+func Evaluate(promos []Promotion, lines []Line) Evaluation {
+	promos = slices.Clone(promos)
+	slices.SortStableFunc(promos, func(a, b Promotion) int {
+		if a.Priority != b.Priority {
+			return b.Priority - a.Priority
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.PromotionID[:], b.PromotionID[:])
+	})
+
+	res := Evaluation{Lines: make([]LineResult, 0, len(lines))}
+	for _, l := range lines {
+		res.Lines = append(res.Lines, LineResult{Line: l, Total: l.Subtotal()})
+	}
+	exclusive := make([]bool, len(lines))
+
+	for _, p := range promos {
+		var eligible []int
+		for i, l := range res.Lines {
+			if exclusive[i] || !p.Covers(l.Line) || !l.Total.IsPositive() {
+				continue
+			}
+			if !p.Stackable && len(l.Discounts) > 0 {
+				continue
+			}
+			eligible = append(eligible, i)
+		}
+		discounts := discountsOf(p, res.Lines, eligible)
+
+		var total money.Money
+		for j, i := range eligible {
+			d := discounts[j]
+			if !d.IsPositive() {
+				continue
+			}
+			l := &res.Lines[i]
+			l.Discounts = append(l.Discounts, Applied{PromotionID: p.PromotionID, Name: p.Name, Code: p.Code, Amount: d})
+			l.Discount = l.Discount.Add(d)
+			l.Total = l.Total.Sub(d)
+			if !p.Stackable {
+				exclusive[i] = true
+			}
+			total = total.Add(d)
+		}
+		if total.IsPositive() {
+			res.Promotions = append(res.Promotions, Applied{PromotionID: p.PromotionID, Name: p.Name, Code: p.Code, Amount: total})
+		}
+	}
+
+	for _, l := range res.Lines {
+		res.Subtotal = res.Subtotal.Add(l.Subtotal())
+		res.Discount = res.Discount.Add(l.Discount)
+		res.Total = res.Total.Add(l.Total)
+	}
+	return res
+}
+
+// discountsOf считает скидку акции на каждую позицию из eligible, не больше
+// остатка суммы позиции.
+func discountsOf(p Promotion, lines []LineResult, eligible []int) []money.Money {
+	discounts := make([]money.Money, len(eligible))
+	switch p.Kind {
+	case KindPercent:
+		for j, i := range eligible {
+			discounts[j] = lines[i].Total.Percent(p.Percent)
+		}
+	case KindBuyXGetY:
+		for j, i := range eligible {
+			free := lines[i].Quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+			discounts[j] = lines[i].UnitPrice.Mul(free)
+		}
+	case KindFixed:
+		var base money.Money
+		for _, i := range eligible {
+			base = base.Add(lines[i].Total)
+		}
+		amount := p.Amount
+		if amount.Cmp(base) > 0 {
+			amount = base
+		}
+		// Доли округляются вниз, остаток копеек достаётся последней позиции,
+		// а то, что не уместилось в её сумму, — предыдущим.
+		left := amount
+		for j, i := range eligible {
+			d := money.New(amount.Amount*lines[i].Total.Amount/base.Amount, amount.Currency)
+			discounts[j] = d
+			left = left.Sub(d)
+		}
+		for j := len(eligible) - 1; j >= 0 && left.IsPositive(); j-- {
+			add := lines[eligible[j]].Total.Sub(discounts[j])
+			if add.Cmp(left) > 0 {
+				add = left
+			}
+			discounts[j] = discounts[j].Add(add)
+			left = left.Sub(add)
+		}
+	}
+	for j, i := range eligible {
+		if discounts[j].Cmp(lines[i].Total) > 0 {
+			discounts[j] = lines[i].Total
+		}
+	}
+	return discounts
+}
@@ -0,0 +1,246 @@
+package promotion
+
+import (
+	"hardware_store/internal/model/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tools     = uuid.New()
+	paints    = uuid.New()
+	createdAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func rub(amount int64) money.Money {
+	return money.New(amount, money.DefaultCurrency)
+}
+
+func line(category uuid.UUID, quantity int, price int64) Line {
+	return Line{ProductID: uuid.New(), CategoryID: category, Quantity: quantity, UnitPrice: rub(price)}
+}
+
+func percent(name string, rate money.Rate, priority int, stackable bool) Promotion {
+	return Promotion{PromotionID: uuid.New(), Name: name, Kind: KindPercent, Percent: rate,
+		Priority: priority, Stackable: stackable, CreatedAt: createdAt}
+}
+
+func fixed(name string, amount int64) Promotion {
+	return Promotion{PromotionID: uuid.New(), Name: name, Kind: KindFixed, Amount: rub(amount), CreatedAt: createdAt}
+}
+
+func buyGet(name string, buy, get, priority int, stackable bool) Promotion {
+	return Promotion{PromotionID: uuid.New(), Name: name, Kind: KindBuyXGetY, BuyQuantity: buy, GetQuantity: get,
+		Priority: priority, Stackable: stackable, CreatedAt: createdAt}
+}
+
+func only(p Promotion, category uuid.UUID) Promotion {
+	p.CategoryID = &category
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	later := percent("later", 2000, 1, false)
+	later.CreatedAt = createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		promos []Promotion
+		lines  []Line
+		// names — применённые акции каждой позиции по порядку применения,
+		// discounts — скидка каждой позиции в копейках.
+		names      [][]string
+		discounts  []int64
+		promotions map[string]int64
+	}{
+		{
+			name:       "no promotions",
+			lines:      []Line{line(tools, 2, 1000)},
+			names:      [][]string{nil},
+			discounts:  []int64{0},
+			promotions: map[string]int64{},
+		},
+		{
+			name:       "higher priority wins exclusive line",
+			promos:     []Promotion{percent("low", 1000, 1, false), percent("high", 2000, 5, false)},
+			lines:      []Line{line(tools, 1, 10000)},
+			names:      [][]string{{"high"}},
+			discounts:  []int64{2000},
+			promotions: map[string]int64{"high": 2000},
+		},
+		{
+			name:       "equal priority applies earlier created first",
+			promos:     []Promotion{later, percent("earlier", 1000, 1, false)},
+			lines:      []Line{line(tools, 1, 10000)},
+			names:      [][]string{{"earlier"}},
+			discounts:  []int64{1000},
+			promotions: map[string]int64{"earlier": 1000},
+		},
+		{
+			name:       "stackable discounts compound",
+			promos:     []Promotion{percent("first", 1000, 2, true), percent("second", 1000, 1, true)},
+			lines:      []Line{line(tools, 1, 10000)},
+			names:      [][]string{{"first", "second"}},
+			discounts:  []int64{1900},
+			promotions: map[string]int64{"first": 1000, "second": 900},
+		},
+		{
+			name:       "exclusive blocks later stackable",
+			promos:     []Promotion{percent("exclusive", 1000, 2, false), percent("stackable", 1000, 1, true)},
+			lines:      []Line{line(tools, 1, 10000)},
+			names:      [][]string{{"exclusive"}},
+			discounts:  []int64{1000},
+			promotions: map[string]int64{"exclusive": 1000},
+		},
+		{
+			name:       "exclusive skips discounted line",
+			promos:     []Promotion{percent("stackable", 1000, 2, true), percent("exclusive", 5000, 1, false)},
+			lines:      []Line{line(tools, 1, 10000)},
+			names:      [][]string{{"stackable"}},
+			discounts:  []int64{1000},
+			promotions: map[string]int64{"stackable": 1000},
+		},
+		{
+			name: "exclusive only on covered lines",
+			promos: []Promotion{
+				only(percent("tools", 1000, 2, false), tools),
+				percent("all", 500, 1, true),
+			},
+			lines:      []Line{line(tools, 1, 10000), line(paints, 1, 10000)},
+			names:      [][]string{{"tools"}, {"all"}},
+			discounts:  []int64{1000, 500},
+			promotions: map[string]int64{"tools": 1000, "all": 500},
+		},
+		{
+			name:       "buy two get one",
+			promos:     []Promotion{buyGet("3 for 2", 2, 1, 0, false)},
+			lines:      []Line{line(tools, 7, 10000), line(paints, 2, 500), line(tools, 3, 100)},
+			names:      [][]string{{"3 for 2"}, nil, {"3 for 2"}},
+			discounts:  []int64{20000, 0, 100},
+			promotions: map[string]int64{"3 for 2": 20100},
+		},
+		{
+			name:       "buy one get two",
+			promos:     []Promotion{buyGet("1+2", 1, 2, 0, false)},
+			lines:      []Line{line(tools, 5, 100)},
+			names:      [][]string{{"1+2"}},
+			discounts:  []int64{200},
+			promotions: map[string]int64{"1+2": 200},
+		},
+		{
+			name:       "fixed split with leftover on last line",
+			promos:     []Promotion{fixed("minus 1", 100)},
+			lines:      []Line{line(tools, 1, 100), line(tools, 1, 100), line(paints, 1, 100)},
+			names:      [][]string{{"minus 1"}, {"minus 1"}, {"minus 1"}},
+			discounts:  []int64{33, 33, 34},
+			promotions: map[string]int64{"minus 1": 100},
+		},
+		{
+			name:       "fixed split proportional to line totals",
+			promos:     []Promotion{fixed("minus 10", 1000)},
+			lines:      []Line{line(tools, 3, 1000), line(paints, 1, 1000)},
+			names:      [][]string{{"minus 10"}, {"minus 10"}},
+			discounts:  []int64{750, 250},
+			promotions: map[string]int64{"minus 10": 1000},
+		},
+		{
+			name:       "fixed capped at basket",
+			promos:     []Promotion{fixed("minus 100", 10000)},
+			lines:      []Line{line(tools, 1, 500), line(paints, 1, 300)},
+			names:      [][]string{{"minus 100"}, {"minus 100"}},
+			discounts:  []int64{500, 300},
+			promotions: map[string]int64{"minus 100": 800},
+		},
+		{
+			name:       "discount capped at remaining line total",
+			promos:     []Promotion{percent("90%", 9000, 2, true), buyGet("1+1", 1, 1, 1, true)},
+			lines:      []Line{line(tools, 2, 1000)},
+			names:      [][]string{{"90%", "1+1"}},
+			discounts:  []int64{2000},
+			promotions: map[string]int64{"90%": 1800, "1+1": 200},
+		},
+		{
+			name:       "stackable percent after buy x get y",
+			promos:     []Promotion{buyGet("1+1", 1, 1, 2, true), percent("10%", 1000, 1, true)},
+			lines:      []Line{line(tools, 2, 1000), line(tools, 1, 1000)},
+			names:      [][]string{{"1+1", "10%"}, {"10%"}},
+			discounts:  []int64{1100, 100},
+			promotions: map[string]int64{"1+1": 1000, "10%": 200},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Evaluate(tt.promos, tt.lines)
+			require.Len(t, res.Lines, len(tt.lines))
+
+			var subtotal, discount int64
+			for i, l := range res.Lines {
+				var names []string
+				for _, d := range l.Discounts {
+					names = append(names, d.Name)
+				}
+				assert.Equal(t, tt.names[i], names, "line %d", i)
+				assert.Equal(t, tt.discounts[i], l.Discount.Amount, "line %d", i)
+				assert.Equal(t, l.Subtotal().Sub(l.Discount), l.Total, "line %d", i)
+				assert.False(t, l.Total.IsNegative(), "line %d", i)
+				subtotal += l.Subtotal().Amount
+				discount += tt.discounts[i]
+			}
+
+			promotions := make(map[string]int64, len(res.Promotions))
+			for _, p := range res.Promotions {
+				promotions[p.Name] = p.Amount.Amount
+			}
+			assert.Equal(t, tt.promotions, promotions)
+			assert.Equal(t, subtotal, res.Subtotal.Amount)
+			assert.Equal(t, discount, res.Discount.Amount)
+			assert.Equal(t, subtotal-discount, res.Total.Amount)
+		})
+	}
+}
+
+func TestEvaluateKeepsPromotionOrder(t *testing.T) {
+	promos := []Promotion{percent("low", 1000, 1, true), percent("high", 1000, 2, true)}
+	Evaluate(promos, []Line{line(tools, 1, 1000)})
+	assert.Equal(t, "low", promos[0].Name)
+}
+
+func TestDiscountsOf(t *testing.T) {
+	lines := []LineResult{
+		{Line: line(tools, 1, 1000), Total: rub(1000)},
+		{Line: line(tools, 1, 1000), Total: rub(10)},
+		{Line: line(tools, 1, 1000), Total: rub(1)},
+	}
+	tests := []struct {
+		name     string
+		promo    Promotion
+		eligible []int
+		want     []int64
+	}{
+		{name: "nothing eligible", promo: fixed("f", 100), want: []int64{}},
+		{name: "percent of remaining total", promo: percent("p", 1500, 0, true), eligible: []int{0, 1}, want: []int64{150, 2}},
+		{name: "buy x get y capped", promo: buyGet("b", 0, 1, 0, true), eligible: []int{1}, want: []int64{10}},
+		{name: "fixed on single line", promo: fixed("f", 500), eligible: []int{0}, want: []int64{500}},
+		{name: "fixed shares round down", promo: fixed("f", 100), eligible: []int{0, 1}, want: []int64{99, 1}},
+		{name: "fixed leftover beyond last line", promo: fixed("f", 100), eligible: []int{0, 1, 2}, want: []int64{98, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discountsOf(tt.promo, lines, tt.eligible)
+			amounts := make([]int64, len(got))
+			var sum int64
+			for i, d := range got {
+				amounts[i] = d.Amount
+				sum += d.Amount
+			}
+			assert.Equal(t, tt.want, amounts)
+			if tt.promo.Kind == KindFixed && len(tt.eligible) > 0 {
+				assert.Equal(t, tt.promo.Amount.Amount, sum)
+			}
+		})
+	}
+}
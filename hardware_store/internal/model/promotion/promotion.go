@@ -0,0 +1,139 @@
+package promotion
+
+import (
+	"errors"
+	"hardware_store/internal/model/money"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindPercent скидка Percent с каждой подходящей позиции.
+	KindPercent Kind = "percent"
+	// KindFixed скидка Amount на все подходящие позиции заказа вместе,
+	// распределённая между ними пропорционально их сумме.
+	KindFixed Kind = "fixed"
+	// KindBuyXGetY из каждых BuyQuantity+GetQuantity единиц товара
+	// GetQuantity единиц бесплатны.
+	KindBuyXGetY Kind = "buy_x_get_y"
+)
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindPercent, KindFixed, KindBuyXGetY:
+		return true
+	}
+	return false
+}
+
+// Promotion правило скидки. CategoryID и SupplierID ограничивают товары,
+// к которым применяется акция; без них акция действует на все товары. Акция
+// с Code применяется только по промокоду. UsageLimit ограничивает число
+// заказов с акцией, PerClientLimit — число заказов одного клиента.
+//
+// Акции применяются по убыванию Priority. Скидки акций складываются на
+// одной позиции, если только одна из них не Stackable: такая акция не
+// применяется к позиции, на которую уже есть скидка, и запрещает
+// последующие скидки на свои позиции.
+type Promotion struct {
+	PromotionID    uuid.UUID
+	Name           string
+	Kind           Kind
+	Percent        money.Rate
+	Amount         money.Money
+	BuyQuantity    int
+	GetQuantity    int
+	CategoryID     *uuid.UUID
+	SupplierID     *uuid.UUID
+	Code           string
+	UsageLimit     *int
+	PerClientLimit *int
+	ValidFrom      *time.Time
+	ValidTo        *time.Time
+	Stackable      bool
+	Priority       int
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Redemption скидка, которую акция дала заказу.
+type Redemption struct {
+	PromotionID uuid.UUID
+	OrderID     uuid.UUID
+	ClientID    uuid.UUID
+	Amount      money.Money
+	CreatedAt   time.Time
+}
+
+// Usage сколько неотменённых заказов уже получили скидку по акции: всего и
+// у конкретного клиента.
+type Usage struct {
+	Total  int
+	Client int
+}
+
+// Validate проверяет, что параметры акции соответствуют её виду.
+func (p Promotion) Validate() error {
+	switch p.Kind {
+	case KindPercent:
+		if p.Percent <= 0 || p.Percent > 10000 {
+			return errors.New("percent must be between 0.01 and 100")
+		}
+	case KindFixed:
+		if !p.Amount.IsPositive() {
+			return errors.New("amount must be positive")
+		}
+	case KindBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return errors.New("buy_quantity and get_quantity must be positive")
+		}
+	default:
+		return errors.New("unknown kind")
+	}
+	if p.ValidFrom != nil && p.ValidTo != nil && !p.ValidTo.After(*p.ValidFrom) {
+		return errors.New("valid_to must be after valid_from")
+	}
+	if p.UsageLimit != nil && *p.UsageLimit <= 0 || p.PerClientLimit != nil && *p.PerClientLimit <= 0 {
+		return errors.New("usage limits must be positive")
+	}
+	return nil
+}
+
+// NormalizeCode приводит промокод к виду, в котором он хранится: без
+// пробелов по краям и в верхнем регистре.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ActiveAt сообщает, действует ли акция в момент t.
+func (p Promotion) ActiveAt(t time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.ValidFrom != nil && t.Before(*p.ValidFrom) {
+		return false
+	}
+	return p.ValidTo == nil || t.Before(*p.ValidTo)
+}
+
+// Exhausted сообщает, исчерпаны ли лимиты акции для клиента с таким
+// использованием.
+func (p Promotion) Exhausted(u Usage) bool {
+	if p.UsageLimit != nil && u.Total >= *p.UsageLimit {
+		return true
+	}
+	return p.PerClientLimit != nil && u.Client >= *p.PerClientLimit
+}
+
+// Covers сообщает, относится ли акция к товару позиции.
+func (p Promotion) Covers(l Line) bool {
+	if p.CategoryID != nil && *p.CategoryID != l.CategoryID {
+		return false
+	}
+	return p.SupplierID == nil || *p.SupplierID == l.SupplierID
+}
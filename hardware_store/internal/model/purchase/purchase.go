@@ -0,0 +1,120 @@
+package purchase
+
+import (
+	"hardware_store/internal/model/money"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusDraft             Status = "draft"
+	StatusSent              Status = "sent"
+	StatusPartiallyReceived Status = "partially_received"
+	StatusReceived          Status = "received"
+	StatusCancelled         Status = "cancelled"
+)
+
+// transitions переходы, доступные вручную. В частично и полностью принятый
+// заказ переводит приёмка товара; частично принятый заказ можно закрыть как
+// принятый, если остаток поставки уже не ожидается.
+var transitions = map[Status][]Status{
+	StatusDraft:             {StatusSent, StatusCancelled},
+	StatusSent:              {StatusCancelled},
+	StatusPartiallyReceived: {StatusReceived},
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusSent, StatusPartiallyReceived, StatusReceived, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// CanReceive сообщает, можно ли принимать товар по заказу в этом статусе.
+func (s Status) CanReceive() bool {
+	return s == StatusSent || s == StatusPartiallyReceived
+}
+
+// PurchaseOrder заказ поставщику. Товар принимается на склад WarehouseID.
+type PurchaseOrder struct {
+	PurchaseOrderID uuid.UUID
+	SupplierID      uuid.UUID
+	WarehouseID     uuid.UUID
+	Status          Status
+	Note            string
+	Lines           []Line
+	Receipts        []Receipt
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Line позиция заказа поставщику. ReceivedQuantity накапливается по всем
+// приёмкам и может превышать заказанное Quantity.
+type Line struct {
+	ProductID        uuid.UUID
+	Name             string
+	Quantity         int
+	ExpectedPrice    money.Money
+	ReceivedQuantity int
+}
+
+// Outstanding сколько единиц по позиции ещё ожидается.
+func (l Line) Outstanding() int {
+	return max(l.Quantity-l.ReceivedQuantity, 0)
+}
+
+func (l Line) LineTotal() money.Money {
+	return l.ExpectedPrice.Mul(l.Quantity)
+}
+
+// Total ожидаемая сумма заказа по заказанным количествам.
+func (po PurchaseOrder) Total() money.Money {
+	var total money.Money
+	for _, l := range po.Lines {
+		total = total.Add(l.LineTotal())
+	}
+	return total
+}
+
+// FullyReceived сообщает, получены ли все позиции полностью.
+func (po PurchaseOrder) FullyReceived() bool {
+	for _, l := range po.Lines {
+		if l.Outstanding() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Receipt приёмка товара по заказу поставщику.
+type Receipt struct {
+	ReceiptID       uuid.UUID
+	PurchaseOrderID uuid.UUID
+	WarehouseID     uuid.UUID
+	Lines           []ReceiptLine
+	ActorID         *uuid.UUID
+	CreatedAt       time.Time
+}
+
+// ReceiptLine принятая позиция. ExpectedQuantity — сколько оставалось
+// получить по позиции заказа до этой приёмки.
+type ReceiptLine struct {
+	ProductID        uuid.UUID
+	ExpectedQuantity int
+	ReceivedQuantity int
+}
+
+// Discrepancy расхождение принятого количества с ожидаемым: отрицательное
+// при недопоставке, положительное при излишке.
+func (l ReceiptLine) Discrepancy() int {
+	return l.ReceivedQuantity - l.ExpectedQuantity
+}
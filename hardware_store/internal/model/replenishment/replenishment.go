@@ -0,0 +1,69 @@
+package replenishment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Policy точка заказа товара. Когда остаток опускается ниже ReorderPoint,
+// создаётся оповещение с рекомендацией заказать ReorderQuantity единиц.
+// nil-поле не задано и берётся из политики категории.
+type Policy struct {
+	ReorderPoint    *int
+	ReorderQuantity *int
+}
+
+// Or дополняет незаданные поля политики значениями def.
+func (p Policy) Or(def Policy) Policy {
+	if p.ReorderPoint == nil {
+		p.ReorderPoint = def.ReorderPoint
+	}
+	if p.ReorderQuantity == nil {
+		p.ReorderQuantity = def.ReorderQuantity
+	}
+	return p
+}
+
+func (p Policy) Valid() bool {
+	return (p.ReorderPoint == nil || *p.ReorderPoint >= 0) &&
+		(p.ReorderQuantity == nil || *p.ReorderQuantity > 0)
+}
+
+// ProductPolicy политика товара вместе с политикой его категории.
+type ProductPolicy struct {
+	ProductID uuid.UUID
+	Own       Policy
+	Category  Policy
+}
+
+// Effective политика, по которой проверяется остаток товара.
+func (p ProductPolicy) Effective() Policy {
+	return p.Own.Or(p.Category)
+}
+
+type AlertStatus string
+
+const (
+	AlertOpen     AlertStatus = "open"
+	AlertResolved AlertStatus = "resolved"
+)
+
+// Alert оповещение о низком остатке. Stock и ReorderPoint фиксируются на
+// момент создания, SupplierID — поставщик товара, у которого рекомендуется
+// заказать SuggestedQuantity единиц. Оповещение закрывается, когда остаток
+// снова достигает точки заказа.
+type Alert struct {
+	AlertID           uuid.UUID
+	ProductID         uuid.UUID
+	ProductName       string
+	SupplierID        *uuid.UUID
+	SupplierName      string
+	Stock             int
+	ReorderPoint      int
+	SuggestedQuantity int
+	Status            AlertStatus
+	CreatedAt         time.Time
+	NotifiedAt        *time.Time
+	ResolvedAt        *time.Time
+}
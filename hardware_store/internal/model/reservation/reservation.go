@@ -0,0 +1,36 @@
+package reservation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusConfirmed Status = "confirmed"
+	StatusReleased  Status = "released"
+	StatusExpired   Status = "expired"
+)
+
+// Reservation удерживает Quantity единиц товара на складе WarehouseID до
+// ExpiresAt. Пока резерв активен, это количество входит в ReservedStock товара
+// и в резерв склада и не может быть продано или перемещено с этого склада.
+// При подтверждении товар списывается со склада WarehouseID.
+type Reservation struct {
+	ReservationID uuid.UUID
+	ProductID     uuid.UUID
+	WarehouseID   uuid.UUID
+	Quantity      int
+	Status        Status
+	Reference     string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (r Reservation) Active(now time.Time) bool {
+	return r.Status == StatusActive && now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,31 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationActive(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status Status
+		expiry time.Time
+		want   bool
+	}{
+		{name: "active", status: StatusActive, expiry: now.Add(time.Minute), want: true},
+		{name: "expires now", status: StatusActive, expiry: now},
+		{name: "past expiry", status: StatusActive, expiry: now.Add(-time.Minute)},
+		{name: "confirmed", status: StatusConfirmed, expiry: now.Add(time.Minute)},
+		{name: "released", status: StatusReleased, expiry: now.Add(time.Minute)},
+		{name: "expired", status: StatusExpired, expiry: now.Add(time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Reservation{Status: tt.status, ExpiresAt: tt.expiry}
+			assert.Equal(t, tt.want, r.Active(now))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package returns
+
+import (
+	"hardware_store/internal/model/money"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusReceived  Status = "received"
+	StatusInspected Status = "inspected"
+	StatusRefunded  Status = "refunded"
+	StatusCancelled Status = "cancelled"
+)
+
+// transitions переходы, доступные вручную. В проверенную заявку переводит
+// осмотр товара, в закрытую возвратом денег — только возврат денег по
+// платежу. Отклонённая, отменённая и закрытая возвратом денег заявки
+// конечные.
+var transitions = map[Status][]Status{
+	StatusRequested: {StatusApproved, StatusRejected, StatusCancelled},
+	StatusApproved:  {StatusReceived, StatusCancelled},
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusRequested, StatusApproved, StatusRejected, StatusReceived, StatusInspected, StatusRefunded, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// Refundable сообщает, можно ли вернуть деньги по заявке в этом статусе:
+// только после осмотра товара.
+func (s Status) Refundable() bool {
+	return s == StatusInspected
+}
+
+// Active сообщает, занимает ли заявка в этом статусе количество позиций
+// заказа. Отклонённая и отменённая заявки количество освобождают.
+func (s Status) Active() bool {
+	return s.Valid() && s != StatusRejected && s != StatusCancelled
+}
+
+// Reason причина возврата, которую называет покупатель.
+type Reason string
+
+const (
+	ReasonDefective      Reason = "defective"
+	ReasonDamaged        Reason = "damaged"
+	ReasonWrongItem      Reason = "wrong_item"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonChangedMind    Reason = "changed_mind"
+	ReasonOther          Reason = "other"
+)
+
+func (r Reason) Valid() bool {
+	switch r {
+	case ReasonDefective, ReasonDamaged, ReasonWrongItem, ReasonNotAsDescribed, ReasonChangedMind, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Outcome решение по итогам осмотра вернувшегося товара.
+type Outcome string
+
+const (
+	// OutcomeRestock товар годен к продаже и возвращается на склад.
+	OutcomeRestock Outcome = "restock"
+	// OutcomeWriteOff товар принимается и сразу списывается.
+	OutcomeWriteOff Outcome = "write_off"
+	// OutcomeSendToSupplier товар принимается и отправляется поставщику.
+	OutcomeSendToSupplier Outcome = "send_to_supplier"
+)
+
+func (o Outcome) Valid() bool {
+	switch o {
+	case OutcomeRestock, OutcomeWriteOff, OutcomeSendToSupplier:
+		return true
+	}
+	return false
+}
+
+// Return заявка на возврат по заказу. Вернувшийся товар принимается на склад
+// WarehouseID. Refund — сумма к возврату покупателю по всем позициям.
+type Return struct {
+	ReturnID    uuid.UUID
+	OrderID     uuid.UUID
+	ClientID    uuid.UUID
+	WarehouseID uuid.UUID
+	Status      Status
+	Note        string
+	Lines       []Line
+	Refund      money.Money
+	ActorID     *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	RefundedAt  *time.Time
+}
+
+// Line возвращаемая позиция заказа. Name копируется из позиции заказа.
+// Outcome пуст, пока товар не осмотрен.
+type Line struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Quantity  int
+	Reason    Reason
+	Comment   string
+	Outcome   Outcome
+	Refund    money.Money
+}
+
+// Filter условия списка заявок. Пустые поля не ограничивают выборку.
+type Filter struct {
+	OrderID uuid.UUID
+	Status  Status
+}
+
+// CalcRefund пересчитывает сумму к возврату по позициям.
+func (r *Return) CalcRefund() {
+	var refund money.Money
+	for _, l := range r.Lines {
+		refund = refund.Add(l.Refund)
+	}
+	r.Refund = refund
+}
+
+// Inspected сообщает, осмотрены ли все позиции.
+func (r Return) Inspected() bool {
+	for _, l := range r.Lines {
+		if l.Outcome == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// RefundShare доля оплаченной суммы позиции paid за quantity из total единиц,
+// если claimed единиц позиции уже заявлено к возврату. Доли считаются от
+// нарастающего количества с округлением вниз, поэтому при возврате всех
+// единиц по частям их сумма в точности равна paid.
+func RefundShare(paid money.Money, total, claimed, quantity int) money.Money {
+	if total <= 0 {
+		return money.New(0, paid.Currency)
+	}
+	before := paid.Amount * int64(claimed) / int64(total)
+	after := paid.Amount * int64(claimed+quantity) / int64(total)
+	return money.New(after-before, paid.Currency)
+}
@@ -0,0 +1,49 @@
+package returns
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var statuses = []Status{StatusRequested, StatusApproved, StatusRejected, StatusReceived, StatusInspected, StatusRefunded, StatusCancelled}
+
+func TestCanTransitionTo(t *testing.T) {
+	allowed := map[Status][]Status{
+		StatusRequested: {StatusApproved, StatusRejected, StatusCancelled},
+		StatusApproved:  {StatusReceived, StatusCancelled},
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				assert.Equal(t, slices.Contains(allowed[from], to), from.CanTransitionTo(to))
+			})
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		status     Status
+		valid      bool
+		active     bool
+		refundable bool
+	}{
+		{status: StatusRequested, valid: true, active: true},
+		{status: StatusApproved, valid: true, active: true},
+		{status: StatusRejected, valid: true},
+		{status: StatusReceived, valid: true, active: true},
+		{status: StatusInspected, valid: true, active: true, refundable: true},
+		{status: StatusRefunded, valid: true, active: true},
+		{status: StatusCancelled, valid: true},
+		{status: "lost"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.status.Valid())
+			assert.Equal(t, tt.active, tt.status.Active())
+			assert.Equal(t, tt.refundable, tt.status.Refundable())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package stock
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MovementType string
+
+const (
+	MovementReceipt        MovementType = "receipt"
+	MovementSale           MovementType = "sale"
+	MovementReturn         MovementType = "return"
+	MovementAdjustment     MovementType = "adjustment"
+	MovementWriteOff       MovementType = "write_off"
+	MovementSupplierReturn MovementType = "supplier_return"
+)
+
+// Movement запись журнала движения остатков. Quantity хранится со знаком:
+// положительное значение увеличивает остаток, отрицательное уменьшает.
+// uuid.Nil в WarehouseID означает склад по умолчанию.
+type Movement struct {
+	MovementID  uuid.UUID
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+	Type        MovementType
+	Quantity    int
+	Reason      string
+	ActorID     *uuid.UUID
+	CreatedAt   time.Time
+}
+
+func (t MovementType) Valid() bool {
+	switch t {
+	case MovementReceipt, MovementSale, MovementReturn, MovementAdjustment, MovementWriteOff, MovementSupplierReturn:
+		return true
+	}
+	return false
+}
+
+// Delta переводит количество из запроса в изменение остатка. Для корректировки
+// знак задаёт вызывающий, для остальных типов он определяется типом движения.
+func (t MovementType) Delta(quantity int) int {
+	switch t {
+	case MovementSale, MovementWriteOff, MovementSupplierReturn:
+		return -quantity
+	}
+	return quantity
+}
+
+// Discrepancy расхождение между остатком товара и суммой движений по журналу.
+type Discrepancy struct {
+	ProductID   uuid.UUID
+	Stock       int
+	LedgerStock int
+}
+
+// Transfer перемещение товара между складами. Общий остаток товара не меняется.
+type Transfer struct {
+	TransferID      uuid.UUID
+	ProductID       uuid.UUID
+	FromWarehouseID uuid.UUID
+	ToWarehouseID   uuid.UUID
+	Quantity        int
+	ActorID         *uuid.UUID
+	CreatedAt       time.Time
+}
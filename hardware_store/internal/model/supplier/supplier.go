@@ -1,12 +1,56 @@
 package supplier
 
 import (
+	"hardware_store/internal/model/money"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// DefaultCurrency валюта закупочной цены, если она не указана.
+const DefaultCurrency = money.DefaultCurrency
+
 type Supplier struct {
 	SupplierID  uuid.UUID
 	Name        string
 	AddressID   uuid.UUID
 	PhoneNumber string
 }
+
+// Product позиция прайс-листа поставщика: по какой цене, в какие сроки и
+// от какого количества поставщик продаёт товар. Валюта прайс-листа —
+// валюта PurchasePrice.
+type Product struct {
+	SupplierID       uuid.UUID
+	SupplierName     string
+	ProductID        uuid.UUID
+	ProductName      string
+	SKU              string
+	PurchasePrice    money.Money
+	LeadTimeDays     int
+	MinOrderQuantity int
+	UpdatedAt        time.Time
+}
+
+func (p Product) Valid() bool {
+	return !p.PurchasePrice.IsNegative() && p.LeadTimeDays >= 0 && p.MinOrderQuantity > 0 && len(p.PurchasePrice.Currency) == 3
+}
+
+type Preference string
+
+const (
+	PreferPrice    Preference = "price"
+	PreferLeadTime Preference = "lead_time"
+)
+
+// OfferQuery условия выбора поставщика товара. Рассматриваются предложения в
+// валюте Currency с минимальной партией не больше Quantity и, если задан
+// MaxLeadTimeDays, со сроком поставки не дольше него. Prefer задаёт, что
+// важнее: цена или срок поставки; второй критерий разрешает равенство.
+type OfferQuery struct {
+	ProductID       uuid.UUID
+	Quantity        int
+	Currency        string
+	MaxLeadTimeDays *int
+	Prefer          Preference
+}
@@ -0,0 +1,29 @@
+package supplier
+
+import (
+	"hardware_store/internal/model/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		product Product
+		want    bool
+	}{
+		{name: "valid", product: Product{PurchasePrice: money.New(1000, "RUB"), LeadTimeDays: 3, MinOrderQuantity: 10}, want: true},
+		{name: "free same day", product: Product{PurchasePrice: money.New(0, "USD"), MinOrderQuantity: 1}, want: true},
+		{name: "negative price", product: Product{PurchasePrice: money.New(-1, "RUB"), MinOrderQuantity: 1}},
+		{name: "negative lead time", product: Product{PurchasePrice: money.New(1000, "RUB"), LeadTimeDays: -1, MinOrderQuantity: 1}},
+		{name: "zero min order", product: Product{PurchasePrice: money.New(1000, "RUB")}},
+		{name: "no currency", product: Product{PurchasePrice: money.Money{Amount: 1000}, MinOrderQuantity: 1}},
+		{name: "bad currency", product: Product{PurchasePrice: money.New(1000, "RUBL"), MinOrderQuantity: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.product.Valid())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package tax
+
+import (
+	"cmp"
+	"hardware_store/internal/model/money"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Mode как цены каталога соотносятся с налогом.
+type Mode string
+
+const (
+	// ModeInclusive цены уже включают налог: налог выделяется из суммы.
+	ModeInclusive Mode = "inclusive"
+	// ModeExclusive цены указаны без налога: налог начисляется сверху.
+	ModeExclusive Mode = "exclusive"
+)
+
+func (m Mode) Valid() bool {
+	return m == ModeInclusive || m == ModeExclusive
+}
+
+// MaxRate наибольшая допустимая ставка: 100%.
+const MaxRate money.Rate = 10000
+
+func ValidRate(r money.Rate) bool {
+	return r >= 0 && r <= MaxRate
+}
+
+// Source откуда взята ставка товара.
+type Source string
+
+const (
+	SourceProduct  Source = "product"
+	SourceCategory Source = "category"
+	SourceDefault  Source = "default"
+)
+
+// ProductRate ставка налога товара. Override — ставка самого товара,
+// CategoryRate — ставка его категории; Rate — действующая ставка: ставка
+// товара, иначе категории, иначе ставка по умолчанию.
+type ProductRate struct {
+	ProductID    uuid.UUID
+	Override     *money.Rate
+	CategoryRate *money.Rate
+	Rate         money.Rate
+	Source       Source
+}
+
+// Resolve выбирает действующую ставку товара.
+func (r *ProductRate) Resolve(def money.Rate) {
+	switch {
+	case r.Override != nil:
+		r.Rate, r.Source = *r.Override, SourceProduct
+	case r.CategoryRate != nil:
+		r.Rate, r.Source = *r.CategoryRate, SourceCategory
+	default:
+		r.Rate, r.Source = def, SourceDefault
+	}
+}
+
+// Line разбивка суммы на сумму без налога, налог и сумму с налогом.
+type Line struct {
+	Rate  money.Rate
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+}
+
+// Split считает налог по ставке rate на сумму amount, указанную в режиме
+// mode. Налог округляется до копейки отдельно для каждой суммы.
+func Split(amount money.Money, rate money.Rate, mode Mode) Line {
+	l := Line{Rate: rate}
+	if mode == ModeExclusive {
+		l.Net = amount
+		l.Tax = amount.Percent(rate)
+		l.Gross = l.Net.Add(l.Tax)
+		return l
+	}
+	l.Gross = amount
+	l.Tax = amount.IncludedTax(rate)
+	l.Net = l.Gross.Sub(l.Tax)
+	return l
+}
+
+// Summarize складывает строки с одинаковой ставкой. Итоги упорядочены по
+// убыванию ставки.
+func Summarize(lines []Line) []Line {
+	var summary []Line
+	for _, l := range lines {
+		i := slices.IndexFunc(summary, func(s Line) bool { return s.Rate == l.Rate })
+		if i < 0 {
+			summary = append(summary, Line{Rate: l.Rate})
+			i = len(summary) - 1
+		}
+		summary[i].Net = summary[i].Net.Add(l.Net)
+		summary[i].Tax = summary[i].Tax.Add(l.Tax)
+		summary[i].Gross = summary[i].Gross.Add(l.Gross)
+	}
+	slices.SortFunc(summary, func(a, b Line) int { return cmp.Compare(b.Rate, a.Rate) })
+	return summary
+}
+
+// Total складывает строки в один итог без ставки.
+func Total(lines []Line) Line {
+	var total Line
+	for _, l := range lines {
+		total.Net = total.Net.Add(l.Net)
+		total.Tax = total.Tax.Add(l.Tax)
+		total.Gross = total.Gross.Add(l.Gross)
+	}
+	return total
+}
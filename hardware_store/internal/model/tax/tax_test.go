@@ -0,0 +1,89 @@
+package tax
+
+import (
+	"hardware_store/internal/model/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rub(amount int64) money.Money {
+	return money.New(amount, money.DefaultCurrency)
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name            string
+		amount          int64
+		rate            money.Rate
+		mode            Mode
+		net, tax, gross int64
+	}{
+		{name: "exclusive", amount: 10000, rate: 2000, mode: ModeExclusive, net: 10000, tax: 2000, gross: 12000},
+		{name: "exclusive half kopeck rounds up", amount: 5, rate: 1000, mode: ModeExclusive, net: 5, tax: 1, gross: 6},
+		{name: "exclusive below half rounds down", amount: 2, rate: 2000, mode: ModeExclusive, net: 2, tax: 0, gross: 2},
+		{name: "exclusive zero rate", amount: 9999, rate: 0, mode: ModeExclusive, net: 9999, tax: 0, gross: 9999},
+		{name: "inclusive", amount: 12000, rate: 2000, mode: ModeInclusive, net: 10000, tax: 2000, gross: 12000},
+		{name: "inclusive rounded", amount: 100, rate: 2000, mode: ModeInclusive, net: 83, tax: 17, gross: 100},
+		{name: "inclusive reduced rate", amount: 11000, rate: 1000, mode: ModeInclusive, net: 10000, tax: 1000, gross: 11000},
+		{name: "inclusive tiny amount", amount: 1, rate: 1000, mode: ModeInclusive, net: 1, tax: 0, gross: 1},
+		{name: "inclusive full rate", amount: 200, rate: MaxRate, mode: ModeInclusive, net: 100, tax: 100, gross: 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(rub(tt.amount), tt.rate, tt.mode)
+			assert.Equal(t, Line{Rate: tt.rate, Net: rub(tt.net), Tax: rub(tt.tax), Gross: rub(tt.gross)}, got)
+		})
+	}
+}
+
+func TestSplitRoundsEachLine(t *testing.T) {
+	var lines []Line
+	for range 3 {
+		lines = append(lines, Split(rub(100), 2000, ModeInclusive))
+	}
+	// Налог с каждой позиции округляется отдельно: 3 × 0.17, а не 0.50 с 3.00.
+	assert.Equal(t, Line{Net: rub(249), Tax: rub(51), Gross: rub(300)}, Total(lines))
+	assert.Equal(t, rub(50), Split(rub(300), 2000, ModeInclusive).Tax)
+}
+
+func TestSummarize(t *testing.T) {
+	lines := []Line{
+		Split(rub(12000), 2000, ModeInclusive),
+		Split(rub(11000), 1000, ModeInclusive),
+		Split(rub(100), 2000, ModeInclusive),
+		Split(rub(500), 0, ModeInclusive),
+		Split(rub(2200), 1000, ModeInclusive),
+	}
+	assert.Equal(t, []Line{
+		{Rate: 2000, Net: rub(10083), Tax: rub(2017), Gross: rub(12100)},
+		{Rate: 1000, Net: rub(12000), Tax: rub(1200), Gross: rub(13200)},
+		{Rate: 0, Net: rub(500), Tax: rub(0), Gross: rub(500)},
+	}, Summarize(lines))
+	assert.Equal(t, Line{Net: rub(22583), Tax: rub(3217), Gross: rub(25800)}, Total(lines))
+	assert.Equal(t, Total(lines), Total(Summarize(lines)))
+
+	assert.Empty(t, Summarize(nil))
+	assert.Equal(t, Line{}, Total(nil))
+}
+
+func TestResolve(t *testing.T) {
+	override, category := money.Rate(1000), money.Rate(0)
+	tests := []struct {
+		name   string
+		rate   ProductRate
+		want   money.Rate
+		source Source
+	}{
+		{name: "product", rate: ProductRate{Override: &override, CategoryRate: &category}, want: 1000, source: SourceProduct},
+		{name: "category", rate: ProductRate{CategoryRate: &category}, want: 0, source: SourceCategory},
+		{name: "default", want: 2000, source: SourceDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rate.Resolve(2000)
+			assert.Equal(t, tt.want, tt.rate.Rate)
+			assert.Equal(t, tt.source, tt.rate.Source)
+		})
+	}
+}
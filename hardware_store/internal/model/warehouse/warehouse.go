@@ -0,0 +1,25 @@
+package warehouse
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindWarehouse Kind = "warehouse"
+	KindShowroom  Kind = "showroom"
+)
+
+// Warehouse место хранения товара. Склад по умолчанию принимает движения
+// остатков, в которых склад не указан.
+type Warehouse struct {
+	WarehouseID uuid.UUID
+	Name        string
+	Kind        Kind
+	AddressID   *uuid.UUID
+	IsDefault   bool
+	CreatedAt   time.Time
+}
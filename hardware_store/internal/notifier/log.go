@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"hardware_store/internal/model/replenishment"
+	"log/slog"
+)
+
+// LogNotifier пишет оповещения в журнал приложения.
+type LogNotifier struct {
+	log *slog.Logger
+}
+
+func NewLogNotifier(log *slog.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, alerts []replenishment.Alert) error {
+	for _, a := range alerts {
+		attrs := []any{
+			slog.String("alert_id", a.AlertID.String()),
+			slog.String("product_id", a.ProductID.String()),
+			slog.String("product", a.ProductName),
+			slog.Int("stock", a.Stock),
+			slog.Int("reorder_point", a.ReorderPoint),
+			slog.Int("suggested_quantity", a.SuggestedQuantity),
+		}
+		if a.SupplierID != nil {
+			attrs = append(attrs, slog.String("supplier_id", a.SupplierID.String()), slog.String("supplier", a.SupplierName))
+		}
+		n.log.WarnContext(ctx, "Low stock", attrs...)
+	}
+	return nil
+}
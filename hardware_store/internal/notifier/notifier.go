@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/replenishment"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type sink interface {
+	Notify(ctx context.Context, alerts []replenishment.Alert) error
+}
+
+// Multi рассылает оповещения всем получателям из конфигурации.
+type Multi struct {
+	sinks []sink
+}
+
+// New собирает получателей по списку cfg.Alerts.Notifiers.
+func New(cfg *config.Config, log *slog.Logger) (*Multi, error) {
+	m := &Multi{}
+	for _, name := range cfg.Alerts.Notifiers {
+		switch name {
+		case "log":
+			m.sinks = append(m.sinks, NewLogNotifier(log))
+		case "webhook":
+			if cfg.Alerts.WebhookURL == "" {
+				return nil, errors.New("alerts: webhook_url is required for webhook notifier")
+			}
+			m.sinks = append(m.sinks, NewWebhookNotifier(cfg.Alerts.WebhookURL, cfg.Alerts.WebhookTimeout))
+		case "file":
+			if cfg.Alerts.FilePath == "" {
+				return nil, errors.New("alerts: file_path is required for file notifier")
+			}
+			m.sinks = append(m.sinks, NewFileNotifier(cfg.Alerts.FilePath))
+		default:
+			return nil, fmt.Errorf("alerts: unknown notifier %q", name)
+		}
+	}
+	return m, nil
+}
+
+// Notify отправляет оповещения всем получателям. Ошибка одного получателя не
+// мешает отправке остальным, но возвращается вызывающему: оповещения не
+// отмечаются отправленными, и вся пачка уходит повторно при следующей сверке.
+// Получатели, уже принявшие пачку, получат её ещё раз и должны отбрасывать
+// повторы по alert_id.
+func (m *Multi) Notify(ctx context.Context, alerts []replenishment.Alert) error {
+	var errs []error
+	for _, s := range m.sinks {
+		if err := s.Notify(ctx, alerts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// alertPayload представление оповещения для внешних получателей.
+type alertPayload struct {
+	AlertID           uuid.UUID  `json:"alert_id"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	ProductName       string     `json:"product_name"`
+	SupplierID        *uuid.UUID `json:"supplier_id,omitempty"`
+	SupplierName      string     `json:"supplier_name,omitempty"`
+	Stock             int        `json:"stock"`
+	ReorderPoint      int        `json:"reorder_point"`
+	SuggestedQuantity int        `json:"suggested_quantity"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func toPayload(a replenishment.Alert) alertPayload {
+	return alertPayload{
+		AlertID:           a.AlertID,
+		ProductID:         a.ProductID,
+		ProductName:       a.ProductName,
+		SupplierID:        a.SupplierID,
+		SupplierName:      a.SupplierName,
+		Stock:             a.Stock,
+		ReorderPoint:      a.ReorderPoint,
+		SuggestedQuantity: a.SuggestedQuantity,
+		CreatedAt:         a.CreatedAt,
+	}
+}
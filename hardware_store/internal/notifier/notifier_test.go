@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"hardware_store/internal/model/replenishment"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubSink struct {
+	err   error
+	calls int
+}
+
+func (s *stubSink) Notify(context.Context, []replenishment.Alert) error {
+	s.calls++
+	return s.err
+}
+
+func TestMultiNotify(t *testing.T) {
+	failure := errors.New("sink is down")
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr bool
+	}{
+		{name: "all delivered", errs: []error{nil, nil, nil}},
+		{name: "one sink failed", errs: []error{nil, failure, nil}, wantErr: true},
+		{name: "only one sink delivered", errs: []error{failure, nil, failure}, wantErr: true},
+		{name: "every sink failed", errs: []error{failure, failure}, wantErr: true},
+		{name: "no sinks", errs: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Multi{}
+			var sinks []*stubSink
+			for _, err := range tt.errs {
+				s := &stubSink{err: err}
+				sinks = append(sinks, s)
+				m.sinks = append(m.sinks, s)
+			}
+
+			err := m.Notify(context.Background(), []replenishment.Alert{{AlertID: uuid.New()}})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, failure)
+			} else {
+				assert.NoError(t, err)
+			}
+			for i, s := range sinks {
+				assert.Equal(t, 1, s.calls, "sink %d", i)
+			}
+		})
+	}
+}
+
+func TestMultiNotifyRetriesWhenOneSinkFails(t *testing.T) {
+	webhook := &stubSink{err: errors.New("webhook is down")}
+	m := &Multi{sinks: []sink{NewLogNotifier(slog.New(slog.NewTextHandler(io.Discard, nil))), webhook}}
+	alerts := []replenishment.Alert{{AlertID: uuid.New()}}
+
+	err := m.Notify(context.Background(), alerts)
+	assert.ErrorIs(t, err, webhook.err, "log sink success must not hide the failed webhook")
+
+	webhook.err = nil
+	assert.NoError(t, m.Notify(context.Background(), alerts))
+	assert.Equal(t, 2, webhook.calls, "failed sink receives the batch again")
+}
@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hardware_store/internal/model/replenishment"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier отправляет пачку оповещений одним POST-запросом с телом
+// {"alerts": [...]}. Любой ответ кроме 2xx считается ошибкой доставки.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []replenishment.Alert) error {
+	body := struct {
+		Alerts []alertPayload `json:"alerts"`
+	}{Alerts: make([]alertPayload, 0, len(alerts))}
+	for _, a := range alerts {
+		body.Alerts = append(body.Alerts, toPayload(a))
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
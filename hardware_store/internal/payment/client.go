@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/payment"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPProvider клиент шлюза с REST API, которое реализует заглушка
+// cmd/paymentstub. Уведомления шлюза подписываются общим секретом.
+type HTTPProvider struct {
+	baseURL string
+	secret  string
+	client  *http.Client
+}
+
+func NewHTTPProvider(baseURL, secret string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  secret,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *HTTPProvider) Name() string {
+	return "http"
+}
+
+func (p *HTTPProvider) CreatePayment(ctx context.Context, req payment.Request) (payment.State, error) {
+	body := createPayload{
+		OrderID:     req.OrderID,
+		Amount:      req.Amount,
+		Currency:    req.Amount.Currency,
+		Description: req.Description,
+		Capture:     req.Capture,
+	}
+	return p.do(ctx, http.MethodPost, "/payments", req.Key, body)
+}
+
+func (p *HTTPProvider) Capture(ctx context.Context, externalID string, amount money.Money) (payment.State, error) {
+	body := amountPayload{Amount: amount, Currency: amount.Currency}
+	return p.do(ctx, http.MethodPost, "/payments/"+url.PathEscape(externalID)+"/capture", "", body)
+}
+
+func (p *HTTPProvider) Refund(ctx context.Context, externalID string, amount money.Money, key string) (payment.State, error) {
+	body := amountPayload{Amount: amount, Currency: amount.Currency}
+	return p.do(ctx, http.MethodPost, "/payments/"+url.PathEscape(externalID)+"/refunds", key, body)
+}
+
+func (p *HTTPProvider) GetPayment(ctx context.Context, externalID string) (payment.State, error) {
+	return p.do(ctx, http.MethodGet, "/payments/"+url.PathEscape(externalID), "", nil)
+}
+
+func (p *HTTPProvider) VerifyWebhook(body []byte, signature string) (payment.Event, error) {
+	return decodeEvent(p.secret, body, signature)
+}
+
+// do выполняет запрос к шлюзу и разбирает платёж из ответа. Любой ответ
+// кроме 2xx считается ошибкой шлюза.
+func (p *HTTPProvider) do(ctx context.Context, method, path, key string, body any) (payment.State, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return payment.State{}, fmt.Errorf("%w: %w", model.ErrPaymentProvider, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return payment.State{}, fmt.Errorf("%w: %w", model.ErrPaymentProvider, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set(IdempotenceHeader, key)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return payment.State{}, fmt.Errorf("%w: %w", model.ErrPaymentProvider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var e errorPayload
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&e)
+		return payment.State{}, fmt.Errorf("%w: unexpected status %s: %s", model.ErrPaymentProvider, resp.Status, e.Error)
+	}
+	var res paymentPayload
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return payment.State{}, fmt.Errorf("%w: %w", model.ErrPaymentProvider, err)
+	}
+	return fromPayload(res), nil
+}
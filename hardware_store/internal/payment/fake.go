@@ -0,0 +1,201 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/payment"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// errUnknownPayment шлюз не знает платежа с таким идентификатором.
+var errUnknownPayment = errors.New("unknown payment")
+
+// Fake платёжный шлюз в памяти процесса для тестов и локальной работы.
+// Оплату покупателем имитируют Pay и Decline, а Webhook подписывает
+// уведомление так же, как настоящий шлюз. С autoPay платёж оплачивается
+// сразу при создании.
+type Fake struct {
+	secret     string
+	autoPay    bool
+	confirmURL string
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+	keys     map[string]string
+	refunds  map[string]bool
+}
+
+type fakePayment struct {
+	state   payment.State
+	capture bool
+}
+
+func NewFake(secret string, autoPay bool) *Fake {
+	return &Fake{
+		secret:   secret,
+		autoPay:  autoPay,
+		payments: make(map[string]*fakePayment),
+		keys:     make(map[string]string),
+		refunds:  make(map[string]bool),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreatePayment(_ context.Context, req payment.Request) (payment.State, error) {
+	if !req.Amount.IsPositive() {
+		return payment.State{}, fmt.Errorf("%w: amount must be positive", model.ErrPaymentProvider)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id, ok := f.keys[req.Key]; ok && req.Key != "" {
+		return f.payments[id].state, nil
+	}
+
+	id := "fake_" + uuid.NewString()
+	p := &fakePayment{
+		state: payment.State{
+			ExternalID: id,
+			Status:     payment.StatusPending,
+			Amount:     req.Amount,
+			Refunded:   money.New(0, req.Amount.Currency),
+		},
+		capture: req.Capture,
+	}
+	if f.confirmURL != "" {
+		p.state.ConfirmationURL = f.confirmURL + "/payments/" + id + "/pay"
+	}
+	if f.autoPay {
+		p.pay()
+	}
+	f.payments[id] = p
+	if req.Key != "" {
+		f.keys[req.Key] = id
+	}
+	return p.state, nil
+}
+
+// Capture списывает заблокированные деньги. Сумма может быть меньше
+// заблокированной, тогда остаток разблокируется.
+func (f *Fake) Capture(_ context.Context, externalID string, amount money.Money) (payment.State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.get(externalID)
+	if err != nil {
+		return payment.State{}, err
+	}
+	if p.state.Status != payment.StatusAuthorized {
+		return payment.State{}, fmt.Errorf("%w: cannot capture %s payment", model.ErrPaymentProvider, p.state.Status)
+	}
+	if err := p.checkCurrency(amount); err != nil {
+		return payment.State{}, err
+	}
+	if !amount.IsPositive() || amount.Cmp(p.state.Amount) > 0 {
+		return payment.State{}, fmt.Errorf("%w: capture amount %s is out of range", model.ErrPaymentProvider, amount)
+	}
+	p.state.Amount = amount
+	p.state.Status = payment.StatusCaptured
+	return p.state, nil
+}
+
+func (f *Fake) Refund(_ context.Context, externalID string, amount money.Money, key string) (payment.State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.get(externalID)
+	if err != nil {
+		return payment.State{}, err
+	}
+	if key != "" && f.refunds[key] {
+		return p.state, nil
+	}
+	if p.state.Status != payment.StatusCaptured {
+		return payment.State{}, fmt.Errorf("%w: cannot refund %s payment", model.ErrPaymentProvider, p.state.Status)
+	}
+	if err := p.checkCurrency(amount); err != nil {
+		return payment.State{}, err
+	}
+	if !amount.IsPositive() || p.state.Refunded.Add(amount).Cmp(p.state.Amount) > 0 {
+		return payment.State{}, fmt.Errorf("%w: refund amount %s is out of range", model.ErrPaymentProvider, amount)
+	}
+	p.state.Refunded = p.state.Refunded.Add(amount)
+	if key != "" {
+		f.refunds[key] = true
+	}
+	return p.state, nil
+}
+
+func (f *Fake) GetPayment(_ context.Context, externalID string) (payment.State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.get(externalID)
+	if err != nil {
+		return payment.State{}, err
+	}
+	return p.state, nil
+}
+
+func (f *Fake) VerifyWebhook(body []byte, signature string) (payment.Event, error) {
+	return decodeEvent(f.secret, body, signature)
+}
+
+// Pay имитирует оплату покупателем: деньги блокируются или, если платёж
+// создан со списанием, сразу списываются. Возвращает уведомление об этом.
+func (f *Fake) Pay(externalID string) (payment.Event, error) {
+	return f.settle(externalID, (*fakePayment).pay)
+}
+
+// Decline имитирует отказ банка в оплате.
+func (f *Fake) Decline(externalID string) (payment.Event, error) {
+	return f.settle(externalID, func(p *fakePayment) { p.state.Status = payment.StatusFailed })
+}
+
+// Webhook сериализует уведомление и подписывает его секретом шлюза.
+// Возвращает тело запроса и значение заголовка SignatureHeader.
+func (f *Fake) Webhook(e payment.Event) ([]byte, string, error) {
+	return encodeEvent(f.secret, e)
+}
+
+func (f *Fake) settle(externalID string, fn func(p *fakePayment)) (payment.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.get(externalID)
+	if err != nil {
+		return payment.Event{}, err
+	}
+	if p.state.Status != payment.StatusPending {
+		return payment.Event{}, fmt.Errorf("%w: payment is already %s", model.ErrPaymentProvider, p.state.Status)
+	}
+	fn(p)
+	return payment.Event{EventID: "evt_" + uuid.NewString(), State: p.state}, nil
+}
+
+func (f *Fake) get(externalID string) (*fakePayment, error) {
+	p, ok := f.payments[externalID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %w %s", model.ErrPaymentProvider, errUnknownPayment, externalID)
+	}
+	return p, nil
+}
+
+func (p *fakePayment) pay() {
+	if p.capture {
+		p.state.Status = payment.StatusCaptured
+	} else {
+		p.state.Status = payment.StatusAuthorized
+	}
+}
+
+func (p *fakePayment) checkCurrency(amount money.Money) error {
+	if err := p.state.Amount.SameCurrency(amount); err != nil {
+		return fmt.Errorf("%w: %w", model.ErrPaymentProvider, err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	service "hardware_store/internal/service/payment"
+)
+
+// New выбирает платёжный шлюз по cfg.Payment.Provider.
+func New(cfg *config.Config) (service.PaymentProvider, error) {
+	switch cfg.Payment.Provider {
+	case "fake":
+		return NewFake(cfg.Payment.WebhookSecret, true), nil
+	case "http":
+		if cfg.Payment.BaseURL == "" {
+			return nil, errors.New("payment: base_url is required for http provider")
+		}
+		if cfg.Payment.WebhookSecret == "" {
+			return nil, errors.New("payment: webhook_secret is required for http provider")
+		}
+		return NewHTTPProvider(cfg.Payment.BaseURL, cfg.Payment.WebhookSecret, cfg.Payment.Timeout), nil
+	default:
+		return nil, fmt.Errorf("payment: unknown provider %q", cfg.Payment.Provider)
+	}
+}
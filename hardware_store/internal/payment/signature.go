@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignatureHeader заголовок, в котором шлюз передаёт подпись уведомления.
+const SignatureHeader = "X-Payment-Signature"
+
+// IdempotenceHeader заголовок с ключом идемпотентности запроса к шлюзу.
+const IdempotenceHeader = "Idempotence-Key"
+
+// Sign подписывает тело уведомления HMAC-SHA256 общим секретом и
+// возвращает подпись в шестнадцатеричном виде.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify сравнивает подпись за постоянное время.
+func verify(secret string, body []byte, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
@@ -0,0 +1,203 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/payment"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// webhookAttempts сколько раз заглушка пытается доставить уведомление.
+const webhookAttempts = 3
+
+var payPage = template.Must(template.New("pay").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><title>Оплата {{.ID}}</title></head>
+<body>
+<h1>Оплата заказа</h1>
+<p>Платёж {{.ID}} на сумму {{.Amount}} {{.Currency}}, статус: {{.Status}}</p>
+{{if eq .Status "pending"}}
+<form method="post" action="/payments/{{.ID}}/pay"><button type="submit">Оплатить</button></form>
+<form method="post" action="/payments/{{.ID}}/decline"><button type="submit">Отклонить</button></form>
+{{end}}
+</body>
+</html>
+`))
+
+// StubServer заглушка платёжного шлюза поверх Fake с REST API, которое
+// использует HTTPProvider. На странице оплаты платёж можно оплатить или
+// отклонить вручную, уведомление об этом отправляется на webhookURL.
+type StubServer struct {
+	fake       *Fake
+	webhookURL string
+	client     *http.Client
+	log        *slog.Logger
+	mux        *http.ServeMux
+}
+
+// NewStubServer publicURL — адрес заглушки, доступный покупателю: из него
+// строятся ссылки на страницу оплаты.
+func NewStubServer(secret, publicURL, webhookURL string, log *slog.Logger) *StubServer {
+	fake := NewFake(secret, false)
+	fake.confirmURL = strings.TrimRight(publicURL, "/")
+	s := &StubServer{
+		fake:       fake,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		log:        log,
+		mux:        http.NewServeMux(),
+	}
+	s.mux.HandleFunc("POST /payments", s.create)
+	s.mux.HandleFunc("GET /payments/{id}", s.get)
+	s.mux.HandleFunc("POST /payments/{id}/capture", s.capture)
+	s.mux.HandleFunc("POST /payments/{id}/refunds", s.refund)
+	s.mux.HandleFunc("GET /payments/{id}/pay", s.page)
+	s.mux.HandleFunc("POST /payments/{id}/pay", s.settle(s.fake.Pay))
+	s.mux.HandleFunc("POST /payments/{id}/decline", s.settle(s.fake.Decline))
+	return s
+}
+
+func (s *StubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *StubServer) create(w http.ResponseWriter, r *http.Request) {
+	var req createPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+		return
+	}
+	st, err := s.fake.CreatePayment(r.Context(), payment.Request{
+		Key:         r.Header.Get(IdempotenceHeader),
+		OrderID:     req.OrderID,
+		Amount:      money.New(req.Amount.Amount, currencyOrDefault(req.Currency)),
+		Description: req.Description,
+		Capture:     req.Capture,
+	})
+	s.write(w, http.StatusCreated, st, err)
+}
+
+func (s *StubServer) get(w http.ResponseWriter, r *http.Request) {
+	st, err := s.fake.GetPayment(r.Context(), r.PathValue("id"))
+	s.write(w, http.StatusOK, st, err)
+}
+
+func (s *StubServer) capture(w http.ResponseWriter, r *http.Request) {
+	var req amountPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+		return
+	}
+	amount := money.New(req.Amount.Amount, currencyOrDefault(req.Currency))
+	st, err := s.fake.Capture(r.Context(), r.PathValue("id"), amount)
+	s.write(w, http.StatusOK, st, err)
+}
+
+func (s *StubServer) refund(w http.ResponseWriter, r *http.Request) {
+	var req amountPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+		return
+	}
+	amount := money.New(req.Amount.Amount, currencyOrDefault(req.Currency))
+	st, err := s.fake.Refund(r.Context(), r.PathValue("id"), amount, r.Header.Get(IdempotenceHeader))
+	s.write(w, http.StatusOK, st, err)
+}
+
+func (s *StubServer) page(w http.ResponseWriter, r *http.Request) {
+	st, err := s.fake.GetPayment(r.Context(), r.PathValue("id"))
+	if err != nil {
+		s.write(w, http.StatusOK, st, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := payPage.Execute(w, toPayload(st)); err != nil {
+		s.log.Error("Failed to render payment page", logger.Err(err))
+	}
+}
+
+// settle меняет состояние платежа по fn и отправляет уведомление магазину.
+// Недоставленное уведомление магазин подхватит сверкой при запуске.
+func (s *StubServer) settle(fn func(externalID string) (payment.Event, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e, err := fn(r.PathValue("id"))
+		if err != nil {
+			s.write(w, http.StatusOK, payment.State{}, err)
+			return
+		}
+		if err := s.deliver(context.WithoutCancel(r.Context()), e); err != nil {
+			s.log.Error("Failed to deliver payment webhook", logger.Err(err),
+				slog.String("payment_id", e.ExternalID), slog.String("event_id", e.EventID))
+		}
+		s.write(w, http.StatusOK, e.State, nil)
+	}
+}
+
+// deliver отправляет уведомление POST-запросом с подписью в заголовке
+// SignatureHeader и повторяет попытку, пока магазин не ответит 2xx.
+func (s *StubServer) deliver(ctx context.Context, e payment.Event) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+	body, signature, err := s.fake.Webhook(e)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * time.Second)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SignatureHeader, signature)
+		resp, err := s.client.Do(req)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("unexpected status %s", resp.Status))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *StubServer) write(w http.ResponseWriter, status int, st payment.State, err error) {
+	switch {
+	case err == nil:
+		writeJSON(w, status, toPayload(st))
+	case errors.Is(err, errUnknownPayment):
+		writeJSON(w, http.StatusNotFound, errorPayload{Error: err.Error()})
+	default:
+		writeJSON(w, http.StatusConflict, errorPayload{Error: err.Error()})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return money.DefaultCurrency
+	}
+	return currency
+}
@@ -0,0 +1,90 @@
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/payment"
+
+	"github.com/google/uuid"
+)
+
+// paymentPayload представление платежа в API шлюза. Суммы передаются
+// строкой, валюта — отдельным полем.
+type paymentPayload struct {
+	ID              string      `json:"id"`
+	Status          string      `json:"status"`
+	Amount          money.Money `json:"amount"`
+	Refunded        money.Money `json:"refunded"`
+	Currency        string      `json:"currency"`
+	ConfirmationURL string      `json:"confirmation_url,omitempty"`
+}
+
+type createPayload struct {
+	OrderID     uuid.UUID   `json:"order_id"`
+	Amount      money.Money `json:"amount"`
+	Currency    string      `json:"currency"`
+	Description string      `json:"description"`
+	Capture     bool        `json:"capture"`
+}
+
+type amountPayload struct {
+	Amount   money.Money `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+type eventPayload struct {
+	EventID string         `json:"event_id"`
+	Payment paymentPayload `json:"payment"`
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+func toPayload(st payment.State) paymentPayload {
+	return paymentPayload{
+		ID:              st.ExternalID,
+		Status:          string(st.Status),
+		Amount:          st.Amount,
+		Refunded:        st.Refunded,
+		Currency:        st.Amount.Currency,
+		ConfirmationURL: st.ConfirmationURL,
+	}
+}
+
+func fromPayload(p paymentPayload) payment.State {
+	currency := p.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	return payment.State{
+		ExternalID:      p.ID,
+		Status:          payment.Status(p.Status),
+		Amount:          money.New(p.Amount.Amount, currency),
+		Refunded:        money.New(p.Refunded.Amount, currency),
+		ConfirmationURL: p.ConfirmationURL,
+	}
+}
+
+// decodeEvent проверяет подпись уведомления и разбирает его.
+func decodeEvent(secret string, body []byte, signature string) (payment.Event, error) {
+	if !verify(secret, body, signature) {
+		return payment.Event{}, fmt.Errorf("%w: bad signature", model.ErrInvalidWebhook)
+	}
+	var e eventPayload
+	if err := json.Unmarshal(body, &e); err != nil {
+		return payment.Event{}, fmt.Errorf("%w: %w", model.ErrInvalidWebhook, err)
+	}
+	return payment.Event{EventID: e.EventID, State: fromPayload(e.Payment)}, nil
+}
+
+// encodeEvent сериализует уведомление и подписывает его.
+func encodeEvent(secret string, e payment.Event) ([]byte, string, error) {
+	body, err := json.Marshal(eventPayload{EventID: e.EventID, Payment: toPayload(e.State)})
+	if err != nil {
+		return nil, "", err
+	}
+	return body, Sign(secret, body), nil
+}
@@ -0,0 +1,340 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Размер страницы A4 в пунктах.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document PDF-документ из страниц с текстом и линиями. Документ собирается
+// в памяти и записывается целиком методом WriteTo.
+type Document struct {
+	title string
+	fonts []*docFont
+	pages []*Page
+}
+
+type docFont struct {
+	font *Font
+	res  string
+	used map[int]bool
+	text map[int]rune
+}
+
+// Page страница документа. Координаты задаются в пунктах от левого
+// верхнего угла страницы, y текста — его базовая линия.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// SetTitle задаёт заголовок, который программы просмотра показывают в
+// свойствах документа.
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+func (d *Document) use(f *Font) *docFont {
+	for _, df := range d.fonts {
+		if df.font == f {
+			return df
+		}
+	}
+	df := &docFont{font: f, res: "F" + strconv.Itoa(len(d.fonts)+1), used: map[int]bool{}, text: map[int]rune{}}
+	d.fonts = append(d.fonts, df)
+	return df
+}
+
+// Text выводит строку s шрифтом font кегля size от точки x.
+func (p *Page) Text(font *Font, size, x, y float64, s string) {
+	df := p.doc.use(font)
+	var hex strings.Builder
+	for _, r := range s {
+		g := font.glyph(r)
+		df.used[int(g)] = true
+		if _, ok := df.text[int(g)]; !ok && g != 0 {
+			df.text[int(g)] = r
+		}
+		fmt.Fprintf(&hex, "%04X", g)
+	}
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td <%s> Tj ET\n",
+		df.res, num(size), num(x), num(PageHeight-y), hex.String())
+}
+
+// TextRight выводит строку так, что она заканчивается в точке x.
+func (p *Page) TextRight(font *Font, size, x, y float64, s string) {
+	p.Text(font, size, x-font.Width(s, size), y, s)
+}
+
+// Line проводит линию толщиной width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect заливает прямоугольник с левым верхним углом в (x, y) оттенком
+// серого gray: 0 — чёрный, 1 — белый.
+func (p *Page) Rect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "%s g %s %s %s %s re f 0 g\n",
+		num(gray), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Wrap разбивает строку по словам на строки не шире width пунктов. Слово
+// шире width переносится по символам.
+func (f *Font) Wrap(s string, size, width float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if f.Width(candidate, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, r := range word {
+			if line != "" && f.Width(line+string(r), size) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// objects нумерует объекты документа и запоминает их смещения для таблицы
+// xref.
+type objects struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (o *objects) reserve() int {
+	o.offsets = append(o.offsets, 0)
+	return len(o.offsets)
+}
+
+func (o *objects) begin(id int) {
+	o.offsets[id-1] = o.buf.Len()
+	fmt.Fprintf(&o.buf, "%d 0 obj\n", id)
+}
+
+func (o *objects) dict(id int, body string) {
+	o.begin(id)
+	o.buf.WriteString(body)
+	o.buf.WriteString("\nendobj\n")
+}
+
+// stream записывает поток, сжатый FlateDecode. extra дополняет словарь
+// потока.
+func (o *objects) stream(id int, data []byte, extra string) error {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	o.begin(id)
+	fmt.Fprintf(&o.buf, "<< /Length %d /Filter /FlateDecode%s >>\nstream\n", z.Len(), extra)
+	o.buf.Write(z.Bytes())
+	o.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+// WriteTo записывает документ. Шрифты встраиваются подмножеством глифов,
+// использованных в тексте.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	o := &objects{}
+	o.buf.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+
+	catalog, pages, info := o.reserve(), o.reserve(), o.reserve()
+	fontIDs := make([]int, len(d.fonts))
+	for i := range d.fonts {
+		fontIDs[i] = o.reserve()
+	}
+	pageIDs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = o.reserve()
+	}
+
+	o.dict(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pages))
+	kids := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	o.dict(pages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pageIDs)))
+	o.dict(info, fmt.Sprintf("<< /Title <%s> /Producer (hardware_store) >>", utf16Hex(d.title)))
+
+	var fontRes strings.Builder
+	for i, df := range d.fonts {
+		fmt.Fprintf(&fontRes, "/%s %d 0 R ", df.res, fontIDs[i])
+	}
+	for i, p := range d.pages {
+		content := o.reserve()
+		o.dict(pageIDs[i], fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pages, num(PageWidth), num(PageHeight), fontRes.String(), content))
+		if err := o.stream(content, p.content.Bytes(), ""); err != nil {
+			return 0, err
+		}
+	}
+	for i, df := range d.fonts {
+		if err := o.font(fontIDs[i], df); err != nil {
+			return 0, err
+		}
+	}
+
+	xref := o.buf.Len()
+	fmt.Fprintf(&o.buf, "xref\n0 %d\n0000000000 65535 f \n", len(o.offsets)+1)
+	for _, off := range o.offsets {
+		fmt.Fprintf(&o.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&o.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(o.offsets)+1, catalog, info, xref)
+	return o.buf.WriteTo(w)
+}
+
+// font записывает шрифт Type0 с кодировкой Identity-H: коды в тексте — это
+// номера глифов шрифта.
+func (o *objects) font(id int, df *docFont) error {
+	f := df.font
+	cid, descriptor, file, toUnicode := o.reserve(), o.reserve(), o.reserve(), o.reserve()
+	name := subsetTag(df.used) + "+" + f.name
+
+	o.dict(id, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		name, cid, toUnicode))
+
+	glyphs := make([]int, 0, len(df.used))
+	for g := range df.used {
+		glyphs = append(glyphs, g)
+	}
+	slices.Sort(glyphs)
+	var widths strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", g, f.width(uint16(g)))
+	}
+	o.dict(cid, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>",
+		name, descriptor, widths.String()))
+
+	o.dict(descriptor, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, f.scale(f.bbox[0]), f.scale(f.bbox[1]), f.scale(f.bbox[2]), f.scale(f.bbox[3]),
+		f.scale(f.ascent), f.scale(f.descent), f.scale(f.capHeight), file))
+
+	program := f.subset(df.used)
+	if err := o.stream(file, program, fmt.Sprintf(" /Length1 %d", len(program))); err != nil {
+		return err
+	}
+	return o.stream(toUnicode, toUnicodeCMap(df.text), "")
+}
+
+// toUnicodeCMap сопоставляет глифы символам, чтобы текст документа можно
+// было искать и копировать.
+func toUnicodeCMap(text map[int]rune) []byte {
+	glyphs := make([]int, 0, len(text))
+	for g := range text {
+		glyphs = append(glyphs, g)
+	}
+	slices.Sort(glyphs)
+
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for len(glyphs) > 0 {
+		chunk := glyphs[:min(len(glyphs), 100)]
+		glyphs = glyphs[len(chunk):]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(chunk))
+		for _, g := range chunk {
+			var u strings.Builder
+			for _, c := range utf16.Encode([]rune{text[g]}) {
+				fmt.Fprintf(&u, "%04X", c)
+			}
+			fmt.Fprintf(&b, "<%04X> <%s>\n", g, u.String())
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+// subsetTag метка подмножества шрифта из шести заглавных букв. Метка зависит
+// только от набора глифов, поэтому один и тот же документ собирается
+// одинаково.
+func subsetTag(used map[int]bool) string {
+	glyphs := make([]int, 0, len(used))
+	for g := range used {
+		glyphs = append(glyphs, g)
+	}
+	slices.Sort(glyphs)
+	h := fnv.New32a()
+	for _, g := range glyphs {
+		h.Write([]byte{byte(g >> 8), byte(g)})
+	}
+	sum := h.Sum32()
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = byte('A' + sum%26)
+		sum /= 26
+	}
+	return string(tag)
+}
+
+// utf16Hex кодирует строку для текстовых полей PDF: UTF-16BE с меткой
+// порядка байтов.
+func utf16Hex(s string) string {
+	var b strings.Builder
+	b.WriteString("FEFF")
+	for _, c := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", c)
+	}
+	return b.String()
+}
+
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,417 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidFont = errors.New("invalid TrueType font")
+
+// Font шрифт TrueType для встраивания в документ. В документ попадают только
+// использованные глифы, поэтому кириллица и любые другие символы шрифта
+// выводятся без ограничений однобайтовых кодировок.
+type Font struct {
+	name       string
+	tables     map[string][]byte
+	unitsPerEm int
+	ascent     int
+	descent    int
+	capHeight  int
+	bbox       [4]int
+	longLoca   bool
+	numGlyphs  int
+	advances   []int
+	cmap       map[rune]uint16
+}
+
+// LoadFont читает шрифт TrueType из файла.
+func LoadFont(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return ParseFont(name, data)
+}
+
+// ParseFont разбирает шрифт TrueType. Шрифты с контурами CFF (OpenType .otf)
+// не поддерживаются.
+func ParseFont(name string, data []byte) (*Font, error) {
+	if len(data) < 12 {
+		return nil, ErrInvalidFont
+	}
+	if v := binary.BigEndian.Uint32(data); v != 0x00010000 && v != 0x74727565 {
+		return nil, fmt.Errorf("%w: unsupported sfnt version %#x", ErrInvalidFont, v)
+	}
+	f := &Font{name: sanitizeName(name), tables: make(map[string][]byte)}
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < n; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, ErrInvalidFont
+		}
+		tag := string(data[rec : rec+4])
+		off := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if off < 0 || length < 0 || off+length > len(data) {
+			return nil, fmt.Errorf("%w: table %s out of range", ErrInvalidFont, tag)
+		}
+		f.tables[tag] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "loca", "glyf", "cmap"} {
+		if _, ok := f.tables[tag]; !ok {
+			return nil, fmt.Errorf("%w: missing table %s", ErrInvalidFont, tag)
+		}
+	}
+	if err := f.parseMetrics(); err != nil {
+		return nil, err
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *Font) parseMetrics() error {
+	head, hhea, maxp := f.tables["head"], f.tables["hhea"], f.tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return fmt.Errorf("%w: truncated header tables", ErrInvalidFont)
+	}
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsPerEm == 0 {
+		return fmt.Errorf("%w: zero unitsPerEm", ErrInvalidFont)
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+	f.longLoca = binary.BigEndian.Uint16(head[50:]) == 1
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.capHeight = f.ascent
+	if os2 := f.tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		f.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+	}
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+
+	hmtx := f.tables["hmtx"]
+	metrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if metrics == 0 || metrics > f.numGlyphs || len(hmtx) < 4*metrics {
+		return fmt.Errorf("%w: bad hmtx", ErrInvalidFont)
+	}
+	f.advances = make([]int, f.numGlyphs)
+	for g := range f.advances {
+		i := min(g, metrics-1)
+		f.advances[g] = int(binary.BigEndian.Uint16(hmtx[4*i:]))
+	}
+	return nil
+}
+
+// parseCmap читает таблицу символов Unicode: формат 12 или 4.
+func (f *Font) parseCmap() error {
+	cmap := f.tables["cmap"]
+	if len(cmap) < 4 {
+		return fmt.Errorf("%w: truncated cmap", ErrInvalidFont)
+	}
+	var best []byte
+	bestFormat := 0
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+2 > len(cmap) {
+			continue
+		}
+		format := int(binary.BigEndian.Uint16(cmap[off:]))
+		unicode := platform == 0 || platform == 3 && (encoding == 1 || encoding == 10)
+		if !unicode || format != 4 && format != 12 || format <= bestFormat {
+			continue
+		}
+		best, bestFormat = cmap[off:], format
+	}
+	f.cmap = make(map[rune]uint16)
+	switch bestFormat {
+	case 4:
+		return f.parseCmap4(best)
+	case 12:
+		return f.parseCmap12(best)
+	}
+	return fmt.Errorf("%w: no unicode cmap", ErrInvalidFont)
+}
+
+func (f *Font) parseCmap4(t []byte) error {
+	if len(t) < 14 {
+		return fmt.Errorf("%w: truncated cmap format 4", ErrInvalidFont)
+	}
+	segs := int(binary.BigEndian.Uint16(t[6:])) / 2
+	ends, starts := 14, 16+2*segs
+	deltas, ranges := starts+2*segs, starts+4*segs
+	if ranges+2*segs > len(t) {
+		return fmt.Errorf("%w: truncated cmap format 4", ErrInvalidFont)
+	}
+	for s := 0; s < segs; s++ {
+		end := int(binary.BigEndian.Uint16(t[ends+2*s:]))
+		start := int(binary.BigEndian.Uint16(t[starts+2*s:]))
+		delta := binary.BigEndian.Uint16(t[deltas+2*s:])
+		rangeOff := int(binary.BigEndian.Uint16(t[ranges+2*s:]))
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var g uint16
+			if rangeOff == 0 {
+				g = uint16(c) + delta
+			} else {
+				i := ranges + 2*s + rangeOff + 2*(c-start)
+				if i+2 > len(t) {
+					continue
+				}
+				if g = binary.BigEndian.Uint16(t[i:]); g != 0 {
+					g += delta
+				}
+			}
+			if g != 0 && int(g) < f.numGlyphs {
+				f.cmap[rune(c)] = g
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Font) parseCmap12(t []byte) error {
+	if len(t) < 16 {
+		return fmt.Errorf("%w: truncated cmap format 12", ErrInvalidFont)
+	}
+	groups := int(binary.BigEndian.Uint32(t[12:]))
+	if 16+12*groups > len(t) {
+		return fmt.Errorf("%w: truncated cmap format 12", ErrInvalidFont)
+	}
+	for i := 0; i < groups; i++ {
+		g := t[16+12*i:]
+		start, end := binary.BigEndian.Uint32(g), binary.BigEndian.Uint32(g[4:])
+		glyph := binary.BigEndian.Uint32(g[8:])
+		for c := start; c <= end && c <= 0x10FFFF; c++ {
+			if id := glyph + c - start; id != 0 && int(id) < f.numGlyphs {
+				f.cmap[rune(c)] = uint16(id)
+			}
+		}
+	}
+	return nil
+}
+
+// glyph возвращает глиф символа; символы, которых нет в шрифте, выводятся
+// глифом .notdef.
+func (f *Font) glyph(r rune) uint16 {
+	return f.cmap[r]
+}
+
+// width ширина глифа в тысячных долях кегля, как её ожидает PDF.
+func (f *Font) width(g uint16) int {
+	return f.advances[g] * 1000 / f.unitsPerEm
+}
+
+func (f *Font) scale(v int) int {
+	return v * 1000 / f.unitsPerEm
+}
+
+// Width возвращает ширину строки в пунктах при кегле size.
+func (f *Font) Width(s string, size float64) float64 {
+	total := 0
+	for _, r := range s {
+		total += f.advances[f.glyph(r)]
+	}
+	return float64(total) * size / float64(f.unitsPerEm)
+}
+
+// glyphRange возвращает данные глифа g из таблицы glyf.
+func (f *Font) glyphRange(g int) []byte {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	var start, end int
+	if f.longLoca {
+		if 4*g+8 > len(loca) {
+			return nil
+		}
+		start = int(binary.BigEndian.Uint32(loca[4*g:]))
+		end = int(binary.BigEndian.Uint32(loca[4*g+4:]))
+	} else {
+		if 2*g+4 > len(loca) {
+			return nil
+		}
+		start = 2 * int(binary.BigEndian.Uint16(loca[2*g:]))
+		end = 2 * int(binary.BigEndian.Uint16(loca[2*g+2:]))
+	}
+	if start >= end || end > len(glyf) {
+		return nil
+	}
+	return glyf[start:end]
+}
+
+// components добавляет в used глифы, из которых собран составной глиф.
+func (f *Font) components(g int, used map[int]bool) {
+	data := f.glyphRange(g)
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return
+	}
+	const (
+		argsAreWords = 0x0001
+		haveScale    = 0x0008
+		moreComps    = 0x0020
+		haveXYScale  = 0x0040
+		haveTwoByTwo = 0x0080
+	)
+	for p := 10; p+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[p:])
+		c := int(binary.BigEndian.Uint16(data[p+2:]))
+		if c < f.numGlyphs && !used[c] {
+			used[c] = true
+			f.components(c, used)
+		}
+		p += 4
+		if flags&argsAreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+		if flags&moreComps == 0 {
+			break
+		}
+	}
+}
+
+// subset собирает шрифт, в котором остались только глифы used. Номера
+// глифов не меняются: глифы вне подмножества становятся пустыми, поэтому
+// номера из текста документа остаются верными без перекодировки.
+func (f *Font) subset(used map[int]bool) []byte {
+	used = maps.Clone(used)
+	used[0] = true
+	for g := range maps.Clone(used) {
+		f.components(g, used)
+	}
+
+	var glyf bytes.Buffer
+	loca := make([]byte, 4*(f.numGlyphs+1))
+	for g := 0; g < f.numGlyphs; g++ {
+		binary.BigEndian.PutUint32(loca[4*g:], uint32(glyf.Len()))
+		if used[g] {
+			glyf.Write(f.glyphRange(g))
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[4*f.numGlyphs:], uint32(glyf.Len()))
+
+	head := slices.Clone(f.tables["head"])
+	binary.BigEndian.PutUint32(head[8:], 0)
+	binary.BigEndian.PutUint16(head[50:], 1)
+
+	tables := map[string][]byte{
+		"head": head,
+		"hhea": f.tables["hhea"],
+		"maxp": f.tables["maxp"],
+		"hmtx": f.tables["hmtx"],
+		"loca": loca,
+		"glyf": glyf.Bytes(),
+	}
+	for _, tag := range []string{"cvt ", "fpgm", "prep"} {
+		if t, ok := f.tables[tag]; ok {
+			tables[tag] = t
+		}
+	}
+	out := writeSfnt(tables)
+	binary.BigEndian.PutUint32(out[headOffset(out)+8:], 0xB1B0AFBA-checksum(out))
+	return out
+}
+
+// writeSfnt собирает файл шрифта из таблиц, упорядоченных по тегу.
+func writeSfnt(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	n := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := 16 << entrySelector
+
+	var buf bytes.Buffer
+	header := make([]byte, 12+16*n)
+	binary.BigEndian.PutUint32(header, 0x00010000)
+	binary.BigEndian.PutUint16(header[4:], uint16(n))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(16*n-searchRange))
+	buf.Write(header)
+
+	for i, tag := range tags {
+		t := tables[tag]
+		rec := header[12+16*i:]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], checksum(t))
+		binary.BigEndian.PutUint32(rec[8:], uint32(buf.Len()))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(t)))
+		buf.Write(t)
+		for buf.Len()%4 != 0 {
+			buf.WriteByte(0)
+		}
+	}
+	out := buf.Bytes()
+	copy(out, header)
+	return out
+}
+
+func headOffset(sfnt []byte) int {
+	n := int(binary.BigEndian.Uint16(sfnt[4:]))
+	for i := 0; i < n; i++ {
+		rec := sfnt[12+16*i:]
+		if string(rec[:4]) == "head" {
+			return int(binary.BigEndian.Uint32(rec[8:]))
+		}
+	}
+	return 0
+}
+
+func checksum(b []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(b); i += 4 {
+		var word [4]byte
+		copy(word[:], b[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// sanitizeName оставляет в имени шрифта только символы, допустимые в имени
+// PDF без экранирования.
+func sanitizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < 0x80 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "Font"
+	}
+	return b.String()
+}
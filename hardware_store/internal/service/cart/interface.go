@@ -0,0 +1,23 @@
+package cart
+
+import (
+	"context"
+	"hardware_store/internal/model/cart"
+	"hardware_store/internal/model/order"
+
+	"github.com/google/uuid"
+)
+
+type CartService interface {
+	CreateCart(ctx context.Context) (cart.Cart, error)
+	GetCart(ctx context.Context, token string) (cart.Cart, error)
+	GetClientCart(ctx context.Context, clientID uuid.UUID) (cart.Cart, error)
+	AddItem(ctx context.Context, token string, productID uuid.UUID, quantity int) (cart.Cart, error)
+	UpdateItem(ctx context.Context, token string, productID uuid.UUID, quantity int) (cart.Cart, error)
+	RemoveItem(ctx context.Context, token string, productID uuid.UUID) (cart.Cart, error)
+	GetUserCart(ctx context.Context, userID uuid.UUID) (cart.Cart, error)
+	Merge(ctx context.Context, clientID uuid.UUID, token string) (cart.Cart, error)
+	MergeUserCart(ctx context.Context, userID uuid.UUID, token string) (cart.Cart, error)
+	Checkout(ctx context.Context, token string, codes []string, warehouseID uuid.UUID) (order.Order, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
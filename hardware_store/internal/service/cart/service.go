@@ -0,0 +1,323 @@
+package cart
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	"hardware_store/internal/model/cart"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/client"
+	orderservice "hardware_store/internal/service/order"
+	"hardware_store/internal/service/product"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CartRepository interface {
+	Insert(ctx context.Context, cart cart.Cart) error
+	GetByToken(ctx context.Context, token string, activeSince time.Time) (cart.Cart, error)
+	GetByClient(ctx context.Context, clientID uuid.UUID, activeSince time.Time) (cart.Cart, error)
+	AddItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error
+	SetItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error
+	RemoveItem(ctx context.Context, cartID, productID uuid.UUID) error
+	Touch(ctx context.Context, cartID uuid.UUID, at time.Time) error
+	Delete(ctx context.Context, cartID uuid.UUID) error
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
+
+type cartService struct {
+	repo    CartRepository
+	client  client.ClientService
+	product product.ProductService
+	order   orderservice.OrderService
+	tx      tx.Manager
+	ttl     time.Duration
+}
+
+func NewCartService(repo CartRepository, client client.ClientService, product product.ProductService,
+	order orderservice.OrderService, tx tx.Manager, cfg *config.Config) *cartService {
+	return &cartService{repo: repo, client: client, product: product, order: order, tx: tx, ttl: cfg.Cart.TTL}
+}
+
+// CreateCart создаёт анонимную корзину. Token выдаётся один раз и служит
+// единственным способом доступа к ней.
+func (s *cartService) CreateCart(ctx context.Context) (cart.Cart, error) {
+	c, err := newCart(nil)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	if err := s.repo.Insert(ctx, c); err != nil {
+		return cart.Cart{}, err
+	}
+	return c, nil
+}
+
+func (s *cartService) GetCart(ctx context.Context, token string) (cart.Cart, error) {
+	return s.repo.GetByToken(ctx, token, s.activeSince())
+}
+
+// GetClientCart возвращает корзину клиента, создавая её при первом обращении.
+func (s *cartService) GetClientCart(ctx context.Context, clientID uuid.UUID) (cart.Cart, error) {
+	if err := s.authorize(ctx, clientID); err != nil {
+		return cart.Cart{}, err
+	}
+	var c cart.Cart
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		c, err = s.clientCart(ctx, clientID)
+		return err
+	})
+	return c, err
+}
+
+func (s *cartService) AddItem(ctx context.Context, token string, productID uuid.UUID, quantity int) (cart.Cart, error) {
+	if quantity <= 0 {
+		return cart.Cart{}, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidCart)
+	}
+	return s.modify(ctx, token, func(ctx context.Context, c cart.Cart) error {
+		if err := s.checkAvailable(ctx, productID, quantityOf(c, productID)+quantity); err != nil {
+			return err
+		}
+		return s.repo.AddItem(ctx, c.CartID, productID, quantity)
+	})
+}
+
+func (s *cartService) UpdateItem(ctx context.Context, token string, productID uuid.UUID, quantity int) (cart.Cart, error) {
+	if quantity <= 0 {
+		return cart.Cart{}, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidCart)
+	}
+	return s.modify(ctx, token, func(ctx context.Context, c cart.Cart) error {
+		if err := s.checkAvailable(ctx, productID, quantity); err != nil {
+			return err
+		}
+		return s.repo.SetItem(ctx, c.CartID, productID, quantity)
+	})
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, token string, productID uuid.UUID) (cart.Cart, error) {
+	return s.modify(ctx, token, func(ctx context.Context, c cart.Cart) error {
+		return s.repo.RemoveItem(ctx, c.CartID, productID)
+	})
+}
+
+// GetUserCart возвращает корзину клиента, принадлежащего пользователю userID.
+func (s *cartService) GetUserCart(ctx context.Context, userID uuid.UUID) (cart.Cart, error) {
+	owned, err := s.client.GetClientByUser(ctx, userID)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	return s.GetClientCart(ctx, owned.ClientID)
+}
+
+// Merge переносит позиции анонимной корзины в корзину клиента после входа и
+// удаляет анонимную корзину. Количества одинаковых товаров складываются.
+func (s *cartService) Merge(ctx context.Context, clientID uuid.UUID, token string) (cart.Cart, error) {
+	if err := s.authorize(ctx, clientID); err != nil {
+		return cart.Cart{}, err
+	}
+	var merged cart.Cart
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		target, err := s.clientCart(ctx, clientID)
+		if err != nil {
+			return err
+		}
+		anon, err := s.repo.GetByToken(ctx, token, s.activeSince())
+		if err != nil {
+			return err
+		}
+		if !anon.Anonymous() {
+			return fmt.Errorf("%w: only anonymous carts can be merged", model.ErrInvalidCart)
+		}
+
+		for _, item := range anon.Items {
+			if err := s.repo.AddItem(ctx, target.CartID, item.ProductID, item.Quantity); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Delete(ctx, anon.CartID); err != nil {
+			return err
+		}
+		if err := s.repo.Touch(ctx, target.CartID, time.Now()); err != nil {
+			return err
+		}
+		merged, err = s.repo.GetByClient(ctx, clientID, s.activeSince())
+		return err
+	})
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	return merged, nil
+}
+
+// MergeUserCart сливает анонимную корзину с корзиной клиента пользователя
+// userID. Вызывается покупателем сразу после входа через auth-service.
+func (s *cartService) MergeUserCart(ctx context.Context, userID uuid.UUID, token string) (cart.Cart, error) {
+	owned, err := s.client.GetClientByUser(ctx, userID)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	return s.Merge(ctx, owned.ClientID, token)
+}
+
+// Checkout оформляет заказ по корзине клиента с промокодами codes со склада
+// warehouseID и удаляет корзину в той же транзакции, в которой списываются
+// остатки. uuid.Nil означает склад по умолчанию.
+func (s *cartService) Checkout(ctx context.Context, token string, codes []string, warehouseID uuid.UUID) (order.Order, error) {
+	var created order.Order
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.repo.GetByToken(ctx, token, s.activeSince())
+		if err != nil {
+			return err
+		}
+		if c.Anonymous() {
+			return fmt.Errorf("%w: anonymous cart must be merged into a client cart before checkout", model.ErrInvalidCart)
+		}
+		if err := s.authorize(ctx, *c.ClientID); err != nil {
+			return err
+		}
+		if len(c.Items) == 0 {
+			return fmt.Errorf("%w: cart is empty", model.ErrInvalidCart)
+		}
+
+		o := order.Order{ClientID: *c.ClientID, WarehouseID: warehouseID, Items: make([]order.Item, 0, len(c.Items)), PromoCodes: codes}
+		for _, item := range c.Items {
+			o.Items = append(o.Items, order.Item{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+		created, err = s.order.CreateOrder(ctx, o)
+		if err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, c.CartID)
+	})
+	if err != nil {
+		return order.Order{}, err
+	}
+	return created, nil
+}
+
+func (s *cartService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.repo.DeleteExpired(ctx, s.activeSince())
+}
+
+// modify выполняет изменение корзины в транзакции, продлевает её жизнь и
+// возвращает корзину с актуальными ценами и остатками.
+func (s *cartService) modify(ctx context.Context, token string, fn func(ctx context.Context, c cart.Cart) error) (cart.Cart, error) {
+	var updated cart.Cart
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.repo.GetByToken(ctx, token, s.activeSince())
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, c); err != nil {
+			return err
+		}
+		if err := s.repo.Touch(ctx, c.CartID, time.Now()); err != nil {
+			return err
+		}
+		updated, err = s.repo.GetByToken(ctx, token, s.activeSince())
+		return err
+	})
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	return updated, nil
+}
+
+// clientCart возвращает корзину клиента или создаёт новую. Истёкшая корзина
+// клиента удаляется, чтобы не нарушать уникальность client_id.
+func (s *cartService) clientCart(ctx context.Context, clientID uuid.UUID) (cart.Cart, error) {
+	if _, err := s.client.GetClientByID(ctx, clientID); err != nil {
+		return cart.Cart{}, err
+	}
+	c, err := s.repo.GetByClient(ctx, clientID, s.activeSince())
+	if err == nil {
+		return c, nil
+	}
+	if !errors.Is(err, model.ErrCartNotFound) {
+		return cart.Cart{}, err
+	}
+	if expired, err := s.repo.GetByClient(ctx, clientID, time.Time{}); err == nil {
+		if err := s.repo.Delete(ctx, expired.CartID); err != nil {
+			return cart.Cart{}, err
+		}
+	}
+
+	c, err = newCart(&clientID)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	if err := s.repo.Insert(ctx, c); err != nil {
+		return cart.Cart{}, err
+	}
+	return c, nil
+}
+
+// authorize проверяет, что клиент clientID принадлежит пользователю из
+// токена. Менеджерам и кассирам доступны корзины всех клиентов.
+func (s *cartService) authorize(ctx context.Context, clientID uuid.UUID) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return model.ErrAccessDenied
+	}
+	if claims.HasAnyRole(auth.RoleManager, auth.RoleCashier) {
+		return nil
+	}
+	owned, err := s.client.GetClientByUser(ctx, claims.UserID)
+	if errors.Is(err, model.ErrClientNotFound) {
+		return fmt.Errorf("%w: user has no client", model.ErrAccessDenied)
+	}
+	if err != nil {
+		return err
+	}
+	if owned.ClientID != clientID {
+		return fmt.Errorf("%w: cart belongs to another client", model.ErrAccessDenied)
+	}
+	return nil
+}
+
+func (s *cartService) checkAvailable(ctx context.Context, productID uuid.UUID, quantity int) error {
+	p, err := s.product.GetProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if quantity > p.FreeStock() {
+		return fmt.Errorf("%w: %d of %s requested, %d available",
+			model.ErrInsufficientStock, quantity, productID, p.FreeStock())
+	}
+	return nil
+}
+
+func (s *cartService) activeSince() time.Time {
+	return time.Now().Add(-s.ttl)
+}
+
+func quantityOf(c cart.Cart, productID uuid.UUID) int {
+	for _, item := range c.Items {
+		if item.ProductID == productID {
+			return item.Quantity
+		}
+	}
+	return 0
+}
+
+func newCart(clientID *uuid.UUID) (cart.Cart, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return cart.Cart{}, fmt.Errorf("cart token: %w", err)
+	}
+	now := time.Now()
+	return cart.Cart{
+		CartID:    uuid.New(),
+		ClientID:  clientID,
+		Token:     base64.RawURLEncoding.EncodeToString(buf),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package cart
+
+import (
+	"context"
+	"hardware_store/internal/model/auth"
+	"hardware_store/internal/model/client"
+	model "hardware_store/internal/model/error"
+	clientservice "hardware_store/internal/service/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientsByUser отдаёт клиентов по пользователю auth-service, остальные
+// методы ClientService в тестах не вызываются.
+type clientsByUser struct {
+	clientservice.ClientService
+	owners map[uuid.UUID]uuid.UUID
+}
+
+func (c clientsByUser) GetClientByUser(_ context.Context, userID uuid.UUID) (client.Client, error) {
+	clientID, ok := c.owners[userID]
+	if !ok {
+		return client.Client{}, model.ErrClientNotFound
+	}
+	return client.Client{ClientID: clientID, UserID: &userID}, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	owner, stranger, clientID := uuid.New(), uuid.New(), uuid.New()
+	s := &cartService{client: clientsByUser{owners: map[uuid.UUID]uuid.UUID{
+		owner:    clientID,
+		stranger: uuid.New(),
+	}}}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		denied bool
+	}{
+		{name: "owner", claims: &auth.Claims{UserID: owner, Roles: []string{auth.RoleCustomer}}},
+		{name: "other customer", claims: &auth.Claims{UserID: stranger, Roles: []string{auth.RoleCustomer}}, denied: true},
+		{name: "customer without client", claims: &auth.Claims{UserID: uuid.New(), Roles: []string{auth.RoleCustomer}}, denied: true},
+		{name: "manager", claims: &auth.Claims{UserID: uuid.New(), Roles: []string{auth.RoleManager}}},
+		{name: "cashier", claims: &auth.Claims{UserID: uuid.New(), Roles: []string{auth.RoleCashier}}},
+		{name: "admin", claims: &auth.Claims{UserID: uuid.New(), Roles: []string{auth.RoleAdmin}}},
+		{name: "no token", denied: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.WithClaims(ctx, *tt.claims)
+			}
+			err := s.authorize(ctx, clientID)
+			if tt.denied {
+				assert.ErrorIs(t, err, model.ErrAccessDenied)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetClientCartDeniedForOtherClient(t *testing.T) {
+	user := uuid.New()
+	s := &cartService{client: clientsByUser{owners: map[uuid.UUID]uuid.UUID{user: uuid.New()}}}
+	ctx := auth.WithClaims(context.Background(), auth.Claims{UserID: user, Roles: []string{auth.RoleCustomer}})
+
+	_, err := s.GetClientCart(ctx, uuid.New())
+	assert.ErrorIs(t, err, model.ErrAccessDenied)
+	_, err = s.Merge(ctx, uuid.New(), "token")
+	assert.ErrorIs(t, err, model.ErrAccessDenied)
+}
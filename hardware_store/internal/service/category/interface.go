@@ -3,6 +3,7 @@ package category
 import (
 	"context"
 	"hardware_store/internal/model/category"
+	"hardware_store/internal/model/page"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +12,6 @@ type CategoryService interface {
 	CreateCategory(ctx context.Context, category category.Category) error
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 	GetCategory(ctx context.Context, id uuid.UUID) (category.Category, error)
-	GetCategories(ctx context.Context) ([]category.Category, error)
+	GetCategories(ctx context.Context, req page.Request) ([]category.Category, error)
 	UpdateCategory(ctx context.Context, categoty category.Category) (category.Category, error)
 }
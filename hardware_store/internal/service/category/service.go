@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"hardware_store/internal/model/category"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/tax"
 	"hardware_store/internal/model/tx"
 
 	"github.com/google/uuid"
@@ -13,7 +16,7 @@ type CategoryRepository interface {
 	Insert(ctx context.Context, category category.Category) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetById(ctx context.Context, id uuid.UUID) (category.Category, error)
-	GetAll(ctx context.Context) ([]category.Category, error)
+	GetAll(ctx context.Context, req page.Request) ([]category.Category, error)
 	Update(ctx context.Context, category category.Category) (category.Category, error)
 	UnsetCategory(ctx context.Context, category uuid.UUID) error
 }
@@ -31,6 +34,9 @@ func NewCategoryService(repo CategoryRepository, tx tx.Manager) *categoryService
 }
 
 func (s *categoryService) CreateCategory(ctx context.Context, category category.Category) error {
+	if err := validateTaxRate(category); err != nil {
+		return err
+	}
 	return s.repo.Insert(ctx, category)
 }
 func (s *categoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
@@ -48,9 +54,19 @@ func (s *categoryService) DeleteCategory(ctx context.Context, id uuid.UUID) erro
 func (s *categoryService) GetCategory(ctx context.Context, id uuid.UUID) (category.Category, error) {
 	return s.repo.GetById(ctx, id)
 }
-func (s *categoryService) GetCategories(ctx context.Context) ([]category.Category, error) {
-	return s.repo.GetAll(ctx)
+func (s *categoryService) GetCategories(ctx context.Context, req page.Request) ([]category.Category, error) {
+	return s.repo.GetAll(ctx, req)
 }
 func (s *categoryService) UpdateCategory(ctx context.Context, category category.Category) (category.Category, error) {
+	if err := validateTaxRate(category); err != nil {
+		return category, err
+	}
 	return s.repo.Update(ctx, category)
 }
+
+func validateTaxRate(c category.Category) error {
+	if c.TaxRate != nil && !tax.ValidRate(*c.TaxRate) {
+		return fmt.Errorf("%w: %s must be between 0 and 100", model.ErrInvalidTaxRate, *c.TaxRate)
+	}
+	return nil
+}
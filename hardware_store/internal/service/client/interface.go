@@ -4,6 +4,7 @@ import (
 	"context"
 	"hardware_store/internal/model/address"
 	"hardware_store/internal/model/client"
+	"hardware_store/internal/model/page"
 
 	"github.com/google/uuid"
 )
@@ -13,5 +14,7 @@ type ClientService interface {
 	DeleteClient(ctx context.Context, id uuid.UUID) error
 	UpdateAddressClient(ctx context.Context, id uuid.UUID, address address.Address) error
 	GetClient(ctx context.Context, name, surname string) (client.Client, error)
-	GetClients(ctx context.Context, limit, offset int) ([]client.Client, error)
+	GetClientByID(ctx context.Context, id uuid.UUID) (client.Client, error)
+	GetClientByUser(ctx context.Context, userID uuid.UUID) (client.Client, error)
+	GetClients(ctx context.Context, req page.Request) ([]client.Client, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"hardware_store/internal/model/address"
 	"hardware_store/internal/model/client"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/tx"
 	service "hardware_store/internal/service/address"
 
@@ -15,7 +16,8 @@ type ClientRepository interface {
 	Delete(ctx context.Context, clientID uuid.UUID) error
 	GetByName(ctx context.Context, name, surname string) (client.Client, error)
 	GetById(ctx context.Context, id uuid.UUID) (client.Client, error)
-	GetAll(ctx context.Context, limit, offset int) ([]client.Client, error)
+	GetByUser(ctx context.Context, userID uuid.UUID) (client.Client, error)
+	GetAll(ctx context.Context, req page.Request) ([]client.Client, error)
 	UpdateAddress(ctx context.Context, clientUUID uuid.UUID, address address.Address) error
 	UnsetAddress(ctx context.Context, addressId uuid.UUID) error
 }
@@ -58,6 +60,12 @@ func (s *clientService) UpdateAddressClient(ctx context.Context, id uuid.UUID, a
 func (s *clientService) GetClient(ctx context.Context, name, surname string) (client.Client, error) {
 	return s.repo.GetByName(ctx, name, surname)
 }
-func (s *clientService) GetClients(ctx context.Context, limit, offset int) ([]client.Client, error) {
-	return s.repo.GetAll(ctx, limit, offset)
+func (s *clientService) GetClientByID(ctx context.Context, id uuid.UUID) (client.Client, error) {
+	return s.repo.GetById(ctx, id)
+}
+func (s *clientService) GetClientByUser(ctx context.Context, userID uuid.UUID) (client.Client, error) {
+	return s.repo.GetByUser(ctx, userID)
+}
+func (s *clientService) GetClients(ctx context.Context, req page.Request) ([]client.Client, error) {
+	return s.repo.GetAll(ctx, req)
 }
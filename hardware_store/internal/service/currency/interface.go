@@ -0,0 +1,22 @@
+package currency
+
+import (
+	"context"
+	"hardware_store/internal/model/currency"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/page"
+	"time"
+)
+
+type CurrencyService interface {
+	GetCurrencies(ctx context.Context) ([]currency.Currency, error)
+	SaveCurrency(ctx context.Context, c currency.Currency) error
+	SetRates(ctx context.Context, rates []currency.ExchangeRate) error
+	ImportRates(ctx context.Context, data []byte) (int, error)
+	GetRates(ctx context.Context, code string, f currency.RateFilter, req page.Request) ([]currency.ExchangeRate, error)
+	NewConverter(ctx context.Context, code string, date time.Time) (Converter, error)
+}
+
+// Converter пересчитывает сумму в валюту, для которой он создан, по курсам
+// на выбранную дату.
+type Converter func(m money.Money) (money.Money, error)
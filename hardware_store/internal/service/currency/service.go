@@ -0,0 +1,210 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"hardware_store/internal/model/currency"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/tx"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CurrencyRepository interface {
+	GetAll(ctx context.Context) ([]currency.Currency, error)
+	GetByCode(ctx context.Context, code string) (currency.Currency, error)
+	Upsert(ctx context.Context, c currency.Currency) error
+	UpsertRate(ctx context.Context, rate currency.ExchangeRate) error
+	GetRates(ctx context.Context, code string, f currency.RateFilter, req page.Request) ([]currency.ExchangeRate, error)
+	GetEffectiveRates(ctx context.Context, date time.Time) ([]currency.ExchangeRate, error)
+}
+
+// rateDateLayouts форматы даты в файле курсов.
+var rateDateLayouts = []string{time.DateOnly, "02.01.2006"}
+
+type currencyService struct {
+	repo CurrencyRepository
+	tx   tx.Manager
+}
+
+func NewCurrencyService(repo CurrencyRepository, tx tx.Manager) *currencyService {
+	return &currencyService{repo: repo, tx: tx}
+}
+
+func (s *currencyService) GetCurrencies(ctx context.Context) ([]currency.Currency, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *currencyService) SaveCurrency(ctx context.Context, c currency.Currency) error {
+	if !c.Valid() {
+		return model.ErrInvalidCurrency
+	}
+	return s.repo.Upsert(ctx, c)
+}
+
+// SetRates сохраняет курсы одной транзакцией: если хотя бы один курс
+// некорректен, не сохраняется ни один.
+func (s *currencyService) SetRates(ctx context.Context, rates []currency.ExchangeRate) error {
+	if len(rates) == 0 {
+		return fmt.Errorf("%w: no rates", model.ErrInvalidExchangeRate)
+	}
+	now := time.Now()
+	for i := range rates {
+		r := &rates[i]
+		if r.Currency == currency.Base {
+			return fmt.Errorf("%w: rate of base currency %s is always 1", model.ErrInvalidExchangeRate, currency.Base)
+		}
+		if r.Rate == nil || r.Rate.Sign() <= 0 {
+			return fmt.Errorf("%w: rate of %s must be positive", model.ErrInvalidExchangeRate, r.Currency)
+		}
+		r.RateID = uuid.New()
+		r.EffectiveDate = dateOf(r.EffectiveDate)
+		r.CreatedAt = now
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, r := range rates {
+			if err := s.repo.UpsertRate(ctx, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ImportRates загружает курсы из CSV со столбцами currency, date и rate.
+// Файл загружается целиком или не загружается вовсе.
+func (s *currencyService) ImportRates(ctx context.Context, data []byte) (int, error) {
+	rates, err := parseRates(data)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.SetRates(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func (s *currencyService) GetRates(ctx context.Context, code string, f currency.RateFilter, req page.Request) ([]currency.ExchangeRate, error) {
+	if _, err := s.repo.GetByCode(ctx, code); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRates(ctx, code, f, req)
+}
+
+// NewConverter загружает курсы, действующие на дату date, и возвращает
+// пересчёт в валюту code. Суммы без валюты считаются в базовой валюте.
+func (s *currencyService) NewConverter(ctx context.Context, code string, date time.Time) (Converter, error) {
+	target, err := s.repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := s.repo.GetEffectiveRates(ctx, dateOf(date))
+	if err != nil {
+		return nil, err
+	}
+	rates := map[string]*big.Rat{currency.Base: big.NewRat(1, 1)}
+	for _, r := range effective {
+		rates[r.Currency] = r.Rate
+	}
+	day := date.Format(time.DateOnly)
+	to, ok := rates[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: no rate for %s on %s", model.ErrExchangeRateNotFound, code, day)
+	}
+
+	return func(m money.Money) (money.Money, error) {
+		if m.Currency == "" {
+			m.Currency = currency.Base
+		}
+		if m.Currency == code {
+			return m, nil
+		}
+		from, ok := rates[m.Currency]
+		if !ok {
+			return money.Money{}, fmt.Errorf("%w: no rate for %s on %s", model.ErrExchangeRateNotFound, m.Currency, day)
+		}
+		return currency.Convert(m, from, to, target), nil
+	}, nil
+}
+
+// parseRates разбирает файл курсов. Разделитель — запятая или точка с
+// запятой, в курсе допускается десятичная запятая.
+func parseRates(data []byte) ([]currency.ExchangeRate, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid csv: %v", model.ErrInvalidExchangeRate, err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("%w: file has no rates", model.ErrInvalidExchangeRate)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "currency", "code", "валюта":
+			columns["currency"] = i
+		case "date", "effective_date", "дата":
+			columns["date"] = i
+		case "rate", "курс":
+			columns["rate"] = i
+		}
+	}
+	for _, name := range []string{"currency", "date", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", model.ErrInvalidExchangeRate, name)
+		}
+	}
+
+	rates := make([]currency.ExchangeRate, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		line := i + 2
+		cell := func(name string) string {
+			if j := columns[name]; j < len(row) {
+				return strings.TrimSpace(row[j])
+			}
+			return ""
+		}
+		if strings.Join(row, "") == "" {
+			continue
+		}
+		rate := currency.ExchangeRate{Currency: strings.ToUpper(cell("currency"))}
+		var err error
+		if rate.EffectiveDate, err = parseDate(cell("date")); err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", model.ErrInvalidExchangeRate, line, err)
+		}
+		if rate.Rate, err = currency.ParseRate(strings.ReplaceAll(cell("rate"), ",", ".")); err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", model.ErrInvalidExchangeRate, line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range rateDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date %q must be YYYY-MM-DD or DD.MM.YYYY", s)
+}
+
+// dateOf отбрасывает время: курсы действуют с начала календарного дня.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,14 @@
+package imports
+
+import (
+	"context"
+	"hardware_store/internal/model/imports"
+
+	"github.com/google/uuid"
+)
+
+type ImportService interface {
+	StartImport(ctx context.Context, job imports.Job, data []byte) (imports.Job, error)
+	GetImport(ctx context.Context, id uuid.UUID) (imports.Job, error)
+	ProcessPending(ctx context.Context) (int, error)
+}
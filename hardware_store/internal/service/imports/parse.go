@@ -0,0 +1,86 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/imports"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/xlsx"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// readRows разбирает файл в строки таблицы. Первая строка — заголовок.
+// XLSX читается не дальше maxRows строк данных, чтобы ссылки на дальние
+// строки не раздували таблицу в памяти.
+func readRows(format imports.Format, data []byte, maxRows int) ([][]string, error) {
+	switch format {
+	case imports.FormatCSV:
+		return readCSV(data)
+	case imports.FormatXLSX:
+		return xlsx.Read(data, maxRows+1)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// readCSV принимает разделители "," и ";": выбирается тот, которого больше
+// в строке заголовка.
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	return rows, nil
+}
+
+func blank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePrice допускает десятичную запятую и пробелы между разрядами. XLSX
+// хранит числа в двоичном виде, поэтому "19.990000000000002" из ячейки
+// принимается как 19.99, если отличие меньше миллионной доли копейки.
+func parsePrice(s string) (money.Money, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(s)
+	if s == "" {
+		return money.Money{}, nil
+	}
+	if v, err := money.Parse(s, money.DefaultCurrency); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.Abs(f*100-math.Round(f*100)) > 1e-6 {
+		return money.Money{}, errors.New("must be a number with at most 2 decimal places")
+	}
+	return money.Parse(strconv.FormatFloat(f, 'f', 2, 64), money.DefaultCurrency)
+}
+
+// parseStock принимает целые числа, в том числе записанные XLSX как "15.0".
+func parseStock(s string) (int, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != float64(int(f)) {
+		return 0, errors.New("must be an integer")
+	}
+	return int(f), nil
+}
@@ -0,0 +1,124 @@
+package imports
+
+import (
+	"hardware_store/internal/model/imports"
+	"hardware_store/internal/model/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "comma",
+			data: "sku,price\nA-1,10.50\n",
+			want: [][]string{{"sku", "price"}, {"A-1", "10.50"}},
+		},
+		{
+			name: "semicolon with decimal comma",
+			data: "sku;price\nA-1;10,50\n",
+			want: [][]string{{"sku", "price"}, {"A-1", "10,50"}},
+		},
+		{
+			name: "byte order mark stripped",
+			data: "\ufeffsku,price\nA-1,1\n",
+			want: [][]string{{"sku", "price"}, {"A-1", "1"}},
+		},
+		{
+			name: "ragged rows",
+			data: "sku,price,stock\nA-1,1\n",
+			want: [][]string{{"sku", "price", "stock"}, {"A-1", "1"}},
+		},
+		{name: "unterminated quote", data: "sku,price\n\"A-1,1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCSV([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadRowsRejectsBadInput(t *testing.T) {
+	_, err := readRows(imports.FormatXLSX, []byte("not a zip"), 10)
+	assert.Error(t, err)
+	_, err = readRows("ods", nil, 10)
+	assert.Error(t, err)
+}
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "19.99", want: 1999},
+		{in: "19,99", want: 1999},
+		{in: "1 299,50", want: 129950},
+		{in: "1 299", want: 129900},
+		{in: "19.990000000000002", want: 1999},
+		{in: "19.989999999999998", want: 1999},
+		{in: "19.995", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parsePrice(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Amount)
+			if tt.in != "" {
+				assert.Equal(t, money.DefaultCurrency, got.Currency)
+			}
+		})
+	}
+}
+
+func TestParseStock(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "15", want: 15},
+		{in: "15.0", want: 15},
+		{in: "1 500", want: 1500},
+		{in: "-3", want: -3},
+		{in: "1.5", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseStock(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBlank(t *testing.T) {
+	assert.True(t, blank(nil))
+	assert.True(t, blank([]string{"", "  ", "\t"}))
+	assert.False(t, blank([]string{"", "x"}))
+}
@@ -0,0 +1,349 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/imports"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/model/tx"
+	productservice "hardware_store/internal/service/product"
+	"hardware_store/internal/xlsx"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ImportRepository interface {
+	Insert(ctx context.Context, job imports.Job, payload []byte) error
+	GetById(ctx context.Context, id uuid.UUID) (imports.Job, error)
+	ClaimPending(ctx context.Context, now time.Time) (imports.Job, []byte, error)
+	Finish(ctx context.Context, job imports.Job) error
+	ResolveCategory(ctx context.Context, ref string) (uuid.UUID, error)
+	ResolveSupplier(ctx context.Context, ref string) (uuid.UUID, error)
+}
+
+// requiredColumns без этих столбцов файл не обрабатывается.
+var requiredColumns = []string{imports.FieldName, imports.FieldCategory, imports.FieldSupplier, imports.FieldPrice}
+
+type importService struct {
+	repo        ImportRepository
+	product     productservice.ProductService
+	validator   *validator.Validate
+	tx          tx.Manager
+	maxFileSize int64
+	maxRows     int
+}
+
+func NewImportService(repo ImportRepository, product productservice.ProductService, validator *validator.Validate,
+	tx tx.Manager, cfg *config.Config) *importService {
+	return &importService{repo: repo, product: product, validator: validator, tx: tx,
+		maxFileSize: cfg.Import.MaxFileSize, maxRows: cfg.Import.MaxRows}
+}
+
+// StartImport ставит файл в очередь на обработку. Строки разбираются и
+// проверяются в фоне, результат доступен через GetImport.
+func (s *importService) StartImport(ctx context.Context, job imports.Job, data []byte) (imports.Job, error) {
+	if !job.Format.Valid() {
+		return imports.Job{}, fmt.Errorf("%w: unsupported format %q", model.ErrInvalidImport, job.Format)
+	}
+	if len(data) == 0 {
+		return imports.Job{}, fmt.Errorf("%w: file is empty", model.ErrInvalidImport)
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return imports.Job{}, fmt.Errorf("%w: file exceeds %d bytes", model.ErrInvalidImport, s.maxFileSize)
+	}
+	for header, field := range job.Mapping {
+		if !imports.ValidField(field) {
+			return imports.Job{}, fmt.Errorf("%w: column %q mapped to unknown field %q", model.ErrInvalidImport, header, field)
+		}
+	}
+
+	job.ImportID = uuid.New()
+	job.Status = imports.StatusPending
+	job.CreatedAt = time.Now()
+	if claims, ok := auth.FromContext(ctx); ok {
+		job.CreatedBy = claims.UserID
+	}
+	if err := s.repo.Insert(ctx, job, data); err != nil {
+		return imports.Job{}, err
+	}
+	return job, nil
+}
+
+func (s *importService) GetImport(ctx context.Context, id uuid.UUID) (imports.Job, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+// ProcessPending обрабатывает ожидающие импорты по одному, пока очередь не
+// опустеет, и возвращает их число.
+func (s *importService) ProcessPending(ctx context.Context) (int, error) {
+	var n int
+	for ctx.Err() == nil {
+		job, data, err := s.repo.ClaimPending(ctx, time.Now())
+		if errors.Is(err, model.ErrImportNotFound) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		job = s.run(ctx, job, data)
+		now := time.Now()
+		job.FinishedAt = &now
+		// Итог сохраняется и при остановке приложения, чтобы импорт не
+		// остался в работе навсегда.
+		err = s.tx.WithinTransaction(context.WithoutCancel(ctx), func(ctx context.Context) error {
+			return s.repo.Finish(ctx, job)
+		})
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// run загружает строки файла. Ошибка в строке не прерывает импорт и
+// попадает в отчёт; импорт завершается ошибкой, только если файл нельзя
+// прочитать целиком.
+func (s *importService) run(ctx context.Context, job imports.Job, data []byte) imports.Job {
+	fail := func(format string, args ...any) imports.Job {
+		job.Status = imports.StatusFailed
+		job.Error = fmt.Sprintf(format, args...)
+		return job
+	}
+
+	rows, err := readRows(job.Format, data, s.maxRows)
+	if errors.Is(err, xlsx.ErrTooManyRows) {
+		return fail("file has more than %d rows", s.maxRows)
+	}
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(rows) == 0 {
+		return fail("file is empty")
+	}
+	if len(rows)-1 > s.maxRows {
+		return fail("file has %d rows, at most %d allowed", len(rows)-1, s.maxRows)
+	}
+	columns := imports.Columns(rows[0], job.Mapping)
+	var missing []string
+	for _, field := range requiredColumns {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return fail("missing columns: %s", strings.Join(missing, ", "))
+	}
+
+	// Движения остатка при импорте записываются от имени того, кто его начал.
+	if job.CreatedBy != uuid.Nil {
+		ctx = auth.WithClaims(ctx, auth.Claims{UserID: job.CreatedBy})
+	}
+	l := &loader{
+		service:    s,
+		job:        &job,
+		columns:    columns,
+		categories: make(map[string]resolved),
+		suppliers:  make(map[string]resolved),
+		seen:       make(map[string]int),
+	}
+	for i, record := range rows[1:] {
+		if ctx.Err() != nil {
+			return fail("import interrupted at row %d", i+2)
+		}
+		if blank(record) {
+			continue
+		}
+		job.TotalRows++
+		l.load(ctx, i+2, record)
+	}
+	job.Status = imports.StatusCompleted
+	return job
+}
+
+type resolved struct {
+	id  uuid.UUID
+	err error
+}
+
+// loader загружает строки одного импорта и кэширует разрешённые по
+// названию категории и поставщиков.
+type loader struct {
+	service    *importService
+	job        *imports.Job
+	columns    map[string]int
+	categories map[string]resolved
+	suppliers  map[string]resolved
+	seen       map[string]int
+}
+
+func (l *loader) load(ctx context.Context, line int, record []string) {
+	errs := l.apply(ctx, line, record)
+	if len(errs) == 0 {
+		return
+	}
+	l.job.FailedRows++
+	for _, e := range errs {
+		e.Row = line
+		l.job.Errors = append(l.job.Errors, e)
+	}
+}
+
+func (l *loader) apply(ctx context.Context, line int, record []string) []imports.RowError {
+	row, errs := l.parse(ctx, record)
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := l.service.validator.Struct(row); err != nil {
+		return validationErrors(err)
+	}
+	if row.SKU != "" {
+		if first, ok := l.seen[row.SKU]; ok {
+			return []imports.RowError{{Field: imports.FieldSKU, Message: fmt.Sprintf("duplicate sku, first used in row %d", first)}}
+		}
+		l.seen[row.SKU] = line
+	}
+
+	existing, err := l.existing(ctx, row.SKU)
+	if err != nil {
+		return []imports.RowError{{Message: err.Error()}}
+	}
+	if existing != nil && !l.job.Upsert {
+		return []imports.RowError{{Field: imports.FieldSKU, Message: "product with this sku already exists"}}
+	}
+	if l.job.DryRun {
+		l.count(existing != nil)
+		return nil
+	}
+
+	p := product.Product{
+		ProductID:      uuid.New(),
+		SKU:            row.SKU,
+		Name:           row.Name,
+		CategoryID:     row.CategoryID,
+		Price:          row.Price,
+		SupplierID:     row.SupplierID,
+		LastUpdateDate: time.Now(),
+	}
+	if row.AvailableStock != nil {
+		p.AvailableStock = *row.AvailableStock
+	}
+	if existing != nil {
+		p.ProductID = existing.ProductID
+		if row.AvailableStock == nil {
+			p.AvailableStock = existing.AvailableStock
+		}
+		_, err = l.service.product.ReplaceProduct(ctx, p)
+	} else {
+		err = l.service.product.CreateProduct(ctx, p)
+	}
+	if err != nil {
+		return []imports.RowError{{Message: err.Error()}}
+	}
+	l.count(existing != nil)
+	return nil
+}
+
+func (l *loader) count(updated bool) {
+	if updated {
+		l.job.UpdatedRows++
+	} else {
+		l.job.CreatedRows++
+	}
+}
+
+// existing возвращает товар с артикулом sku или nil, если такого нет.
+func (l *loader) existing(ctx context.Context, sku string) (*product.Product, error) {
+	if sku == "" {
+		return nil, nil
+	}
+	p, err := l.service.product.GetProductBySKU(ctx, sku)
+	if errors.Is(err, model.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// parse переводит ячейки строки в поля товара. Пустые категория и
+// поставщик остаются uuid.Nil и отклоняются валидацией.
+func (l *loader) parse(ctx context.Context, record []string) (imports.Row, []imports.RowError) {
+	var (
+		row  imports.Row
+		errs []imports.RowError
+		err  error
+	)
+	cell := func(field string) (string, bool) {
+		i, ok := l.columns[field]
+		if !ok {
+			return "", false
+		}
+		if i >= len(record) {
+			return "", true
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+
+	row.SKU, _ = cell(imports.FieldSKU)
+	row.Name, _ = cell(imports.FieldName)
+	price, _ := cell(imports.FieldPrice)
+	if row.Price, err = parsePrice(price); err != nil {
+		errs = append(errs, imports.RowError{Field: imports.FieldPrice, Message: err.Error()})
+	}
+	if stock, ok := cell(imports.FieldStock); ok && stock != "" {
+		v, err := parseStock(stock)
+		if err != nil {
+			errs = append(errs, imports.RowError{Field: imports.FieldStock, Message: err.Error()})
+		}
+		row.AvailableStock = &v
+	}
+	if ref, _ := cell(imports.FieldCategory); ref != "" {
+		if row.CategoryID, err = l.resolve(ctx, l.categories, l.service.repo.ResolveCategory, ref); err != nil {
+			errs = append(errs, imports.RowError{Field: imports.FieldCategory, Message: err.Error()})
+		}
+	}
+	if ref, _ := cell(imports.FieldSupplier); ref != "" {
+		if row.SupplierID, err = l.resolve(ctx, l.suppliers, l.service.repo.ResolveSupplier, ref); err != nil {
+			errs = append(errs, imports.RowError{Field: imports.FieldSupplier, Message: err.Error()})
+		}
+	}
+	return row, errs
+}
+
+func (l *loader) resolve(ctx context.Context, cache map[string]resolved,
+	lookup func(ctx context.Context, ref string) (uuid.UUID, error), ref string) (uuid.UUID, error) {
+	key := strings.ToLower(ref)
+	if r, ok := cache[key]; ok {
+		return r.id, r.err
+	}
+	id, err := lookup(ctx, ref)
+	cache[key] = resolved{id: id, err: err}
+	return id, err
+}
+
+// validationErrors раскладывает ошибку валидатора по полям файла.
+func validationErrors(err error) []imports.RowError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []imports.RowError{{Message: err.Error()}}
+	}
+	res := make([]imports.RowError, 0, len(verrs))
+	for _, fe := range verrs {
+		msg := "failed on " + fe.Tag()
+		if fe.Param() != "" {
+			msg += "=" + fe.Param()
+		}
+		res = append(res, imports.RowError{Field: imports.RowFields[fe.StructField()], Message: msg})
+	}
+	return res
+}
@@ -0,0 +1,12 @@
+package invoice
+
+import (
+	"context"
+	"hardware_store/internal/model/invoice"
+
+	"github.com/google/uuid"
+)
+
+type InvoiceService interface {
+	GetDocument(ctx context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error)
+}
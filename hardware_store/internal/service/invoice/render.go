@@ -0,0 +1,309 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/client"
+	"hardware_store/internal/model/invoice"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/pdf"
+	"strconv"
+	"strings"
+)
+
+const (
+	margin     = 40.0
+	pageBottom = pdf.PageHeight - 50
+	bodySize   = 9.0
+	lineHeight = 12.0
+)
+
+type column struct {
+	title string
+	width float64
+	right bool
+}
+
+// columns столбцы таблицы позиций; вместе занимают ширину страницы без
+// полей.
+var columns = []column{
+	{"№", 22, false},
+	{"Товар", 168, false},
+	{"Кол-во", 42, true},
+	{"Цена", 62, true},
+	{"Скидка", 55, true},
+	{"НДС, %", 42, true},
+	{"НДС", 57, true},
+	{"Сумма", 67, true},
+}
+
+// document данные, из которых набирается документ.
+type document struct {
+	invoice invoice.Invoice
+	order   order.Order
+	client  client.Client
+	address *address.Address
+}
+
+// renderer набирает счета и квитанции в PDF.
+type renderer struct {
+	regular *pdf.Font
+	bold    *pdf.Font
+	seller  config.SellerConfig
+}
+
+func newRenderer(cfg config.InvoiceConfig) (*renderer, error) {
+	regular, err := pdf.LoadFont(cfg.FontPath)
+	if err != nil {
+		return nil, fmt.Errorf("invoice font: %w", err)
+	}
+	bold, err := pdf.LoadFont(cfg.BoldFontPath)
+	if err != nil {
+		return nil, fmt.Errorf("invoice bold font: %w", err)
+	}
+	return &renderer{regular: regular, bold: bold, seller: cfg.Seller}, nil
+}
+
+// layout страница, на которой набирается документ, и текущая строка y.
+// Когда место на странице кончается, набор продолжается на новой.
+type layout struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.AddPage()
+	l.y = margin
+}
+
+// ensure переходит на новую страницу, если до низа страницы меньше h.
+func (l *layout) ensure(h float64) bool {
+	if l.y+h <= pageBottom {
+		return false
+	}
+	l.newPage()
+	return true
+}
+
+func (r *renderer) render(d document) ([]byte, error) {
+	l := &layout{doc: pdf.New()}
+	l.doc.SetTitle(r.title(d.invoice))
+	l.newPage()
+
+	r.header(l, d)
+	r.items(l, d.order)
+	r.totals(l, d)
+
+	var buf bytes.Buffer
+	if _, err := l.doc.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("render %s: %w", d.invoice.Kind, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *renderer) title(inv invoice.Invoice) string {
+	name := "Счёт на оплату"
+	if inv.Kind == invoice.KindReceipt {
+		name = "Квитанция об оплате"
+	}
+	return fmt.Sprintf("%s № %s от %s", name, inv.Code(), inv.IssuedAt.Format("02.01.2006"))
+}
+
+// header выводит реквизиты продавца, заголовок документа и покупателя.
+func (r *renderer) header(l *layout, d document) {
+	s := r.seller
+	if s.Name != "" {
+		l.page.Text(r.bold, 12, margin, l.y+12, s.Name)
+		l.y += 18
+	}
+	var details []string
+	if s.INN != "" {
+		details = append(details, "ИНН "+s.INN)
+	}
+	if s.KPP != "" {
+		details = append(details, "КПП "+s.KPP)
+	}
+	r.detail(l, strings.Join(details, ", "))
+	r.detail(l, s.Address)
+	r.detail(l, joinNonEmpty(", ", prefixed("тел. ", s.Phone), s.Email))
+	r.detail(l, joinNonEmpty(", ", s.Bank, prefixed("БИК ", s.BIC)))
+	r.detail(l, joinNonEmpty(", ", prefixed("р/с ", s.Account), prefixed("к/с ", s.CorrespondentAccount)))
+
+	l.y += 16
+	l.page.Text(r.bold, 14, margin, l.y+14, r.title(d.invoice))
+	l.y += 24
+	r.field(l, "Заказ", d.order.OrderID.String())
+	r.field(l, "Дата заказа", d.order.CreatedAt.Format("02.01.2006 15:04"))
+	r.field(l, "Покупатель", strings.TrimSpace(d.client.Surname+" "+d.client.Name))
+	if d.address != nil {
+		r.field(l, "Адрес", joinNonEmpty(", ", d.address.Country, d.address.City, d.address.Street))
+	}
+	l.y += 10
+}
+
+func (r *renderer) detail(l *layout, s string) {
+	if s == "" {
+		return
+	}
+	l.page.Text(r.regular, bodySize, margin, l.y+bodySize, s)
+	l.y += lineHeight
+}
+
+// field выводит подпись и значение; длинное значение переносится.
+func (r *renderer) field(l *layout, label, value string) {
+	const indent = 90.0
+	l.page.Text(r.bold, bodySize, margin, l.y+bodySize, label+":")
+	for _, line := range r.regular.Wrap(value, bodySize, pdf.PageWidth-2*margin-indent) {
+		l.page.Text(r.regular, bodySize, margin+indent, l.y+bodySize, line)
+		l.y += lineHeight
+	}
+}
+
+// items выводит таблицу позиций. Шапка таблицы повторяется на каждой
+// странице.
+func (r *renderer) items(l *layout, o order.Order) {
+	r.tableHeader(l)
+	for i, item := range o.Items {
+		line := o.TaxLine(item)
+		name := r.regular.Wrap(item.Name, bodySize, columns[1].width-6)
+		h := float64(len(name))*lineHeight + 4
+		if l.ensure(h) {
+			r.tableHeader(l)
+		}
+		cells := []string{
+			strconv.Itoa(i + 1),
+			"",
+			strconv.Itoa(item.Quantity),
+			formatMoney(item.UnitPrice),
+			formatMoney(item.Discount),
+			item.TaxRate.String(),
+			formatMoney(line.Tax),
+			formatMoney(line.Gross),
+		}
+		r.row(l, r.regular, cells)
+		for j, s := range name {
+			l.page.Text(r.regular, bodySize, margin+columns[0].width+3, l.y+bodySize+2+float64(j)*lineHeight, s)
+		}
+		l.y += h
+		l.page.Line(margin, l.y, pdf.PageWidth-margin, l.y, 0.3)
+	}
+}
+
+func (r *renderer) tableHeader(l *layout) {
+	l.ensure(2 * lineHeight)
+	titles := make([]string, len(columns))
+	for i, c := range columns {
+		titles[i] = c.title
+	}
+	l.page.Rect(margin, l.y, pdf.PageWidth-2*margin, lineHeight+4, 0.9)
+	r.row(l, r.bold, titles)
+	l.y += lineHeight + 4
+	l.page.Line(margin, l.y, pdf.PageWidth-margin, l.y, 0.5)
+}
+
+// row выводит ячейки строки таблицы; числа выравниваются по правому краю.
+func (r *renderer) row(l *layout, font *pdf.Font, cells []string) {
+	x := margin
+	for i, c := range columns {
+		if cells[i] != "" {
+			if c.right {
+				l.page.TextRight(font, bodySize, x+c.width-3, l.y+bodySize+2, cells[i])
+			} else {
+				l.page.Text(font, bodySize, x+3, l.y+bodySize+2, cells[i])
+			}
+		}
+		x += c.width
+	}
+}
+
+// totals выводит итоги без налога, налог по ставкам и сумму к оплате.
+func (r *renderer) totals(l *layout, d document) {
+	lines := d.order.TaxLines()
+	summary := tax.Summarize(lines)
+	total := tax.Total(lines)
+	currency := d.order.Total.Currency
+
+	l.ensure(float64(len(summary)+5) * lineHeight)
+	l.y += 8
+	right := pdf.PageWidth - margin
+	label := right - 140
+	put := func(font *pdf.Font, name string, m money.Money) {
+		l.page.TextRight(font, bodySize, label, l.y+bodySize, name)
+		l.page.TextRight(font, bodySize, right, l.y+bodySize, formatMoney(m)+" "+currency)
+		l.y += lineHeight
+	}
+
+	if discount := d.order.Discount(); discount.IsPositive() {
+		put(r.regular, "Скидка:", discount)
+	}
+	put(r.regular, "Итого без НДС:", total.Net)
+	for _, s := range summary {
+		put(r.regular, "НДС "+s.Rate.String()+"%:", s.Tax)
+	}
+	put(r.bold, "Итого к оплате:", total.Gross)
+	if d.invoice.Kind == invoice.KindReceipt {
+		paid := "Оплачено:"
+		if at, ok := paidAt(d.order); ok {
+			paid = "Оплачено " + at + ":"
+		}
+		put(r.bold, paid, total.Gross)
+	}
+
+	l.y += 8
+	note := "Цены указаны с учётом НДС."
+	if d.order.TaxMode == tax.ModeExclusive {
+		note = "НДС начислен сверх цен."
+	}
+	l.page.Text(r.regular, bodySize, margin, l.y+bodySize, note)
+}
+
+// paidAt дата перевода заказа в статус «оплачен».
+func paidAt(o order.Order) (string, bool) {
+	for _, t := range o.Transitions {
+		if t.To == order.StatusPaid {
+			return t.CreatedAt.Format("02.01.2006"), true
+		}
+	}
+	return "", false
+}
+
+// formatMoney записывает сумму по-русски: разряды через пробел, копейки
+// через запятую.
+func formatMoney(m money.Money) string {
+	s := m.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(c)
+	}
+	return sign + b.String() + "," + frac
+}
+
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,160 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/invoice"
+	"hardware_store/internal/model/tx"
+	addressservice "hardware_store/internal/service/address"
+	"hardware_store/internal/service/client"
+	"hardware_store/internal/service/order"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvoiceRepository interface {
+	GetByOrder(ctx context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error)
+	NextNumber(ctx context.Context, kind invoice.Kind, year int) (int, error)
+	Insert(ctx context.Context, invoice invoice.Invoice) error
+	SetDocument(ctx context.Context, invoiceID uuid.UUID, document []byte) ([]byte, error)
+}
+
+// documentRenderer набирает PDF документа.
+type documentRenderer interface {
+	render(d document) ([]byte, error)
+}
+
+type invoiceService struct {
+	repo     InvoiceRepository
+	order    order.OrderService
+	client   client.ClientService
+	address  addressservice.AddressService
+	tx       tx.Manager
+	renderer documentRenderer
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewInvoiceService загружает шрифты документов и часовой пояс магазина и не
+// запускается без них.
+func NewInvoiceService(repo InvoiceRepository, order order.OrderService, client client.ClientService,
+	address addressservice.AddressService, tx tx.Manager, cfg *config.Config) (*invoiceService, error) {
+	r, err := newRenderer(cfg.Invoice)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(cfg.Invoice.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invoice time zone: %w", err)
+	}
+	return &invoiceService{repo: repo, order: order, client: client, address: address, tx: tx, renderer: r,
+		location: location, now: time.Now}, nil
+}
+
+// GetDocument возвращает документ вида kind по заказу. Документ выпускается
+// при первом запросе и дальше отдаётся сохранённым, поэтому повторная печать
+// совпадает с первой.
+func (s *invoiceService) GetDocument(ctx context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error) {
+	inv, err := s.repo.GetByOrder(ctx, orderID, kind)
+	switch {
+	case errors.Is(err, model.ErrInvoiceNotFound):
+		return s.issue(ctx, orderID, kind)
+	case err != nil:
+		return invoice.Invoice{}, err
+	case inv.Document == nil:
+		// Номер присвоен, но PDF не записан: набор прервался или ещё идёт
+		// в параллельном запросе.
+		d, err := s.load(ctx, orderID)
+		if err != nil {
+			return invoice.Invoice{}, err
+		}
+		d.invoice = inv
+		return s.render(ctx, d)
+	}
+	return inv, nil
+}
+
+// issue присваивает документу следующий номер за год короткой транзакцией
+// и затем набирает PDF, не держа блокировку счётчика. Номер выдаётся в той
+// же транзакции, что и сохраняется документ, поэтому номера идут без
+// пропусков.
+func (s *invoiceService) issue(ctx context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error) {
+	d, err := s.load(ctx, orderID)
+	if err != nil {
+		return invoice.Invoice{}, err
+	}
+	if !kind.IssuableFor(d.order.Status) {
+		return invoice.Invoice{}, fmt.Errorf("%w: %s for %s order", model.ErrInvoiceNotIssuable, kind, d.order.Status)
+	}
+
+	now := s.now().In(s.location)
+	inv := invoice.Invoice{
+		InvoiceID: uuid.New(),
+		OrderID:   d.order.OrderID,
+		Kind:      kind,
+		Year:      now.Year(),
+		Total:     d.order.Total,
+		IssuedAt:  now,
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		number, err := s.repo.NextNumber(ctx, kind, inv.Year)
+		if err != nil {
+			return err
+		}
+		inv.Number = number
+		return s.repo.Insert(ctx, inv)
+	})
+	if errors.Is(err, model.ErrDuplicateInvoice) {
+		// Документ успел выпустить параллельный запрос, а номер этой
+		// транзакции вернулся в счётчик при откате.
+		if inv, err = s.repo.GetByOrder(ctx, orderID, kind); err != nil || inv.Document != nil {
+			return inv, err
+		}
+	} else if err != nil {
+		return invoice.Invoice{}, err
+	}
+	d.invoice = inv
+	return s.render(ctx, d)
+}
+
+// load собирает заказ, покупателя и его адрес для документа.
+func (s *invoiceService) load(ctx context.Context, orderID uuid.UUID) (document, error) {
+	o, err := s.order.GetOrder(ctx, orderID)
+	if err != nil {
+		return document{}, err
+	}
+	c, err := s.client.GetClientByID(ctx, o.ClientID)
+	if err != nil {
+		return document{}, err
+	}
+	d := document{order: o, client: c}
+	if c.AddressID != uuid.Nil {
+		a, err := s.address.GetAddress(ctx, c.AddressID)
+		if err != nil && !errors.Is(err, model.ErrAddressNotFound) {
+			return document{}, err
+		}
+		if err == nil {
+			d.address = &a
+		}
+	}
+	return d, nil
+}
+
+// render набирает PDF выпущенного документа с датой по часовому поясу
+// магазина и сохраняет его.
+func (s *invoiceService) render(ctx context.Context, d document) (invoice.Invoice, error) {
+	inv := d.invoice
+	d.invoice.IssuedAt = inv.IssuedAt.In(s.location)
+	pdf, err := s.renderer.render(d)
+	if err != nil {
+		return invoice.Invoice{}, err
+	}
+	if inv.Document, err = s.repo.SetDocument(ctx, inv.InvoiceID, pdf); err != nil {
+		return invoice.Invoice{}, err
+	}
+	return inv, nil
+}
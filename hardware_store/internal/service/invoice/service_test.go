@@ -0,0 +1,260 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"hardware_store/internal/model/client"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/invoice"
+	"hardware_store/internal/model/money"
+	ordermodel "hardware_store/internal/model/order"
+	clientservice "hardware_store/internal/service/client"
+	"hardware_store/internal/service/order"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counterKey struct {
+	kind invoice.Kind
+	year int
+}
+
+type invoiceKey struct {
+	order uuid.UUID
+	kind  invoice.Kind
+}
+
+// fakeRepo хранит счётчики и документы в памяти. Транзакция fakeTx
+// откатывает их при ошибке, как postgres возвращает номер в счётчик.
+// hide скрывает документы заказов от ближайшего GetByOrder, будто их
+// выпустил параллельный запрос.
+type fakeRepo struct {
+	counters map[counterKey]int
+	invoices map[invoiceKey]invoice.Invoice
+	hide     map[uuid.UUID]bool
+	inTx     bool
+}
+
+func (r *fakeRepo) GetByOrder(_ context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error) {
+	inv, ok := r.invoices[invoiceKey{orderID, kind}]
+	if !ok || r.hide[orderID] {
+		delete(r.hide, orderID)
+		return invoice.Invoice{}, model.ErrInvoiceNotFound
+	}
+	return inv, nil
+}
+
+func (r *fakeRepo) NextNumber(_ context.Context, kind invoice.Kind, year int) (int, error) {
+	r.counters[counterKey{kind, year}]++
+	return r.counters[counterKey{kind, year}], nil
+}
+
+func (r *fakeRepo) Insert(_ context.Context, inv invoice.Invoice) error {
+	k := invoiceKey{inv.OrderID, inv.Kind}
+	if _, ok := r.invoices[k]; ok {
+		return model.ErrDuplicateInvoice
+	}
+	r.invoices[k] = inv
+	return nil
+}
+
+func (r *fakeRepo) SetDocument(_ context.Context, invoiceID uuid.UUID, document []byte) ([]byte, error) {
+	for k, inv := range r.invoices {
+		if inv.InvoiceID == invoiceID {
+			if inv.Document == nil {
+				inv.Document = document
+				r.invoices[k] = inv
+			}
+			return inv.Document, nil
+		}
+	}
+	return nil, model.ErrInvoiceNotFound
+}
+
+type fakeTx struct {
+	repo *fakeRepo
+}
+
+func (t fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	counters, invoices := maps.Clone(t.repo.counters), maps.Clone(t.repo.invoices)
+	t.repo.inTx = true
+	err := fn(ctx)
+	t.repo.inTx = false
+	if err != nil {
+		t.repo.counters, t.repo.invoices = counters, invoices
+	}
+	return err
+}
+
+// fakeRenderer записывает в PDF код документа и запоминает, набирался ли
+// он внутри транзакции. err отклоняет ближайший набор.
+type fakeRenderer struct {
+	repo     *fakeRepo
+	err      error
+	inTx     bool
+	rendered []invoice.Invoice
+}
+
+func (f *fakeRenderer) render(d document) ([]byte, error) {
+	f.inTx = f.inTx || f.repo.inTx
+	if err := f.err; err != nil {
+		f.err = nil
+		return nil, err
+	}
+	f.rendered = append(f.rendered, d.invoice)
+	return []byte(string(d.invoice.Kind) + " " + d.invoice.Code()), nil
+}
+
+type fakeOrders struct {
+	order.OrderService
+	orders map[uuid.UUID]ordermodel.Order
+}
+
+func (f fakeOrders) GetOrder(_ context.Context, id uuid.UUID) (ordermodel.Order, error) {
+	o, ok := f.orders[id]
+	if !ok {
+		return ordermodel.Order{}, model.ErrOrderNotFound
+	}
+	return o, nil
+}
+
+type fakeClients struct {
+	clientservice.ClientService
+}
+
+func (fakeClients) GetClientByID(_ context.Context, id uuid.UUID) (client.Client, error) {
+	return client.Client{ClientID: id, Name: "Иван", Surname: "Петров"}, nil
+}
+
+// msk часовой пояс магазина в тестах: UTC+3 без перехода на летнее время.
+var msk = time.FixedZone("MSK", 3*60*60)
+
+type testService struct {
+	*invoiceService
+	repo     *fakeRepo
+	renderer *fakeRenderer
+	orders   fakeOrders
+	clock    time.Time
+}
+
+func newTestService() *testService {
+	repo := &fakeRepo{counters: map[counterKey]int{}, invoices: map[invoiceKey]invoice.Invoice{}, hide: map[uuid.UUID]bool{}}
+	ts := &testService{
+		repo:     repo,
+		renderer: &fakeRenderer{repo: repo},
+		orders:   fakeOrders{orders: map[uuid.UUID]ordermodel.Order{}},
+		clock:    time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC),
+	}
+	ts.invoiceService = &invoiceService{repo: repo, order: ts.orders, client: fakeClients{}, tx: fakeTx{repo: repo},
+		renderer: ts.renderer, location: msk, now: func() time.Time { return ts.clock }}
+	return ts
+}
+
+// order добавляет заказ в статусе status и возвращает его номер.
+func (ts *testService) order(status ordermodel.Status) uuid.UUID {
+	o := ordermodel.Order{OrderID: uuid.New(), ClientID: uuid.New(), Status: status, Total: money.New(10000, "RUB")}
+	ts.orders.orders[o.OrderID] = o
+	return o.OrderID
+}
+
+func TestGetDocumentNumbersWithoutGaps(t *testing.T) {
+	ts := newTestService()
+	ctx := context.Background()
+
+	var orders []uuid.UUID
+	for range 3 {
+		orders = append(orders, ts.order(ordermodel.StatusPaid))
+	}
+	for i, id := range orders {
+		inv, err := ts.GetDocument(ctx, id, invoice.KindInvoice)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, inv.Number)
+		assert.Equal(t, 2026, inv.Year)
+		assert.Equal(t, []byte("invoice "+inv.Code()), inv.Document)
+	}
+	receipt, err := ts.GetDocument(ctx, orders[0], invoice.KindReceipt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, receipt.Number, "receipts are numbered separately")
+
+	again, err := ts.GetDocument(ctx, orders[1], invoice.KindInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 2, again.Number, "reprint keeps the number")
+	assert.Len(t, ts.renderer.rendered, 4, "reprint is not rendered again")
+
+	// Параллельный запрос успел выпустить счёт: номер этого запроса
+	// возвращается в счётчик, и следующий заказ получает следующий номер.
+	ts.repo.hide[orders[2]] = true
+	inv, err := ts.GetDocument(ctx, orders[2], invoice.KindInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 3, inv.Number)
+
+	_, err = ts.GetDocument(ctx, ts.order(ordermodel.StatusCancelled), invoice.KindInvoice)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotIssuable)
+	_, err = ts.GetDocument(ctx, ts.order(ordermodel.StatusNew), invoice.KindReceipt)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotIssuable)
+
+	next, err := ts.GetDocument(ctx, ts.order(ordermodel.StatusNew), invoice.KindInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.Number)
+	assert.Equal(t, 4, ts.repo.counters[counterKey{invoice.KindInvoice, 2026}])
+	assert.False(t, ts.renderer.inTx, "PDF is rendered after the numbering transaction")
+}
+
+func TestGetDocumentRenderFailure(t *testing.T) {
+	ts := newTestService()
+	ctx := context.Background()
+	id := ts.order(ordermodel.StatusPaid)
+	ts.renderer.err = errors.New("font is broken")
+
+	_, err := ts.GetDocument(ctx, id, invoice.KindInvoice)
+	require.Error(t, err)
+	stored := ts.repo.invoices[invoiceKey{id, invoice.KindInvoice}]
+	assert.Equal(t, 1, stored.Number, "the number stays with the order")
+	assert.Nil(t, stored.Document)
+
+	inv, err := ts.GetDocument(ctx, id, invoice.KindInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inv.Number)
+	assert.Equal(t, []byte("invoice 2026-000001"), inv.Document)
+
+	next, err := ts.GetDocument(ctx, ts.order(ordermodel.StatusPaid), invoice.KindInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.Number)
+}
+
+func TestGetDocumentYearRollover(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		year int
+		date string
+	}{
+		{name: "last evening of the year", at: time.Date(2026, time.December, 31, 20, 59, 0, 0, time.UTC), year: 2026, date: "31.12.2026"},
+		{name: "new year in the store, old year in UTC", at: time.Date(2026, time.December, 31, 21, 0, 0, 0, time.UTC), year: 2027, date: "01.01.2027"},
+		{name: "new year everywhere", at: time.Date(2027, time.January, 1, 9, 0, 0, 0, time.UTC), year: 2027, date: "01.01.2027"},
+	}
+	ts := newTestService()
+	ts.repo.counters[counterKey{invoice.KindInvoice, 2026}] = 41
+
+	numbers := map[int]int{2026: 41}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.clock = tt.at
+			inv, err := ts.GetDocument(context.Background(), ts.order(ordermodel.StatusPaid), invoice.KindInvoice)
+			require.NoError(t, err)
+
+			numbers[tt.year]++
+			assert.Equal(t, tt.year, inv.Year)
+			assert.Equal(t, numbers[tt.year], inv.Number, "numbering restarts with the store's year")
+			rendered := ts.renderer.rendered[len(ts.renderer.rendered)-1]
+			assert.Equal(t, tt.date, rendered.IssuedAt.Format("02.01.2006"))
+		})
+	}
+	assert.Equal(t, 42, ts.repo.counters[counterKey{invoice.KindInvoice, 2026}])
+	assert.Equal(t, 2, ts.repo.counters[counterKey{invoice.KindInvoice, 2027}])
+}
@@ -0,0 +1,18 @@
+package order
+
+import (
+	"context"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/page"
+
+	"github.com/google/uuid"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order order.Order) (order.Order, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, to order.Status) (order.Order, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (order.Order, error)
+	GetOrderForUpdate(ctx context.Context, id uuid.UUID) (order.Order, error)
+	RegisterReturn(ctx context.Context, id uuid.UUID, quantities map[uuid.UUID]int) (order.Order, error)
+	GetClientOrders(ctx context.Context, clientID uuid.UUID, req page.Request) ([]order.Order, error)
+}
@@ -0,0 +1,306 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/promotion"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/client"
+	"hardware_store/internal/service/product"
+	promotionservice "hardware_store/internal/service/promotion"
+	"hardware_store/internal/service/stock"
+	"hardware_store/internal/service/warehouse"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderRepository interface {
+	Insert(ctx context.Context, order order.Order) error
+	GetById(ctx context.Context, id uuid.UUID) (order.Order, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (order.Order, error)
+	UpdateStatus(ctx context.Context, order order.Order) error
+	InsertTransition(ctx context.Context, transition order.Transition) error
+	AddReturnedQuantity(ctx context.Context, itemID uuid.UUID, quantity int) error
+	GetByClient(ctx context.Context, clientID uuid.UUID, req page.Request) ([]order.Order, error)
+}
+
+type orderService struct {
+	repo      OrderRepository
+	client    client.ClientService
+	product   product.ProductService
+	stock     stock.StockService
+	promotion promotionservice.PromotionService
+	warehouse warehouse.WarehouseService
+	tx        tx.Manager
+	taxMode   tax.Mode
+}
+
+func NewOrderService(repo OrderRepository, client client.ClientService, product product.ProductService,
+	stock stock.StockService, promotion promotionservice.PromotionService, warehouse warehouse.WarehouseService,
+	tx tx.Manager, cfg *config.Config) *orderService {
+	return &orderService{repo: repo, client: client, product: product, stock: stock, promotion: promotion,
+		warehouse: warehouse, tx: tx, taxMode: cfg.Tax.Mode}
+}
+
+// CreateOrder оформляет заказ: проверяет клиента, фиксирует цены и ставки
+// налога позиций, применяет акции и промокоды и списывает остатки по всем
+// позициям в одной транзакции. Если какого-то товара не хватает или промокод недействителен,
+// заказ не создаётся и остатки не меняются.
+func (s *orderService) CreateOrder(ctx context.Context, o order.Order) (order.Order, error) {
+	items, err := mergeItems(o.Items)
+	if err != nil {
+		return order.Order{}, err
+	}
+
+	now := time.Now()
+	o.OrderID = uuid.New()
+	o.Status = order.StatusNew
+	o.TaxMode = s.taxMode
+	o.CreatedAt = now
+	o.UpdatedAt = now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.client.GetClientByID(ctx, o.ClientID); err != nil {
+			return err
+		}
+		if o.WarehouseID == uuid.Nil {
+			w, err := s.warehouse.GetDefaultWarehouse(ctx)
+			if err != nil {
+				return err
+			}
+			o.WarehouseID = w.WarehouseID
+		} else if _, err := s.warehouse.GetWarehouse(ctx, o.WarehouseID); err != nil {
+			return err
+		}
+
+		o.Items = make([]order.Item, 0, len(items))
+		basket := promotion.Basket{ClientID: &o.ClientID, Codes: o.PromoCodes}
+		for _, item := range items {
+			p, err := s.product.GetProduct(ctx, item.ProductID)
+			if err != nil {
+				return err
+			}
+			_, _, err = s.stock.RecordMovement(ctx, stockmodel.Movement{
+				ProductID:   item.ProductID,
+				WarehouseID: o.WarehouseID,
+				Type:        stockmodel.MovementSale,
+				Quantity:    item.Quantity,
+				Reason:      "order " + o.OrderID.String(),
+			})
+			if err != nil {
+				return fmt.Errorf("product %s: %w", item.ProductID, err)
+			}
+			o.Items = append(o.Items, order.Item{
+				ItemID:    uuid.New(),
+				ProductID: p.ProductID,
+				Name:      p.Name,
+				Quantity:  item.Quantity,
+				UnitPrice: p.Price,
+			})
+			basket.Lines = append(basket.Lines, promotion.Line{
+				ProductID:  p.ProductID,
+				Name:       p.Name,
+				CategoryID: p.CategoryID,
+				SupplierID: p.SupplierID,
+				Quantity:   item.Quantity,
+				UnitPrice:  p.Price,
+			})
+		}
+
+		eval, err := s.promotion.Apply(ctx, basket)
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(o.Items))
+		for _, item := range o.Items {
+			ids = append(ids, item.ProductID)
+		}
+		rates, err := s.product.GetTaxRates(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range o.Items {
+			o.Items[i].Discount = eval.Lines[i].Discount
+			o.Items[i].TaxRate = rates[o.Items[i].ProductID].Rate
+		}
+		o.CalcTotal()
+
+		if err := s.repo.Insert(ctx, o); err != nil {
+			return err
+		}
+		return s.promotion.Redeem(ctx, o.OrderID, o.ClientID, eval)
+	})
+	if err != nil {
+		return order.Order{}, err
+	}
+	return o, nil
+}
+
+// ChangeStatus переводит заказ в новый статус по таблице переходов и
+// записывает переход в историю. При отмене или возврате товары возвращаются
+// на склад в той же транзакции.
+func (s *orderService) ChangeStatus(ctx context.Context, id uuid.UUID, to order.Status) (order.Order, error) {
+	if !to.Valid() {
+		return order.Order{}, fmt.Errorf("%w: unknown status %q", model.ErrInvalidOrder, to)
+	}
+
+	var o order.Order
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		o, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !o.Status.CanTransitionTo(to) {
+			return fmt.Errorf("%w: %s -> %s", model.ErrIllegalTransition, o.Status, to)
+		}
+
+		if to.RestoresStock() {
+			if err := s.restoreStock(ctx, o, to); err != nil {
+				return err
+			}
+		}
+
+		t := order.Transition{
+			TransitionID: uuid.New(),
+			OrderID:      o.OrderID,
+			From:         o.Status,
+			To:           to,
+			CreatedAt:    time.Now(),
+		}
+		if claims, ok := auth.FromContext(ctx); ok {
+			t.ActorID = &claims.UserID
+		}
+		o.Status = to
+		o.UpdatedAt = t.CreatedAt
+		if err := s.repo.UpdateStatus(ctx, o); err != nil {
+			return err
+		}
+		if err := s.repo.InsertTransition(ctx, t); err != nil {
+			return err
+		}
+		o.Transitions = append(o.Transitions, t)
+		return nil
+	})
+	if err != nil {
+		return order.Order{}, err
+	}
+	return o, nil
+}
+
+func (s *orderService) GetOrder(ctx context.Context, id uuid.UUID) (order.Order, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+// GetOrderForUpdate блокирует заказ до конца транзакции вызывающего, чтобы
+// параллельные заявки на возврат не вернули одну позицию дважды.
+func (s *orderService) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (order.Order, error) {
+	return s.repo.GetByIdForUpdate(ctx, id)
+}
+
+// RegisterReturn отмечает вернувшиеся по заявке на возврат единицы позиций
+// заказа; quantities — количества по item_id. Возврат принимается только по
+// доставленному заказу и не больше невозвращённого остатка позиции. Движения
+// остатков проводит вызывающий.
+func (s *orderService) RegisterReturn(ctx context.Context, id uuid.UUID, quantities map[uuid.UUID]int) (order.Order, error) {
+	var o order.Order
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		o, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if o.Status != order.StatusDelivered {
+			return fmt.Errorf("%w: cannot return items of %s order", model.ErrIllegalTransition, o.Status)
+		}
+		for itemID := range quantities {
+			if !slices.ContainsFunc(o.Items, func(item order.Item) bool { return item.ItemID == itemID }) {
+				return fmt.Errorf("%w: item %s is not in order", model.ErrInvalidOrder, itemID)
+			}
+		}
+		for i, item := range o.Items {
+			quantity, ok := quantities[item.ItemID]
+			if !ok {
+				continue
+			}
+			if quantity <= 0 || quantity > item.Remaining() {
+				return fmt.Errorf("%w: item %s has %d units left to return", model.ErrInvalidOrder, item.ItemID, item.Remaining())
+			}
+			if err := s.repo.AddReturnedQuantity(ctx, item.ItemID, quantity); err != nil {
+				return err
+			}
+			o.Items[i].ReturnedQuantity += quantity
+		}
+		return nil
+	})
+	if err != nil {
+		return order.Order{}, err
+	}
+	return o, nil
+}
+
+func (s *orderService) GetClientOrders(ctx context.Context, clientID uuid.UUID, req page.Request) ([]order.Order, error) {
+	return s.repo.GetByClient(ctx, clientID, req)
+}
+
+// mergeItems объединяет повторяющиеся товары и упорядочивает позиции по
+// product_id, чтобы параллельные заказы блокировали строки товаров в одном
+// порядке и не попадали во взаимную блокировку.
+func mergeItems(items []order.Item) ([]order.Item, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: order has no items", model.ErrInvalidOrder)
+	}
+	byProduct := make(map[uuid.UUID]int, len(items))
+	merged := make([]order.Item, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidOrder)
+		}
+		if i, ok := byProduct[item.ProductID]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		byProduct[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+	slices.SortFunc(merged, func(a, b order.Item) int {
+		return bytes.Compare(a.ProductID[:], b.ProductID[:])
+	})
+	return merged, nil
+}
+
+// restoreStock проводит возврат на склад заказа по всем его позициям. Единицы,
+// уже вернувшиеся по заявкам на возврат, не приходуются повторно. Позиции
+// удалённых товаров пропускаются: возвращать остаток некуда.
+func (s *orderService) restoreStock(ctx context.Context, o order.Order, to order.Status) error {
+	items := slices.Clone(o.Items)
+	slices.SortFunc(items, func(a, b order.Item) int {
+		return bytes.Compare(a.ProductID[:], b.ProductID[:])
+	})
+	for _, item := range items {
+		if item.ProductID == uuid.Nil || item.Remaining() == 0 {
+			continue
+		}
+		_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+			ProductID:   item.ProductID,
+			WarehouseID: o.WarehouseID,
+			Type:        stockmodel.MovementReturn,
+			Quantity:    item.Remaining(),
+			Reason:      fmt.Sprintf("order %s %s", o.OrderID, to),
+		})
+		if err != nil {
+			return fmt.Errorf("product %s: %w", item.ProductID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package order
+
+import (
+	"context"
+	"hardware_store/internal/model/client"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/model/promotion"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/model/warehouse"
+	clientservice "hardware_store/internal/service/client"
+	productservice "hardware_store/internal/service/product"
+	promotionservice "hardware_store/internal/service/promotion"
+	stockservice "hardware_store/internal/service/stock"
+	warehouseservice "hardware_store/internal/service/warehouse"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	knownClient   = uuid.New()
+	mainWarehouse = uuid.New()
+	drill         = uuid.New()
+	nails         = uuid.New()
+)
+
+func rub(amount int64) money.Money {
+	return money.New(amount, money.DefaultCurrency)
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeRepo хранит заказы и историю переходов в памяти.
+type fakeRepo struct {
+	OrderRepository
+	orders      map[uuid.UUID]order.Order
+	transitions []order.Transition
+}
+
+func (r *fakeRepo) Insert(_ context.Context, o order.Order) error {
+	r.orders[o.OrderID] = o
+	return nil
+}
+
+func (r *fakeRepo) GetByIdForUpdate(_ context.Context, id uuid.UUID) (order.Order, error) {
+	o, ok := r.orders[id]
+	if !ok {
+		return order.Order{}, model.ErrOrderNotFound
+	}
+	return o, nil
+}
+
+func (r *fakeRepo) UpdateStatus(_ context.Context, o order.Order) error {
+	r.orders[o.OrderID] = o
+	return nil
+}
+
+func (r *fakeRepo) InsertTransition(_ context.Context, t order.Transition) error {
+	r.transitions = append(r.transitions, t)
+	return nil
+}
+
+type fakeClients struct {
+	clientservice.ClientService
+}
+
+func (fakeClients) GetClientByID(_ context.Context, id uuid.UUID) (client.Client, error) {
+	if id != knownClient {
+		return client.Client{}, model.ErrClientNotFound
+	}
+	return client.Client{}, nil
+}
+
+type fakeWarehouses struct {
+	warehouseservice.WarehouseService
+}
+
+func (fakeWarehouses) GetDefaultWarehouse(context.Context) (warehouse.Warehouse, error) {
+	return warehouse.Warehouse{WarehouseID: mainWarehouse}, nil
+}
+
+type fakeProducts struct {
+	productservice.ProductService
+	products map[uuid.UUID]product.Product
+	rates    map[uuid.UUID]money.Rate
+}
+
+func (f fakeProducts) GetProduct(_ context.Context, id uuid.UUID) (product.Product, error) {
+	p, ok := f.products[id]
+	if !ok {
+		return product.Product{}, model.ErrProductNotFound
+	}
+	return p, nil
+}
+
+func (f fakeProducts) GetTaxRates(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]tax.ProductRate, error) {
+	rates := make(map[uuid.UUID]tax.ProductRate, len(ids))
+	for _, id := range ids {
+		rates[id] = tax.ProductRate{ProductID: id, Rate: f.rates[id]}
+	}
+	return rates, nil
+}
+
+// fakeStock ведёт остатки склада по товарам и журнал проведённых движений.
+type fakeStock struct {
+	stockservice.StockService
+	onHand    map[uuid.UUID]int
+	movements []stockmodel.Movement
+}
+
+func (f *fakeStock) RecordMovement(_ context.Context, m stockmodel.Movement) (stockmodel.Movement, int, error) {
+	delta := m.Type.Delta(m.Quantity)
+	if f.onHand[m.ProductID]+delta < 0 {
+		return stockmodel.Movement{}, 0, model.ErrInsufficientStock
+	}
+	f.onHand[m.ProductID] += delta
+	f.movements = append(f.movements, m)
+	return m, f.onHand[m.ProductID], nil
+}
+
+type fakePromotions struct {
+	promotionservice.PromotionService
+	promos []promotion.Promotion
+}
+
+func (f fakePromotions) Apply(_ context.Context, basket promotion.Basket) (promotion.Evaluation, error) {
+	return promotion.Evaluate(f.promos, basket.Lines), nil
+}
+
+func (fakePromotions) Redeem(context.Context, uuid.UUID, uuid.UUID, promotion.Evaluation) error {
+	return nil
+}
+
+func newTestService(promos ...promotion.Promotion) (*orderService, *fakeRepo, *fakeStock) {
+	repo := &fakeRepo{orders: map[uuid.UUID]order.Order{}}
+	stock := &fakeStock{onHand: map[uuid.UUID]int{drill: 5, nails: 1000}}
+	products := fakeProducts{
+		products: map[uuid.UUID]product.Product{
+			drill: {ProductID: drill, Name: "Дрель", Price: rub(10000)},
+			nails: {ProductID: nails, Name: "Гвозди", Price: rub(5)},
+		},
+		rates: map[uuid.UUID]money.Rate{drill: 2000, nails: 1000},
+	}
+	s := &orderService{repo: repo, client: fakeClients{}, product: products, stock: stock,
+		promotion: fakePromotions{promos: promos}, warehouse: fakeWarehouses{}, tx: fakeTx{}, taxMode: tax.ModeExclusive}
+	return s, repo, stock
+}
+
+func TestCreateOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientID   uuid.UUID
+		items      []order.Item
+		wantErr    error
+		total      int64
+		quantities map[uuid.UUID]int
+	}{
+		{
+			name:       "tax added on top",
+			clientID:   knownClient,
+			items:      []order.Item{{ProductID: drill, Quantity: 2}, {ProductID: nails, Quantity: 100}},
+			total:      24000 + 550,
+			quantities: map[uuid.UUID]int{drill: 2, nails: 100},
+		},
+		{
+			name:       "duplicate products merged",
+			clientID:   knownClient,
+			items:      []order.Item{{ProductID: drill, Quantity: 1}, {ProductID: drill, Quantity: 2}},
+			total:      36000,
+			quantities: map[uuid.UUID]int{drill: 3},
+		},
+		{
+			name:       "whole stock",
+			clientID:   knownClient,
+			items:      []order.Item{{ProductID: drill, Quantity: 5}},
+			total:      60000,
+			quantities: map[uuid.UUID]int{drill: 5},
+		},
+		{name: "insufficient stock", clientID: knownClient, items: []order.Item{{ProductID: drill, Quantity: 6}}, wantErr: model.ErrInsufficientStock},
+		{name: "unknown client", clientID: uuid.New(), items: []order.Item{{ProductID: drill, Quantity: 1}}, wantErr: model.ErrClientNotFound},
+		{name: "unknown product", clientID: knownClient, items: []order.Item{{ProductID: uuid.New(), Quantity: 1}}, wantErr: model.ErrProductNotFound},
+		{name: "no items", clientID: knownClient, wantErr: model.ErrInvalidOrder},
+		{name: "zero quantity", clientID: knownClient, items: []order.Item{{ProductID: drill}}, wantErr: model.ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, stock := newTestService()
+
+			o, err := s.CreateOrder(context.Background(), order.Order{ClientID: tt.clientID, Items: tt.items})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.orders, "order must not be saved")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, order.StatusNew, o.Status)
+			assert.Equal(t, mainWarehouse, o.WarehouseID)
+			assert.Equal(t, rub(tt.total), o.Total)
+			assert.Equal(t, o, repo.orders[o.OrderID])
+
+			quantities := make(map[uuid.UUID]int, len(o.Items))
+			for _, item := range o.Items {
+				quantities[item.ProductID] = item.Quantity
+			}
+			assert.Equal(t, tt.quantities, quantities)
+			for _, m := range stock.movements {
+				assert.Equal(t, stockmodel.MovementSale, m.Type)
+				assert.Equal(t, mainWarehouse, m.WarehouseID)
+			}
+			assert.Len(t, stock.movements, len(tt.quantities))
+		})
+	}
+}
+
+func TestCreateOrderSnapshotsPricesAndDiscounts(t *testing.T) {
+	sale := promotion.Promotion{PromotionID: uuid.New(), Name: "10%", Kind: promotion.KindPercent, Percent: 1000}
+	s, _, _ := newTestService(sale)
+
+	o, err := s.CreateOrder(context.Background(), order.Order{ClientID: knownClient,
+		Items: []order.Item{{ProductID: drill, Quantity: 1, UnitPrice: rub(1)}}})
+	require.NoError(t, err)
+	require.Len(t, o.Items, 1)
+	item := o.Items[0]
+	assert.Equal(t, "Дрель", item.Name)
+	assert.Equal(t, rub(10000), item.UnitPrice, "price comes from the catalog, not the request")
+	assert.Equal(t, rub(1000), item.Discount)
+	assert.Equal(t, money.Rate(2000), item.TaxRate)
+	assert.Equal(t, rub(10800), o.Total)
+}
+
+func TestMergeItemsOrdersByProduct(t *testing.T) {
+	a, b := uuid.UUID{1}, uuid.UUID{2}
+	items, err := mergeItems([]order.Item{{ProductID: b, Quantity: 1}, {ProductID: a, Quantity: 2}, {ProductID: b, Quantity: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, []order.Item{{ProductID: a, Quantity: 2}, {ProductID: b, Quantity: 4}}, items)
+}
+
+func TestChangeStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    order.Status
+		to      order.Status
+		wantErr error
+		// restored — сколько единиц каждого товара вернулось на склад.
+		restored map[uuid.UUID]int
+	}{
+		{name: "pay", from: order.StatusNew, to: order.StatusPaid},
+		{name: "ship packed", from: order.StatusPacked, to: order.StatusShipped},
+		{name: "cancel new", from: order.StatusNew, to: order.StatusCancelled, restored: map[uuid.UUID]int{drill: 2, nails: 70}},
+		{name: "cancel packed", from: order.StatusPacked, to: order.StatusCancelled, restored: map[uuid.UUID]int{drill: 2, nails: 70}},
+		{name: "return delivered", from: order.StatusDelivered, to: order.StatusReturned, restored: map[uuid.UUID]int{drill: 2, nails: 70}},
+		{name: "ship unpaid", from: order.StatusNew, to: order.StatusShipped, wantErr: model.ErrIllegalTransition},
+		{name: "ship unpacked", from: order.StatusPaid, to: order.StatusShipped, wantErr: model.ErrIllegalTransition},
+		{name: "cancel shipped", from: order.StatusShipped, to: order.StatusCancelled, wantErr: model.ErrIllegalTransition},
+		{name: "cancel twice", from: order.StatusCancelled, to: order.StatusCancelled, wantErr: model.ErrIllegalTransition},
+		{name: "reopen returned", from: order.StatusReturned, to: order.StatusNew, wantErr: model.ErrIllegalTransition},
+		{name: "unknown status", from: order.StatusNew, to: "lost", wantErr: model.ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, stock := newTestService()
+			o := order.Order{OrderID: uuid.New(), WarehouseID: mainWarehouse, Status: tt.from, Items: []order.Item{
+				{ItemID: uuid.New(), ProductID: drill, Quantity: 2},
+				{ItemID: uuid.New(), ProductID: nails, Quantity: 100, ReturnedQuantity: 30},
+				{ItemID: uuid.New(), ProductID: uuid.Nil, Quantity: 1},
+			}}
+			repo.orders[o.OrderID] = o
+
+			got, err := s.ChangeStatus(context.Background(), o.OrderID, tt.to)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.from, repo.orders[o.OrderID].Status)
+				assert.Empty(t, repo.transitions)
+				assert.Empty(t, stock.movements)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, got.Status)
+			assert.Equal(t, tt.to, repo.orders[o.OrderID].Status)
+			require.Len(t, repo.transitions, 1)
+			assert.Equal(t, tt.from, repo.transitions[0].From)
+			assert.Equal(t, tt.to, repo.transitions[0].To)
+
+			restored := map[uuid.UUID]int{}
+			for _, m := range stock.movements {
+				assert.Equal(t, stockmodel.MovementReturn, m.Type)
+				assert.Equal(t, mainWarehouse, m.WarehouseID)
+				restored[m.ProductID] += m.Quantity
+			}
+			if tt.restored == nil {
+				assert.Empty(t, restored)
+				return
+			}
+			assert.Equal(t, tt.restored, restored, "returned units and deleted products are skipped")
+		})
+	}
+}
+
+func TestChangeStatusUnknownOrder(t *testing.T) {
+	s, _, _ := newTestService()
+	_, err := s.ChangeStatus(context.Background(), uuid.New(), order.StatusPaid)
+	assert.ErrorIs(t, err, model.ErrOrderNotFound)
+}
@@ -0,0 +1,18 @@
+package payment
+
+import (
+	"context"
+	"hardware_store/internal/model/payment"
+
+	"github.com/google/uuid"
+)
+
+type PaymentService interface {
+	CreatePayment(ctx context.Context, orderID uuid.UUID) (payment.Payment, error)
+	Capture(ctx context.Context, id uuid.UUID) (payment.Payment, error)
+	Refund(ctx context.Context, id uuid.UUID, refund payment.Refund) (payment.Payment, error)
+	HandleWebhook(ctx context.Context, body []byte, signature string) error
+	Reconcile(ctx context.Context) (int, error)
+	GetPayment(ctx context.Context, id uuid.UUID) (payment.Payment, error)
+	GetOrderPayments(ctx context.Context, orderID uuid.UUID) ([]payment.Payment, error)
+}
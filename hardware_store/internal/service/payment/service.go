@@ -0,0 +1,522 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	ordermodel "hardware_store/internal/model/order"
+	"hardware_store/internal/model/payment"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/order"
+	returnsservice "hardware_store/internal/service/returns"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentRepository interface {
+	Insert(ctx context.Context, p payment.Payment) error
+	GetById(ctx context.Context, id uuid.UUID) (payment.Payment, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (payment.Payment, error)
+	GetByExternalIDForUpdate(ctx context.Context, provider, externalID string) (payment.Payment, error)
+	GetByOrder(ctx context.Context, orderID uuid.UUID) ([]payment.Payment, error)
+	GetOpen(ctx context.Context, provider string) ([]payment.Payment, error)
+	Update(ctx context.Context, p payment.Payment) error
+	InsertRefund(ctx context.Context, refund payment.Refund) error
+	GetRefundByKey(ctx context.Context, paymentID uuid.UUID, key string) (payment.Refund, error)
+	GetPendingRefunds(ctx context.Context, provider string, paymentID *uuid.UUID) ([]payment.Refund, error)
+	CompleteRefund(ctx context.Context, refundID uuid.UUID) (bool, error)
+	RecordEvent(ctx context.Context, provider string, e payment.Event, at time.Time) (bool, error)
+}
+
+// PaymentProvider платёжный шлюз. Создание платежа и возврат принимают ключ
+// идемпотентности, поэтому запрос можно безопасно повторить после сбоя сети.
+// Ошибки связи со шлюзом оборачивают model.ErrPaymentProvider, неверная
+// подпись уведомления — model.ErrInvalidWebhook.
+type PaymentProvider interface {
+	Name() string
+	CreatePayment(ctx context.Context, req payment.Request) (payment.State, error)
+	Capture(ctx context.Context, externalID string, amount money.Money) (payment.State, error)
+	Refund(ctx context.Context, externalID string, amount money.Money, key string) (payment.State, error)
+	GetPayment(ctx context.Context, externalID string) (payment.State, error)
+	VerifyWebhook(body []byte, signature string) (payment.Event, error)
+}
+
+// maxRefundKeyLength наибольшая длина ключа идемпотентности возврата.
+const maxRefundKeyLength = 128
+
+type paymentService struct {
+	repo        PaymentRepository
+	provider    PaymentProvider
+	order       order.OrderService
+	returns     returnsservice.ReturnService
+	tx          tx.Manager
+	autoCapture bool
+	logger      *slog.Logger
+}
+
+func NewPaymentService(repo PaymentRepository, provider PaymentProvider, order order.OrderService,
+	returns returnsservice.ReturnService, tx tx.Manager, cfg *config.Config, logger *slog.Logger) *paymentService {
+	return &paymentService{repo: repo, provider: provider, order: order, returns: returns, tx: tx,
+		autoCapture: cfg.Payment.AutoCapture, logger: logger}
+}
+
+// CreatePayment создаёт в шлюзе платёж на сумму нового заказа. Пока у заказа
+// есть незавершённый платёж, возвращается он, чтобы повторное нажатие
+// «Оплатить» не выставляло покупателю второй счёт. Ключ идемпотентности
+// выводится из заказа и номера попытки: если платёж не сохранился после
+// ответа шлюза, повторный вызов получит от шлюза тот же платёж, а не
+// откроет второй. Шлюз вызывается без блокировки заказа, поэтому после его
+// ответа заказ проверяется ещё раз.
+func (s *paymentService) CreatePayment(ctx context.Context, orderID uuid.UUID) (payment.Payment, error) {
+	o, err := s.order.GetOrder(ctx, orderID)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	if err := payable(o); err != nil {
+		return payment.Payment{}, err
+	}
+	existing, err := s.repo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	open, attempt := s.findOpen(existing)
+	if open != nil {
+		return *open, nil
+	}
+
+	st, err := s.provider.CreatePayment(ctx, payment.Request{
+		Key:         paymentKey(o.OrderID, attempt),
+		OrderID:     o.OrderID,
+		Amount:      o.Total,
+		Description: "Заказ " + o.OrderID.String(),
+		Capture:     s.autoCapture,
+	})
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	var p payment.Payment
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		o, err := s.order.GetOrderForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		existing, err := s.repo.GetByOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		for _, e := range existing {
+			if e.Provider == s.provider.Name() && e.ExternalID == st.ExternalID {
+				p = e
+				return nil
+			}
+		}
+		if err := payable(o); err != nil {
+			return err
+		}
+		if open, _ := s.findOpen(existing); open != nil {
+			p = *open
+			return nil
+		}
+
+		now := time.Now()
+		p = payment.Payment{
+			PaymentID:       uuid.New(),
+			OrderID:         o.OrderID,
+			Provider:        s.provider.Name(),
+			ExternalID:      st.ExternalID,
+			Status:          payment.StatusPending,
+			Amount:          o.Total,
+			Refunded:        money.New(0, o.Total.Currency),
+			ConfirmationURL: st.ConfirmationURL,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := s.repo.Insert(ctx, p); err != nil {
+			return err
+		}
+		_, err = s.apply(ctx, &p, st)
+		return err
+	})
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	return p, nil
+}
+
+// Capture списывает заблокированные деньги на всю сумму платежа. Шлюз
+// вызывается без блокировки платежа, ответ применяется отдельной
+// транзакцией.
+func (s *paymentService) Capture(ctx context.Context, id uuid.UUID) (payment.Payment, error) {
+	p, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	if p.Status != payment.StatusAuthorized {
+		return payment.Payment{}, fmt.Errorf("%w: cannot capture %s payment", model.ErrIllegalTransition, p.Status)
+	}
+	st, err := s.provider.Capture(ctx, p.ExternalID, p.Amount)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		_, err = s.apply(ctx, &p, st)
+		return err
+	})
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	return p, nil
+}
+
+// Refund возвращает покупателю часть списанных денег. Если указана заявка на
+// возврат товара, она должна быть проверена и относиться к заказу платежа;
+// без суммы возвращается сумма заявки, а сама заявка закрывается возвратом
+// денег.
+//
+// Ключ идемпотентности берётся из запроса, а для заявки на возврат без
+// ключа выводится из неё: повторный запрос с тем же ключом не возвращает
+// деньги второй раз. Возврат записывается неподтверждённым до запроса к
+// шлюзу, а шлюз вызывается вне транзакции с блокировкой платежа. Если шлюз
+// не ответил, возврат завершит повторный запрос с тем же ключом или сверка
+// со шлюзом, а другие возвраты по платежу до тех пор отклоняются.
+func (s *paymentService) Refund(ctx context.Context, id uuid.UUID, refund payment.Refund) (payment.Payment, error) {
+	if refund.Amount.IsNegative() {
+		return payment.Payment{}, fmt.Errorf("%w: refund amount must be positive", model.ErrInvalidPayment)
+	}
+	if refund.Key == "" && refund.ReturnID != nil {
+		refund.Key = "return-" + refund.ReturnID.String()
+	}
+	if refund.Key == "" {
+		return payment.Payment{}, fmt.Errorf("%w: idempotency key is required", model.ErrInvalidPayment)
+	}
+	if len(refund.Key) > maxRefundKeyLength {
+		return payment.Payment{}, fmt.Errorf("%w: idempotency key is longer than %d bytes", model.ErrInvalidPayment,
+			maxRefundKeyLength)
+	}
+	refund.RefundID = uuid.New()
+	refund.PaymentID = id
+	refund.Status = payment.RefundPending
+	refund.CreatedAt = time.Now()
+	if claims, ok := auth.FromContext(ctx); ok {
+		refund.ActorID = &claims.UserID
+	}
+
+	var p payment.Payment
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		stored, err := s.repo.GetRefundByKey(ctx, id, refund.Key)
+		if err == nil {
+			if !stored.Matches(refund) {
+				return fmt.Errorf("%w: idempotency key %q is used by another refund", model.ErrInvalidPayment, refund.Key)
+			}
+			refund = stored
+			return nil
+		}
+		if !errors.Is(err, model.ErrRefundNotFound) {
+			return err
+		}
+		pending, err := s.repo.GetPendingRefunds(ctx, p.Provider, &id)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%w: refund %s is not confirmed by the provider yet", model.ErrIllegalTransition,
+				pending[0].RefundID)
+		}
+
+		if p.Status != payment.StatusCaptured {
+			return fmt.Errorf("%w: cannot refund %s payment", model.ErrIllegalTransition, p.Status)
+		}
+		if refund.ReturnID != nil {
+			r, err := s.returns.GetReturn(ctx, *refund.ReturnID)
+			if err != nil {
+				return err
+			}
+			if r.OrderID != p.OrderID {
+				return fmt.Errorf("%w: return %s belongs to another order", model.ErrInvalidPayment, r.ReturnID)
+			}
+			if !r.Status.Refundable() {
+				return fmt.Errorf("%w: cannot refund %s return", model.ErrIllegalTransition, r.Status)
+			}
+			if refund.Amount.IsZero() {
+				refund.Amount = r.Refund
+			}
+		}
+		if !refund.Amount.IsPositive() {
+			return fmt.Errorf("%w: refund amount must be positive", model.ErrInvalidPayment)
+		}
+		if err := p.Amount.SameCurrency(refund.Amount); err != nil {
+			return fmt.Errorf("%w: %w", model.ErrInvalidPayment, err)
+		}
+		if refund.Amount.Cmp(p.Refundable()) > 0 {
+			return fmt.Errorf("%w: only %s left to refund", model.ErrInvalidPayment, p.Refundable())
+		}
+		return s.repo.InsertRefund(ctx, refund)
+	})
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	if refund.Status == payment.RefundSucceeded {
+		return p, nil
+	}
+	p, _, err = s.sendRefund(ctx, p, refund)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+	return p, nil
+}
+
+// HandleWebhook проверяет подпись уведомления шлюза и применяет новое
+// состояние платежа. Повторно доставленное событие пропускается. Уведомление
+// о неизвестном платеже отклоняется, чтобы шлюз повторил его позже: платёж
+// мог ещё не сохраниться.
+func (s *paymentService) HandleWebhook(ctx context.Context, body []byte, signature string) error {
+	e, err := s.provider.VerifyWebhook(body, signature)
+	if err != nil {
+		return err
+	}
+	if e.EventID == "" || e.ExternalID == "" || !e.Status.Valid() {
+		return fmt.Errorf("%w: incomplete event", model.ErrInvalidWebhook)
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		fresh, err := s.repo.RecordEvent(ctx, s.provider.Name(), e, time.Now())
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return nil
+		}
+		p, err := s.repo.GetByExternalIDForUpdate(ctx, s.provider.Name(), e.ExternalID)
+		if err != nil {
+			return err
+		}
+		_, err = s.apply(ctx, &p, e.State)
+		return err
+	})
+}
+
+// Reconcile сверяет незавершённые платежи с состоянием в шлюзе, чтобы
+// подхватить уведомления, пропущенные, пока приложение не работало, и
+// повторяет неподтверждённые возвраты с их ключами идемпотентности.
+// Возвращает число изменившихся платежей и подтверждённых возвратов. Ошибка
+// по одному платежу или возврату не мешает сверке остальных.
+func (s *paymentService) Reconcile(ctx context.Context) (int, error) {
+	open, err := s.repo.GetOpen(ctx, s.provider.Name())
+	if err != nil {
+		return 0, err
+	}
+	refunds, err := s.repo.GetPendingRefunds(ctx, s.provider.Name(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	var errs []error
+	for _, o := range open {
+		changed, err := s.reconcilePayment(ctx, o)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("payment %s: %w", o.PaymentID, err))
+			continue
+		}
+		if changed {
+			n++
+		}
+	}
+	for _, r := range refunds {
+		p, err := s.repo.GetById(ctx, r.PaymentID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: %w", r.RefundID, err))
+			continue
+		}
+		_, completed, err := s.sendRefund(ctx, p, r)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: %w", r.RefundID, err))
+			continue
+		}
+		if completed {
+			n++
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// reconcilePayment запрашивает состояние платежа в шлюзе и применяет его.
+func (s *paymentService) reconcilePayment(ctx context.Context, open payment.Payment) (bool, error) {
+	st, err := s.provider.GetPayment(ctx, open.ExternalID)
+	if err != nil {
+		return false, err
+	}
+	var changed bool
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.repo.GetByIdForUpdate(ctx, open.PaymentID)
+		if err != nil {
+			return err
+		}
+		if !p.Status.Open() {
+			return nil
+		}
+		changed, err = s.apply(ctx, &p, st)
+		return err
+	})
+	return changed, err
+}
+
+// sendRefund отправляет записанный возврат в шлюз и подтверждает его по
+// ответу. Сумма возвращённых денег берётся из ответа шлюза, чтобы не учесть
+// возврат второй раз после уведомления о нём. Заявка на возврат товара
+// закрывается вместе с подтверждением. Возвращает false, если возврат уже
+// был подтверждён параллельным запросом.
+func (s *paymentService) sendRefund(ctx context.Context, p payment.Payment, refund payment.Refund) (payment.Payment, bool, error) {
+	st, err := s.provider.Refund(ctx, p.ExternalID, refund.Amount, refundKey(refund))
+	if err != nil {
+		return payment.Payment{}, false, err
+	}
+
+	var completed bool
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = s.repo.GetByIdForUpdate(ctx, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+		completed, err = s.repo.CompleteRefund(ctx, refund.RefundID)
+		if err != nil || !completed {
+			return err
+		}
+		if _, err := s.apply(ctx, &p, st); err != nil {
+			return err
+		}
+		if refund.ReturnID == nil {
+			return nil
+		}
+		_, err = s.returns.MarkRefunded(ctx, *refund.ReturnID)
+		return err
+	})
+	if err != nil {
+		return payment.Payment{}, false, err
+	}
+	return p, completed, nil
+}
+
+func (s *paymentService) GetPayment(ctx context.Context, id uuid.UUID) (payment.Payment, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *paymentService) GetOrderPayments(ctx context.Context, orderID uuid.UUID) ([]payment.Payment, error) {
+	if _, err := s.order.GetOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrder(ctx, orderID)
+}
+
+// payable проверяет, что заказ ждёт оплаты и его сумма положительна.
+func payable(o ordermodel.Order) error {
+	if o.Status != ordermodel.StatusNew {
+		return fmt.Errorf("%w: cannot pay for %s order", model.ErrIllegalTransition, o.Status)
+	}
+	if !o.Total.IsPositive() {
+		return fmt.Errorf("%w: order total must be positive", model.ErrInvalidPayment)
+	}
+	return nil
+}
+
+// findOpen возвращает незавершённый платёж заказа в шлюзе сервиса и число
+// завершённых попыток оплаты в нём.
+func (s *paymentService) findOpen(existing []payment.Payment) (*payment.Payment, int) {
+	attempt := 0
+	for _, e := range existing {
+		if e.Provider != s.provider.Name() {
+			continue
+		}
+		if e.Status.Open() {
+			return &e, attempt
+		}
+		attempt++
+	}
+	return nil, attempt
+}
+
+// paymentKey ключ идемпотентности попытки оплаты заказа. attempt — число
+// завершённых платежей заказа в этом шлюзе.
+func paymentKey(orderID uuid.UUID, attempt int) string {
+	return fmt.Sprintf("order-%s-%d", orderID, attempt)
+}
+
+// refundKey ключ идемпотентности возврата в шлюзе. Ключ запроса уникален
+// только в пределах платежа, поэтому к нему добавляется платёж.
+func refundKey(refund payment.Refund) string {
+	return fmt.Sprintf("refund-%s-%s", refund.PaymentID, refund.Key)
+}
+
+// apply записывает состояние платежа из шлюза. Переход назад по статусам
+// пропускается: уведомления могут прийти не по порядку. Когда деньги
+// списаны, новый заказ переводится в оплаченные. Состояние с возвратом в
+// другой валюте отклоняется. Платёж должен быть заблокирован вызывающим.
+func (s *paymentService) apply(ctx context.Context, p *payment.Payment, st payment.State) (bool, error) {
+	if err := p.Amount.SameCurrency(st.Refunded); err != nil {
+		return false, fmt.Errorf("%w: refund: %w", model.ErrInvalidWebhook, err)
+	}
+	captured := false
+	changed := false
+	if st.Status != p.Status && p.Status.CanTransitionTo(st.Status) {
+		p.Status = st.Status
+		captured = st.Status == payment.StatusCaptured
+		changed = true
+	}
+	if st.Refunded.Cmp(p.Refunded) > 0 && st.Refunded.Cmp(p.Amount) <= 0 {
+		p.Refunded = st.Refunded
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	p.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, *p); err != nil {
+		return false, err
+	}
+	if captured {
+		if err := s.markPaid(ctx, *p); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// markPaid переводит заказ оплаченного платежа в статус paid. Заказ, который
+// успели отменить или оплатить иначе, не меняется: деньги по такому платежу
+// возвращаются вручную.
+func (s *paymentService) markPaid(ctx context.Context, p payment.Payment) error {
+	o, err := s.order.GetOrderForUpdate(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+	if o.Status != ordermodel.StatusNew {
+		s.logger.Warn("Payment captured for order that is not awaiting payment",
+			slog.String("payment_id", p.PaymentID.String()),
+			slog.String("order_id", o.OrderID.String()),
+			slog.String("order_status", string(o.Status)))
+		return nil
+	}
+	_, err = s.order.ChangeStatus(ctx, o.OrderID, ordermodel.StatusPaid)
+	return err
+}
@@ -0,0 +1,467 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	ordermodel "hardware_store/internal/model/order"
+	"hardware_store/internal/model/payment"
+	"hardware_store/internal/model/returns"
+	"hardware_store/internal/service/order"
+	returnsservice "hardware_store/internal/service/returns"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx отмечает, что вызов идёт внутри транзакции.
+type fakeTx struct {
+	inTx *bool
+}
+
+func (t fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	*t.inTx = true
+	defer func() { *t.inTx = false }()
+	return fn(ctx)
+}
+
+// fakeRepo хранит платежи и возвраты в памяти; insertErr отклоняет ближайшую
+// вставку платежа.
+type fakeRepo struct {
+	PaymentRepository
+	payments  []payment.Payment
+	refunds   []payment.Refund
+	insertErr error
+}
+
+func (r *fakeRepo) Insert(_ context.Context, p payment.Payment) error {
+	if err := r.insertErr; err != nil {
+		r.insertErr = nil
+		return err
+	}
+	r.payments = append(r.payments, p)
+	return nil
+}
+
+func (r *fakeRepo) GetById(_ context.Context, id uuid.UUID) (payment.Payment, error) {
+	for _, p := range r.payments {
+		if p.PaymentID == id {
+			return p, nil
+		}
+	}
+	return payment.Payment{}, model.ErrPaymentNotFound
+}
+
+func (r *fakeRepo) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (payment.Payment, error) {
+	return r.GetById(ctx, id)
+}
+
+func (r *fakeRepo) GetByOrder(_ context.Context, orderID uuid.UUID) ([]payment.Payment, error) {
+	var res []payment.Payment
+	for _, p := range r.payments {
+		if p.OrderID == orderID {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) GetOpen(_ context.Context, provider string) ([]payment.Payment, error) {
+	var res []payment.Payment
+	for _, p := range r.payments {
+		if p.Provider == provider && p.Status.Open() {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) Update(_ context.Context, p payment.Payment) error {
+	for i := range r.payments {
+		if r.payments[i].PaymentID == p.PaymentID {
+			r.payments[i] = p
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) InsertRefund(_ context.Context, refund payment.Refund) error {
+	r.refunds = append(r.refunds, refund)
+	return nil
+}
+
+func (r *fakeRepo) GetRefundByKey(_ context.Context, paymentID uuid.UUID, key string) (payment.Refund, error) {
+	for _, f := range r.refunds {
+		if f.PaymentID == paymentID && f.Key == key {
+			return f, nil
+		}
+	}
+	return payment.Refund{}, model.ErrRefundNotFound
+}
+
+func (r *fakeRepo) GetPendingRefunds(_ context.Context, _ string, paymentID *uuid.UUID) ([]payment.Refund, error) {
+	var res []payment.Refund
+	for _, f := range r.refunds {
+		if f.Status == payment.RefundPending && (paymentID == nil || f.PaymentID == *paymentID) {
+			res = append(res, f)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) CompleteRefund(_ context.Context, refundID uuid.UUID) (bool, error) {
+	for i := range r.refunds {
+		if r.refunds[i].RefundID == refundID && r.refunds[i].Status == payment.RefundPending {
+			r.refunds[i].Status = payment.RefundSucceeded
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fakeProvider возвращает один и тот же платёж или возврат на повторный
+// ключ, как настоящий шлюз, и запоминает, вызывался ли он внутри
+// транзакции. err отклоняет ближайший запрос.
+type fakeProvider struct {
+	PaymentProvider
+	inTx       *bool
+	calledInTx bool
+	err        error
+	keys       []string
+	created    map[string]string
+	states     map[string]payment.State
+	refundKeys []string
+	refunded   map[string]bool
+}
+
+func (f *fakeProvider) call() error {
+	f.calledInTx = f.calledInTx || *f.inTx
+	err := f.err
+	f.err = nil
+	return err
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) CreatePayment(_ context.Context, req payment.Request) (payment.State, error) {
+	if err := f.call(); err != nil {
+		return payment.State{}, err
+	}
+	f.keys = append(f.keys, req.Key)
+	id, ok := f.created[req.Key]
+	if !ok {
+		id = "ext_" + uuid.NewString()
+		f.created[req.Key] = id
+		f.states[id] = payment.State{ExternalID: id, Status: payment.StatusPending, Amount: req.Amount,
+			Refunded: money.New(0, req.Amount.Currency)}
+	}
+	return f.states[id], nil
+}
+
+func (f *fakeProvider) Capture(_ context.Context, externalID string, _ money.Money) (payment.State, error) {
+	if err := f.call(); err != nil {
+		return payment.State{}, err
+	}
+	st := f.states[externalID]
+	st.Status = payment.StatusCaptured
+	f.states[externalID] = st
+	return st, nil
+}
+
+func (f *fakeProvider) Refund(_ context.Context, externalID string, amount money.Money, key string) (payment.State, error) {
+	if err := f.call(); err != nil {
+		return payment.State{}, err
+	}
+	f.refundKeys = append(f.refundKeys, key)
+	st := f.states[externalID]
+	if !f.refunded[key] {
+		st.Refunded = st.Refunded.Add(amount)
+		f.states[externalID] = st
+		f.refunded[key] = true
+	}
+	return st, nil
+}
+
+func (f *fakeProvider) GetPayment(_ context.Context, externalID string) (payment.State, error) {
+	if err := f.call(); err != nil {
+		return payment.State{}, err
+	}
+	return f.states[externalID], nil
+}
+
+type fakeOrders struct {
+	order.OrderService
+	order ordermodel.Order
+}
+
+func (f fakeOrders) GetOrder(context.Context, uuid.UUID) (ordermodel.Order, error) {
+	return f.order, nil
+}
+
+func (f fakeOrders) GetOrderForUpdate(context.Context, uuid.UUID) (ordermodel.Order, error) {
+	return f.order, nil
+}
+
+func (f fakeOrders) ChangeStatus(_ context.Context, _ uuid.UUID, to ordermodel.Status) (ordermodel.Order, error) {
+	o := f.order
+	o.Status = to
+	return o, nil
+}
+
+// fakeReturns хранит заявки на возврат в памяти.
+type fakeReturns struct {
+	returnsservice.ReturnService
+	returns map[uuid.UUID]returns.Return
+}
+
+func (f fakeReturns) GetReturn(_ context.Context, id uuid.UUID) (returns.Return, error) {
+	r, ok := f.returns[id]
+	if !ok {
+		return returns.Return{}, model.ErrReturnNotFound
+	}
+	return r, nil
+}
+
+func (f fakeReturns) MarkRefunded(_ context.Context, id uuid.UUID) (returns.Return, error) {
+	r := f.returns[id]
+	if !r.Status.Refundable() {
+		return returns.Return{}, model.ErrIllegalTransition
+	}
+	r.Status = returns.StatusRefunded
+	f.returns[id] = r
+	return r, nil
+}
+
+func newTestService(o ordermodel.Order) (*paymentService, *fakeRepo, *fakeProvider) {
+	inTx := new(bool)
+	repo := &fakeRepo{}
+	provider := &fakeProvider{inTx: inTx, created: map[string]string{}, states: map[string]payment.State{},
+		refunded: map[string]bool{}}
+	return &paymentService{repo: repo, provider: provider, order: fakeOrders{order: o},
+		returns: fakeReturns{returns: map[uuid.UUID]returns.Return{}}, tx: fakeTx{inTx: inTx}}, repo, provider
+}
+
+// captured сохраняет в repo и provider платёж по заказу o со списанными
+// деньгами.
+func captured(repo *fakeRepo, provider *fakeProvider, o ordermodel.Order) payment.Payment {
+	p := payment.Payment{PaymentID: uuid.New(), OrderID: o.OrderID, Provider: "fake", ExternalID: "ext_" + uuid.NewString(),
+		Status: payment.StatusCaptured, Amount: o.Total, Refunded: money.New(0, o.Total.Currency)}
+	repo.payments = append(repo.payments, p)
+	provider.states[p.ExternalID] = payment.State{ExternalID: p.ExternalID, Status: p.Status, Amount: p.Amount,
+		Refunded: p.Refunded}
+	return p
+}
+
+func TestCreatePaymentRetryReusesGatewayPayment(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusNew, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+	repo.insertErr = errors.New("connection reset")
+
+	_, err := s.CreatePayment(context.Background(), o.OrderID)
+	require.Error(t, err)
+	p, err := s.CreatePayment(context.Background(), o.OrderID)
+	require.NoError(t, err)
+
+	require.Len(t, provider.keys, 2)
+	assert.Equal(t, provider.keys[0], provider.keys[1])
+	assert.Len(t, provider.created, 1, "retry must not open a second gateway payment")
+	assert.Equal(t, provider.created[provider.keys[0]], p.ExternalID)
+	assert.Len(t, repo.payments, 1)
+}
+
+func TestCreatePaymentNewAttemptAfterFailure(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusNew, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+
+	first, err := s.CreatePayment(context.Background(), o.OrderID)
+	require.NoError(t, err)
+	again, err := s.CreatePayment(context.Background(), o.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, first.PaymentID, again.PaymentID, "open payment is returned")
+
+	repo.payments[0].Status = payment.StatusFailed
+	second, err := s.CreatePayment(context.Background(), o.OrderID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ExternalID, second.ExternalID)
+	assert.Equal(t, []string{paymentKey(o.OrderID, 0), paymentKey(o.OrderID, 1)}, provider.keys)
+	assert.False(t, provider.calledInTx, "gateway is called without locks")
+}
+
+func TestCreatePaymentGatewayFailure(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusNew, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+	provider.err = model.ErrPaymentProvider
+
+	_, err := s.CreatePayment(context.Background(), o.OrderID)
+	assert.ErrorIs(t, err, model.ErrPaymentProvider)
+	assert.Empty(t, repo.payments)
+
+	_, err = s.CreatePayment(context.Background(), o.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{paymentKey(o.OrderID, 0)}, provider.keys)
+}
+
+func TestCapture(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusNew, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+	p := captured(repo, provider, o)
+	repo.payments[0].Status = payment.StatusAuthorized
+
+	got, err := s.Capture(context.Background(), p.PaymentID)
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusCaptured, got.Status)
+	assert.Equal(t, payment.StatusCaptured, repo.payments[0].Status)
+	assert.False(t, provider.calledInTx, "gateway is called without locks")
+
+	_, err = s.Capture(context.Background(), p.PaymentID)
+	assert.ErrorIs(t, err, model.ErrIllegalTransition)
+}
+
+func TestApplyRejectsRefundInOtherCurrency(t *testing.T) {
+	s, repo, _ := newTestService(ordermodel.Order{})
+	p := payment.Payment{PaymentID: uuid.New(), Status: payment.StatusCaptured,
+		Amount: money.New(10000, "RUB"), Refunded: money.New(0, "RUB")}
+	repo.payments = []payment.Payment{p}
+
+	_, err := s.apply(context.Background(), &p, payment.State{Status: payment.StatusCaptured, Refunded: money.New(500, "USD")})
+	assert.ErrorIs(t, err, model.ErrInvalidWebhook)
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+	assert.True(t, p.Refunded.IsZero())
+
+	changed, err := s.apply(context.Background(), &p, payment.State{Status: payment.StatusCaptured, Refunded: money.New(500, "RUB")})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, money.New(500, "RUB"), p.Refunded)
+}
+
+func TestRefund(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusPaid, Total: money.New(10000, "RUB")}
+	rub := func(a int64) money.Money { return money.New(a, "RUB") }
+
+	tests := []struct {
+		name       string
+		status     returns.Status
+		otherOrder bool
+		refund     payment.Refund
+		err        error
+		refunded   money.Money
+	}{
+		{name: "amount with key", refund: payment.Refund{Key: "k1", Amount: rub(3000)}, refunded: rub(3000)},
+		{name: "return without key", status: returns.StatusInspected, refund: payment.Refund{}, refunded: rub(2500)},
+		{name: "return with amount", status: returns.StatusInspected, refund: payment.Refund{Amount: rub(1000)}, refunded: rub(1000)},
+		{name: "no key", refund: payment.Refund{Amount: rub(3000)}, err: model.ErrInvalidPayment},
+		{name: "key too long", refund: payment.Refund{Key: string(make([]byte, maxRefundKeyLength+1)), Amount: rub(3000)},
+			err: model.ErrInvalidPayment},
+		{name: "negative amount", refund: payment.Refund{Key: "k1", Amount: rub(-1)}, err: model.ErrInvalidPayment},
+		{name: "zero amount", refund: payment.Refund{Key: "k1"}, err: model.ErrInvalidPayment},
+		{name: "more than paid", refund: payment.Refund{Key: "k1", Amount: rub(10001)}, err: model.ErrInvalidPayment},
+		{name: "other currency", refund: payment.Refund{Key: "k1", Amount: money.New(3000, "USD")},
+			err: money.ErrCurrencyMismatch},
+		{name: "return not inspected", status: returns.StatusReceived, refund: payment.Refund{},
+			err: model.ErrIllegalTransition},
+		{name: "return of other order", status: returns.StatusInspected, otherOrder: true, refund: payment.Refund{},
+			err: model.ErrInvalidPayment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, provider := newTestService(o)
+			p := captured(repo, provider, o)
+			var r returns.Return
+			if tt.status != "" {
+				r = returns.Return{ReturnID: uuid.New(), OrderID: o.OrderID, Status: tt.status, Refund: rub(2500)}
+				if tt.otherOrder {
+					r.OrderID = uuid.New()
+				}
+				s.returns.(fakeReturns).returns[r.ReturnID] = r
+				tt.refund.ReturnID = &r.ReturnID
+			}
+
+			got, err := s.Refund(context.Background(), p.PaymentID, tt.refund)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, repo.refunds)
+				assert.Empty(t, provider.refundKeys)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.refunded, got.Refunded)
+			assert.Equal(t, tt.refunded, repo.payments[0].Refunded)
+			require.Len(t, repo.refunds, 1)
+			assert.Equal(t, payment.RefundSucceeded, repo.refunds[0].Status)
+			assert.Equal(t, []string{refundKey(repo.refunds[0])}, provider.refundKeys)
+			assert.False(t, provider.calledInTx, "gateway is called without locks")
+			if tt.refund.ReturnID != nil {
+				assert.Equal(t, "return-"+r.ReturnID.String(), repo.refunds[0].Key, "key is derived from the return")
+				assert.Equal(t, returns.StatusRefunded, s.returns.(fakeReturns).returns[r.ReturnID].Status)
+			}
+		})
+	}
+}
+
+func TestRefundIdempotency(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusPaid, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+	p := captured(repo, provider, o)
+	ctx := context.Background()
+	refund := payment.Refund{Key: "k1", Amount: money.New(3000, "RUB")}
+
+	// Шлюз не ответил: возврат записан и ждёт подтверждения.
+	provider.err = model.ErrPaymentProvider
+	_, err := s.Refund(ctx, p.PaymentID, refund)
+	assert.ErrorIs(t, err, model.ErrPaymentProvider)
+	require.Len(t, repo.refunds, 1)
+	assert.Equal(t, payment.RefundPending, repo.refunds[0].Status)
+	assert.True(t, repo.payments[0].Refunded.IsZero())
+
+	_, err = s.Refund(ctx, p.PaymentID, payment.Refund{Key: "k2", Amount: money.New(1000, "RUB")})
+	assert.ErrorIs(t, err, model.ErrIllegalTransition, "other refunds wait for the pending one")
+	_, err = s.Refund(ctx, p.PaymentID, payment.Refund{Key: "k1", Amount: money.New(1000, "RUB")})
+	assert.ErrorIs(t, err, model.ErrInvalidPayment, "key is bound to the refund")
+
+	got, err := s.Refund(ctx, p.PaymentID, refund)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(3000, "RUB"), got.Refunded)
+	require.Len(t, repo.refunds, 1)
+	assert.Equal(t, payment.RefundSucceeded, repo.refunds[0].Status)
+
+	again, err := s.Refund(ctx, p.PaymentID, refund)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(3000, "RUB"), again.Refunded, "replay does not refund twice")
+	assert.Len(t, repo.refunds, 1)
+	assert.Len(t, provider.refundKeys, 1)
+	assert.Equal(t, money.New(3000, "RUB"), provider.states[p.ExternalID].Refunded)
+
+	next, err := s.Refund(ctx, p.PaymentID, payment.Refund{Key: "k2", Amount: money.New(1000, "RUB")})
+	require.NoError(t, err)
+	assert.Equal(t, money.New(4000, "RUB"), next.Refunded)
+}
+
+func TestReconcile(t *testing.T) {
+	o := ordermodel.Order{OrderID: uuid.New(), Status: ordermodel.StatusNew, Total: money.New(10000, "RUB")}
+	s, repo, provider := newTestService(o)
+	ctx := context.Background()
+
+	paid := captured(repo, provider, o)
+	provider.err = model.ErrPaymentProvider
+	_, err := s.Refund(ctx, paid.PaymentID, payment.Refund{Key: "k1", Amount: money.New(3000, "RUB")})
+	require.ErrorIs(t, err, model.ErrPaymentProvider)
+
+	open := captured(repo, provider, o)
+	repo.payments[1].Status = payment.StatusPending
+
+	n, err := s.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, payment.StatusCaptured, repo.payments[1].Status, "payment %s is captured", open.PaymentID)
+	assert.Equal(t, money.New(3000, "RUB"), repo.payments[0].Refunded)
+	assert.Equal(t, payment.RefundSucceeded, repo.refunds[0].Status)
+	assert.Equal(t, []string{refundKey(repo.refunds[0])}, provider.refundKeys, "refund is retried with its key")
+	assert.False(t, provider.calledInTx, "gateway is called without locks")
+
+	n, err = s.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -2,7 +2,12 @@ package product
 
 import (
 	"context"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/product"
+	"hardware_store/internal/model/reservation"
+	"hardware_store/internal/model/tax"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,7 +15,23 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, product product.Product) error
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
-	UpdateProduct(ctx context.Context, id uuid.UUID, col int) (product.Product, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, col int, warehouseID uuid.UUID) (product.Product, error)
+	ReplaceProduct(ctx context.Context, product product.Product) (product.Product, error)
+	PatchProduct(ctx context.Context, id uuid.UUID, patch product.Patch) (product.Product, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (product.Product, error)
-	GetProducts(ctx context.Context) ([]product.Product, error)
+	GetProductBySKU(ctx context.Context, sku string) (product.Product, error)
+	GetProducts(ctx context.Context, filter product.Filter) ([]product.Product, int, error)
+	ExportProducts(ctx context.Context, filter product.Filter, fn func(product.ExportRow) error) error
+	Reserve(ctx context.Context, id uuid.UUID, quantity int, warehouseID uuid.UUID, ttl time.Duration, reference string) (reservation.Reservation, error)
+	ReleaseReservation(ctx context.Context, id uuid.UUID) (reservation.Reservation, error)
+	ConfirmReservation(ctx context.Context, id uuid.UUID) (reservation.Reservation, error)
+	ReleaseExpired(ctx context.Context) (int, error)
+	SchedulePrice(ctx context.Context, price product.Price) (product.Price, error)
+	CancelScheduledPrice(ctx context.Context, productID, priceID uuid.UUID) error
+	GetPriceHistory(ctx context.Context, productID uuid.UUID, req page.Request) ([]product.Price, error)
+	ActivateScheduledPrices(ctx context.Context) (int, error)
+	Reprice(ctx context.Context, r product.Repricing) (product.RepricingResult, error)
+	GetTaxRate(ctx context.Context, id uuid.UUID) (tax.ProductRate, error)
+	SetTaxRate(ctx context.Context, id uuid.UUID, rate *money.Rate) (tax.ProductRate, error)
+	GetTaxRates(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]tax.ProductRate, error)
 }
@@ -0,0 +1,179 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/product"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchedulePrice планирует смену цены товара на момент price.ValidFrom в
+// будущем. Цена, уже запланированная на тот же момент, заменяется.
+func (s *productService) SchedulePrice(ctx context.Context, price product.Price) (product.Price, error) {
+	now := time.Now()
+	if !price.Price.IsPositive() {
+		return product.Price{}, fmt.Errorf("%w: price must be positive", model.ErrInvalidPriceChange)
+	}
+	if !price.ValidFrom.After(now) {
+		return product.Price{}, fmt.Errorf("%w: valid_from must be in the future", model.ErrInvalidPriceChange)
+	}
+	price.PriceID = uuid.New()
+	price.CreatedAt = now
+	price.CreatedBy = actor(ctx)
+
+	var res product.Price
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetById(ctx, price.ProductID); err != nil {
+			return err
+		}
+		var err error
+		res, err = s.repo.SchedulePrice(ctx, price)
+		return err
+	})
+	return res, err
+}
+
+// CancelScheduledPrice отменяет запланированную цену, пока она не применена.
+func (s *productService) CancelScheduledPrice(ctx context.Context, productID, priceID uuid.UUID) error {
+	return s.repo.DeleteScheduledPrice(ctx, productID, priceID)
+}
+
+func (s *productService) GetPriceHistory(ctx context.Context, productID uuid.UUID, req page.Request) ([]product.Price, error) {
+	if _, err := s.repo.GetById(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPrices(ctx, productID, req)
+}
+
+// ActivateScheduledPrices применяет запланированные цены, срок которых
+// наступил, пачками и возвращает их число. Цены одного товара применяются в
+// порядке наступления, каждая закрывает предыдущую.
+func (s *productService) ActivateScheduledPrices(ctx context.Context) (int, error) {
+	var total int
+	for {
+		var n int
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			now := time.Now()
+			due, err := s.repo.GetDuePricesForUpdate(ctx, now, sweepBatch)
+			if err != nil {
+				return err
+			}
+			n = len(due)
+			for _, p := range due {
+				if _, err := s.repo.GetByIdForUpdate(ctx, p.ProductID); err != nil {
+					return err
+				}
+				if err := s.repo.ClosePrice(ctx, p.ProductID, p.ValidFrom); err != nil {
+					return err
+				}
+				if err := s.repo.ApplyPrice(ctx, p, now); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < sweepBatch {
+			return total, nil
+		}
+	}
+}
+
+// Reprice меняет цены товаров по фильтру одной транзакцией: если новая цена
+// хотя бы одного товара не положительна, не меняется ни одна. Каждая
+// изменённая цена попадает в историю цен. При DryRun цены только
+// рассчитываются.
+func (s *productService) Reprice(ctx context.Context, r product.Repricing) (product.RepricingResult, error) {
+	if (r.Percent == nil) == (r.Amount == nil) {
+		return product.RepricingResult{}, fmt.Errorf("%w: exactly one of percent and amount is required", model.ErrInvalidPriceChange)
+	}
+	if r.Ending != nil && (*r.Ending < 0 || *r.Ending > 99) {
+		return product.RepricingResult{}, fmt.Errorf("%w: ending must be between 0 and 99 kopecks", model.ErrInvalidPriceChange)
+	}
+	if !r.HasTarget() {
+		return product.RepricingResult{}, fmt.Errorf("%w: filter by category, supplier or price range is required", model.ErrInvalidPriceChange)
+	}
+	if r.Reason == "" {
+		r.Reason = "bulk repricing"
+	}
+
+	res := product.RepricingResult{DryRun: r.DryRun}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		products, err := s.repo.GetForRepricing(ctx, r.Filter)
+		if err != nil {
+			return err
+		}
+		res.Matched = len(products)
+		for _, p := range products {
+			price := r.NewPrice(p.Price)
+			if !price.IsPositive() {
+				return fmt.Errorf("%w: new price of %q is %s", model.ErrInvalidPriceChange, p.Name, price)
+			}
+			if price.Cmp(p.Price) == 0 {
+				continue
+			}
+			res.Items = append(res.Items, product.RepricingItem{
+				ProductID: p.ProductID,
+				Name:      p.Name,
+				OldPrice:  p.Price,
+				NewPrice:  price,
+			})
+		}
+		if r.DryRun {
+			return nil
+		}
+
+		now := time.Now()
+		for _, item := range res.Items {
+			p := product.Product{ProductID: item.ProductID, Price: item.NewPrice, LastUpdateDate: now}
+			if err := s.recordPrice(ctx, p, r.Reason); err != nil {
+				return err
+			}
+			if err := s.repo.UpdatePrice(ctx, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return product.RepricingResult{}, err
+	}
+	return res, nil
+}
+
+// recordPrice закрывает действующую цену товара и записывает в историю его
+// новую цену с момента p.LastUpdateDate.
+func (s *productService) recordPrice(ctx context.Context, p product.Product, reason string) error {
+	at := p.LastUpdateDate
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if err := s.repo.ClosePrice(ctx, p.ProductID, at); err != nil {
+		return err
+	}
+	return s.repo.InsertPrice(ctx, product.Price{
+		PriceID:   uuid.New(),
+		ProductID: p.ProductID,
+		Price:     p.Price,
+		ValidFrom: at,
+		Reason:    reason,
+		CreatedBy: actor(ctx),
+		CreatedAt: time.Now(),
+		AppliedAt: &at,
+	})
+}
+
+func actor(ctx context.Context) *uuid.UUID {
+	if claims, ok := auth.FromContext(ctx); ok && claims.UserID != uuid.Nil {
+		return &claims.UserID
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+package product
+
+import (
+	"context"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/product"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// priceRepo хранит товары и историю их цен в памяти с теми же условиями, что
+// запросы postgres, и считает пачки выборки наступивших цен.
+type priceRepo struct {
+	ProductRepository
+	products map[uuid.UUID]product.Product
+	prices   []product.Price
+	batches  int
+}
+
+func (r *priceRepo) GetById(_ context.Context, id uuid.UUID) (product.Product, error) {
+	p, ok := r.products[id]
+	if !ok {
+		return product.Product{}, model.ErrProductNotFound
+	}
+	return p, nil
+}
+
+func (r *priceRepo) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (product.Product, error) {
+	return r.GetById(ctx, id)
+}
+
+func (r *priceRepo) SchedulePrice(_ context.Context, p product.Price) (product.Price, error) {
+	for i, old := range r.prices {
+		if old.ProductID == p.ProductID && old.Scheduled() && old.ValidFrom.Equal(p.ValidFrom) {
+			p.PriceID = old.PriceID
+			r.prices[i] = p
+			return p, nil
+		}
+	}
+	r.prices = append(r.prices, p)
+	return p, nil
+}
+
+func (r *priceRepo) DeleteScheduledPrice(_ context.Context, productID, priceID uuid.UUID) error {
+	for i, p := range r.prices {
+		if p.PriceID == priceID && p.ProductID == productID && p.Scheduled() {
+			r.prices = slices.Delete(r.prices, i, i+1)
+			return nil
+		}
+	}
+	return model.ErrPriceChangeNotFound
+}
+
+func (r *priceRepo) GetDuePricesForUpdate(_ context.Context, now time.Time, limit int) ([]product.Price, error) {
+	r.batches++
+	var due []product.Price
+	for _, p := range r.prices {
+		if p.Scheduled() && !p.ValidFrom.After(now) {
+			due = append(due, p)
+		}
+	}
+	slices.SortFunc(due, func(a, b product.Price) int { return a.ValidFrom.Compare(b.ValidFrom) })
+	return due[:min(len(due), limit)], nil
+}
+
+func (r *priceRepo) ClosePrice(_ context.Context, productID uuid.UUID, at time.Time) error {
+	for i, p := range r.prices {
+		if p.ProductID == productID && !p.Scheduled() && p.ValidTo == nil {
+			to := at
+			if p.ValidFrom.After(at) {
+				to = p.ValidFrom
+			}
+			r.prices[i].ValidTo = &to
+		}
+	}
+	return nil
+}
+
+func (r *priceRepo) ApplyPrice(_ context.Context, price product.Price, at time.Time) error {
+	for i, p := range r.prices {
+		if p.PriceID == price.PriceID {
+			r.prices[i].AppliedAt = &at
+		}
+	}
+	p := r.products[price.ProductID]
+	p.Price = price.Price
+	r.products[price.ProductID] = p
+	return nil
+}
+
+func (r *priceRepo) InsertPrice(_ context.Context, p product.Price) error {
+	r.prices = append(r.prices, p)
+	return nil
+}
+
+// GetForRepricing учитывает из фильтра только категорию, как и нужно тестам.
+func (r *priceRepo) GetForRepricing(_ context.Context, filter product.Filter) ([]product.Product, error) {
+	var res []product.Product
+	for _, p := range r.products {
+		if filter.CategoryID == nil || p.CategoryID == *filter.CategoryID {
+			res = append(res, p)
+		}
+	}
+	slices.SortFunc(res, func(a, b product.Product) int { return strings.Compare(a.Name, b.Name) })
+	return res, nil
+}
+
+func (r *priceRepo) UpdatePrice(_ context.Context, p product.Product) error {
+	current, ok := r.products[p.ProductID]
+	if !ok {
+		return model.ErrProductNotFound
+	}
+	current.Price = p.Price
+	current.LastUpdateDate = p.LastUpdateDate
+	r.products[p.ProductID] = current
+	return nil
+}
+
+// history возвращает цены товара в порядке начала действия.
+func (r *priceRepo) history(productID uuid.UUID) []product.Price {
+	var res []product.Price
+	for _, p := range r.prices {
+		if p.ProductID == productID {
+			res = append(res, p)
+		}
+	}
+	slices.SortFunc(res, func(a, b product.Price) int { return a.ValidFrom.Compare(b.ValidFrom) })
+	return res
+}
+
+// newPriceService создаёт сервис с товаром по цене price, которая действует
+// с прошлого дня.
+func newPriceService(price int64) (*productService, uuid.UUID, *priceRepo) {
+	id := uuid.New()
+	since := time.Now().Add(-24 * time.Hour)
+	repo := &priceRepo{
+		products: map[uuid.UUID]product.Product{id: {ProductID: id, Name: "Дрель", Price: rub(price)}},
+		prices: []product.Price{{PriceID: uuid.New(), ProductID: id, Price: rub(price),
+			ValidFrom: since, AppliedAt: &since}},
+	}
+	return &productService{repo: repo, tx: fakeTx{}}, id, repo
+}
+
+func rub(amount int64) money.Money {
+	return money.New(amount, money.DefaultCurrency)
+}
+
+func TestSchedulePrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     int64
+		validFrom time.Duration
+		product   bool
+		wantErr   error
+	}{
+		{name: "future price", price: 12000, validFrom: time.Hour, product: true},
+		{name: "price in the past", price: 12000, validFrom: -time.Hour, product: true, wantErr: model.ErrInvalidPriceChange},
+		{name: "zero price", validFrom: time.Hour, product: true, wantErr: model.ErrInvalidPriceChange},
+		{name: "negative price", price: -100, validFrom: time.Hour, product: true, wantErr: model.ErrInvalidPriceChange},
+		{name: "unknown product", price: 12000, validFrom: time.Hour, wantErr: model.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, id, repo := newPriceService(10000)
+			userID := uuid.New()
+			ctx := auth.WithClaims(context.Background(), auth.Claims{UserID: userID})
+			if !tt.product {
+				id = uuid.New()
+			}
+
+			got, err := s.SchedulePrice(ctx, product.Price{ProductID: id, Price: rub(tt.price),
+				ValidFrom: time.Now().Add(tt.validFrom)})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, repo.prices, 1)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Scheduled())
+			require.NotNil(t, got.CreatedBy)
+			assert.Equal(t, userID, *got.CreatedBy)
+			assert.Len(t, repo.prices, 2)
+			for _, p := range repo.products {
+				assert.Equal(t, rub(10000), p.Price, "scheduled price is not applied yet")
+			}
+		})
+	}
+}
+
+func TestSchedulePriceReplacesSameMoment(t *testing.T) {
+	s, id, repo := newPriceService(10000)
+	at := time.Now().Add(time.Hour)
+
+	first, err := s.SchedulePrice(context.Background(), product.Price{ProductID: id, Price: rub(12000), ValidFrom: at})
+	require.NoError(t, err)
+	second, err := s.SchedulePrice(context.Background(), product.Price{ProductID: id, Price: rub(13000), ValidFrom: at})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.PriceID, second.PriceID)
+	history := repo.history(id)
+	require.Len(t, history, 2)
+	assert.Equal(t, rub(13000), history[1].Price)
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	s, id, repo := newPriceService(10000)
+	scheduled, err := s.SchedulePrice(context.Background(), product.Price{ProductID: id, Price: rub(12000),
+		ValidFrom: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	current := repo.prices[0]
+
+	assert.ErrorIs(t, s.CancelScheduledPrice(context.Background(), uuid.New(), scheduled.PriceID),
+		model.ErrPriceChangeNotFound, "price of another product")
+	assert.ErrorIs(t, s.CancelScheduledPrice(context.Background(), id, current.PriceID),
+		model.ErrPriceChangeNotFound, "applied price cannot be cancelled")
+	require.NoError(t, s.CancelScheduledPrice(context.Background(), id, scheduled.PriceID))
+	assert.Equal(t, []product.Price{current}, repo.prices)
+	assert.ErrorIs(t, s.CancelScheduledPrice(context.Background(), id, scheduled.PriceID), model.ErrPriceChangeNotFound)
+}
+
+func TestActivateScheduledPrices(t *testing.T) {
+	s, id, repo := newPriceService(10000)
+	now := time.Now()
+	schedule := func(price int64, at time.Time) {
+		repo.prices = append(repo.prices, product.Price{PriceID: uuid.New(), ProductID: id, Price: rub(price), ValidFrom: at})
+	}
+	schedule(15000, now.Add(-time.Hour))
+	schedule(12000, now.Add(-2*time.Hour))
+	schedule(20000, now.Add(time.Hour))
+
+	n, err := s.ActivateScheduledPrices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, rub(15000), repo.products[id].Price, "the latest due price wins")
+
+	history := repo.history(id)
+	require.Len(t, history, 4)
+	// Каждая применённая цена закрывает предыдущую с момента своего начала.
+	for i, p := range history[:2] {
+		require.NotNil(t, p.ValidTo, "price %d is closed", i)
+		assert.Equal(t, history[i+1].ValidFrom, *p.ValidTo)
+	}
+	assert.False(t, history[2].Scheduled())
+	assert.Nil(t, history[2].ValidTo, "the applied price is current")
+	assert.True(t, history[3].Scheduled(), "future price waits")
+	assert.Nil(t, history[3].ValidTo)
+}
+
+func TestActivateScheduledPricesBatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		due     int
+		batches int
+	}{
+		{name: "nothing due", batches: 1},
+		{name: "single batch", due: 3, batches: 1},
+		{name: "exactly one batch", due: sweepBatch, batches: 2},
+		{name: "several batches", due: 2*sweepBatch + 1, batches: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, repo := newPriceService(10000)
+			for i := range tt.due {
+				id := uuid.New()
+				repo.products[id] = product.Product{ProductID: id, Price: rub(10000)}
+				repo.prices = append(repo.prices, product.Price{PriceID: uuid.New(), ProductID: id,
+					Price: rub(int64(20000 + i)), ValidFrom: time.Now().Add(-time.Minute)})
+			}
+
+			n, err := s.ActivateScheduledPrices(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.due, n)
+			assert.Equal(t, tt.batches, repo.batches)
+			for _, p := range repo.prices {
+				assert.False(t, p.Scheduled())
+			}
+		})
+	}
+}
+
+func TestReprice(t *testing.T) {
+	tools, garden := uuid.New(), uuid.New()
+	percent := func(s string) *money.Rate {
+		r, err := money.ParseRate(s)
+		require.NoError(t, err)
+		return &r
+	}
+	amount := func(a int64) *money.Money {
+		m := rub(a)
+		return &m
+	}
+	ending := func(e int64) *int64 { return &e }
+
+	tests := []struct {
+		name      string
+		repricing product.Repricing
+		wantErr   error
+		matched   int
+		want      map[string]int64
+	}{
+		{
+			name:      "percent up",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Percent: percent("10")},
+			matched:   2,
+			want:      map[string]int64{"Дрель": 11000, "Молоток": 1100},
+		},
+		{
+			name:      "percent down with ending",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Percent: percent("-7"), Ending: ending(90)},
+			matched:   2,
+			want:      map[string]int64{"Дрель": 9290, "Молоток": 890},
+		},
+		{
+			name:      "amount",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &garden}, Amount: amount(-500)},
+			matched:   1,
+			want:      map[string]int64{"Лопата": 2000},
+		},
+		{
+			name:      "unchanged prices are skipped",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Amount: amount(0), Ending: ending(0)},
+			matched:   2,
+			want:      map[string]int64{},
+		},
+		{
+			name:      "price would drop to zero",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Amount: amount(-1000)},
+			wantErr:   model.ErrInvalidPriceChange,
+		},
+		{
+			name:      "both percent and amount",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Percent: percent("5"), Amount: amount(100)},
+			wantErr:   model.ErrInvalidPriceChange,
+		},
+		{
+			name:      "neither percent nor amount",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}},
+			wantErr:   model.ErrInvalidPriceChange,
+		},
+		{
+			name:      "ending out of range",
+			repricing: product.Repricing{Filter: product.Filter{CategoryID: &tools}, Percent: percent("5"), Ending: ending(100)},
+			wantErr:   model.ErrInvalidPriceChange,
+		},
+		{
+			name:      "whole catalog",
+			repricing: product.Repricing{Percent: percent("5")},
+			wantErr:   model.ErrInvalidPriceChange,
+		},
+	}
+	for _, tt := range tests {
+		for _, dryRun := range []bool{false, true} {
+			name := tt.name
+			if dryRun {
+				name += " dry run"
+			}
+			t.Run(name, func(t *testing.T) {
+				repo := &priceRepo{products: map[uuid.UUID]product.Product{}}
+				before := map[string]int64{"Дрель": 10000, "Молоток": 1000, "Лопата": 2500}
+				for name, price := range before {
+					category := tools
+					if name == "Лопата" {
+						category = garden
+					}
+					id := uuid.New()
+					repo.products[id] = product.Product{ProductID: id, Name: name, CategoryID: category, Price: rub(price)}
+				}
+				s := &productService{repo: repo, tx: fakeTx{}}
+				r := tt.repricing
+				r.DryRun = dryRun
+
+				res, err := s.Reprice(context.Background(), r)
+				prices := map[string]int64{}
+				for _, p := range repo.products {
+					prices[p.Name] = p.Price.Amount
+				}
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					assert.Equal(t, before, prices, "no price changes on error")
+					assert.Empty(t, repo.prices)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, dryRun, res.DryRun)
+				assert.Equal(t, tt.matched, res.Matched)
+
+				got := map[string]int64{}
+				for _, item := range res.Items {
+					assert.Equal(t, rub(before[item.Name]), item.OldPrice)
+					got[item.Name] = item.NewPrice.Amount
+				}
+				assert.Equal(t, tt.want, got)
+
+				want := maps.Clone(before)
+				if !dryRun {
+					maps.Copy(want, tt.want)
+					assert.Len(t, repo.prices, len(tt.want), "every change goes to the price history")
+				} else {
+					assert.Empty(t, repo.prices)
+				}
+				assert.Equal(t, want, prices)
+			})
+		}
+	}
+}
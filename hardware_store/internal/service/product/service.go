@@ -2,37 +2,105 @@ package product
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/product"
-	"hardware_store/internal/service/images"
+	"hardware_store/internal/model/reservation"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tax"
 	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/category"
+	"hardware_store/internal/service/images"
+	"hardware_store/internal/service/stock"
+	"hardware_store/internal/service/supplier"
+	"hardware_store/internal/service/warehouse"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-var ErrAmountIsNegative = errors.New("amount must be positive")
+var ErrAmountIsNegative = model.ErrAmountIsNegative
 
 type ProductRepository interface {
 	Insert(ctx context.Context, product product.Product) error
-	UpdateBalance(ctx context.Context, id uuid.UUID, col int) (product.Product, error)
+	Update(ctx context.Context, product product.Product) (product.Product, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetById(ctx context.Context, id uuid.UUID) (product.Product, error)
-	GetAll(ctx context.Context) ([]product.Product, error)
+	GetBySKU(ctx context.Context, sku string) (product.Product, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (product.Product, error)
+	GetAll(ctx context.Context, filter product.Filter) ([]product.Product, int, error)
+	Export(ctx context.Context, filter product.Filter, fn func(product.ExportRow) error) error
+	Reserve(ctx context.Context, id, warehouseID uuid.UUID, quantity int) error
+	Unreserve(ctx context.Context, id, warehouseID uuid.UUID, quantity int) error
+	InsertPrice(ctx context.Context, price product.Price) error
+	SchedulePrice(ctx context.Context, price product.Price) (product.Price, error)
+	ClosePrice(ctx context.Context, productID uuid.UUID, at time.Time) error
+	ApplyPrice(ctx context.Context, price product.Price, at time.Time) error
+	DeleteScheduledPrice(ctx context.Context, productID, priceID uuid.UUID) error
+	GetPrices(ctx context.Context, productID uuid.UUID, req page.Request) ([]product.Price, error)
+	GetDuePricesForUpdate(ctx context.Context, now time.Time, limit int) ([]product.Price, error)
+	GetForRepricing(ctx context.Context, filter product.Filter) ([]product.Product, error)
+	UpdatePrice(ctx context.Context, p product.Product) error
+	SetTaxRate(ctx context.Context, id uuid.UUID, rate *money.Rate) error
+	GetTaxRates(ctx context.Context, ids []uuid.UUID) ([]tax.ProductRate, error)
 }
 
+type ReservationRepository interface {
+	Insert(ctx context.Context, reservation reservation.Reservation) error
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (reservation.Reservation, error)
+	UpdateStatus(ctx context.Context, reservation reservation.Reservation) error
+	GetExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]reservation.Reservation, error)
+}
+
+// sweepBatch сколько истёкших резервов освобождается в одной транзакции.
+const sweepBatch = 100
+
 type productService struct {
-	repo    ProductRepository
-	img 	images.ImageService
-	tx      tx.Manager
+	repo      ProductRepository
+	reserve   ReservationRepository
+	img       images.ImageService
+	category  category.CategoryService
+	supplier  supplier.SupplierService
+	stock     stock.StockService
+	warehouse warehouse.WarehouseService
+	tx        tx.Manager
+	ttl       time.Duration
+	taxRate   money.Rate
 }
 
-func NewProductService(repo ProductRepository, img images.ImageService, tx tx.Manager) *productService {
-	return &productService{repo: repo, img: img, tx: tx}
+func NewProductService(repo ProductRepository, reserve ReservationRepository, img images.ImageService,
+	category category.CategoryService, supplier supplier.SupplierService, stock stock.StockService,
+	warehouse warehouse.WarehouseService, tx tx.Manager, cfg *config.Config) *productService {
+	return &productService{repo: repo, reserve: reserve, img: img, category: category, supplier: supplier,
+		stock: stock, warehouse: warehouse, tx: tx, ttl: cfg.Reservation.TTL, taxRate: cfg.Tax.DefaultRate}
 }
 
+// CreateProduct создаёт товар с нулевым остатком и проводит начальный остаток
+// приходом, чтобы он попал в журнал движений. Цена товара начинает историю цен.
 func (s *productService) CreateProduct(ctx context.Context, product product.Product) error {
-
-	return s.repo.Insert(ctx, product)
+	initial := product.AvailableStock
+	product.AvailableStock = 0
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, product); err != nil {
+			return err
+		}
+		if err := s.recordPrice(ctx, product, "product created"); err != nil {
+			return err
+		}
+		if initial == 0 {
+			return nil
+		}
+		_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+			ProductID: product.ProductID,
+			Type:      stockmodel.MovementReceipt,
+			Quantity:  initial,
+			Reason:    "initial stock",
+		})
+		return err
+	})
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
@@ -53,18 +121,252 @@ func (s *productService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 	}
 }
 
-func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, col int) (product.Product, error) {
+// UpdateProduct списывает col единиц товара продажей со склада warehouseID,
+// uuid.Nil означает склад по умолчанию.
+func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, col int, warehouseID uuid.UUID) (product.Product, error) {
 	if col < 0 {
 		return product.Product{}, ErrAmountIsNegative
 	}
 
-	return s.repo.UpdateBalance(ctx, id, col)
+	_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+		ProductID:   id,
+		WarehouseID: warehouseID,
+		Type:        stockmodel.MovementSale,
+		Quantity:    col,
+	})
+	if err != nil {
+		return product.Product{}, err
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *productService) ReplaceProduct(ctx context.Context, p product.Product) (product.Product, error) {
+	var updated product.Product
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByIdForUpdate(ctx, p.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkReferences(ctx, p); err != nil {
+			return err
+		}
+		if err := s.adjustStock(ctx, current, p.AvailableStock); err != nil {
+			return err
+		}
+		p.LastUpdateDate = time.Now()
+		if p.Price.Amount != current.Price.Amount {
+			if err := s.recordPrice(ctx, p, "product update"); err != nil {
+				return err
+			}
+		}
+
+		updated, err = s.repo.Update(ctx, p)
+		return err
+	})
+	return updated, err
+}
+
+func (s *productService) PatchProduct(ctx context.Context, id uuid.UUID, patch product.Patch) (product.Product, error) {
+	var updated product.Product
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := current
+		current.Apply(patch)
+		if err := s.checkReferences(ctx, current); err != nil {
+			return err
+		}
+		if err := s.adjustStock(ctx, before, current.AvailableStock); err != nil {
+			return err
+		}
+		current.LastUpdateDate = time.Now()
+		if current.Price.Amount != before.Price.Amount {
+			if err := s.recordPrice(ctx, current, "product update"); err != nil {
+				return err
+			}
+		}
+
+		updated, err = s.repo.Update(ctx, current)
+		return err
+	})
+	return updated, err
 }
 
 func (s *productService) GetProduct(ctx context.Context, id uuid.UUID) (product.Product, error) {
 	return s.repo.GetById(ctx, id)
 }
 
-func (s *productService) GetProducts(ctx context.Context) ([]product.Product, error) {
-	return s.repo.GetAll(ctx)
+func (s *productService) GetProductBySKU(ctx context.Context, sku string) (product.Product, error) {
+	return s.repo.GetBySKU(ctx, sku)
+}
+
+func (s *productService) GetProducts(ctx context.Context, filter product.Filter) ([]product.Product, int, error) {
+	return s.repo.GetAll(ctx, filter)
+}
+
+// ExportProducts передаёт fn все товары по фильтру, не загружая их в
+// память целиком. Постраничные параметры фильтра не учитываются.
+func (s *productService) ExportProducts(ctx context.Context, filter product.Filter, fn func(product.ExportRow) error) error {
+	return s.repo.Export(ctx, filter, fn)
+}
+
+// Reserve удерживает quantity единиц товара на срок ttl, при нулевом ttl — на
+// срок из конфигурации. Резерв держится на складе warehouseID, uuid.Nil
+// означает склад по умолчанию: на складе должно быть достаточно свободного
+// остатка, и зарезервированное количество нельзя продать или переместить с
+// этого склада, пока резерв не подтверждён или не освобождён. При
+// подтверждении товар списывается с того же склада.
+func (s *productService) Reserve(ctx context.Context, id uuid.UUID, quantity int, warehouseID uuid.UUID, ttl time.Duration, reference string) (reservation.Reservation, error) {
+	if quantity <= 0 {
+		return reservation.Reservation{}, ErrAmountIsNegative
+	}
+	if ttl <= 0 {
+		ttl = s.ttl
+	}
+	now := time.Now()
+	r := reservation.Reservation{
+		ReservationID: uuid.New(),
+		ProductID:     id,
+		WarehouseID:   warehouseID,
+		Quantity:      quantity,
+		Status:        reservation.StatusActive,
+		Reference:     reference,
+		ExpiresAt:     now.Add(ttl),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if r.WarehouseID == uuid.Nil {
+			w, err := s.warehouse.GetDefaultWarehouse(ctx)
+			if err != nil {
+				return err
+			}
+			r.WarehouseID = w.WarehouseID
+		} else if _, err := s.warehouse.GetWarehouse(ctx, r.WarehouseID); err != nil {
+			return err
+		}
+		if err := s.repo.Reserve(ctx, id, r.WarehouseID, quantity); err != nil {
+			return err
+		}
+		return s.reserve.Insert(ctx, r)
+	})
+	if err != nil {
+		return reservation.Reservation{}, err
+	}
+	return r, nil
+}
+
+// ReleaseReservation снимает активный резерв и возвращает количество в свободный остаток.
+func (s *productService) ReleaseReservation(ctx context.Context, id uuid.UUID) (reservation.Reservation, error) {
+	return s.closeReservation(ctx, id, reservation.StatusReleased)
+}
+
+// ConfirmReservation превращает резерв в продажу: снимает резерв и списывает
+// остаток со склада резерва движением продажи в одной транзакции.
+func (s *productService) ConfirmReservation(ctx context.Context, id uuid.UUID) (reservation.Reservation, error) {
+	return s.closeReservation(ctx, id, reservation.StatusConfirmed)
+}
+
+// ReleaseExpired освобождает истёкшие резервы пачками и возвращает их число.
+func (s *productService) ReleaseExpired(ctx context.Context) (int, error) {
+	var total int
+	for {
+		var n int
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			expired, err := s.reserve.GetExpiredForUpdate(ctx, time.Now(), sweepBatch)
+			if err != nil {
+				return err
+			}
+			n = len(expired)
+			for _, r := range expired {
+				if err := s.release(ctx, &r, reservation.StatusExpired); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < sweepBatch {
+			return total, nil
+		}
+	}
+}
+
+func (s *productService) closeReservation(ctx context.Context, id uuid.UUID, status reservation.Status) (reservation.Reservation, error) {
+	var r reservation.Reservation
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.reserve.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !r.Active(time.Now()) {
+			return fmt.Errorf("%w: reservation is %s", model.ErrReservationClosed, r.Status)
+		}
+		if err := s.release(ctx, &r, status); err != nil {
+			return err
+		}
+		if status != reservation.StatusConfirmed {
+			return nil
+		}
+		_, _, err = s.stock.RecordMovement(ctx, stockmodel.Movement{
+			ProductID:   r.ProductID,
+			WarehouseID: r.WarehouseID,
+			Type:        stockmodel.MovementSale,
+			Quantity:    r.Quantity,
+			Reason:      "reservation " + r.ReservationID.String(),
+		})
+		return err
+	})
+	if err != nil {
+		return reservation.Reservation{}, err
+	}
+	return r, nil
+}
+
+// release снимает количество резерва с товара и склада и закрывает резерв статусом status.
+func (s *productService) release(ctx context.Context, r *reservation.Reservation, status reservation.Status) error {
+	if err := s.repo.Unreserve(ctx, r.ProductID, r.WarehouseID, r.Quantity); err != nil {
+		return err
+	}
+	r.Status = status
+	r.UpdatedAt = time.Now()
+	return s.reserve.UpdateStatus(ctx, *r)
+}
+
+// adjustStock проводит изменение остатка при редактировании товара
+// корректировкой, чтобы журнал движений сходился с остатком.
+func (s *productService) adjustStock(ctx context.Context, current product.Product, newStock int) error {
+	delta := newStock - current.AvailableStock
+	if delta == 0 {
+		return nil
+	}
+	_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+		ProductID: current.ProductID,
+		Type:      stockmodel.MovementAdjustment,
+		Quantity:  delta,
+		Reason:    "product update",
+	})
+	return err
+}
+
+// checkReferences проверяет, что категория и поставщик товара существуют.
+// uuid.Nil означает, что связь не задана.
+func (s *productService) checkReferences(ctx context.Context, p product.Product) error {
+	if p.CategoryID != uuid.Nil {
+		if _, err := s.category.GetCategory(ctx, p.CategoryID); err != nil {
+			return err
+		}
+	}
+	if p.SupplierID != uuid.Nil {
+		if _, err := s.supplier.GetSupplier(ctx, p.SupplierID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
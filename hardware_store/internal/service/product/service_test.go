@@ -0,0 +1,421 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/model/reservation"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/warehouse"
+	stockservice "hardware_store/internal/service/stock"
+	warehouseservice "hardware_store/internal/service/warehouse"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mainWarehouse  = uuid.New()
+	otherWarehouse = uuid.New()
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type stockKey struct {
+	product   uuid.UUID
+	warehouse uuid.UUID
+}
+
+// level остаток товара на складе и его зарезервированная часть.
+type level struct {
+	quantity int
+	reserved int
+}
+
+// fakeRepo хранит товары и остатки по складам в памяти и служит хранилищем и
+// товаров, и движений остатков. Условия те же, что в запросах postgres:
+// зарезервировать и списать со склада можно только незарезервированный
+// остаток этого склада.
+type fakeRepo struct {
+	ProductRepository
+	stockservice.StockRepository
+	products  map[uuid.UUID]product.Product
+	levels    map[stockKey]level
+	movements []stockmodel.Movement
+	transfers []stockmodel.Transfer
+}
+
+func (r *fakeRepo) GetById(_ context.Context, id uuid.UUID) (product.Product, error) {
+	p, ok := r.products[id]
+	if !ok {
+		return product.Product{}, model.ErrProductNotFound
+	}
+	return p, nil
+}
+
+func (r *fakeRepo) Reserve(_ context.Context, id, warehouseID uuid.UUID, quantity int) error {
+	p, ok := r.products[id]
+	if !ok {
+		return model.ErrProductNotFound
+	}
+	if p.AvailableStock-p.ReservedStock < quantity {
+		return model.ErrInsufficientStock
+	}
+	k := stockKey{id, warehouseID}
+	l, ok := r.levels[k]
+	if !ok || l.quantity-l.reserved < quantity {
+		return fmt.Errorf("%w in warehouse %s", model.ErrInsufficientStock, warehouseID)
+	}
+	p.ReservedStock += quantity
+	r.products[id] = p
+	l.reserved += quantity
+	r.levels[k] = l
+	return nil
+}
+
+func (r *fakeRepo) Unreserve(_ context.Context, id, warehouseID uuid.UUID, quantity int) error {
+	k := stockKey{id, warehouseID}
+	p, l := r.products[id], r.levels[k]
+	if p.ReservedStock < quantity || l.reserved < quantity {
+		return model.ErrInsufficientStock
+	}
+	p.ReservedStock -= quantity
+	r.products[id] = p
+	l.reserved -= quantity
+	r.levels[k] = l
+	return nil
+}
+
+func (r *fakeRepo) AdjustStock(_ context.Context, productID, warehouseID uuid.UUID, delta int) (int, error) {
+	p := r.products[productID]
+	if p.AvailableStock+delta < p.ReservedStock {
+		return 0, model.ErrInsufficientStock
+	}
+	if err := r.adjustWarehouse(productID, warehouseID, delta); err != nil {
+		return 0, err
+	}
+	p.AvailableStock += delta
+	r.products[productID] = p
+	return p.AvailableStock, nil
+}
+
+func (r *fakeRepo) MoveStock(_ context.Context, productID, from, to uuid.UUID, quantity int) error {
+	if err := r.adjustWarehouse(productID, from, -quantity); err != nil {
+		return err
+	}
+	return r.adjustWarehouse(productID, to, quantity)
+}
+
+func (r *fakeRepo) adjustWarehouse(productID, warehouseID uuid.UUID, delta int) error {
+	k := stockKey{productID, warehouseID}
+	l := r.levels[k]
+	if l.quantity-l.reserved+delta < 0 {
+		return fmt.Errorf("%w in warehouse %s", model.ErrInsufficientStock, warehouseID)
+	}
+	l.quantity += delta
+	r.levels[k] = l
+	return nil
+}
+
+func (r *fakeRepo) InsertMovement(_ context.Context, m stockmodel.Movement) error {
+	r.movements = append(r.movements, m)
+	return nil
+}
+
+func (r *fakeRepo) InsertTransfer(_ context.Context, t stockmodel.Transfer) error {
+	r.transfers = append(r.transfers, t)
+	return nil
+}
+
+// reserved задаёт уже зарезервированное количество товара на складе.
+func (r *fakeRepo) reserved(id, warehouseID uuid.UUID, quantity int) {
+	p := r.products[id]
+	p.ReservedStock += quantity
+	r.products[id] = p
+	l := r.levels[stockKey{id, warehouseID}]
+	l.reserved += quantity
+	r.levels[stockKey{id, warehouseID}] = l
+}
+
+// fakeReservations хранит резервы в памяти и считает пачки выборки истёкших.
+type fakeReservations struct {
+	ReservationRepository
+	reservations map[uuid.UUID]reservation.Reservation
+	batches      int
+}
+
+func (r *fakeReservations) Insert(_ context.Context, res reservation.Reservation) error {
+	r.reservations[res.ReservationID] = res
+	return nil
+}
+
+func (r *fakeReservations) GetByIdForUpdate(_ context.Context, id uuid.UUID) (reservation.Reservation, error) {
+	res, ok := r.reservations[id]
+	if !ok {
+		return reservation.Reservation{}, model.ErrReservationNotFound
+	}
+	return res, nil
+}
+
+func (r *fakeReservations) UpdateStatus(_ context.Context, res reservation.Reservation) error {
+	r.reservations[res.ReservationID] = res
+	return nil
+}
+
+func (r *fakeReservations) GetExpiredForUpdate(_ context.Context, now time.Time, limit int) ([]reservation.Reservation, error) {
+	r.batches++
+	var expired []reservation.Reservation
+	for _, res := range r.reservations {
+		if res.Status == reservation.StatusActive && !res.ExpiresAt.After(now) && len(expired) < limit {
+			expired = append(expired, res)
+		}
+	}
+	return expired, nil
+}
+
+type fakeWarehouses struct {
+	warehouseservice.WarehouseService
+}
+
+func (fakeWarehouses) GetWarehouse(_ context.Context, id uuid.UUID) (warehouse.Warehouse, error) {
+	if id != mainWarehouse && id != otherWarehouse {
+		return warehouse.Warehouse{}, model.ErrWarehouseNotFound
+	}
+	return warehouse.Warehouse{WarehouseID: id}, nil
+}
+
+func (fakeWarehouses) GetDefaultWarehouse(context.Context) (warehouse.Warehouse, error) {
+	return warehouse.Warehouse{WarehouseID: mainWarehouse}, nil
+}
+
+// newReservationService создаёт сервис с товаром, у которого onHand единиц
+// лежит на основном складе, а на втором складе остатка нет.
+func newReservationService(onHand int) (*productService, uuid.UUID, *fakeRepo, *fakeReservations) {
+	id := uuid.New()
+	repo := &fakeRepo{
+		products: map[uuid.UUID]product.Product{id: {ProductID: id, AvailableStock: onHand}},
+		levels:   map[stockKey]level{{id, mainWarehouse}: {quantity: onHand}},
+	}
+	reserve := &fakeReservations{reservations: map[uuid.UUID]reservation.Reservation{}}
+	stock := stockservice.NewStockService(repo, fakeWarehouses{}, fakeTx{})
+	s := &productService{repo: repo, reserve: reserve, stock: stock, warehouse: fakeWarehouses{}, tx: fakeTx{}, ttl: 15 * time.Minute}
+	return s, id, repo, reserve
+}
+
+func TestReserve(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantity  int
+		warehouse uuid.UUID
+		ttl       time.Duration
+		wantErr   error
+		wantTTL   time.Duration
+	}{
+		{name: "default ttl and warehouse", quantity: 3, wantTTL: 15 * time.Minute},
+		{name: "explicit ttl", quantity: 3, ttl: time.Hour, wantTTL: time.Hour},
+		{name: "whole free stock", quantity: 8, warehouse: mainWarehouse, wantTTL: 15 * time.Minute},
+		{name: "more than free stock", quantity: 9, wantErr: model.ErrInsufficientStock},
+		{name: "warehouse without stock", quantity: 1, warehouse: otherWarehouse, wantErr: model.ErrInsufficientStock},
+		{name: "zero quantity", wantErr: ErrAmountIsNegative},
+		{name: "unknown warehouse", quantity: 1, warehouse: uuid.New(), wantErr: model.ErrWarehouseNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, id, repo, reserve := newReservationService(10)
+			repo.reserved(id, mainWarehouse, 2)
+
+			r, err := s.Reserve(context.Background(), id, tt.quantity, tt.warehouse, tt.ttl, "cart")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, reserve.reservations)
+				assert.Equal(t, 2, repo.products[id].ReservedStock)
+				assert.Equal(t, 2, repo.levels[stockKey{id, mainWarehouse}].reserved)
+				assert.Zero(t, repo.levels[stockKey{id, otherWarehouse}].reserved)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, reservation.StatusActive, r.Status)
+			assert.Equal(t, mainWarehouse, r.WarehouseID)
+			assert.Equal(t, tt.wantTTL, r.ExpiresAt.Sub(r.CreatedAt))
+			assert.Equal(t, 2+tt.quantity, repo.products[id].ReservedStock)
+			assert.Equal(t, 2+tt.quantity, repo.levels[stockKey{id, mainWarehouse}].reserved)
+			assert.Equal(t, r, reserve.reservations[r.ReservationID])
+		})
+	}
+}
+
+func TestReservedUnitsStayInWarehouse(t *testing.T) {
+	tests := []struct {
+		name    string
+		consume func(s *productService, id uuid.UUID) error
+		wantErr error
+	}{
+		{
+			name: "sale of free units",
+			consume: func(s *productService, id uuid.UUID) error {
+				_, err := s.UpdateProduct(context.Background(), id, 2, mainWarehouse)
+				return err
+			},
+		},
+		{
+			name: "sale of reserved units",
+			consume: func(s *productService, id uuid.UUID) error {
+				_, err := s.UpdateProduct(context.Background(), id, 3, mainWarehouse)
+				return err
+			},
+			wantErr: model.ErrInsufficientStock,
+		},
+		{
+			name: "transfer of free units",
+			consume: func(s *productService, id uuid.UUID) error {
+				_, err := s.stock.Transfer(context.Background(), stockmodel.Transfer{ProductID: id,
+					FromWarehouseID: mainWarehouse, ToWarehouseID: otherWarehouse, Quantity: 2})
+				return err
+			},
+		},
+		{
+			name: "transfer of reserved units",
+			consume: func(s *productService, id uuid.UUID) error {
+				_, err := s.stock.Transfer(context.Background(), stockmodel.Transfer{ProductID: id,
+					FromWarehouseID: mainWarehouse, ToWarehouseID: otherWarehouse, Quantity: 3})
+				return err
+			},
+			wantErr: model.ErrInsufficientStock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Второй склад получает свой остаток, чтобы общий остаток товара
+			// покрывал продажу: отказать должен именно склад резерва.
+			s, id, repo, _ := newReservationService(10)
+			_, _, err := s.stock.RecordMovement(context.Background(), stockmodel.Movement{ProductID: id,
+				WarehouseID: otherWarehouse, Type: stockmodel.MovementReceipt, Quantity: 10})
+			require.NoError(t, err)
+			r, err := s.Reserve(context.Background(), id, 8, mainWarehouse, 0, "cart")
+			require.NoError(t, err)
+
+			err = tt.consume(s, id)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, level{quantity: 10, reserved: 8}, repo.levels[stockKey{id, mainWarehouse}])
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, level{quantity: 8, reserved: 8}, repo.levels[stockKey{id, mainWarehouse}])
+			}
+
+			_, err = s.ConfirmReservation(context.Background(), r.ReservationID)
+			require.NoError(t, err, "reserved units are still there to confirm")
+			assert.Zero(t, repo.levels[stockKey{id, mainWarehouse}].reserved)
+		})
+	}
+}
+
+func TestCloseReservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   reservation.Status
+		expiry   time.Duration
+		confirm  bool
+		wantErr  error
+		want     reservation.Status
+		reserved int
+		onHand   int
+	}{
+		{name: "release", status: reservation.StatusActive, expiry: time.Minute, want: reservation.StatusReleased, onHand: 10},
+		{name: "confirm sells reserved units", status: reservation.StatusActive, expiry: time.Minute, confirm: true, want: reservation.StatusConfirmed, onHand: 7},
+		{name: "confirm after expiry", status: reservation.StatusActive, expiry: -time.Minute, confirm: true, wantErr: model.ErrReservationClosed},
+		{name: "release twice", status: reservation.StatusReleased, expiry: time.Minute, wantErr: model.ErrReservationClosed},
+		{name: "confirm twice", status: reservation.StatusConfirmed, expiry: time.Minute, confirm: true, wantErr: model.ErrReservationClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, id, repo, reserve := newReservationService(10)
+			repo.reserved(id, mainWarehouse, 3)
+			r := reservation.Reservation{ReservationID: uuid.New(), ProductID: id, WarehouseID: mainWarehouse,
+				Quantity: 3, Status: tt.status, ExpiresAt: time.Now().Add(tt.expiry)}
+			reserve.reservations[r.ReservationID] = r
+
+			closeFn := s.ReleaseReservation
+			if tt.confirm {
+				closeFn = s.ConfirmReservation
+			}
+			got, err := closeFn(context.Background(), r.ReservationID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.status, reserve.reservations[r.ReservationID].Status)
+				assert.Equal(t, 3, repo.products[id].ReservedStock)
+				assert.Empty(t, repo.movements)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Status)
+			assert.Equal(t, tt.want, reserve.reservations[r.ReservationID].Status)
+			assert.Equal(t, tt.reserved, repo.products[id].ReservedStock)
+			assert.Equal(t, level{quantity: tt.onHand, reserved: tt.reserved}, repo.levels[stockKey{id, mainWarehouse}])
+			assert.Equal(t, tt.onHand, repo.products[id].AvailableStock)
+		})
+	}
+
+	s, _, _, _ := newReservationService(10)
+	_, err := s.ReleaseReservation(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrReservationNotFound)
+}
+
+func TestReleaseExpired(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired int
+		active  int
+		batches int
+	}{
+		{name: "nothing expired", active: 2, batches: 1},
+		{name: "single batch", expired: 5, active: 2, batches: 1},
+		{name: "exactly one batch", expired: sweepBatch, batches: 2},
+		{name: "several batches", expired: 2*sweepBatch + 1, active: 1, batches: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, id, repo, reserve := newReservationService(10000)
+			now := time.Now()
+			add := func(n int, expiry time.Time) {
+				for range n {
+					r := reservation.Reservation{ReservationID: uuid.New(), ProductID: id, WarehouseID: mainWarehouse,
+						Quantity: 1, Status: reservation.StatusActive, ExpiresAt: expiry}
+					reserve.reservations[r.ReservationID] = r
+				}
+			}
+			add(tt.expired, now.Add(-time.Second))
+			add(tt.active, now.Add(time.Hour))
+			repo.reserved(id, mainWarehouse, tt.expired+tt.active)
+
+			n, err := s.ReleaseExpired(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expired, n)
+			assert.Equal(t, tt.batches, reserve.batches)
+			assert.Equal(t, tt.active, repo.products[id].ReservedStock)
+			assert.Equal(t, tt.active, repo.levels[stockKey{id, mainWarehouse}].reserved)
+			assert.Empty(t, repo.movements, "expired reservations are not sold")
+
+			statuses := map[reservation.Status]int{}
+			for _, r := range reserve.reservations {
+				statuses[r.Status]++
+			}
+			want := map[reservation.Status]int{}
+			if tt.expired > 0 {
+				want[reservation.StatusExpired] = tt.expired
+			}
+			if tt.active > 0 {
+				want[reservation.StatusActive] = tt.active
+			}
+			assert.Equal(t, want, statuses)
+		})
+	}
+}
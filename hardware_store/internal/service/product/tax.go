@@ -0,0 +1,50 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/tax"
+
+	"github.com/google/uuid"
+)
+
+func (s *productService) GetTaxRate(ctx context.Context, id uuid.UUID) (tax.ProductRate, error) {
+	rates, err := s.GetTaxRates(ctx, []uuid.UUID{id})
+	if err != nil {
+		return tax.ProductRate{}, err
+	}
+	rate, ok := rates[id]
+	if !ok {
+		return tax.ProductRate{}, model.ErrProductNotFound
+	}
+	return rate, nil
+}
+
+// SetTaxRate задаёт товару собственную ставку налога вместо ставки
+// категории; nil снимает её.
+func (s *productService) SetTaxRate(ctx context.Context, id uuid.UUID, rate *money.Rate) (tax.ProductRate, error) {
+	if rate != nil && !tax.ValidRate(*rate) {
+		return tax.ProductRate{}, fmt.Errorf("%w: %s must be between 0 and 100", model.ErrInvalidTaxRate, *rate)
+	}
+	if err := s.repo.SetTaxRate(ctx, id, rate); err != nil {
+		return tax.ProductRate{}, err
+	}
+	return s.GetTaxRate(ctx, id)
+}
+
+// GetTaxRates возвращает действующие ставки товаров по product_id. Товаров,
+// которых нет, в результате нет.
+func (s *productService) GetTaxRates(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]tax.ProductRate, error) {
+	rates, err := s.repo.GetTaxRates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[uuid.UUID]tax.ProductRate, len(rates))
+	for _, rate := range rates {
+		rate.Resolve(s.taxRate)
+		byProduct[rate.ProductID] = rate
+	}
+	return byProduct, nil
+}
@@ -0,0 +1,19 @@
+package promotion
+
+import (
+	"context"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/promotion"
+
+	"github.com/google/uuid"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, p promotion.Promotion) (promotion.Promotion, error)
+	UpdatePromotion(ctx context.Context, p promotion.Promotion) (promotion.Promotion, error)
+	GetPromotion(ctx context.Context, id uuid.UUID) (promotion.Promotion, error)
+	GetPromotions(ctx context.Context, req page.Request) ([]promotion.Promotion, error)
+	Evaluate(ctx context.Context, basket promotion.Basket) (promotion.Evaluation, error)
+	Apply(ctx context.Context, basket promotion.Basket) (promotion.Evaluation, error)
+	Redeem(ctx context.Context, orderID, clientID uuid.UUID, eval promotion.Evaluation) error
+}
@@ -0,0 +1,214 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/promotion"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/product"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromotionRepository interface {
+	Insert(ctx context.Context, p promotion.Promotion) error
+	Update(ctx context.Context, p promotion.Promotion) error
+	GetById(ctx context.Context, id uuid.UUID) (promotion.Promotion, error)
+	GetAll(ctx context.Context, req page.Request) ([]promotion.Promotion, error)
+	GetActive(ctx context.Context, now time.Time, codes []string) ([]promotion.Promotion, error)
+	LockLimited(ctx context.Context, ids []uuid.UUID) error
+	GetUsage(ctx context.Context, ids []uuid.UUID, clientID *uuid.UUID) (map[uuid.UUID]promotion.Usage, error)
+	InsertRedemption(ctx context.Context, r promotion.Redemption) error
+}
+
+type promotionService struct {
+	repo    PromotionRepository
+	product product.ProductService
+	tx      tx.Manager
+}
+
+func NewPromotionService(repo PromotionRepository, product product.ProductService, tx tx.Manager) *promotionService {
+	return &promotionService{repo: repo, product: product, tx: tx}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, p promotion.Promotion) (promotion.Promotion, error) {
+	if err := p.Validate(); err != nil {
+		return promotion.Promotion{}, fmt.Errorf("%w: %v", model.ErrInvalidPromotion, err)
+	}
+	now := time.Now()
+	p.PromotionID = uuid.New()
+	p.Code = promotion.NormalizeCode(p.Code)
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	if err := s.repo.Insert(ctx, p); err != nil {
+		return promotion.Promotion{}, err
+	}
+	return p, nil
+}
+
+// UpdatePromotion заменяет условия акции. Уже оформленные заказы сохраняют
+// полученные скидки.
+func (s *promotionService) UpdatePromotion(ctx context.Context, p promotion.Promotion) (promotion.Promotion, error) {
+	if err := p.Validate(); err != nil {
+		return promotion.Promotion{}, fmt.Errorf("%w: %v", model.ErrInvalidPromotion, err)
+	}
+	var updated promotion.Promotion
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetById(ctx, p.PromotionID)
+		if err != nil {
+			return err
+		}
+		p.Code = promotion.NormalizeCode(p.Code)
+		p.CreatedAt = current.CreatedAt
+		p.UpdatedAt = time.Now()
+		if err := s.repo.Update(ctx, p); err != nil {
+			return err
+		}
+		updated = p
+		return nil
+	})
+	if err != nil {
+		return promotion.Promotion{}, err
+	}
+	return updated, nil
+}
+
+func (s *promotionService) GetPromotion(ctx context.Context, id uuid.UUID) (promotion.Promotion, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *promotionService) GetPromotions(ctx context.Context, req page.Request) ([]promotion.Promotion, error) {
+	return s.repo.GetAll(ctx, req)
+}
+
+// Evaluate подбирает скидки к позициям, заданным товаром и количеством:
+// название, цена, категория и поставщик берутся из текущих данных товара.
+// Погашения не записываются.
+func (s *promotionService) Evaluate(ctx context.Context, basket promotion.Basket) (promotion.Evaluation, error) {
+	lines, err := s.lines(ctx, basket.Lines)
+	if err != nil {
+		return promotion.Evaluation{}, err
+	}
+	basket.Lines = lines
+	return s.evaluate(ctx, basket, false)
+}
+
+// Apply подбирает скидки к полностью заполненным позициям оформляемого
+// заказа. Акции с лимитами блокируются до конца транзакции, поэтому Apply
+// и Redeem должны вызываться в одной транзакции с созданием заказа.
+func (s *promotionService) Apply(ctx context.Context, basket promotion.Basket) (promotion.Evaluation, error) {
+	return s.evaluate(ctx, basket, true)
+}
+
+// Redeem записывает погашения акций, давших скидку заказу.
+func (s *promotionService) Redeem(ctx context.Context, orderID, clientID uuid.UUID, eval promotion.Evaluation) error {
+	now := time.Now()
+	for _, applied := range eval.Promotions {
+		err := s.repo.InsertRedemption(ctx, promotion.Redemption{
+			PromotionID: applied.PromotionID,
+			OrderID:     orderID,
+			ClientID:    clientID,
+			Amount:      applied.Amount,
+			CreatedAt:   now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluate отбирает действующие акции и применяет их. Неизвестный,
+// недействующий или исчерпанный промокод — ошибка; акции без кода с
+// исчерпанным лимитом просто пропускаются.
+func (s *promotionService) evaluate(ctx context.Context, basket promotion.Basket, lock bool) (promotion.Evaluation, error) {
+	codes := normalizeCodes(basket.Codes)
+	promos, err := s.repo.GetActive(ctx, time.Now(), codes)
+	if err != nil {
+		return promotion.Evaluation{}, err
+	}
+	found := make(map[string]bool, len(codes))
+	var limited []uuid.UUID
+	for _, p := range promos {
+		if p.Code != "" {
+			found[p.Code] = true
+		}
+		if p.UsageLimit != nil || p.PerClientLimit != nil {
+			limited = append(limited, p.PromotionID)
+		}
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return promotion.Evaluation{}, fmt.Errorf("%w: %s is unknown or not active", model.ErrInvalidPromoCode, code)
+		}
+	}
+
+	var usage map[uuid.UUID]promotion.Usage
+	if len(limited) > 0 {
+		if lock {
+			if err := s.repo.LockLimited(ctx, limited); err != nil {
+				return promotion.Evaluation{}, err
+			}
+		}
+		if usage, err = s.repo.GetUsage(ctx, limited, basket.ClientID); err != nil {
+			return promotion.Evaluation{}, err
+		}
+	}
+	applicable := promos[:0]
+	for _, p := range promos {
+		if p.Exhausted(usage[p.PromotionID]) {
+			if p.Code != "" {
+				return promotion.Evaluation{}, fmt.Errorf("%w: %s", model.ErrPromoCodeExhausted, p.Code)
+			}
+			continue
+		}
+		applicable = append(applicable, p)
+	}
+	return promotion.Evaluate(applicable, basket.Lines), nil
+}
+
+// lines заполняет позиции данными товаров и объединяет повторяющиеся товары.
+func (s *promotionService) lines(ctx context.Context, in []promotion.Line) ([]promotion.Line, error) {
+	byProduct := make(map[uuid.UUID]int, len(in))
+	lines := make([]promotion.Line, 0, len(in))
+	for _, l := range in {
+		if l.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidOrder)
+		}
+		if i, ok := byProduct[l.ProductID]; ok {
+			lines[i].Quantity += l.Quantity
+			continue
+		}
+		p, err := s.product.GetProduct(ctx, l.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		byProduct[l.ProductID] = len(lines)
+		lines = append(lines, promotion.Line{
+			ProductID:  p.ProductID,
+			Name:       p.Name,
+			CategoryID: p.CategoryID,
+			SupplierID: p.SupplierID,
+			Quantity:   l.Quantity,
+			UnitPrice:  p.Price,
+		})
+	}
+	return lines, nil
+}
+
+func normalizeCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	res := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = promotion.NormalizeCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		res = append(res, code)
+	}
+	return res
+}
@@ -0,0 +1,17 @@
+package purchase
+
+import (
+	"context"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/purchase"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseService interface {
+	CreatePurchaseOrder(ctx context.Context, po purchase.PurchaseOrder) (purchase.PurchaseOrder, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, to purchase.Status) (purchase.PurchaseOrder, error)
+	Receive(ctx context.Context, id uuid.UUID, lines []purchase.ReceiptLine) (purchase.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error)
+	GetSupplierPurchaseOrders(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]purchase.PurchaseOrder, error)
+}
@@ -0,0 +1,254 @@
+package purchase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/purchase"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/product"
+	"hardware_store/internal/service/stock"
+	"hardware_store/internal/service/supplier"
+	"hardware_store/internal/service/warehouse"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseRepository interface {
+	Insert(ctx context.Context, po purchase.PurchaseOrder) error
+	GetById(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error)
+	UpdateStatus(ctx context.Context, po purchase.PurchaseOrder) error
+	InsertReceipt(ctx context.Context, receipt purchase.Receipt) error
+	GetBySupplier(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]purchase.PurchaseOrder, error)
+}
+
+type purchaseService struct {
+	repo      PurchaseRepository
+	supplier  supplier.SupplierService
+	product   product.ProductService
+	stock     stock.StockService
+	warehouse warehouse.WarehouseService
+	tx        tx.Manager
+}
+
+func NewPurchaseService(repo PurchaseRepository, supplier supplier.SupplierService, product product.ProductService,
+	stock stock.StockService, warehouse warehouse.WarehouseService, tx tx.Manager) *purchaseService {
+	return &purchaseService{repo: repo, supplier: supplier, product: product, stock: stock, warehouse: warehouse, tx: tx}
+}
+
+// CreatePurchaseOrder создаёт черновик заказа поставщику. Названия товаров
+// копируются в позиции. Без склада товар будет приниматься на склад по
+// умолчанию.
+func (s *purchaseService) CreatePurchaseOrder(ctx context.Context, po purchase.PurchaseOrder) (purchase.PurchaseOrder, error) {
+	lines, err := mergeLines(po.Lines)
+	if err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+
+	now := time.Now()
+	po.PurchaseOrderID = uuid.New()
+	po.Status = purchase.StatusDraft
+	po.CreatedAt = now
+	po.UpdatedAt = now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.supplier.GetSupplier(ctx, po.SupplierID); err != nil {
+			return err
+		}
+		if po.WarehouseID == uuid.Nil {
+			w, err := s.warehouse.GetDefaultWarehouse(ctx)
+			if err != nil {
+				return err
+			}
+			po.WarehouseID = w.WarehouseID
+		} else if _, err := s.warehouse.GetWarehouse(ctx, po.WarehouseID); err != nil {
+			return err
+		}
+
+		po.Lines = make([]purchase.Line, 0, len(lines))
+		for _, line := range lines {
+			p, err := s.product.GetProduct(ctx, line.ProductID)
+			if err != nil {
+				return err
+			}
+			line.Name = p.Name
+			line.ReceivedQuantity = 0
+			po.Lines = append(po.Lines, line)
+		}
+		return s.repo.Insert(ctx, po)
+	})
+	if err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+	return po, nil
+}
+
+// ChangeStatus переводит заказ поставщику в новый статус по таблице ручных
+// переходов.
+func (s *purchaseService) ChangeStatus(ctx context.Context, id uuid.UUID, to purchase.Status) (purchase.PurchaseOrder, error) {
+	if !to.Valid() {
+		return purchase.PurchaseOrder{}, fmt.Errorf("%w: unknown status %q", model.ErrInvalidPurchaseOrder, to)
+	}
+
+	var po purchase.PurchaseOrder
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		po, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !po.Status.CanTransitionTo(to) {
+			return fmt.Errorf("%w: %s -> %s", model.ErrIllegalTransition, po.Status, to)
+		}
+		po.Status = to
+		po.UpdatedAt = time.Now()
+		return s.repo.UpdateStatus(ctx, po)
+	})
+	if err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+	return po, nil
+}
+
+// Receive принимает товар по заказу поставщику: приходует принятые позиции на
+// склад заказа, записывает приёмку с расхождениями между ожидаемым и
+// принятым количеством и переводит заказ в частично или полностью принятый.
+// Всё выполняется в одной транзакции.
+func (s *purchaseService) Receive(ctx context.Context, id uuid.UUID, lines []purchase.ReceiptLine) (purchase.PurchaseOrder, error) {
+	lines, err := mergeReceiptLines(lines)
+	if err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+
+	var po purchase.PurchaseOrder
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		po, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !po.Status.CanReceive() {
+			return fmt.Errorf("%w: cannot receive goods for %s purchase order", model.ErrIllegalTransition, po.Status)
+		}
+
+		receipt := purchase.Receipt{
+			ReceiptID:       uuid.New(),
+			PurchaseOrderID: po.PurchaseOrderID,
+			WarehouseID:     po.WarehouseID,
+			Lines:           make([]purchase.ReceiptLine, 0, len(lines)),
+			CreatedAt:       time.Now(),
+		}
+		if claims, ok := auth.FromContext(ctx); ok {
+			receipt.ActorID = &claims.UserID
+		}
+		for _, l := range lines {
+			i := slices.IndexFunc(po.Lines, func(line purchase.Line) bool { return line.ProductID == l.ProductID })
+			if i < 0 {
+				return fmt.Errorf("%w: product %s is not in purchase order", model.ErrInvalidPurchaseOrder, l.ProductID)
+			}
+			l.ExpectedQuantity = po.Lines[i].Outstanding()
+			po.Lines[i].ReceivedQuantity += l.ReceivedQuantity
+			receipt.Lines = append(receipt.Lines, l)
+
+			if l.ReceivedQuantity == 0 {
+				continue
+			}
+			_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+				ProductID:   l.ProductID,
+				WarehouseID: po.WarehouseID,
+				Type:        stockmodel.MovementReceipt,
+				Quantity:    l.ReceivedQuantity,
+				Reason:      "purchase order " + po.PurchaseOrderID.String(),
+			})
+			if err != nil {
+				return fmt.Errorf("product %s: %w", l.ProductID, err)
+			}
+		}
+		if err := s.repo.InsertReceipt(ctx, receipt); err != nil {
+			return err
+		}
+
+		po.Status = purchase.StatusPartiallyReceived
+		if po.FullyReceived() {
+			po.Status = purchase.StatusReceived
+		}
+		po.UpdatedAt = receipt.CreatedAt
+		po.Receipts = append(po.Receipts, receipt)
+		return s.repo.UpdateStatus(ctx, po)
+	})
+	if err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+	return po, nil
+}
+
+func (s *purchaseService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *purchaseService) GetSupplierPurchaseOrders(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]purchase.PurchaseOrder, error) {
+	return s.repo.GetBySupplier(ctx, supplierID, req)
+}
+
+// mergeLines объединяет повторяющиеся товары. Цена берётся из первой позиции.
+func mergeLines(lines []purchase.Line) ([]purchase.Line, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: purchase order has no lines", model.ErrInvalidPurchaseOrder)
+	}
+	byProduct := make(map[uuid.UUID]int, len(lines))
+	merged := make([]purchase.Line, 0, len(lines))
+	for _, l := range lines {
+		if l.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidPurchaseOrder)
+		}
+		if l.ExpectedPrice.IsNegative() {
+			return nil, fmt.Errorf("%w: expected price must not be negative", model.ErrInvalidPurchaseOrder)
+		}
+		if i, ok := byProduct[l.ProductID]; ok {
+			merged[i].Quantity += l.Quantity
+			continue
+		}
+		byProduct[l.ProductID] = len(merged)
+		merged = append(merged, l)
+	}
+	return merged, nil
+}
+
+// mergeReceiptLines объединяет повторяющиеся товары и упорядочивает позиции
+// по product_id, чтобы приёмки блокировали строки товаров в одном порядке с
+// заказами покупателей. Приёмка, в которой не принято ни одной единицы,
+// отклоняется: она не должна менять статус заказа.
+func mergeReceiptLines(lines []purchase.ReceiptLine) ([]purchase.ReceiptLine, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: receipt has no lines", model.ErrInvalidPurchaseOrder)
+	}
+	byProduct := make(map[uuid.UUID]int, len(lines))
+	merged := make([]purchase.ReceiptLine, 0, len(lines))
+	var total int
+	for _, l := range lines {
+		if l.ReceivedQuantity < 0 {
+			return nil, fmt.Errorf("%w: received quantity must not be negative", model.ErrInvalidPurchaseOrder)
+		}
+		total += l.ReceivedQuantity
+		if i, ok := byProduct[l.ProductID]; ok {
+			merged[i].ReceivedQuantity += l.ReceivedQuantity
+			continue
+		}
+		byProduct[l.ProductID] = len(merged)
+		merged = append(merged, l)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: receipt has no received goods", model.ErrInvalidPurchaseOrder)
+	}
+	slices.SortFunc(merged, func(a, b purchase.ReceiptLine) int {
+		return bytes.Compare(a.ProductID[:], b.ProductID[:])
+	})
+	return merged, nil
+}
@@ -0,0 +1,54 @@
+package purchase
+
+import (
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/purchase"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeReceiptLines(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	tests := []struct {
+		name    string
+		lines   []purchase.ReceiptLine
+		want    map[uuid.UUID]int
+		wantErr bool
+	}{
+		{name: "no lines", wantErr: true},
+		{name: "negative quantity", lines: []purchase.ReceiptLine{{ProductID: a, ReceivedQuantity: -1}}, wantErr: true},
+		{
+			name:    "nothing received",
+			lines:   []purchase.ReceiptLine{{ProductID: a}, {ProductID: b}},
+			wantErr: true,
+		},
+		{
+			name:  "some lines empty",
+			lines: []purchase.ReceiptLine{{ProductID: a}, {ProductID: b, ReceivedQuantity: 2}},
+			want:  map[uuid.UUID]int{a: 0, b: 2},
+		},
+		{
+			name:  "repeated product merged",
+			lines: []purchase.ReceiptLine{{ProductID: a, ReceivedQuantity: 1}, {ProductID: a, ReceivedQuantity: 4}},
+			want:  map[uuid.UUID]int{a: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeReceiptLines(tt.lines)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, model.ErrInvalidPurchaseOrder)
+				return
+			}
+			require.NoError(t, err)
+			byProduct := make(map[uuid.UUID]int, len(got))
+			for _, l := range got {
+				byProduct[l.ProductID] = l.ReceivedQuantity
+			}
+			assert.Equal(t, tt.want, byProduct)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package replenishment
+
+import (
+	"context"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/replenishment"
+
+	"github.com/google/uuid"
+)
+
+type ReplenishmentService interface {
+	GetProductPolicy(ctx context.Context, productID uuid.UUID) (replenishment.ProductPolicy, error)
+	SetProductPolicy(ctx context.Context, productID uuid.UUID, policy replenishment.Policy) (replenishment.ProductPolicy, error)
+	SetCategoryPolicy(ctx context.Context, categoryID uuid.UUID, policy replenishment.Policy) error
+	GetAlerts(ctx context.Context, status replenishment.AlertStatus, req page.Request) ([]replenishment.Alert, error)
+	Scan(ctx context.Context) (int, error)
+}
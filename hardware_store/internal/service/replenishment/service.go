@@ -0,0 +1,150 @@
+package replenishment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/replenishment"
+	"hardware_store/internal/model/tx"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// notifyBatch сколько оповещений отправляется получателям за один раз.
+const notifyBatch = 100
+
+// notifyLease на сколько пачка оповещений закрепляется за отправителем. Если
+// отправитель не отметил их за это время, пачку заберёт следующая сверка.
+const notifyLease = 5 * time.Minute
+
+type ReplenishmentRepository interface {
+	GetProductPolicy(ctx context.Context, productID uuid.UUID) (replenishment.ProductPolicy, error)
+	SetProductPolicy(ctx context.Context, productID uuid.UUID, policy replenishment.Policy) error
+	SetCategoryPolicy(ctx context.Context, categoryID uuid.UUID, policy replenishment.Policy) error
+	OpenAlerts(ctx context.Context, now time.Time) (int, error)
+	ResolveRecovered(ctx context.Context, now time.Time) (int, error)
+	GetUnnotifiedForUpdate(ctx context.Context, now time.Time, limit int) ([]replenishment.Alert, error)
+	Claim(ctx context.Context, ids []uuid.UUID, until time.Time) error
+	ReleaseClaim(ctx context.Context, ids []uuid.UUID) error
+	MarkNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	GetAlerts(ctx context.Context, status replenishment.AlertStatus, req page.Request) ([]replenishment.Alert, error)
+}
+
+// Notifier сообщает отделу закупок о новых оповещениях.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []replenishment.Alert) error
+}
+
+type replenishmentService struct {
+	repo     ReplenishmentRepository
+	notifier Notifier
+	tx       tx.Manager
+}
+
+func NewReplenishmentService(repo ReplenishmentRepository, notifier Notifier, tx tx.Manager) *replenishmentService {
+	return &replenishmentService{repo: repo, notifier: notifier, tx: tx}
+}
+
+func (s *replenishmentService) GetProductPolicy(ctx context.Context, productID uuid.UUID) (replenishment.ProductPolicy, error) {
+	return s.repo.GetProductPolicy(ctx, productID)
+}
+
+// SetProductPolicy заменяет точку заказа товара. Незаданные поля берутся из
+// политики категории.
+func (s *replenishmentService) SetProductPolicy(ctx context.Context, productID uuid.UUID, policy replenishment.Policy) (replenishment.ProductPolicy, error) {
+	if !policy.Valid() {
+		return replenishment.ProductPolicy{}, model.ErrInvalidReorderPolicy
+	}
+	var res replenishment.ProductPolicy
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetProductPolicy(ctx, productID, policy); err != nil {
+			return err
+		}
+		var err error
+		res, err = s.repo.GetProductPolicy(ctx, productID)
+		return err
+	})
+	return res, err
+}
+
+func (s *replenishmentService) SetCategoryPolicy(ctx context.Context, categoryID uuid.UUID, policy replenishment.Policy) error {
+	if !policy.Valid() {
+		return model.ErrInvalidReorderPolicy
+	}
+	return s.repo.SetCategoryPolicy(ctx, categoryID, policy)
+}
+
+func (s *replenishmentService) GetAlerts(ctx context.Context, status replenishment.AlertStatus, req page.Request) ([]replenishment.Alert, error) {
+	return s.repo.GetAlerts(ctx, status, req)
+}
+
+// Scan сверяет остатки с точками заказа: открывает оповещения по товарам ниже
+// точки заказа, закрывает восстановившиеся и отправляет получателям новые.
+// Пачка оповещений закрепляется за отправителем в транзакции, а отправляется
+// уже после неё, чтобы не держать блокировки на время сетевых запросов.
+// Оповещение считается отправленным только после успешной доставки, поэтому
+// при сбое получателя оно будет отправлено при следующем запуске.
+// Возвращает число открытых оповещений.
+func (s *replenishmentService) Scan(ctx context.Context) (int, error) {
+	var opened int
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if _, err := s.repo.ResolveRecovered(ctx, now); err != nil {
+			return err
+		}
+		var err error
+		opened, err = s.repo.OpenAlerts(ctx, now)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		n, err := s.notifyBatch(ctx)
+		if err != nil {
+			return opened, err
+		}
+		if n < notifyBatch {
+			return opened, nil
+		}
+	}
+}
+
+// notifyBatch закрепляет за собой пачку неотправленных оповещений, отправляет
+// её получателям и отмечает отправленной. Возвращает размер пачки.
+func (s *replenishmentService) notifyBatch(ctx context.Context) (int, error) {
+	var alerts []replenishment.Alert
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		alerts, err = s.repo.GetUnnotifiedForUpdate(ctx, now, notifyBatch)
+		if err != nil || len(alerts) == 0 {
+			return err
+		}
+		return s.repo.Claim(ctx, alertIDs(alerts), now.Add(notifyLease))
+	})
+	if err != nil || len(alerts) == 0 {
+		return 0, err
+	}
+
+	ids := alertIDs(alerts)
+	if err := s.notifier.Notify(ctx, alerts); err != nil {
+		if releaseErr := s.repo.ReleaseClaim(ctx, ids); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return 0, fmt.Errorf("ошибка отправки оповещений: %w", err)
+	}
+	return len(alerts), s.repo.MarkNotified(ctx, ids, time.Now())
+}
+
+func alertIDs(alerts []replenishment.Alert) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(alerts))
+	for _, a := range alerts {
+		ids = append(ids, a.AlertID)
+	}
+	return ids
+}
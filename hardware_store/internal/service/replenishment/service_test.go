@@ -0,0 +1,117 @@
+package replenishment
+
+import (
+	"context"
+	"errors"
+	"hardware_store/internal/model/replenishment"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inTxKey struct{}
+
+// fakeTx помечает контекст, чтобы проверить, что выполняется внутри транзакции.
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTxKey{}, true))
+}
+
+func inTx(ctx context.Context) bool {
+	v, _ := ctx.Value(inTxKey{}).(bool)
+	return v
+}
+
+// fakeRepo хранит неотправленные оповещения в памяти.
+type fakeRepo struct {
+	ReplenishmentRepository
+	pending  []replenishment.Alert
+	claimed  map[uuid.UUID]bool
+	notified map[uuid.UUID]bool
+}
+
+func newFakeRepo(n int) *fakeRepo {
+	r := &fakeRepo{claimed: map[uuid.UUID]bool{}, notified: map[uuid.UUID]bool{}}
+	for range n {
+		r.pending = append(r.pending, replenishment.Alert{AlertID: uuid.New()})
+	}
+	return r
+}
+
+func (r *fakeRepo) ResolveRecovered(context.Context, time.Time) (int, error) { return 0, nil }
+func (r *fakeRepo) OpenAlerts(context.Context, time.Time) (int, error)       { return 0, nil }
+
+func (r *fakeRepo) GetUnnotifiedForUpdate(_ context.Context, _ time.Time, limit int) ([]replenishment.Alert, error) {
+	var res []replenishment.Alert
+	for _, a := range r.pending {
+		if !r.claimed[a.AlertID] && !r.notified[a.AlertID] && len(res) < limit {
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) Claim(_ context.Context, ids []uuid.UUID, _ time.Time) error {
+	for _, id := range ids {
+		r.claimed[id] = true
+	}
+	return nil
+}
+
+func (r *fakeRepo) ReleaseClaim(_ context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		delete(r.claimed, id)
+	}
+	return nil
+}
+
+func (r *fakeRepo) MarkNotified(_ context.Context, ids []uuid.UUID, _ time.Time) error {
+	for _, id := range ids {
+		r.notified[id] = true
+		delete(r.claimed, id)
+	}
+	return nil
+}
+
+type fakeNotifier struct {
+	err      error
+	sent     int
+	inTxSeen bool
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, alerts []replenishment.Alert) error {
+	n.inTxSeen = n.inTxSeen || inTx(ctx)
+	if n.err != nil {
+		return n.err
+	}
+	n.sent += len(alerts)
+	return nil
+}
+
+func TestScanNotifiesOutsideTransaction(t *testing.T) {
+	repo := newFakeRepo(notifyBatch + 3)
+	n := &fakeNotifier{}
+	s := NewReplenishmentService(repo, n, fakeTx{})
+
+	_, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	assert.False(t, n.inTxSeen, "notifier must not be called inside a transaction")
+	assert.Equal(t, notifyBatch+3, n.sent)
+	assert.Len(t, repo.notified, notifyBatch+3)
+	assert.Empty(t, repo.claimed)
+}
+
+func TestScanReleasesClaimOnFailure(t *testing.T) {
+	repo := newFakeRepo(2)
+	failure := errors.New("webhook is down")
+	s := NewReplenishmentService(repo, &fakeNotifier{err: failure}, fakeTx{})
+
+	_, err := s.Scan(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.Empty(t, repo.notified)
+	assert.Empty(t, repo.claimed, "failed batch must be picked up by the next scan")
+}
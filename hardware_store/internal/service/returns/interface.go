@@ -0,0 +1,18 @@
+package returns
+
+import (
+	"context"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/returns"
+
+	"github.com/google/uuid"
+)
+
+type ReturnService interface {
+	CreateReturn(ctx context.Context, r returns.Return) (returns.Return, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, to returns.Status) (returns.Return, error)
+	MarkRefunded(ctx context.Context, id uuid.UUID) (returns.Return, error)
+	Inspect(ctx context.Context, id uuid.UUID, outcomes map[uuid.UUID]returns.Outcome) (returns.Return, error)
+	GetReturn(ctx context.Context, id uuid.UUID) (returns.Return, error)
+	GetReturns(ctx context.Context, filter returns.Filter, req page.Request) ([]returns.Return, error)
+}
@@ -0,0 +1,294 @@
+package returns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	ordermodel "hardware_store/internal/model/order"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/returns"
+	stockmodel "hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/order"
+	"hardware_store/internal/service/stock"
+	"hardware_store/internal/service/warehouse"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnRepository interface {
+	Insert(ctx context.Context, r returns.Return) error
+	GetById(ctx context.Context, id uuid.UUID) (returns.Return, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (returns.Return, error)
+	GetByOrder(ctx context.Context, orderID uuid.UUID) ([]returns.Return, error)
+	GetReturns(ctx context.Context, filter returns.Filter, req page.Request) ([]returns.Return, error)
+	UpdateStatus(ctx context.Context, r returns.Return) error
+	UpdateOutcomes(ctx context.Context, r returns.Return) error
+}
+
+type returnService struct {
+	repo      ReturnRepository
+	order     order.OrderService
+	stock     stock.StockService
+	warehouse warehouse.WarehouseService
+	tx        tx.Manager
+}
+
+func NewReturnService(repo ReturnRepository, order order.OrderService, stock stock.StockService,
+	warehouse warehouse.WarehouseService, tx tx.Manager) *returnService {
+	return &returnService{repo: repo, order: order, stock: stock, warehouse: warehouse, tx: tx}
+}
+
+// CreateReturn регистрирует заявку на возврат по доставленному заказу.
+// Позиция не может быть заявлена к возврату больше, чем на количество, ещё не
+// занятое другими действующими заявками. Сумма к возврату по позиции — доля
+// оплаченной суммы позиции после скидок и с налогом. Без склада товар будет
+// приниматься на склад по умолчанию.
+func (s *returnService) CreateReturn(ctx context.Context, r returns.Return) (returns.Return, error) {
+	if err := validateLines(r.Lines); err != nil {
+		return returns.Return{}, err
+	}
+
+	now := time.Now()
+	r.ReturnID = uuid.New()
+	r.Status = returns.StatusRequested
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	r.RefundedAt = nil
+	if claims, ok := auth.FromContext(ctx); ok {
+		r.ActorID = &claims.UserID
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		o, err := s.order.GetOrderForUpdate(ctx, r.OrderID)
+		if err != nil {
+			return err
+		}
+		if o.Status != ordermodel.StatusDelivered {
+			return fmt.Errorf("%w: cannot return items of %s order", model.ErrIllegalTransition, o.Status)
+		}
+		r.ClientID = o.ClientID
+
+		if r.WarehouseID == uuid.Nil {
+			w, err := s.warehouse.GetDefaultWarehouse(ctx)
+			if err != nil {
+				return err
+			}
+			r.WarehouseID = w.WarehouseID
+		} else if _, err := s.warehouse.GetWarehouse(ctx, r.WarehouseID); err != nil {
+			return err
+		}
+
+		existing, err := s.repo.GetByOrder(ctx, r.OrderID)
+		if err != nil {
+			return err
+		}
+		claimed := make(map[uuid.UUID]int)
+		for _, rt := range existing {
+			if !rt.Status.Active() {
+				continue
+			}
+			for _, l := range rt.Lines {
+				claimed[l.ItemID] += l.Quantity
+			}
+		}
+
+		for i, l := range r.Lines {
+			j := slices.IndexFunc(o.Items, func(item ordermodel.Item) bool { return item.ItemID == l.ItemID })
+			if j < 0 {
+				return fmt.Errorf("%w: item %s is not in order", model.ErrInvalidReturn, l.ItemID)
+			}
+			item := o.Items[j]
+			if left := item.Quantity - claimed[item.ItemID]; l.Quantity > left {
+				return fmt.Errorf("%w: item %s has %d units left to return", model.ErrInvalidReturn, item.ItemID, max(left, 0))
+			}
+			r.Lines[i].ProductID = item.ProductID
+			r.Lines[i].Name = item.Name
+			r.Lines[i].Outcome = ""
+			r.Lines[i].Refund = returns.RefundShare(o.TaxLine(item).Gross, item.Quantity, claimed[item.ItemID], l.Quantity)
+		}
+		r.CalcRefund()
+		return s.repo.Insert(ctx, r)
+	})
+	if err != nil {
+		return returns.Return{}, err
+	}
+	return r, nil
+}
+
+// ChangeStatus переводит заявку в новый статус по таблице ручных переходов.
+func (s *returnService) ChangeStatus(ctx context.Context, id uuid.UUID, to returns.Status) (returns.Return, error) {
+	if !to.Valid() {
+		return returns.Return{}, fmt.Errorf("%w: unknown status %q", model.ErrInvalidReturn, to)
+	}
+
+	var r returns.Return
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !r.Status.CanTransitionTo(to) {
+			return fmt.Errorf("%w: %s -> %s", model.ErrIllegalTransition, r.Status, to)
+		}
+		r.Status = to
+		r.UpdatedAt = time.Now()
+		return s.repo.UpdateStatus(ctx, r)
+	})
+	if err != nil {
+		return returns.Return{}, err
+	}
+	return r, nil
+}
+
+// MarkRefunded закрывает проверенную заявку возвратом денег и фиксирует его
+// время. Вызывается только возвратом денег по платежу в его транзакции.
+func (s *returnService) MarkRefunded(ctx context.Context, id uuid.UUID) (returns.Return, error) {
+	var r returns.Return
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !r.Status.Refundable() {
+			return fmt.Errorf("%w: cannot refund %s return", model.ErrIllegalTransition, r.Status)
+		}
+		r.Status = returns.StatusRefunded
+		r.UpdatedAt = time.Now()
+		r.RefundedAt = &r.UpdatedAt
+		return s.repo.UpdateStatus(ctx, r)
+	})
+	if err != nil {
+		return returns.Return{}, err
+	}
+	return r, nil
+}
+
+// Inspect записывает решения осмотра по всем позициям полученной заявки и
+// проводит движения остатков: товар приходуется на склад заявки возвратом, а
+// при списании или отправке поставщику тут же уходит со склада. Вернувшиеся
+// единицы отмечаются в позициях заказа. Всё выполняется в одной транзакции.
+func (s *returnService) Inspect(ctx context.Context, id uuid.UUID, outcomes map[uuid.UUID]returns.Outcome) (returns.Return, error) {
+	for itemID, outcome := range outcomes {
+		if !outcome.Valid() {
+			return returns.Return{}, fmt.Errorf("%w: unknown outcome %q for item %s", model.ErrInvalidReturn, outcome, itemID)
+		}
+	}
+
+	var r returns.Return
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if r.Status != returns.StatusReceived {
+			return fmt.Errorf("%w: cannot inspect %s return", model.ErrIllegalTransition, r.Status)
+		}
+		if len(outcomes) != len(r.Lines) {
+			return fmt.Errorf("%w: every line needs exactly one outcome", model.ErrInvalidReturn)
+		}
+
+		quantities := make(map[uuid.UUID]int, len(r.Lines))
+		for i, l := range r.Lines {
+			outcome, ok := outcomes[l.ItemID]
+			if !ok {
+				return fmt.Errorf("%w: no outcome for item %s", model.ErrInvalidReturn, l.ItemID)
+			}
+			r.Lines[i].Outcome = outcome
+			quantities[l.ItemID] = l.Quantity
+		}
+		if _, err := s.order.RegisterReturn(ctx, r.OrderID, quantities); err != nil {
+			return err
+		}
+		if err := s.recordMovements(ctx, r); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateOutcomes(ctx, r); err != nil {
+			return err
+		}
+
+		r.Status = returns.StatusInspected
+		r.UpdatedAt = time.Now()
+		return s.repo.UpdateStatus(ctx, r)
+	})
+	if err != nil {
+		return returns.Return{}, err
+	}
+	return r, nil
+}
+
+func (s *returnService) GetReturn(ctx context.Context, id uuid.UUID) (returns.Return, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *returnService) GetReturns(ctx context.Context, filter returns.Filter, req page.Request) ([]returns.Return, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", model.ErrInvalidReturn, filter.Status)
+	}
+	return s.repo.GetReturns(ctx, filter, req)
+}
+
+// recordMovements проводит движения по осмотренным позициям в порядке
+// product_id, чтобы не попадать во взаимную блокировку с заказами. Позиции
+// удалённых товаров пропускаются: проводить остаток некуда.
+func (s *returnService) recordMovements(ctx context.Context, r returns.Return) error {
+	lines := slices.Clone(r.Lines)
+	slices.SortFunc(lines, func(a, b returns.Line) int {
+		return bytes.Compare(a.ProductID[:], b.ProductID[:])
+	})
+	reason := "return " + r.ReturnID.String()
+	for _, l := range lines {
+		if l.ProductID == uuid.Nil {
+			continue
+		}
+		types := []stockmodel.MovementType{stockmodel.MovementReturn}
+		switch l.Outcome {
+		case returns.OutcomeWriteOff:
+			types = append(types, stockmodel.MovementWriteOff)
+		case returns.OutcomeSendToSupplier:
+			types = append(types, stockmodel.MovementSupplierReturn)
+		}
+		for _, t := range types {
+			_, _, err := s.stock.RecordMovement(ctx, stockmodel.Movement{
+				ProductID:   l.ProductID,
+				WarehouseID: r.WarehouseID,
+				Type:        t,
+				Quantity:    l.Quantity,
+				Reason:      reason,
+			})
+			if err != nil {
+				return fmt.Errorf("product %s: %w", l.ProductID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLines проверяет позиции заявки: каждая позиция заказа указывается
+// один раз, с положительным количеством и известной причиной.
+func validateLines(lines []returns.Line) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: return has no lines", model.ErrInvalidReturn)
+	}
+	seen := make(map[uuid.UUID]bool, len(lines))
+	for _, l := range lines {
+		if l.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be positive", model.ErrInvalidReturn)
+		}
+		if !l.Reason.Valid() {
+			return fmt.Errorf("%w: unknown reason %q", model.ErrInvalidReturn, l.Reason)
+		}
+		if seen[l.ItemID] {
+			return fmt.Errorf("%w: item %s is listed twice", model.ErrInvalidReturn, l.ItemID)
+		}
+		seen[l.ItemID] = true
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package returns
+
+import (
+	"context"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/returns"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeRepo struct {
+	ReturnRepository
+	returns map[uuid.UUID]returns.Return
+}
+
+func (r *fakeRepo) GetByIdForUpdate(_ context.Context, id uuid.UUID) (returns.Return, error) {
+	rt, ok := r.returns[id]
+	if !ok {
+		return returns.Return{}, model.ErrReturnNotFound
+	}
+	return rt, nil
+}
+
+func (r *fakeRepo) UpdateStatus(_ context.Context, rt returns.Return) error {
+	r.returns[rt.ReturnID] = rt
+	return nil
+}
+
+func newTestService(status returns.Status) (*returnService, *fakeRepo, uuid.UUID) {
+	id := uuid.New()
+	repo := &fakeRepo{returns: map[uuid.UUID]returns.Return{id: {ReturnID: id, Status: status}}}
+	return &returnService{repo: repo, tx: fakeTx{}}, repo, id
+}
+
+func TestChangeStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    returns.Status
+		to      returns.Status
+		wantErr error
+	}{
+		{name: "approve", from: returns.StatusRequested, to: returns.StatusApproved},
+		{name: "receive", from: returns.StatusApproved, to: returns.StatusReceived},
+		{name: "cancel approved", from: returns.StatusApproved, to: returns.StatusCancelled},
+		{name: "refund inspected manually", from: returns.StatusInspected, to: returns.StatusRefunded, wantErr: model.ErrIllegalTransition},
+		{name: "refund requested manually", from: returns.StatusRequested, to: returns.StatusRefunded, wantErr: model.ErrIllegalTransition},
+		{name: "inspect without inspection", from: returns.StatusReceived, to: returns.StatusInspected, wantErr: model.ErrIllegalTransition},
+		{name: "reopen rejected", from: returns.StatusRejected, to: returns.StatusRequested, wantErr: model.ErrIllegalTransition},
+		{name: "unknown status", from: returns.StatusRequested, to: "lost", wantErr: model.ErrInvalidReturn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, id := newTestService(tt.from)
+
+			got, err := s.ChangeStatus(context.Background(), id, tt.to)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.from, repo.returns[id].Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, got.Status)
+			assert.Equal(t, tt.to, repo.returns[id].Status)
+			assert.Nil(t, got.RefundedAt)
+		})
+	}
+}
+
+func TestMarkRefunded(t *testing.T) {
+	for _, from := range []returns.Status{returns.StatusRequested, returns.StatusApproved, returns.StatusRejected,
+		returns.StatusReceived, returns.StatusInspected, returns.StatusRefunded, returns.StatusCancelled} {
+		t.Run(string(from), func(t *testing.T) {
+			s, repo, id := newTestService(from)
+
+			got, err := s.MarkRefunded(context.Background(), id)
+			if from != returns.StatusInspected {
+				assert.ErrorIs(t, err, model.ErrIllegalTransition)
+				assert.Equal(t, from, repo.returns[id].Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, returns.StatusRefunded, got.Status)
+			require.NotNil(t, got.RefundedAt)
+			assert.Equal(t, got.UpdatedAt, *got.RefundedAt)
+			assert.Equal(t, got, repo.returns[id])
+		})
+	}
+
+	s, _, _ := newTestService(returns.StatusInspected)
+	_, err := s.MarkRefunded(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrReturnNotFound)
+}
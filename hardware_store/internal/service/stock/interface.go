@@ -0,0 +1,16 @@
+package stock
+
+import (
+	"context"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/stock"
+
+	"github.com/google/uuid"
+)
+
+type StockService interface {
+	RecordMovement(ctx context.Context, movement stock.Movement) (stock.Movement, int, error)
+	Transfer(ctx context.Context, transfer stock.Transfer) (stock.Transfer, error)
+	GetMovements(ctx context.Context, productID uuid.UUID, req page.Request) ([]stock.Movement, error)
+	Reconcile(ctx context.Context) ([]stock.Discrepancy, error)
+}
@@ -0,0 +1,134 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/service/warehouse"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockRepository interface {
+	AdjustStock(ctx context.Context, productID, warehouseID uuid.UUID, delta int) (int, error)
+	MoveStock(ctx context.Context, productID, from, to uuid.UUID, quantity int) error
+	InsertMovement(ctx context.Context, movement stock.Movement) error
+	InsertTransfer(ctx context.Context, transfer stock.Transfer) error
+	GetMovements(ctx context.Context, productID uuid.UUID, req page.Request) ([]stock.Movement, error)
+	Reconcile(ctx context.Context) ([]stock.Discrepancy, error)
+}
+
+type stockService struct {
+	repo      StockRepository
+	warehouse warehouse.WarehouseService
+	tx        tx.Manager
+}
+
+func NewStockService(repo StockRepository, warehouse warehouse.WarehouseService, tx tx.Manager) *stockService {
+	return &stockService{repo: repo, warehouse: warehouse, tx: tx}
+}
+
+// RecordMovement применяет движение к остатку товара и записывает его в журнал
+// в одной транзакции. Quantity для корректировки задаётся со знаком, для
+// остальных типов оно положительное. Без склада движение проводится по складу
+// по умолчанию. Возвращает записанное движение и новый общий остаток.
+func (s *stockService) RecordMovement(ctx context.Context, m stock.Movement) (stock.Movement, int, error) {
+	if !m.Type.Valid() {
+		return stock.Movement{}, 0, fmt.Errorf("%w: unknown movement type %q", model.ErrInvalidMovement, m.Type)
+	}
+	if m.Type == stock.MovementAdjustment {
+		if m.Quantity == 0 {
+			return stock.Movement{}, 0, fmt.Errorf("%w: adjustment quantity must not be zero", model.ErrInvalidMovement)
+		}
+	} else {
+		if m.Quantity <= 0 {
+			return stock.Movement{}, 0, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidMovement)
+		}
+		m.Quantity = m.Type.Delta(m.Quantity)
+	}
+
+	m.MovementID = uuid.New()
+	m.CreatedAt = time.Now()
+	if m.ActorID == nil {
+		m.ActorID = actorID(ctx)
+	}
+
+	var balance int
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		m.WarehouseID, err = s.resolveWarehouse(ctx, m.WarehouseID)
+		if err != nil {
+			return err
+		}
+		balance, err = s.repo.AdjustStock(ctx, m.ProductID, m.WarehouseID, m.Quantity)
+		if err != nil {
+			return err
+		}
+		return s.repo.InsertMovement(ctx, m)
+	})
+	if err != nil {
+		return stock.Movement{}, 0, err
+	}
+	return m, balance, nil
+}
+
+// Transfer перемещает товар между складами и записывает перемещение в одной
+// транзакции. Если на складе-источнике не хватает товара, ничего не меняется.
+func (s *stockService) Transfer(ctx context.Context, t stock.Transfer) (stock.Transfer, error) {
+	if t.Quantity <= 0 {
+		return stock.Transfer{}, fmt.Errorf("%w: quantity must be positive", model.ErrInvalidTransfer)
+	}
+	if t.FromWarehouseID == t.ToWarehouseID {
+		return stock.Transfer{}, fmt.Errorf("%w: source and destination warehouses must differ", model.ErrInvalidTransfer)
+	}
+
+	t.TransferID = uuid.New()
+	t.CreatedAt = time.Now()
+	t.ActorID = actorID(ctx)
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range []uuid.UUID{t.FromWarehouseID, t.ToWarehouseID} {
+			if _, err := s.warehouse.GetWarehouse(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.MoveStock(ctx, t.ProductID, t.FromWarehouseID, t.ToWarehouseID, t.Quantity); err != nil {
+			return err
+		}
+		return s.repo.InsertTransfer(ctx, t)
+	})
+	if err != nil {
+		return stock.Transfer{}, err
+	}
+	return t, nil
+}
+
+func (s *stockService) GetMovements(ctx context.Context, productID uuid.UUID, req page.Request) ([]stock.Movement, error) {
+	return s.repo.GetMovements(ctx, productID, req)
+}
+
+func (s *stockService) Reconcile(ctx context.Context) ([]stock.Discrepancy, error) {
+	return s.repo.Reconcile(ctx)
+}
+
+// resolveWarehouse проверяет склад движения, uuid.Nil заменяется складом по умолчанию.
+func (s *stockService) resolveWarehouse(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	if id == uuid.Nil {
+		w, err := s.warehouse.GetDefaultWarehouse(ctx)
+		return w.WarehouseID, err
+	}
+	w, err := s.warehouse.GetWarehouse(ctx, id)
+	return w.WarehouseID, err
+}
+
+func actorID(ctx context.Context) *uuid.UUID {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return &claims.UserID
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package stock
+
+import (
+	"context"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/stock"
+	"hardware_store/internal/model/warehouse"
+	warehousesvc "hardware_store/internal/service/warehouse"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mainWarehouse  = uuid.New()
+	otherWarehouse = uuid.New()
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type stockKey struct {
+	product   uuid.UUID
+	warehouse uuid.UUID
+}
+
+// fakeRepo хранит остатки по складам и журнал в памяти и, как хранилище,
+// не даёт остатку склада уйти в минус.
+type fakeRepo struct {
+	StockRepository
+	stock     map[stockKey]int
+	movements []stock.Movement
+	transfers []stock.Transfer
+}
+
+func (r *fakeRepo) total(productID uuid.UUID) int {
+	var total int
+	for k, q := range r.stock {
+		if k.product == productID {
+			total += q
+		}
+	}
+	return total
+}
+
+func (r *fakeRepo) AdjustStock(_ context.Context, productID, warehouseID uuid.UUID, delta int) (int, error) {
+	k := stockKey{productID, warehouseID}
+	if r.stock[k]+delta < 0 {
+		return 0, model.ErrInsufficientStock
+	}
+	r.stock[k] += delta
+	return r.total(productID), nil
+}
+
+func (r *fakeRepo) MoveStock(_ context.Context, productID, from, to uuid.UUID, quantity int) error {
+	src := stockKey{productID, from}
+	if r.stock[src] < quantity {
+		return model.ErrInsufficientStock
+	}
+	r.stock[src] -= quantity
+	r.stock[stockKey{productID, to}] += quantity
+	return nil
+}
+
+func (r *fakeRepo) InsertMovement(_ context.Context, m stock.Movement) error {
+	r.movements = append(r.movements, m)
+	return nil
+}
+
+func (r *fakeRepo) InsertTransfer(_ context.Context, t stock.Transfer) error {
+	r.transfers = append(r.transfers, t)
+	return nil
+}
+
+type fakeWarehouses struct {
+	warehousesvc.WarehouseService
+}
+
+func (fakeWarehouses) GetWarehouse(_ context.Context, id uuid.UUID) (warehouse.Warehouse, error) {
+	if id != mainWarehouse && id != otherWarehouse {
+		return warehouse.Warehouse{}, model.ErrWarehouseNotFound
+	}
+	return warehouse.Warehouse{WarehouseID: id}, nil
+}
+
+func (fakeWarehouses) GetDefaultWarehouse(context.Context) (warehouse.Warehouse, error) {
+	return warehouse.Warehouse{WarehouseID: mainWarehouse}, nil
+}
+
+func newTestService(productID uuid.UUID, onHand int) (*stockService, *fakeRepo) {
+	repo := &fakeRepo{stock: map[stockKey]int{{productID, mainWarehouse}: onHand}}
+	return NewStockService(repo, fakeWarehouses{}, fakeTx{}), repo
+}
+
+func TestMovementTypeDelta(t *testing.T) {
+	tests := []struct {
+		typ  stock.MovementType
+		want int
+	}{
+		{typ: stock.MovementReceipt, want: 5},
+		{typ: stock.MovementReturn, want: 5},
+		{typ: stock.MovementAdjustment, want: 5},
+		{typ: stock.MovementSale, want: -5},
+		{typ: stock.MovementWriteOff, want: -5},
+		{typ: stock.MovementSupplierReturn, want: -5},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			assert.True(t, tt.typ.Valid())
+			assert.Equal(t, tt.want, tt.typ.Delta(5))
+		})
+	}
+	assert.False(t, stock.MovementType("gift").Valid())
+}
+
+func TestRecordMovement(t *testing.T) {
+	tests := []struct {
+		name      string
+		movement  stock.Movement
+		wantErr   error
+		wantDelta int
+		balance   int
+	}{
+		{name: "receipt adds stock", movement: stock.Movement{Type: stock.MovementReceipt, Quantity: 4}, wantDelta: 4, balance: 14},
+		{name: "sale removes stock", movement: stock.Movement{Type: stock.MovementSale, Quantity: 3}, wantDelta: -3, balance: 7},
+		{name: "write off whole stock", movement: stock.Movement{Type: stock.MovementWriteOff, Quantity: 10}, wantDelta: -10, balance: 0},
+		{name: "negative adjustment keeps sign", movement: stock.Movement{Type: stock.MovementAdjustment, Quantity: -2}, wantDelta: -2, balance: 8},
+		{name: "sale beyond stock", movement: stock.Movement{Type: stock.MovementSale, Quantity: 11}, wantErr: model.ErrInsufficientStock},
+		{name: "adjustment below zero", movement: stock.Movement{Type: stock.MovementAdjustment, Quantity: -11}, wantErr: model.ErrInsufficientStock},
+		{name: "zero adjustment", movement: stock.Movement{Type: stock.MovementAdjustment}, wantErr: model.ErrInvalidMovement},
+		{name: "negative receipt", movement: stock.Movement{Type: stock.MovementReceipt, Quantity: -1}, wantErr: model.ErrInvalidMovement},
+		{name: "unknown type", movement: stock.Movement{Type: "gift", Quantity: 1}, wantErr: model.ErrInvalidMovement},
+		{name: "unknown warehouse", movement: stock.Movement{Type: stock.MovementReceipt, Quantity: 1, WarehouseID: uuid.New()}, wantErr: model.ErrWarehouseNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productID := uuid.New()
+			s, repo := newTestService(productID, 10)
+			tt.movement.ProductID = productID
+
+			m, balance, err := s.RecordMovement(context.Background(), tt.movement)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.movements, "rejected movement must not reach the ledger")
+				assert.Equal(t, 10, repo.total(productID))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.balance, balance)
+			assert.Equal(t, tt.wantDelta, m.Quantity)
+			assert.Equal(t, mainWarehouse, m.WarehouseID, "default warehouse is used")
+			require.Len(t, repo.movements, 1)
+			assert.Equal(t, m, repo.movements[0])
+		})
+	}
+}
+
+func TestRecordMovementActor(t *testing.T) {
+	productID, userID := uuid.New(), uuid.New()
+	s, _ := newTestService(productID, 0)
+	ctx := auth.WithClaims(context.Background(), auth.Claims{UserID: userID})
+
+	m, _, err := s.RecordMovement(ctx, stock.Movement{ProductID: productID, Type: stock.MovementReceipt, Quantity: 1})
+	require.NoError(t, err)
+	require.NotNil(t, m.ActorID)
+	assert.Equal(t, userID, *m.ActorID)
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to uuid.UUID
+		quantity int
+		wantErr  error
+	}{
+		{name: "moves stock", from: mainWarehouse, to: otherWarehouse, quantity: 6},
+		{name: "whole stock", from: mainWarehouse, to: otherWarehouse, quantity: 10},
+		{name: "more than source holds", from: mainWarehouse, to: otherWarehouse, quantity: 11, wantErr: model.ErrInsufficientStock},
+		{name: "same warehouse", from: mainWarehouse, to: mainWarehouse, quantity: 1, wantErr: model.ErrInvalidTransfer},
+		{name: "zero quantity", from: mainWarehouse, to: otherWarehouse, wantErr: model.ErrInvalidTransfer},
+		{name: "unknown destination", from: mainWarehouse, to: uuid.New(), quantity: 1, wantErr: model.ErrWarehouseNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productID := uuid.New()
+			s, repo := newTestService(productID, 10)
+
+			_, err := s.Transfer(context.Background(), stock.Transfer{ProductID: productID,
+				FromWarehouseID: tt.from, ToWarehouseID: tt.to, Quantity: tt.quantity})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.transfers)
+				assert.Equal(t, 10, repo.stock[stockKey{productID, mainWarehouse}])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 10-tt.quantity, repo.stock[stockKey{productID, mainWarehouse}])
+			assert.Equal(t, tt.quantity, repo.stock[stockKey{productID, otherWarehouse}])
+			assert.Equal(t, 10, repo.total(productID), "transfer keeps the total stock")
+			assert.Len(t, repo.transfers, 1)
+		})
+	}
+}
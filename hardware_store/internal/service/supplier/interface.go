@@ -3,6 +3,7 @@ package supplier
 import (
 	"context"
 	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/supplier"
 
 	"github.com/google/uuid"
@@ -13,5 +14,10 @@ type SupplierService interface {
 	DeleteSupplier(ctx context.Context, id uuid.UUID) error
 	UpdateAddressSupplier(ctx context.Context, id uuid.UUID, address address.Address) error
 	GetSupplier(ctx context.Context, id uuid.UUID) (supplier.Supplier, error)
-	GetSuppliers(ctx context.Context) ([]supplier.Supplier, error)
+	GetSuppliers(ctx context.Context, req page.Request) ([]supplier.Supplier, error)
+	SetSupplierProduct(ctx context.Context, product supplier.Product) (supplier.Product, error)
+	RemoveSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID) error
+	GetSupplierProducts(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]supplier.Product, error)
+	GetProductOffers(ctx context.Context, productID uuid.UUID) ([]supplier.Product, error)
+	GetBestOffer(ctx context.Context, query supplier.OfferQuery) (supplier.Product, error)
 }
@@ -3,9 +3,12 @@ package supplier
 import (
 	"context"
 	"hardware_store/internal/model/address"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/supplier"
 	"hardware_store/internal/model/tx"
 	service "hardware_store/internal/service/address"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,8 +18,14 @@ type SupplierRepository interface {
 	UpdateAddress(ctx context.Context, id uuid.UUID, addr address.Address) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetById(ctx context.Context, id uuid.UUID) (supplier.Supplier, error)
-	GetAll(ctx context.Context) ([]supplier.Supplier, error)
+	GetAll(ctx context.Context, req page.Request) ([]supplier.Supplier, error)
 	UnsetAddress(ctx context.Context, addressId uuid.UUID) error
+	UpsertProduct(ctx context.Context, product supplier.Product) error
+	DeleteProduct(ctx context.Context, supplierID, productID uuid.UUID) error
+	GetProduct(ctx context.Context, supplierID, productID uuid.UUID) (supplier.Product, error)
+	GetProducts(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]supplier.Product, error)
+	GetOffers(ctx context.Context, productID uuid.UUID) ([]supplier.Product, error)
+	GetBestOffer(ctx context.Context, query supplier.OfferQuery) (supplier.Product, error)
 }
 
 type AddressRepository interface {
@@ -65,6 +74,63 @@ func (s *supplierService) UpdateAddressSupplier(ctx context.Context, id uuid.UUI
 func (s *supplierService) GetSupplier(ctx context.Context, id uuid.UUID) (supplier.Supplier, error) {
 	return s.repo.GetById(ctx, id)
 }
-func (s *supplierService) GetSuppliers(ctx context.Context) ([]supplier.Supplier, error) {
-	return s.repo.GetAll(ctx)
+func (s *supplierService) GetSuppliers(ctx context.Context, req page.Request) ([]supplier.Supplier, error) {
+	return s.repo.GetAll(ctx, req)
+}
+
+// SetSupplierProduct добавляет товар в прайс-лист поставщика или заменяет
+// его условия. Без валюты цена считается в DefaultCurrency, без минимальной
+// партии товар заказывается поштучно.
+func (s *supplierService) SetSupplierProduct(ctx context.Context, p supplier.Product) (supplier.Product, error) {
+	if p.PurchasePrice.Currency == "" {
+		p.PurchasePrice.Currency = supplier.DefaultCurrency
+	}
+	if p.MinOrderQuantity == 0 {
+		p.MinOrderQuantity = 1
+	}
+	if !p.Valid() {
+		return supplier.Product{}, model.ErrInvalidSupplierProduct
+	}
+	p.UpdatedAt = time.Now()
+
+	var res supplier.Product
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetById(ctx, p.SupplierID); err != nil {
+			return err
+		}
+		if err := s.repo.UpsertProduct(ctx, p); err != nil {
+			return err
+		}
+		var err error
+		res, err = s.repo.GetProduct(ctx, p.SupplierID, p.ProductID)
+		return err
+	})
+	return res, err
+}
+
+func (s *supplierService) RemoveSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID) error {
+	return s.repo.DeleteProduct(ctx, supplierID, productID)
+}
+
+func (s *supplierService) GetSupplierProducts(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]supplier.Product, error) {
+	return s.repo.GetProducts(ctx, supplierID, req)
+}
+
+func (s *supplierService) GetProductOffers(ctx context.Context, productID uuid.UUID) ([]supplier.Product, error) {
+	return s.repo.GetOffers(ctx, productID)
+}
+
+// GetBestOffer выбирает поставщика товара: по умолчанию самого дешёвого, при
+// равной цене — с меньшим сроком поставки.
+func (s *supplierService) GetBestOffer(ctx context.Context, q supplier.OfferQuery) (supplier.Product, error) {
+	if q.Currency == "" {
+		q.Currency = supplier.DefaultCurrency
+	}
+	if q.Quantity <= 0 {
+		q.Quantity = 1
+	}
+	if q.Prefer == "" {
+		q.Prefer = supplier.PreferPrice
+	}
+	return s.repo.GetBestOffer(ctx, q)
 }
@@ -0,0 +1,113 @@
+package supplier
+
+import (
+	"context"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/supplier"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var knownSupplier = uuid.New()
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeRepo хранит прайс-лист в памяти и запоминает последний запрос лучшего
+// предложения.
+type fakeRepo struct {
+	SupplierRepository
+	products map[uuid.UUID]supplier.Product
+	query    supplier.OfferQuery
+}
+
+func (r *fakeRepo) GetById(_ context.Context, id uuid.UUID) (supplier.Supplier, error) {
+	if id != knownSupplier {
+		return supplier.Supplier{}, model.ErrSupplierNotFound
+	}
+	return supplier.Supplier{SupplierID: id}, nil
+}
+
+func (r *fakeRepo) UpsertProduct(_ context.Context, p supplier.Product) error {
+	r.products[p.ProductID] = p
+	return nil
+}
+
+func (r *fakeRepo) GetProduct(_ context.Context, _, productID uuid.UUID) (supplier.Product, error) {
+	return r.products[productID], nil
+}
+
+func (r *fakeRepo) GetBestOffer(_ context.Context, q supplier.OfferQuery) (supplier.Product, error) {
+	r.query = q
+	return supplier.Product{}, nil
+}
+
+func TestSetSupplierProduct(t *testing.T) {
+	tests := []struct {
+		name     string
+		product  supplier.Product
+		wantErr  error
+		currency string
+		minOrder int
+	}{
+		{name: "defaults", product: supplier.Product{SupplierID: knownSupplier, PurchasePrice: money.Money{Amount: 1000}},
+			currency: supplier.DefaultCurrency, minOrder: 1},
+		{name: "explicit terms", product: supplier.Product{SupplierID: knownSupplier, PurchasePrice: money.New(1000, "USD"), MinOrderQuantity: 12},
+			currency: "USD", minOrder: 12},
+		{name: "negative price", product: supplier.Product{SupplierID: knownSupplier, PurchasePrice: money.Money{Amount: -1}},
+			wantErr: model.ErrInvalidSupplierProduct},
+		{name: "negative min order", product: supplier.Product{SupplierID: knownSupplier, MinOrderQuantity: -1},
+			wantErr: model.ErrInvalidSupplierProduct},
+		{name: "unknown supplier", product: supplier.Product{SupplierID: uuid.New()}, wantErr: model.ErrSupplierNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{products: map[uuid.UUID]supplier.Product{}}
+			s := NewSupplierService(repo, nil, fakeTx{})
+			tt.product.ProductID = uuid.New()
+
+			got, err := s.SetSupplierProduct(context.Background(), tt.product)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.products)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.currency, got.PurchasePrice.Currency)
+			assert.Equal(t, tt.minOrder, got.MinOrderQuantity)
+			assert.False(t, got.UpdatedAt.IsZero())
+		})
+	}
+}
+
+func TestGetBestOfferDefaults(t *testing.T) {
+	days := 5
+	tests := []struct {
+		name  string
+		query supplier.OfferQuery
+		want  supplier.OfferQuery
+	}{
+		{name: "empty", query: supplier.OfferQuery{},
+			want: supplier.OfferQuery{Quantity: 1, Currency: supplier.DefaultCurrency, Prefer: supplier.PreferPrice}},
+		{name: "negative quantity", query: supplier.OfferQuery{Quantity: -3},
+			want: supplier.OfferQuery{Quantity: 1, Currency: supplier.DefaultCurrency, Prefer: supplier.PreferPrice}},
+		{name: "kept as given", query: supplier.OfferQuery{Quantity: 50, Currency: "USD", MaxLeadTimeDays: &days, Prefer: supplier.PreferLeadTime},
+			want: supplier.OfferQuery{Quantity: 50, Currency: "USD", MaxLeadTimeDays: &days, Prefer: supplier.PreferLeadTime}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			s := NewSupplierService(repo, nil, fakeTx{})
+			_, err := s.GetBestOffer(context.Background(), tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, repo.query)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package warehouse
+
+import (
+	"context"
+	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/warehouse"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, warehouse warehouse.Warehouse, address address.Address) error
+	GetWarehouse(ctx context.Context, id uuid.UUID) (warehouse.Warehouse, error)
+	GetDefaultWarehouse(ctx context.Context) (warehouse.Warehouse, error)
+	GetWarehouses(ctx context.Context, req page.Request) ([]warehouse.Warehouse, error)
+}
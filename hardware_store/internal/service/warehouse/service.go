@@ -0,0 +1,51 @@
+package warehouse
+
+import (
+	"context"
+	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/tx"
+	"hardware_store/internal/model/warehouse"
+	service "hardware_store/internal/service/address"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseRepository interface {
+	Insert(ctx context.Context, warehouse warehouse.Warehouse) error
+	GetById(ctx context.Context, id uuid.UUID) (warehouse.Warehouse, error)
+	GetDefault(ctx context.Context) (warehouse.Warehouse, error)
+	GetAll(ctx context.Context, req page.Request) ([]warehouse.Warehouse, error)
+}
+
+type warehouseService struct {
+	repo  WarehouseRepository
+	addrr service.AddressService
+	tx    tx.Manager
+}
+
+func NewWarehouseService(repo WarehouseRepository, addrr service.AddressService, tx tx.Manager) *warehouseService {
+	return &warehouseService{repo: repo, addrr: addrr, tx: tx}
+}
+
+func (s *warehouseService) CreateWarehouse(ctx context.Context, w warehouse.Warehouse, addr address.Address) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.addrr.CreateAddress(ctx, addr); err != nil {
+			return err
+		}
+		w.AddressID = &addr.AddressID
+		return s.repo.Insert(ctx, w)
+	})
+}
+
+func (s *warehouseService) GetWarehouse(ctx context.Context, id uuid.UUID) (warehouse.Warehouse, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *warehouseService) GetDefaultWarehouse(ctx context.Context) (warehouse.Warehouse, error) {
+	return s.repo.GetDefault(ctx)
+}
+
+func (s *warehouseService) GetWarehouses(ctx context.Context, req page.Request) ([]warehouse.Warehouse, error) {
+	return s.repo.GetAll(ctx, req)
+}
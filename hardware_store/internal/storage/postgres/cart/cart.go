@@ -0,0 +1,167 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/cart"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const cartColumns = `cart_id, client_id, token, created_at, updated_at`
+
+type cartRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCartRepository(db *pgxpool.Pool) *cartRepository {
+	return &cartRepository{
+		pool: db,
+	}
+}
+
+func (r *cartRepository) Insert(ctx context.Context, c cart.Cart) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO carts (` + cartColumns + `) VALUES ($1,$2,$3,$4,$5)`
+
+	d := mapper.CartToDTO(c)
+	if _, err := exec.Exec(ctx, query, d.CartID, d.ClientID, d.Token, d.CreatedAt, d.UpdatedAt); err != nil {
+		return fmt.Errorf("ошибка создания корзины: %w", err)
+	}
+	return nil
+}
+
+// GetByToken возвращает корзину, изменявшуюся не раньше activeSince.
+// Более старые корзины считаются истёкшими.
+func (r *cartRepository) GetByToken(ctx context.Context, token string, activeSince time.Time) (cart.Cart, error) {
+	return r.getOne(ctx, `SELECT `+cartColumns+` FROM carts WHERE token = $1 AND updated_at >= $2 FOR UPDATE`,
+		token, activeSince)
+}
+
+func (r *cartRepository) GetByClient(ctx context.Context, clientID uuid.UUID, activeSince time.Time) (cart.Cart, error) {
+	return r.getOne(ctx, `SELECT `+cartColumns+` FROM carts WHERE client_id = $1 AND updated_at >= $2 FOR UPDATE`,
+		clientID, activeSince)
+}
+
+// AddItem добавляет товар в корзину, увеличивая количество, если товар уже есть.
+func (r *cartRepository) AddItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO cart_items (cart_id, product_id, quantity, added_at)
+	VALUES ($1,$2,$3,NOW())
+	ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity`
+
+	if _, err := exec.Exec(ctx, query, cartID, productID, quantity); err != nil {
+		return fmt.Errorf("ошибка добавления товара в корзину: %w", err)
+	}
+	return nil
+}
+
+func (r *cartRepository) SetItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE cart_items SET quantity = $3 WHERE cart_id = $1 AND product_id = $2`
+
+	tag, err := exec.Exec(ctx, query, cartID, productID, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения позиции корзины: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *cartRepository) RemoveItem(ctx context.Context, cartID, productID uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2`
+
+	tag, err := exec.Exec(ctx, query, cartID, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления позиции корзины: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+// Touch продлевает жизнь корзины.
+func (r *cartRepository) Touch(ctx context.Context, cartID uuid.UUID, at time.Time) error {
+	exec := tx.FromContext(ctx, r.pool)
+	if _, err := exec.Exec(ctx, `UPDATE carts SET updated_at = $2 WHERE cart_id = $1`, cartID, at); err != nil {
+		return fmt.Errorf("ошибка обновления корзины: %w", err)
+	}
+	return nil
+}
+
+func (r *cartRepository) Delete(ctx context.Context, cartID uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	if _, err := exec.Exec(ctx, `DELETE FROM carts WHERE cart_id = $1`, cartID); err != nil {
+		return fmt.Errorf("ошибка удаления корзины: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет корзины, не изменявшиеся с before, и возвращает их число.
+func (r *cartRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM carts WHERE updated_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка удаления истёкших корзин: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *cartRepository) getOne(ctx context.Context, query string, args ...any) (cart.Cart, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.CartDTO
+	err := exec.QueryRow(ctx, query, args...).Scan(&d.CartID, &d.ClientID, &d.Token, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return cart.Cart{}, storage.ErrCartNotFound
+		}
+		return cart.Cart{}, fmt.Errorf("ошибка получения корзины: %w", err)
+	}
+
+	c := mapper.CartFromDTO(d)
+	c.Items, err = r.getItems(ctx, exec, c.CartID)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+	return c, nil
+}
+
+// getItems загружает позиции вместе с текущими ценой и остатком товара.
+func (r *cartRepository) getItems(ctx context.Context, exec tx.Executer, cartID uuid.UUID) ([]cart.Item, error) {
+	query := `SELECT ci.product_id, p.name, ci.quantity, p.price, p.available_stock - p.reserved_stock, ci.added_at
+	FROM cart_items ci
+	JOIN product p ON p.product_id = ci.product_id
+	WHERE ci.cart_id = $1
+	ORDER BY ci.added_at, ci.product_id`
+
+	row, err := exec.Query(ctx, query, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения позиций корзины: %w", err)
+	}
+	defer row.Close()
+	var items []cart.Item
+	for row.Next() {
+		var d dto.CartItemDTO
+
+		if err := row.Scan(&d.ProductID, &d.Name, &d.Quantity, &d.Price, &d.AvailableStock, &d.AddedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		items = append(items, mapper.CartItemFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return items, nil
+}
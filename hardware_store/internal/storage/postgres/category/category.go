@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"hardware_store/internal/model/category"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
 	"hardware_store/internal/storage/postgres/dto"
 	"hardware_store/internal/storage/postgres/mapper"
 	"hardware_store/internal/storage/postgres/tx"
@@ -15,6 +17,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var categoryKeyset = postgres.Keyset{Key: "category", ID: "category_id", Cast: "text"}
+
 type categoryRepository struct {
 	pool *pgxpool.Pool
 }
@@ -27,11 +31,11 @@ func NewCategoryRepository(db *pgxpool.Pool) *categoryRepository {
 
 func (r *categoryRepository) Insert(ctx context.Context, category category.Category) error {
 	query := `INSERT INTO category
-	(category_id, category)
-	VALUES ($1, $2)`
+	(category_id, category, tax_rate)
+	VALUES ($1, $2, $3)`
 
 	dto := mapper.CategoryToDTO(category)
-	_, err := r.pool.Exec(ctx, query, dto.CategoryID, dto.Category)
+	_, err := r.pool.Exec(ctx, query, dto.CategoryID, dto.Category, dto.TaxRate)
 	if err != nil {
 		return storage.ErrCreation
 	}
@@ -50,12 +54,12 @@ func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *categoryRepository) GetById(ctx context.Context, id uuid.UUID) (category.Category, error) {
-	query := `SELECT * FROM category 
+	query := `SELECT category_id, category, tax_rate FROM category 
 	WHERE category_id = $1`
 
 	var dto dto.CategoryDTO
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.CategoryID, &dto.Category)
+	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.CategoryID, &dto.Category, &dto.TaxRate)
 	if err != nil {
 		return category.Category{}, storage.ErrCategoryNotFound
 	}
@@ -63,10 +67,10 @@ func (r *categoryRepository) GetById(ctx context.Context, id uuid.UUID) (categor
 	return mapper.CategoryFromDTO(dto), nil
 }
 
-func (r *categoryRepository) GetAll(ctx context.Context) ([]category.Category, error) {
-	query := `SELECT * FROM category`
+func (r *categoryRepository) GetAll(ctx context.Context, req page.Request) ([]category.Category, error) {
+	query, args := categoryKeyset.Apply(`SELECT category_id, category, tax_rate FROM category`, nil, nil, req)
 
-	row, err := r.pool.Query(ctx, query)
+	row, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return []category.Category{}, storage.ErrCategoryNotFound
 	}
@@ -75,7 +79,7 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]category.Category, e
 	for row.Next() {
 		var dto dto.CategoryDTO
 
-		if err := row.Scan(&dto.CategoryID, &dto.Category); err != nil {
+		if err := row.Scan(&dto.CategoryID, &dto.Category, &dto.TaxRate); err != nil {
 			return []category.Category{}, fmt.Errorf("ошибка сканирования строки: %w", err)
 		}
 		categories = append(categories, mapper.CategoryFromDTO(dto))
@@ -90,14 +94,14 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]category.Category, e
 
 func (r *categoryRepository) Update(ctx context.Context, cat category.Category) (category.Category, error) {
 	query := `UPDATE category 
-	SET category = $2
+	SET category = $2, tax_rate = $3
 	WHERE category_id = $1
-	RETURNING category_id, category`
+	RETURNING category_id, category, tax_rate`
 
 	categoryDTO := mapper.CategoryToDTO(cat)
 	var dtoUPD dto.CategoryDTO
 
-	err := r.pool.QueryRow(ctx, query, categoryDTO.CategoryID, categoryDTO.Category).Scan(&dtoUPD.CategoryID, &dtoUPD.Category)
+	err := r.pool.QueryRow(ctx, query, categoryDTO.CategoryID, categoryDTO.Category, categoryDTO.TaxRate).Scan(&dtoUPD.CategoryID, &dtoUPD.Category, &dtoUPD.TaxRate)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return category.Category{}, storage.ErrCategoryNotFound
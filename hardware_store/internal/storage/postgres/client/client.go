@@ -2,19 +2,25 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hardware_store/internal/model/address"
 	"hardware_store/internal/model/client"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
 	"hardware_store/internal/storage/postgres/dto"
 	"hardware_store/internal/storage/postgres/mapper"
 	"hardware_store/internal/storage/postgres/tx"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// clientKeyset клиенты выдаются в порядке регистрации
+var clientKeyset = postgres.Keyset{Key: "registration_date", ID: "client_id", Cast: "timestamptz"}
+
 type clientRepository struct {
 	pool *pgxpool.Pool
 }
@@ -27,8 +33,8 @@ func NewClientRepository(db *pgxpool.Pool) *clientRepository {
 
 func (r *clientRepository) Insert(ctx context.Context, client client.Client) error {
 	query := `INSERT INTO client 
-	(client_id, name, surname, birthday, gender, registration_date, address_id)
-	VALUES ($1,$2,$3,$4,$5,$6,$7)
+	(client_id, name, surname, birthday, gender, registration_date, address_id, user_id)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
 	ON CONFLICT (client_id) DO UPDATE SET
     name = $2,
     surname = $3,
@@ -36,9 +42,14 @@ func (r *clientRepository) Insert(ctx context.Context, client client.Client) err
 	gender = $5,
 	registration_date = NOW(),
 	address_id = $7`
+	exec := tx.FromContext(ctx, r.pool)
 	dto := mapper.ClientToDTO(client)
-	_, err := r.pool.Exec(ctx, query, dto.ClientID, dto.Name, dto.Surname, dto.Birthday, dto.Gender, dto.RegistrationDate, dto.AddressID)
+	_, err := exec.Exec(ctx, query, dto.ClientID, dto.Name, dto.Surname, dto.Birthday, dto.Gender, dto.RegistrationDate, dto.AddressID, dto.UserID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return storage.ErrClientExists
+		}
 		return storage.ErrCreation
 	}
 
@@ -56,12 +67,12 @@ func (r *clientRepository) Delete(ctx context.Context, clientID uuid.UUID) error
 }
 
 func (r *clientRepository) GetByName(ctx context.Context, name, surname string) (client.Client, error) {
-	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id FROM client 
+	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id, user_id FROM client 
 	WHERE name = $1 AND surname = $2`
 
 	var dto dto.ClientDTO
 
-	err := r.pool.QueryRow(ctx, query, name, surname).Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID)
+	err := r.pool.QueryRow(ctx, query, name, surname).Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID, &dto.UserID)
 	if err != nil {
 		return client.Client{}, storage.ErrClientNotFound
 	}
@@ -69,31 +80,36 @@ func (r *clientRepository) GetByName(ctx context.Context, name, surname string)
 }
 
 func (r *clientRepository) GetById(ctx context.Context, id uuid.UUID) (client.Client, error) {
-	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id FROM client 
+	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id, user_id FROM client 
 	WHERE client_id = $1`
 
 	var dto dto.ClientDTO
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID)
+	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID, &dto.UserID)
 	if err != nil {
 		return client.Client{}, storage.ErrClientNotFound
 	}
 	return mapper.ClientFromDTO(dto), nil
 }
 
-func (r *clientRepository) GetAll(ctx context.Context, limit, offset int) ([]client.Client, error) {
-	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id FROM client`
+// GetByUser возвращает клиента, принадлежащего пользователю auth-service.
+func (r *clientRepository) GetByUser(ctx context.Context, userID uuid.UUID) (client.Client, error) {
+	query := `SELECT client_id, name, surname, birthday, gender, registration_date, address_id, user_id FROM client 
+	WHERE user_id = $1`
 
-	var row pgx.Rows
-	var err error
+	var dto dto.ClientDTO
 
-	if limit > 0 {
-		query += ` LIMIT $1 OFFSET $2`
-		row, err = r.pool.Query(ctx, query, limit, offset)
-	} else {
-		row, err = r.pool.Query(ctx, query)
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID, &dto.UserID)
+	if err != nil {
+		return client.Client{}, storage.ErrClientNotFound
 	}
+	return mapper.ClientFromDTO(dto), nil
+}
+
+func (r *clientRepository) GetAll(ctx context.Context, req page.Request) ([]client.Client, error) {
+	query, args := clientKeyset.Apply(`SELECT client_id, name, surname, birthday, gender, registration_date, address_id, user_id FROM client`, nil, nil, req)
 
+	row, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return []client.Client{}, storage.ErrClientNotFound
 	}
@@ -103,7 +119,7 @@ func (r *clientRepository) GetAll(ctx context.Context, limit, offset int) ([]cli
 	for row.Next() {
 		var dto dto.ClientDTO
 
-		if err := row.Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID); err != nil {
+		if err := row.Scan(&dto.ClientID, &dto.Name, &dto.Surname, &dto.Birthday, &dto.Gender, &dto.RegistrationDate, &dto.AddressID, &dto.UserID); err != nil {
 			return []client.Client{}, fmt.Errorf("ошибка сканирования строки: %w", err)
 		}
 		clients = append(clients, mapper.ClientFromDTO(dto))
@@ -0,0 +1,148 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/currency"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var rateKeyset = postgres.Keyset{Key: "effective_date", ID: "rate_id", Cast: "date", Desc: true}
+
+const (
+	currencyColumns = `code, name, decimals, rounding`
+	rateColumns     = `rate_id, currency_code, rate::text, effective_date, created_at`
+)
+
+type currencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCurrencyRepository(db *pgxpool.Pool) *currencyRepository {
+	return &currencyRepository{
+		pool: db,
+	}
+}
+
+func (r *currencyRepository) GetAll(ctx context.Context) ([]currency.Currency, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	rows, err := exec.Query(ctx, `SELECT `+currencyColumns+` FROM currencies ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения валют: %w", err)
+	}
+	defer rows.Close()
+	var currencies []currency.Currency
+	for rows.Next() {
+		var d dto.CurrencyDTO
+		if err := rows.Scan(&d.Code, &d.Name, &d.Decimals, &d.Rounding); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		currencies = append(currencies, mapper.CurrencyFromDTO(d))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return currencies, nil
+}
+
+func (r *currencyRepository) GetByCode(ctx context.Context, code string) (currency.Currency, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.CurrencyDTO
+	err := exec.QueryRow(ctx, `SELECT `+currencyColumns+` FROM currencies WHERE code = $1`, code).
+		Scan(&d.Code, &d.Name, &d.Decimals, &d.Rounding)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return currency.Currency{}, storage.ErrCurrencyNotFound
+	}
+	if err != nil {
+		return currency.Currency{}, fmt.Errorf("ошибка получения валюты: %w", err)
+	}
+	return mapper.CurrencyFromDTO(d), nil
+}
+
+// Upsert добавляет валюту или меняет её название и правила округления.
+func (r *currencyRepository) Upsert(ctx context.Context, c currency.Currency) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO currencies (` + currencyColumns + `) VALUES ($1,$2,$3,$4)
+	ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, decimals = EXCLUDED.decimals, rounding = EXCLUDED.rounding`
+
+	d := mapper.CurrencyToDTO(c)
+	if _, err := exec.Exec(ctx, query, d.Code, d.Name, d.Decimals, d.Rounding); err != nil {
+		return fmt.Errorf("ошибка сохранения валюты: %w", err)
+	}
+	return nil
+}
+
+// UpsertRate сохраняет курс. Курс той же валюты на ту же дату заменяется.
+func (r *currencyRepository) UpsertRate(ctx context.Context, rate currency.ExchangeRate) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO exchange_rates (rate_id, currency_code, rate, effective_date, created_at)
+	VALUES ($1,$2,$3::numeric,$4,$5)
+	ON CONFLICT (currency_code, effective_date) DO UPDATE SET rate = EXCLUDED.rate, created_at = EXCLUDED.created_at`
+
+	d := mapper.ExchangeRateToDTO(rate)
+	if _, err := exec.Exec(ctx, query, d.RateID, d.CurrencyCode, d.Rate, d.EffectiveDate, d.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %s", storage.ErrCurrencyNotFound, rate.Currency)
+		}
+		return fmt.Errorf("ошибка сохранения курса валюты: %w", err)
+	}
+	return nil
+}
+
+// GetRates возвращает историю курсов валюты, начиная с последнего.
+func (r *currencyRepository) GetRates(ctx context.Context, code string, f currency.RateFilter, req page.Request) ([]currency.ExchangeRate, error) {
+	conds := []string{"currency_code = $1"}
+	args := []any{code}
+	if f.From != nil {
+		args = append(args, *f.From)
+		conds = append(conds, fmt.Sprintf("effective_date >= $%d", len(args)))
+	}
+	if f.To != nil {
+		args = append(args, *f.To)
+		conds = append(conds, fmt.Sprintf("effective_date <= $%d", len(args)))
+	}
+	query, args := rateKeyset.Apply(`SELECT `+rateColumns+` FROM exchange_rates`, conds, args, req)
+	return r.queryRates(ctx, query, args...)
+}
+
+// GetEffectiveRates возвращает для каждой валюты курс, действующий на дату.
+func (r *currencyRepository) GetEffectiveRates(ctx context.Context, date time.Time) ([]currency.ExchangeRate, error) {
+	query := `SELECT DISTINCT ON (currency_code) ` + rateColumns + ` FROM exchange_rates
+	WHERE effective_date <= $1
+	ORDER BY currency_code, effective_date DESC`
+	return r.queryRates(ctx, query, date)
+}
+
+func (r *currencyRepository) queryRates(ctx context.Context, query string, args ...any) ([]currency.ExchangeRate, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	rows, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения курсов валют: %w", err)
+	}
+	defer rows.Close()
+	var rates []currency.ExchangeRate
+	for rows.Next() {
+		var d dto.ExchangeRateDTO
+		if err := rows.Scan(&d.RateID, &d.CurrencyCode, &d.Rate, &d.EffectiveDate, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		rates = append(rates, mapper.ExchangeRateFromDTO(d))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return rates, nil
+}
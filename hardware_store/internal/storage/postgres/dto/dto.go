@@ -1,30 +1,40 @@
 package dto
 
 import (
+	"hardware_store/internal/model/money"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ClientDTO struct {
-	ClientID         uuid.UUID `db:"client_id"`
-	Name             string    `db:"name"`
-	Surname          string    `db:"surname"`
-	Birthday         time.Time `db:"birthday"`
-	Gender           string    `db:"gender"`
-	RegistrationDate time.Time `db:"registration_date"`
-	AddressID        uuid.UUID `db:"address_id"`
+	ClientID         uuid.UUID  `db:"client_id"`
+	Name             string     `db:"name"`
+	Surname          string     `db:"surname"`
+	Birthday         time.Time  `db:"birthday"`
+	Gender           string     `db:"gender"`
+	RegistrationDate time.Time  `db:"registration_date"`
+	AddressID        uuid.UUID  `db:"address_id"`
+	UserID           *uuid.UUID `db:"user_id"`
 }
 
 type ProductDTO struct {
-	ProductID      uuid.UUID  `db:"product_id"`
-	Name           string     `db:"name"`
-	CategoryID     uuid.UUID  `db:"category_id"`
-	Price          float64    `db:"price"`
-	AvailableStock int        `db:"available_stock"`
-	LastUpdateDate time.Time  `db:"last_update_date"`
-	SupplierID     uuid.UUID  `db:"supplier_id"`
-	ImageID        *uuid.UUID `db:"image_uuid"`
+	ProductID      uuid.UUID   `db:"product_id"`
+	Name           string      `db:"name"`
+	CategoryID     uuid.UUID   `db:"category_id"`
+	Price          money.Money `db:"price"`
+	AvailableStock int         `db:"available_stock"`
+	ReservedStock  int         `db:"reserved_stock"`
+	LastUpdateDate time.Time   `db:"last_update_date"`
+	SupplierID     uuid.UUID   `db:"supplier_id"`
+	ImageID        *uuid.UUID  `db:"image_uuid"`
+	SKU            *string     `db:"sku"`
+}
+
+type ProductExportDTO struct {
+	ProductDTO
+	CategoryName *string `db:"category_name"`
+	SupplierName *string `db:"supplier_name"`
 }
 
 type SupplierDTO struct {
@@ -49,4 +59,309 @@ type AddressDTO struct {
 type CategoryDTO struct {
 	CategoryID uuid.UUID `db:"category_id"`
 	Category   string    `db:"category"`
+	TaxRate    *int64    `db:"tax_rate"`
+}
+
+type StockMovementDTO struct {
+	MovementID   uuid.UUID  `db:"movement_id"`
+	ProductID    uuid.UUID  `db:"product_id"`
+	WarehouseID  uuid.UUID  `db:"warehouse_id"`
+	MovementType string     `db:"movement_type"`
+	Quantity     int        `db:"quantity"`
+	Reason       *string    `db:"reason"`
+	ActorID      *uuid.UUID `db:"actor_id"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+type OrderDTO struct {
+	OrderID     uuid.UUID   `db:"order_id"`
+	ClientID    uuid.UUID   `db:"client_id"`
+	WarehouseID uuid.UUID   `db:"warehouse_id"`
+	Status      string      `db:"status"`
+	TaxMode     string      `db:"tax_mode"`
+	Total       money.Money `db:"total"`
+	CreatedAt   time.Time   `db:"created_at"`
+	UpdatedAt   time.Time   `db:"updated_at"`
+}
+
+type OrderItemDTO struct {
+	ItemID           uuid.UUID   `db:"item_id"`
+	OrderID          uuid.UUID   `db:"order_id"`
+	ProductID        *uuid.UUID  `db:"product_id"`
+	Name             string      `db:"name"`
+	Quantity         int         `db:"quantity"`
+	UnitPrice        money.Money `db:"unit_price"`
+	Discount         money.Money `db:"discount"`
+	TaxRate          int64       `db:"tax_rate"`
+	ReturnedQuantity int         `db:"returned_quantity"`
+}
+
+type OrderTransitionDTO struct {
+	TransitionID uuid.UUID  `db:"transition_id"`
+	OrderID      uuid.UUID  `db:"order_id"`
+	FromStatus   string     `db:"from_status"`
+	ToStatus     string     `db:"to_status"`
+	ActorID      *uuid.UUID `db:"actor_id"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+type CartDTO struct {
+	CartID    uuid.UUID  `db:"cart_id"`
+	ClientID  *uuid.UUID `db:"client_id"`
+	Token     string     `db:"token"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+type CartItemDTO struct {
+	ProductID      uuid.UUID   `db:"product_id"`
+	Name           string      `db:"name"`
+	Quantity       int         `db:"quantity"`
+	Price          money.Money `db:"price"`
+	AvailableStock int         `db:"available_stock"`
+	AddedAt        time.Time   `db:"added_at"`
+}
+
+type ReservationDTO struct {
+	ReservationID uuid.UUID `db:"reservation_id"`
+	ProductID     uuid.UUID `db:"product_id"`
+	WarehouseID   uuid.UUID `db:"warehouse_id"`
+	Quantity      int       `db:"quantity"`
+	Status        string    `db:"status"`
+	Reference     *string   `db:"reference"`
+	ExpiresAt     time.Time `db:"expires_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type WarehouseDTO struct {
+	WarehouseID uuid.UUID  `db:"warehouse_id"`
+	Name        string     `db:"name"`
+	Kind        string     `db:"kind"`
+	AddressID   *uuid.UUID `db:"address_id"`
+	IsDefault   bool       `db:"is_default"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+type WarehouseStockDTO struct {
+	WarehouseID uuid.UUID `db:"warehouse_id"`
+	ProductID   uuid.UUID `db:"product_id"`
+	Name        string    `db:"name"`
+	Quantity    int       `db:"quantity"`
+}
+
+type StockTransferDTO struct {
+	TransferID      uuid.UUID  `db:"transfer_id"`
+	ProductID       uuid.UUID  `db:"product_id"`
+	FromWarehouseID uuid.UUID  `db:"from_warehouse_id"`
+	ToWarehouseID   uuid.UUID  `db:"to_warehouse_id"`
+	Quantity        int        `db:"quantity"`
+	ActorID         *uuid.UUID `db:"actor_id"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+type StockAlertDTO struct {
+	AlertID           uuid.UUID  `db:"alert_id"`
+	ProductID         uuid.UUID  `db:"product_id"`
+	ProductName       string     `db:"product_name"`
+	SupplierID        *uuid.UUID `db:"supplier_id"`
+	SupplierName      *string    `db:"supplier_name"`
+	Stock             int        `db:"stock"`
+	ReorderPoint      int        `db:"reorder_point"`
+	SuggestedQuantity int        `db:"suggested_quantity"`
+	Status            string     `db:"status"`
+	CreatedAt         time.Time  `db:"created_at"`
+	NotifiedAt        *time.Time `db:"notified_at"`
+	ResolvedAt        *time.Time `db:"resolved_at"`
+}
+
+type PurchaseOrderDTO struct {
+	PurchaseOrderID uuid.UUID `db:"purchase_order_id"`
+	SupplierID      uuid.UUID `db:"supplier_id"`
+	WarehouseID     uuid.UUID `db:"warehouse_id"`
+	Status          string    `db:"status"`
+	Note            *string   `db:"note"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+type PurchaseOrderLineDTO struct {
+	PurchaseOrderID  uuid.UUID   `db:"purchase_order_id"`
+	ProductID        uuid.UUID   `db:"product_id"`
+	Name             string      `db:"name"`
+	Quantity         int         `db:"quantity"`
+	ExpectedPrice    money.Money `db:"expected_price"`
+	ReceivedQuantity int         `db:"received_quantity"`
+}
+
+type GoodsReceiptDTO struct {
+	ReceiptID       uuid.UUID  `db:"receipt_id"`
+	PurchaseOrderID uuid.UUID  `db:"purchase_order_id"`
+	WarehouseID     uuid.UUID  `db:"warehouse_id"`
+	ActorID         *uuid.UUID `db:"actor_id"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+type GoodsReceiptLineDTO struct {
+	ReceiptID        uuid.UUID `db:"receipt_id"`
+	ProductID        uuid.UUID `db:"product_id"`
+	ExpectedQuantity int       `db:"expected_quantity"`
+	ReceivedQuantity int       `db:"received_quantity"`
+}
+
+type SupplierProductDTO struct {
+	SupplierID       uuid.UUID   `db:"supplier_id"`
+	SupplierName     string      `db:"supplier_name"`
+	ProductID        uuid.UUID   `db:"product_id"`
+	ProductName      string      `db:"product_name"`
+	SKU              *string     `db:"supplier_sku"`
+	PurchasePrice    money.Money `db:"purchase_price"`
+	Currency         string      `db:"currency"`
+	LeadTimeDays     int         `db:"lead_time_days"`
+	MinOrderQuantity int         `db:"min_order_quantity"`
+	UpdatedAt        time.Time   `db:"updated_at"`
+}
+
+type ProductImportDTO struct {
+	ImportID    uuid.UUID  `db:"import_id"`
+	Status      string     `db:"status"`
+	Format      string     `db:"format"`
+	FileName    string     `db:"file_name"`
+	DryRun      bool       `db:"dry_run"`
+	Upsert      bool       `db:"upsert"`
+	Mapping     *string    `db:"mapping"`
+	TotalRows   int        `db:"total_rows"`
+	CreatedRows int        `db:"created_rows"`
+	UpdatedRows int        `db:"updated_rows"`
+	FailedRows  int        `db:"failed_rows"`
+	Error       *string    `db:"error"`
+	CreatedBy   *uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+	StartedAt   *time.Time `db:"started_at"`
+	FinishedAt  *time.Time `db:"finished_at"`
+}
+
+type ProductImportErrorDTO struct {
+	ImportID  uuid.UUID `db:"import_id"`
+	RowNumber int       `db:"row_number"`
+	Field     *string   `db:"field"`
+	Message   string    `db:"message"`
+}
+
+type CurrencyDTO struct {
+	Code     string `db:"code"`
+	Name     string `db:"name"`
+	Decimals int    `db:"decimals"`
+	Rounding string `db:"rounding"`
+}
+
+type ExchangeRateDTO struct {
+	RateID        uuid.UUID `db:"rate_id"`
+	CurrencyCode  string    `db:"currency_code"`
+	Rate          string    `db:"rate"`
+	EffectiveDate time.Time `db:"effective_date"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type ProductPriceDTO struct {
+	PriceID   uuid.UUID   `db:"price_id"`
+	ProductID uuid.UUID   `db:"product_id"`
+	Price     money.Money `db:"price"`
+	ValidFrom time.Time   `db:"valid_from"`
+	ValidTo   *time.Time  `db:"valid_to"`
+	Reason    *string     `db:"reason"`
+	CreatedBy *uuid.UUID  `db:"created_by"`
+	CreatedAt time.Time   `db:"created_at"`
+	AppliedAt *time.Time  `db:"applied_at"`
+}
+
+type PromotionDTO struct {
+	PromotionID    uuid.UUID    `db:"promotion_id"`
+	Name           string       `db:"name"`
+	Kind           string       `db:"kind"`
+	Percent        *int64       `db:"percent"`
+	Amount         *money.Money `db:"amount"`
+	BuyQuantity    *int         `db:"buy_quantity"`
+	GetQuantity    *int         `db:"get_quantity"`
+	CategoryID     *uuid.UUID   `db:"category_id"`
+	SupplierID     *uuid.UUID   `db:"supplier_id"`
+	Code           *string      `db:"code"`
+	UsageLimit     *int         `db:"usage_limit"`
+	PerClientLimit *int         `db:"per_client_limit"`
+	ValidFrom      *time.Time   `db:"valid_from"`
+	ValidTo        *time.Time   `db:"valid_to"`
+	Stackable      bool         `db:"stackable"`
+	Priority       int          `db:"priority"`
+	Active         bool         `db:"active"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+}
+
+type PromotionRedemptionDTO struct {
+	PromotionID uuid.UUID   `db:"promotion_id"`
+	OrderID     uuid.UUID   `db:"order_id"`
+	ClientID    uuid.UUID   `db:"client_id"`
+	Amount      money.Money `db:"amount"`
+	CreatedAt   time.Time   `db:"created_at"`
+}
+
+type InvoiceDTO struct {
+	InvoiceID uuid.UUID   `db:"invoice_id"`
+	OrderID   uuid.UUID   `db:"order_id"`
+	Kind      string      `db:"kind"`
+	Year      int         `db:"year"`
+	Number    int         `db:"number"`
+	Total     money.Money `db:"total"`
+	IssuedAt  time.Time   `db:"issued_at"`
+	Document  []byte      `db:"document"`
+}
+
+type ReturnDTO struct {
+	ReturnID    uuid.UUID   `db:"return_id"`
+	OrderID     uuid.UUID   `db:"order_id"`
+	ClientID    uuid.UUID   `db:"client_id"`
+	WarehouseID uuid.UUID   `db:"warehouse_id"`
+	Status      string      `db:"status"`
+	Note        *string     `db:"note"`
+	Refund      money.Money `db:"refund"`
+	ActorID     *uuid.UUID  `db:"actor_id"`
+	CreatedAt   time.Time   `db:"created_at"`
+	UpdatedAt   time.Time   `db:"updated_at"`
+	RefundedAt  *time.Time  `db:"refunded_at"`
+}
+
+type ReturnLineDTO struct {
+	ReturnID  uuid.UUID   `db:"return_id"`
+	ItemID    uuid.UUID   `db:"item_id"`
+	ProductID *uuid.UUID  `db:"product_id"`
+	Name      string      `db:"name"`
+	Quantity  int         `db:"quantity"`
+	Reason    string      `db:"reason"`
+	Comment   *string     `db:"comment"`
+	Outcome   *string     `db:"outcome"`
+	Refund    money.Money `db:"refund"`
+}
+
+type PaymentDTO struct {
+	PaymentID       uuid.UUID   `db:"payment_id"`
+	OrderID         uuid.UUID   `db:"order_id"`
+	Provider        string      `db:"provider"`
+	ExternalID      string      `db:"external_id"`
+	Status          string      `db:"status"`
+	Amount          money.Money `db:"amount"`
+	Refunded        money.Money `db:"refunded"`
+	ConfirmationURL *string     `db:"confirmation_url"`
+	CreatedAt       time.Time   `db:"created_at"`
+	UpdatedAt       time.Time   `db:"updated_at"`
+}
+
+type PaymentRefundDTO struct {
+	RefundID  uuid.UUID   `db:"refund_id"`
+	PaymentID uuid.UUID   `db:"payment_id"`
+	ReturnID  *uuid.UUID  `db:"return_id"`
+	Key       string      `db:"idempotency_key"`
+	Status    string      `db:"status"`
+	Amount    money.Money `db:"amount"`
+	ActorID   *uuid.UUID  `db:"actor_id"`
+	CreatedAt time.Time   `db:"created_at"`
 }
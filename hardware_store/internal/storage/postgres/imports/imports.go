@@ -0,0 +1,213 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/imports"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const importColumns = `import_id, status, format, file_name, dry_run, upsert, mapping, total_rows,
+	created_rows, updated_rows, failed_rows, error, created_by, created_at, started_at, finished_at`
+
+type importRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportRepository(db *pgxpool.Pool) *importRepository {
+	return &importRepository{
+		pool: db,
+	}
+}
+
+func (r *importRepository) Insert(ctx context.Context, job imports.Job, payload []byte) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO product_imports
+	(import_id, status, format, file_name, dry_run, upsert, mapping, payload, created_by, created_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+	d := mapper.ProductImportToDTO(job)
+	_, err := exec.Exec(ctx, query, d.ImportID, d.Status, d.Format, d.FileName, d.DryRun, d.Upsert, d.Mapping,
+		payload, d.CreatedBy, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания импорта товаров: %w", err)
+	}
+	return nil
+}
+
+// GetById возвращает импорт вместе с отчётом об ошибках строк.
+func (r *importRepository) GetById(ctx context.Context, id uuid.UUID) (imports.Job, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT ` + importColumns + ` FROM product_imports WHERE import_id = $1`
+
+	job, err := scanImport(exec.QueryRow(ctx, query, id))
+	if err != nil {
+		return imports.Job{}, err
+	}
+	if job.Errors, err = r.getErrors(ctx, exec, id); err != nil {
+		return imports.Job{}, err
+	}
+	return job, nil
+}
+
+// ClaimPending переводит самый старый ожидающий импорт в работу и
+// возвращает его вместе с файлом. Импорты, взятые другими экземплярами
+// приложения, пропускаются.
+func (r *importRepository) ClaimPending(ctx context.Context, now time.Time) (imports.Job, []byte, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product_imports SET status = $1, started_at = $2
+	WHERE import_id = (
+		SELECT import_id FROM product_imports
+		WHERE status = $3
+		ORDER BY created_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + importColumns + `, payload`
+
+	var d dto.ProductImportDTO
+	var payload []byte
+	err := exec.QueryRow(ctx, query, string(imports.StatusRunning), now, string(imports.StatusPending)).
+		Scan(&d.ImportID, &d.Status, &d.Format, &d.FileName, &d.DryRun, &d.Upsert, &d.Mapping, &d.TotalRows,
+			&d.CreatedRows, &d.UpdatedRows, &d.FailedRows, &d.Error, &d.CreatedBy, &d.CreatedAt, &d.StartedAt, &d.FinishedAt,
+			&payload)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return imports.Job{}, nil, storage.ErrImportNotFound
+		}
+		return imports.Job{}, nil, fmt.Errorf("ошибка получения ожидающего импорта: %w", err)
+	}
+	return mapper.ProductImportFromDTO(d), payload, nil
+}
+
+// Finish сохраняет итог импорта и отчёт об ошибках и удаляет загруженный файл.
+func (r *importRepository) Finish(ctx context.Context, job imports.Job) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product_imports
+	SET status = $2, total_rows = $3, created_rows = $4, updated_rows = $5, failed_rows = $6,
+		error = $7, finished_at = $8, payload = NULL
+	WHERE import_id = $1`
+
+	d := mapper.ProductImportToDTO(job)
+	tag, err := exec.Exec(ctx, query, d.ImportID, d.Status, d.TotalRows, d.CreatedRows, d.UpdatedRows, d.FailedRows,
+		d.Error, d.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения результата импорта: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrImportNotFound
+	}
+	if len(job.Errors) == 0 {
+		return nil
+	}
+
+	rows := make([]int, 0, len(job.Errors))
+	fields := make([]*string, 0, len(job.Errors))
+	messages := make([]string, 0, len(job.Errors))
+	for _, e := range job.Errors {
+		ed := mapper.ProductImportErrorToDTO(job.ImportID, e)
+		rows = append(rows, ed.RowNumber)
+		fields = append(fields, ed.Field)
+		messages = append(messages, ed.Message)
+	}
+	errQuery := `INSERT INTO product_import_errors (import_id, row_number, field, message)
+	SELECT $1, e.row_number, e.field, e.message
+	FROM unnest($2::int[], $3::text[], $4::text[]) AS e(row_number, field, message)`
+	if _, err := exec.Exec(ctx, errQuery, job.ImportID, rows, fields, messages); err != nil {
+		return fmt.Errorf("ошибка сохранения отчёта импорта: %w", err)
+	}
+	return nil
+}
+
+// ResolveCategory находит категорию по UUID или названию без учёта регистра.
+// Название, которое носят несколько категорий, не разрешается.
+func (r *importRepository) ResolveCategory(ctx context.Context, ref string) (uuid.UUID, error) {
+	query := `SELECT category_id FROM category
+	WHERE category_id::text = lower($1) OR lower(category) = lower($1)
+	LIMIT 2`
+	return r.resolve(ctx, query, ref, storage.ErrCategoryNotFound)
+}
+
+// ResolveSupplier находит поставщика по UUID или названию без учёта регистра.
+func (r *importRepository) ResolveSupplier(ctx context.Context, ref string) (uuid.UUID, error) {
+	query := `SELECT supplier_id FROM supplier
+	WHERE supplier_id::text = lower($1) OR lower(name) = lower($1)
+	LIMIT 2`
+	return r.resolve(ctx, query, ref, storage.ErrSupplierNotFound)
+}
+
+func (r *importRepository) resolve(ctx context.Context, query, ref string, notFound error) (uuid.UUID, error) {
+	row, err := r.pool.Query(ctx, query, ref)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка поиска по ссылке %q: %w", ref, err)
+	}
+	defer row.Close()
+	var ids []uuid.UUID
+	for row.Next() {
+		var id uuid.UUID
+		if err := row.Scan(&id); err != nil {
+			return uuid.Nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err = row.Err(); err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	switch len(ids) {
+	case 0:
+		return uuid.Nil, notFound
+	case 1:
+		return ids[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("%w: %q matches several records", notFound, ref)
+	}
+}
+
+func (r *importRepository) getErrors(ctx context.Context, exec tx.Executer, id uuid.UUID) ([]imports.RowError, error) {
+	query := `SELECT import_id, row_number, field, message
+	FROM product_import_errors
+	WHERE import_id = $1
+	ORDER BY row_number`
+
+	row, err := exec.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения отчёта импорта: %w", err)
+	}
+	defer row.Close()
+	var res []imports.RowError
+	for row.Next() {
+		var d dto.ProductImportErrorDTO
+
+		if err := row.Scan(&d.ImportID, &d.RowNumber, &d.Field, &d.Message); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		res = append(res, mapper.ProductImportErrorFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return res, nil
+}
+
+func scanImport(row pgx.Row) (imports.Job, error) {
+	var d dto.ProductImportDTO
+
+	err := row.Scan(&d.ImportID, &d.Status, &d.Format, &d.FileName, &d.DryRun, &d.Upsert, &d.Mapping, &d.TotalRows,
+		&d.CreatedRows, &d.UpdatedRows, &d.FailedRows, &d.Error, &d.CreatedBy, &d.CreatedAt, &d.StartedAt, &d.FinishedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return imports.Job{}, storage.ErrImportNotFound
+		}
+		return imports.Job{}, fmt.Errorf("ошибка получения импорта: %w", err)
+	}
+	return mapper.ProductImportFromDTO(d), nil
+}
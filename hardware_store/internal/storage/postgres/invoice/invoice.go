@@ -0,0 +1,99 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/invoice"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type invoiceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewInvoiceRepository(db *pgxpool.Pool) *invoiceRepository {
+	return &invoiceRepository{
+		pool: db,
+	}
+}
+
+func (r *invoiceRepository) GetByOrder(ctx context.Context, orderID uuid.UUID, kind invoice.Kind) (invoice.Invoice, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT invoice_id, order_id, kind, year, number, total, issued_at, document
+	FROM invoices
+	WHERE order_id = $1 AND kind = $2`
+
+	var d dto.InvoiceDTO
+	err := exec.QueryRow(ctx, query, orderID, string(kind)).Scan(&d.InvoiceID, &d.OrderID, &d.Kind, &d.Year,
+		&d.Number, &d.Total, &d.IssuedAt, &d.Document)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invoice.Invoice{}, storage.ErrInvoiceNotFound
+		}
+		return invoice.Invoice{}, fmt.Errorf("ошибка получения документа: %w", err)
+	}
+	return mapper.InvoiceFromDTO(d), nil
+}
+
+// NextNumber выдаёт следующий номер документа вида kind за год. Строка
+// счётчика остаётся заблокированной до конца транзакции, поэтому номера
+// выдаются по одному, а откат транзакции возвращает номер обратно.
+// Транзакция должна быть короткой: пока она открыта, документы того же
+// вида за год не выпускаются.
+func (r *invoiceRepository) NextNumber(ctx context.Context, kind invoice.Kind, year int) (int, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO invoice_counters (kind, year, last_number) VALUES ($1, $2, 1)
+	ON CONFLICT (kind, year) DO UPDATE SET last_number = invoice_counters.last_number + 1
+	RETURNING last_number`
+
+	var number int
+	if err := exec.QueryRow(ctx, query, string(kind), year).Scan(&number); err != nil {
+		return 0, fmt.Errorf("ошибка выдачи номера документа: %w", err)
+	}
+	return number, nil
+}
+
+// Insert сохраняет документ; PDF может быть ещё не набран. Если документ
+// того же вида по заказу уже выпущен, возвращает storage.ErrDuplicateInvoice.
+func (r *invoiceRepository) Insert(ctx context.Context, i invoice.Invoice) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO invoices (invoice_id, order_id, kind, year, number, total, issued_at, document)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	ON CONFLICT (order_id, kind) DO NOTHING`
+
+	d := mapper.InvoiceToDTO(i)
+	tag, err := exec.Exec(ctx, query, d.InvoiceID, d.OrderID, d.Kind, d.Year, d.Number, d.Total, d.IssuedAt, d.Document)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения документа: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrDuplicateInvoice
+	}
+	return nil
+}
+
+// SetDocument записывает PDF документа, если он ещё не записан, и возвращает
+// сохранённый PDF: при параллельном наборе остаётся записанный первым.
+func (r *invoiceRepository) SetDocument(ctx context.Context, invoiceID uuid.UUID, document []byte) ([]byte, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE invoices SET document = COALESCE(document, $2)
+	WHERE invoice_id = $1
+	RETURNING document`
+
+	var stored []byte
+	if err := exec.QueryRow(ctx, query, invoiceID, document).Scan(&stored); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("ошибка сохранения документа: %w", err)
+	}
+	return stored, nil
+}
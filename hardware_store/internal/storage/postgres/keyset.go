@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"fmt"
+	"hardware_store/internal/model/page"
+	"strings"
+)
+
+// Keyset описывает порядок строк для постраничной выборки: колонка ключа
+// сортировки и колонка UUID, которая делает порядок строгим.
+type Keyset struct {
+	Key  string
+	ID   string
+	Cast string
+	Desc bool
+}
+
+// Apply дописывает к запросу условия, сортировку и LIMIT/OFFSET. Если в
+// запросе страницы есть курсор, выборка продолжается строго после него,
+// поэтому вставка новых строк не сдвигает следующие страницы.
+func (k Keyset) Apply(query string, conds []string, args []any, req page.Request) (string, []any) {
+	direction, cmp := "ASC", ">"
+	if k.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if req.After != nil {
+		args = append(args, req.After.Key, req.After.ID)
+		conds = append(conds, fmt.Sprintf("(%s, %s) %s ($%d::%s, $%d)",
+			k.Key, k.ID, cmp, len(args)-1, k.Cast, len(args)))
+	}
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s", k.Key, direction, k.ID, direction)
+
+	if req.Limit > 0 {
+		args = append(args, req.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if req.After == nil && req.Offset > 0 {
+		args = append(args, req.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	return query, args
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/cart"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func CartToDTO(c model.Cart) dto.CartDTO {
+	return dto.CartDTO{
+		CartID:    c.CartID,
+		ClientID:  c.ClientID,
+		Token:     c.Token,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func CartFromDTO(d dto.CartDTO) model.Cart {
+	return model.Cart{
+		CartID:    d.CartID,
+		ClientID:  d.ClientID,
+		Token:     d.Token,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func CartItemFromDTO(d dto.CartItemDTO) model.Item {
+	return model.Item{
+		ProductID:      d.ProductID,
+		Name:           d.Name,
+		Quantity:       d.Quantity,
+		UnitPrice:      d.Price,
+		AvailableStock: d.AvailableStock,
+		AddedAt:        d.AddedAt,
+	}
+}
@@ -2,19 +2,30 @@ package mapper
 
 import (
 	"hardware_store/internal/model/category"
+	"hardware_store/internal/model/money"
 	"hardware_store/internal/storage/postgres/dto"
 )
 
 func CategoryToDTO(i category.Category) dto.CategoryDTO {
-	return dto.CategoryDTO{
+	d := dto.CategoryDTO{
 		CategoryID: i.CategoryID,
 		Category:   i.Category,
 	}
+	if i.TaxRate != nil {
+		rate := int64(*i.TaxRate)
+		d.TaxRate = &rate
+	}
+	return d
 }
 
 func CategoryFromDTO(d dto.CategoryDTO) category.Category {
-	return category.Category{
+	c := category.Category{
 		CategoryID: d.CategoryID,
 		Category:   d.Category,
 	}
+	if d.TaxRate != nil {
+		rate := money.Rate(*d.TaxRate)
+		c.TaxRate = &rate
+	}
+	return c
 }
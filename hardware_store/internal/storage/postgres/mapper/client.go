@@ -14,6 +14,7 @@ func ClientToDTO(c client.Client) dto.ClientDTO {
 		Gender:           c.Gender,
 		RegistrationDate: c.RegistrationDate,
 		AddressID:        c.AddressID,
+		UserID:           c.UserID,
 	}
 }
 
@@ -26,5 +27,6 @@ func ClientFromDTO(d dto.ClientDTO) client.Client {
 		Gender:           d.Gender,
 		RegistrationDate: d.RegistrationDate,
 		AddressID:        d.AddressID,
+		UserID:           d.UserID,
 	}
 }
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/currency"
+	"hardware_store/internal/storage/postgres/dto"
+	"math/big"
+)
+
+func CurrencyToDTO(c model.Currency) dto.CurrencyDTO {
+	return dto.CurrencyDTO{
+		Code:     c.Code,
+		Name:     c.Name,
+		Decimals: c.Decimals,
+		Rounding: string(c.Rounding),
+	}
+}
+
+func CurrencyFromDTO(d dto.CurrencyDTO) model.Currency {
+	return model.Currency{
+		Code:     d.Code,
+		Name:     d.Name,
+		Decimals: d.Decimals,
+		Rounding: model.Rounding(d.Rounding),
+	}
+}
+
+func ExchangeRateToDTO(r model.ExchangeRate) dto.ExchangeRateDTO {
+	return dto.ExchangeRateDTO{
+		RateID:        r.RateID,
+		CurrencyCode:  r.Currency,
+		Rate:          model.FormatRate(r.Rate),
+		EffectiveDate: r.EffectiveDate,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+// ExchangeRateFromDTO ожидает курс в том виде, в каком его возвращает
+// PostgreSQL для NUMERIC.
+func ExchangeRateFromDTO(d dto.ExchangeRateDTO) model.ExchangeRate {
+	rate, _ := new(big.Rat).SetString(d.Rate)
+	return model.ExchangeRate{
+		RateID:        d.RateID,
+		Currency:      d.CurrencyCode,
+		Rate:          rate,
+		EffectiveDate: d.EffectiveDate,
+		CreatedAt:     d.CreatedAt,
+	}
+}
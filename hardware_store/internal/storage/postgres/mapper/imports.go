@@ -0,0 +1,92 @@
+package mapper
+
+import (
+	"encoding/json"
+	model "hardware_store/internal/model/imports"
+	"hardware_store/internal/storage/postgres/dto"
+
+	"github.com/google/uuid"
+)
+
+// ProductImportToDTO сохраняет сопоставление столбцов как JSON-объект.
+func ProductImportToDTO(j model.Job) dto.ProductImportDTO {
+	d := dto.ProductImportDTO{
+		ImportID:    j.ImportID,
+		Status:      string(j.Status),
+		Format:      string(j.Format),
+		FileName:    j.FileName,
+		DryRun:      j.DryRun,
+		Upsert:      j.Upsert,
+		TotalRows:   j.TotalRows,
+		CreatedRows: j.CreatedRows,
+		UpdatedRows: j.UpdatedRows,
+		FailedRows:  j.FailedRows,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+	if len(j.Mapping) > 0 {
+		if b, err := json.Marshal(j.Mapping); err == nil {
+			mapping := string(b)
+			d.Mapping = &mapping
+		}
+	}
+	if j.Error != "" {
+		d.Error = &j.Error
+	}
+	if j.CreatedBy != uuid.Nil {
+		d.CreatedBy = &j.CreatedBy
+	}
+	return d
+}
+
+func ProductImportFromDTO(d dto.ProductImportDTO) model.Job {
+	j := model.Job{
+		ImportID:    d.ImportID,
+		Status:      model.Status(d.Status),
+		Format:      model.Format(d.Format),
+		FileName:    d.FileName,
+		DryRun:      d.DryRun,
+		Upsert:      d.Upsert,
+		TotalRows:   d.TotalRows,
+		CreatedRows: d.CreatedRows,
+		UpdatedRows: d.UpdatedRows,
+		FailedRows:  d.FailedRows,
+		CreatedAt:   d.CreatedAt,
+		StartedAt:   d.StartedAt,
+		FinishedAt:  d.FinishedAt,
+	}
+	if d.Mapping != nil {
+		_ = json.Unmarshal([]byte(*d.Mapping), &j.Mapping)
+	}
+	if d.Error != nil {
+		j.Error = *d.Error
+	}
+	if d.CreatedBy != nil {
+		j.CreatedBy = *d.CreatedBy
+	}
+	return j
+}
+
+func ProductImportErrorToDTO(importID uuid.UUID, e model.RowError) dto.ProductImportErrorDTO {
+	d := dto.ProductImportErrorDTO{
+		ImportID:  importID,
+		RowNumber: e.Row,
+		Message:   e.Message,
+	}
+	if e.Field != "" {
+		d.Field = &e.Field
+	}
+	return d
+}
+
+func ProductImportErrorFromDTO(d dto.ProductImportErrorDTO) model.RowError {
+	e := model.RowError{
+		Row:     d.RowNumber,
+		Message: d.Message,
+	}
+	if d.Field != nil {
+		e.Field = *d.Field
+	}
+	return e
+}
@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/invoice"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func InvoiceToDTO(i model.Invoice) dto.InvoiceDTO {
+	return dto.InvoiceDTO{
+		InvoiceID: i.InvoiceID,
+		OrderID:   i.OrderID,
+		Kind:      string(i.Kind),
+		Year:      i.Year,
+		Number:    i.Number,
+		Total:     i.Total,
+		IssuedAt:  i.IssuedAt,
+		Document:  i.Document,
+	}
+}
+
+func InvoiceFromDTO(d dto.InvoiceDTO) model.Invoice {
+	return model.Invoice{
+		InvoiceID: d.InvoiceID,
+		OrderID:   d.OrderID,
+		Kind:      model.Kind(d.Kind),
+		Year:      d.Year,
+		Number:    d.Number,
+		Total:     d.Total,
+		IssuedAt:  d.IssuedAt,
+		Document:  d.Document,
+	}
+}
@@ -0,0 +1,91 @@
+package mapper
+
+import (
+	"hardware_store/internal/model/money"
+	model "hardware_store/internal/model/order"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/storage/postgres/dto"
+
+	"github.com/google/uuid"
+)
+
+func OrderToDTO(o model.Order) dto.OrderDTO {
+	return dto.OrderDTO{
+		OrderID:     o.OrderID,
+		ClientID:    o.ClientID,
+		WarehouseID: o.WarehouseID,
+		Status:      string(o.Status),
+		TaxMode:     string(o.TaxMode),
+		Total:       o.Total,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+func OrderFromDTO(d dto.OrderDTO) model.Order {
+	return model.Order{
+		OrderID:     d.OrderID,
+		ClientID:    d.ClientID,
+		WarehouseID: d.WarehouseID,
+		Status:      model.Status(d.Status),
+		TaxMode:     tax.Mode(d.TaxMode),
+		Total:       d.Total,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+func OrderItemToDTO(orderID uuid.UUID, i model.Item) dto.OrderItemDTO {
+	d := dto.OrderItemDTO{
+		ItemID:           i.ItemID,
+		OrderID:          orderID,
+		Name:             i.Name,
+		Quantity:         i.Quantity,
+		UnitPrice:        i.UnitPrice,
+		Discount:         i.Discount,
+		TaxRate:          int64(i.TaxRate),
+		ReturnedQuantity: i.ReturnedQuantity,
+	}
+	if i.ProductID != uuid.Nil {
+		d.ProductID = &i.ProductID
+	}
+	return d
+}
+
+func OrderItemFromDTO(d dto.OrderItemDTO) model.Item {
+	i := model.Item{
+		ItemID:           d.ItemID,
+		Name:             d.Name,
+		Quantity:         d.Quantity,
+		UnitPrice:        d.UnitPrice,
+		Discount:         d.Discount,
+		TaxRate:          money.Rate(d.TaxRate),
+		ReturnedQuantity: d.ReturnedQuantity,
+	}
+	if d.ProductID != nil {
+		i.ProductID = *d.ProductID
+	}
+	return i
+}
+
+func OrderTransitionToDTO(t model.Transition) dto.OrderTransitionDTO {
+	return dto.OrderTransitionDTO{
+		TransitionID: t.TransitionID,
+		OrderID:      t.OrderID,
+		FromStatus:   string(t.From),
+		ToStatus:     string(t.To),
+		ActorID:      t.ActorID,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+func OrderTransitionFromDTO(d dto.OrderTransitionDTO) model.Transition {
+	return model.Transition{
+		TransitionID: d.TransitionID,
+		OrderID:      d.OrderID,
+		From:         model.Status(d.FromStatus),
+		To:           model.Status(d.ToStatus),
+		ActorID:      d.ActorID,
+		CreatedAt:    d.CreatedAt,
+	}
+}
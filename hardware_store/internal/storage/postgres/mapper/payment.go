@@ -0,0 +1,68 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/payment"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func PaymentToDTO(p model.Payment) dto.PaymentDTO {
+	d := dto.PaymentDTO{
+		PaymentID:  p.PaymentID,
+		OrderID:    p.OrderID,
+		Provider:   p.Provider,
+		ExternalID: p.ExternalID,
+		Status:     string(p.Status),
+		Amount:     p.Amount,
+		Refunded:   p.Refunded,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+	if p.ConfirmationURL != "" {
+		d.ConfirmationURL = &p.ConfirmationURL
+	}
+	return d
+}
+
+func PaymentFromDTO(d dto.PaymentDTO) model.Payment {
+	p := model.Payment{
+		PaymentID:  d.PaymentID,
+		OrderID:    d.OrderID,
+		Provider:   d.Provider,
+		ExternalID: d.ExternalID,
+		Status:     model.Status(d.Status),
+		Amount:     d.Amount,
+		Refunded:   d.Refunded,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+	if d.ConfirmationURL != nil {
+		p.ConfirmationURL = *d.ConfirmationURL
+	}
+	return p
+}
+
+func PaymentRefundToDTO(r model.Refund) dto.PaymentRefundDTO {
+	return dto.PaymentRefundDTO{
+		RefundID:  r.RefundID,
+		PaymentID: r.PaymentID,
+		ReturnID:  r.ReturnID,
+		Key:       r.Key,
+		Status:    string(r.Status),
+		Amount:    r.Amount,
+		ActorID:   r.ActorID,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func PaymentRefundFromDTO(d dto.PaymentRefundDTO) model.Refund {
+	return model.Refund{
+		RefundID:  d.RefundID,
+		PaymentID: d.PaymentID,
+		ReturnID:  d.ReturnID,
+		Key:       d.Key,
+		Status:    model.RefundStatus(d.Status),
+		Amount:    d.Amount,
+		ActorID:   d.ActorID,
+		CreatedAt: d.CreatedAt,
+	}
+}
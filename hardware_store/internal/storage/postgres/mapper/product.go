@@ -6,27 +6,90 @@ import (
 )
 
 func ProductToDTO(p model.Product) dto.ProductDTO {
-	return dto.ProductDTO{
+	d := dto.ProductDTO{
 		ProductID:      p.ProductID,
 		Name:           p.Name,
 		CategoryID:     p.CategoryID,
 		Price:          p.Price,
 		AvailableStock: p.AvailableStock,
+		ReservedStock:  p.ReservedStock,
 		LastUpdateDate: p.LastUpdateDate,
 		SupplierID:     p.SupplierID,
 		ImageID:        p.ImageID,
 	}
+	if p.SKU != "" {
+		d.SKU = &p.SKU
+	}
+	return d
 }
 
 func ProductFromDTO(d dto.ProductDTO) model.Product {
-	return model.Product{
+	p := model.Product{
 		ProductID:      d.ProductID,
 		Name:           d.Name,
 		CategoryID:     d.CategoryID,
 		Price:          d.Price,
 		AvailableStock: d.AvailableStock,
+		ReservedStock:  d.ReservedStock,
 		LastUpdateDate: d.LastUpdateDate,
 		SupplierID:     d.SupplierID,
 		ImageID:        d.ImageID,
 	}
+	if d.SKU != nil {
+		p.SKU = *d.SKU
+	}
+	return p
+}
+
+func ProductExportFromDTO(d dto.ProductExportDTO) model.ExportRow {
+	row := model.ExportRow{Product: ProductFromDTO(d.ProductDTO)}
+	if d.CategoryName != nil {
+		row.CategoryName = *d.CategoryName
+	}
+	if d.SupplierName != nil {
+		row.SupplierName = *d.SupplierName
+	}
+	return row
+}
+
+func LocationFromDTO(d dto.WarehouseStockDTO) model.Location {
+	return model.Location{
+		WarehouseID: d.WarehouseID,
+		Name:        d.Name,
+		Quantity:    d.Quantity,
+	}
+}
+
+func ProductPriceToDTO(p model.Price) dto.ProductPriceDTO {
+	d := dto.ProductPriceDTO{
+		PriceID:   p.PriceID,
+		ProductID: p.ProductID,
+		Price:     p.Price,
+		ValidFrom: p.ValidFrom,
+		ValidTo:   p.ValidTo,
+		CreatedBy: p.CreatedBy,
+		CreatedAt: p.CreatedAt,
+		AppliedAt: p.AppliedAt,
+	}
+	if p.Reason != "" {
+		d.Reason = &p.Reason
+	}
+	return d
+}
+
+func ProductPriceFromDTO(d dto.ProductPriceDTO) model.Price {
+	p := model.Price{
+		PriceID:   d.PriceID,
+		ProductID: d.ProductID,
+		Price:     d.Price,
+		ValidFrom: d.ValidFrom,
+		ValidTo:   d.ValidTo,
+		CreatedBy: d.CreatedBy,
+		CreatedAt: d.CreatedAt,
+		AppliedAt: d.AppliedAt,
+	}
+	if d.Reason != nil {
+		p.Reason = *d.Reason
+	}
+	return p
 }
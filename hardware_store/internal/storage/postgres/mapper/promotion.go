@@ -0,0 +1,89 @@
+package mapper
+
+import (
+	"hardware_store/internal/model/money"
+	model "hardware_store/internal/model/promotion"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+// PromotionToDTO параметры, не относящиеся к виду акции, сохраняются
+// пустыми, чтобы проверки таблицы не требовали их.
+func PromotionToDTO(p model.Promotion) dto.PromotionDTO {
+	d := dto.PromotionDTO{
+		PromotionID:    p.PromotionID,
+		Name:           p.Name,
+		Kind:           string(p.Kind),
+		CategoryID:     p.CategoryID,
+		SupplierID:     p.SupplierID,
+		UsageLimit:     p.UsageLimit,
+		PerClientLimit: p.PerClientLimit,
+		ValidFrom:      p.ValidFrom,
+		ValidTo:        p.ValidTo,
+		Stackable:      p.Stackable,
+		Priority:       p.Priority,
+		Active:         p.Active,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+	switch p.Kind {
+	case model.KindPercent:
+		percent := int64(p.Percent)
+		d.Percent = &percent
+	case model.KindFixed:
+		amount := p.Amount
+		d.Amount = &amount
+	case model.KindBuyXGetY:
+		buy, get := p.BuyQuantity, p.GetQuantity
+		d.BuyQuantity, d.GetQuantity = &buy, &get
+	}
+	if p.Code != "" {
+		code := p.Code
+		d.Code = &code
+	}
+	return d
+}
+
+func PromotionFromDTO(d dto.PromotionDTO) model.Promotion {
+	p := model.Promotion{
+		PromotionID:    d.PromotionID,
+		Name:           d.Name,
+		Kind:           model.Kind(d.Kind),
+		CategoryID:     d.CategoryID,
+		SupplierID:     d.SupplierID,
+		UsageLimit:     d.UsageLimit,
+		PerClientLimit: d.PerClientLimit,
+		ValidFrom:      d.ValidFrom,
+		ValidTo:        d.ValidTo,
+		Stackable:      d.Stackable,
+		Priority:       d.Priority,
+		Active:         d.Active,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	if d.Percent != nil {
+		p.Percent = money.Rate(*d.Percent)
+	}
+	if d.Amount != nil {
+		p.Amount = *d.Amount
+	}
+	if d.BuyQuantity != nil {
+		p.BuyQuantity = *d.BuyQuantity
+	}
+	if d.GetQuantity != nil {
+		p.GetQuantity = *d.GetQuantity
+	}
+	if d.Code != nil {
+		p.Code = *d.Code
+	}
+	return p
+}
+
+func PromotionRedemptionToDTO(r model.Redemption) dto.PromotionRedemptionDTO {
+	return dto.PromotionRedemptionDTO{
+		PromotionID: r.PromotionID,
+		OrderID:     r.OrderID,
+		ClientID:    r.ClientID,
+		Amount:      r.Amount,
+		CreatedAt:   r.CreatedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/purchase"
+	"hardware_store/internal/storage/postgres/dto"
+
+	"github.com/google/uuid"
+)
+
+func PurchaseOrderToDTO(po model.PurchaseOrder) dto.PurchaseOrderDTO {
+	d := dto.PurchaseOrderDTO{
+		PurchaseOrderID: po.PurchaseOrderID,
+		SupplierID:      po.SupplierID,
+		WarehouseID:     po.WarehouseID,
+		Status:          string(po.Status),
+		CreatedAt:       po.CreatedAt,
+		UpdatedAt:       po.UpdatedAt,
+	}
+	if po.Note != "" {
+		d.Note = &po.Note
+	}
+	return d
+}
+
+func PurchaseOrderFromDTO(d dto.PurchaseOrderDTO) model.PurchaseOrder {
+	po := model.PurchaseOrder{
+		PurchaseOrderID: d.PurchaseOrderID,
+		SupplierID:      d.SupplierID,
+		WarehouseID:     d.WarehouseID,
+		Status:          model.Status(d.Status),
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+	}
+	if d.Note != nil {
+		po.Note = *d.Note
+	}
+	return po
+}
+
+func PurchaseOrderLineToDTO(poID uuid.UUID, l model.Line) dto.PurchaseOrderLineDTO {
+	return dto.PurchaseOrderLineDTO{
+		PurchaseOrderID:  poID,
+		ProductID:        l.ProductID,
+		Name:             l.Name,
+		Quantity:         l.Quantity,
+		ExpectedPrice:    l.ExpectedPrice,
+		ReceivedQuantity: l.ReceivedQuantity,
+	}
+}
+
+func PurchaseOrderLineFromDTO(d dto.PurchaseOrderLineDTO) model.Line {
+	return model.Line{
+		ProductID:        d.ProductID,
+		Name:             d.Name,
+		Quantity:         d.Quantity,
+		ExpectedPrice:    d.ExpectedPrice,
+		ReceivedQuantity: d.ReceivedQuantity,
+	}
+}
+
+func GoodsReceiptToDTO(r model.Receipt) dto.GoodsReceiptDTO {
+	return dto.GoodsReceiptDTO{
+		ReceiptID:       r.ReceiptID,
+		PurchaseOrderID: r.PurchaseOrderID,
+		WarehouseID:     r.WarehouseID,
+		ActorID:         r.ActorID,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+func GoodsReceiptFromDTO(d dto.GoodsReceiptDTO) model.Receipt {
+	return model.Receipt{
+		ReceiptID:       d.ReceiptID,
+		PurchaseOrderID: d.PurchaseOrderID,
+		WarehouseID:     d.WarehouseID,
+		ActorID:         d.ActorID,
+		CreatedAt:       d.CreatedAt,
+	}
+}
+
+func GoodsReceiptLineFromDTO(d dto.GoodsReceiptLineDTO) model.ReceiptLine {
+	return model.ReceiptLine{
+		ProductID:        d.ProductID,
+		ExpectedQuantity: d.ExpectedQuantity,
+		ReceivedQuantity: d.ReceivedQuantity,
+	}
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/replenishment"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func StockAlertFromDTO(d dto.StockAlertDTO) model.Alert {
+	a := model.Alert{
+		AlertID:           d.AlertID,
+		ProductID:         d.ProductID,
+		ProductName:       d.ProductName,
+		SupplierID:        d.SupplierID,
+		Stock:             d.Stock,
+		ReorderPoint:      d.ReorderPoint,
+		SuggestedQuantity: d.SuggestedQuantity,
+		Status:            model.AlertStatus(d.Status),
+		CreatedAt:         d.CreatedAt,
+		NotifiedAt:        d.NotifiedAt,
+		ResolvedAt:        d.ResolvedAt,
+	}
+	if d.SupplierName != nil {
+		a.SupplierName = *d.SupplierName
+	}
+	return a
+}
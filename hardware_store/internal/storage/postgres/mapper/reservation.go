@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/reservation"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func ReservationToDTO(r model.Reservation) dto.ReservationDTO {
+	d := dto.ReservationDTO{
+		ReservationID: r.ReservationID,
+		ProductID:     r.ProductID,
+		WarehouseID:   r.WarehouseID,
+		Quantity:      r.Quantity,
+		Status:        string(r.Status),
+		ExpiresAt:     r.ExpiresAt,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+	if r.Reference != "" {
+		d.Reference = &r.Reference
+	}
+	return d
+}
+
+func ReservationFromDTO(d dto.ReservationDTO) model.Reservation {
+	r := model.Reservation{
+		ReservationID: d.ReservationID,
+		ProductID:     d.ProductID,
+		WarehouseID:   d.WarehouseID,
+		Quantity:      d.Quantity,
+		Status:        model.Status(d.Status),
+		ExpiresAt:     d.ExpiresAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+	if d.Reference != nil {
+		r.Reference = *d.Reference
+	}
+	return r
+}
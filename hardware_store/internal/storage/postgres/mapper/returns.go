@@ -0,0 +1,88 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/returns"
+	"hardware_store/internal/storage/postgres/dto"
+
+	"github.com/google/uuid"
+)
+
+func ReturnToDTO(r model.Return) dto.ReturnDTO {
+	d := dto.ReturnDTO{
+		ReturnID:    r.ReturnID,
+		OrderID:     r.OrderID,
+		ClientID:    r.ClientID,
+		WarehouseID: r.WarehouseID,
+		Status:      string(r.Status),
+		Refund:      r.Refund,
+		ActorID:     r.ActorID,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		RefundedAt:  r.RefundedAt,
+	}
+	if r.Note != "" {
+		d.Note = &r.Note
+	}
+	return d
+}
+
+func ReturnFromDTO(d dto.ReturnDTO) model.Return {
+	r := model.Return{
+		ReturnID:    d.ReturnID,
+		OrderID:     d.OrderID,
+		ClientID:    d.ClientID,
+		WarehouseID: d.WarehouseID,
+		Status:      model.Status(d.Status),
+		Refund:      d.Refund,
+		ActorID:     d.ActorID,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		RefundedAt:  d.RefundedAt,
+	}
+	if d.Note != nil {
+		r.Note = *d.Note
+	}
+	return r
+}
+
+func ReturnLineToDTO(returnID uuid.UUID, l model.Line) dto.ReturnLineDTO {
+	d := dto.ReturnLineDTO{
+		ReturnID: returnID,
+		ItemID:   l.ItemID,
+		Name:     l.Name,
+		Quantity: l.Quantity,
+		Reason:   string(l.Reason),
+		Refund:   l.Refund,
+	}
+	if l.ProductID != uuid.Nil {
+		d.ProductID = &l.ProductID
+	}
+	if l.Comment != "" {
+		d.Comment = &l.Comment
+	}
+	if l.Outcome != "" {
+		outcome := string(l.Outcome)
+		d.Outcome = &outcome
+	}
+	return d
+}
+
+func ReturnLineFromDTO(d dto.ReturnLineDTO) model.Line {
+	l := model.Line{
+		ItemID:   d.ItemID,
+		Name:     d.Name,
+		Quantity: d.Quantity,
+		Reason:   model.Reason(d.Reason),
+		Refund:   d.Refund,
+	}
+	if d.ProductID != nil {
+		l.ProductID = *d.ProductID
+	}
+	if d.Comment != nil {
+		l.Comment = *d.Comment
+	}
+	if d.Outcome != nil {
+		l.Outcome = model.Outcome(*d.Outcome)
+	}
+	return l
+}
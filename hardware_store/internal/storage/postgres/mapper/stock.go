@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/stock"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func StockMovementToDTO(m model.Movement) dto.StockMovementDTO {
+	d := dto.StockMovementDTO{
+		MovementID:   m.MovementID,
+		ProductID:    m.ProductID,
+		WarehouseID:  m.WarehouseID,
+		MovementType: string(m.Type),
+		Quantity:     m.Quantity,
+		ActorID:      m.ActorID,
+		CreatedAt:    m.CreatedAt,
+	}
+	if m.Reason != "" {
+		d.Reason = &m.Reason
+	}
+	return d
+}
+
+func StockMovementFromDTO(d dto.StockMovementDTO) model.Movement {
+	m := model.Movement{
+		MovementID:  d.MovementID,
+		ProductID:   d.ProductID,
+		WarehouseID: d.WarehouseID,
+		Type:        model.MovementType(d.MovementType),
+		Quantity:    d.Quantity,
+		ActorID:     d.ActorID,
+		CreatedAt:   d.CreatedAt,
+	}
+	if d.Reason != nil {
+		m.Reason = *d.Reason
+	}
+	return m
+}
+
+func StockTransferToDTO(t model.Transfer) dto.StockTransferDTO {
+	return dto.StockTransferDTO{
+		TransferID:      t.TransferID,
+		ProductID:       t.ProductID,
+		FromWarehouseID: t.FromWarehouseID,
+		ToWarehouseID:   t.ToWarehouseID,
+		Quantity:        t.Quantity,
+		ActorID:         t.ActorID,
+		CreatedAt:       t.CreatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"hardware_store/internal/model/money"
 	model "hardware_store/internal/model/supplier"
 	"hardware_store/internal/storage/postgres/dto"
 )
@@ -22,3 +23,36 @@ func SupplierFromDTO(d dto.SupplierDTO) model.Supplier {
 		PhoneNumber: d.PhoneNumber,
 	}
 }
+
+func SupplierProductToDTO(p model.Product) dto.SupplierProductDTO {
+	d := dto.SupplierProductDTO{
+		SupplierID:       p.SupplierID,
+		ProductID:        p.ProductID,
+		PurchasePrice:    p.PurchasePrice,
+		Currency:         p.PurchasePrice.Currency,
+		LeadTimeDays:     p.LeadTimeDays,
+		MinOrderQuantity: p.MinOrderQuantity,
+		UpdatedAt:        p.UpdatedAt,
+	}
+	if p.SKU != "" {
+		d.SKU = &p.SKU
+	}
+	return d
+}
+
+func SupplierProductFromDTO(d dto.SupplierProductDTO) model.Product {
+	p := model.Product{
+		SupplierID:       d.SupplierID,
+		SupplierName:     d.SupplierName,
+		ProductID:        d.ProductID,
+		ProductName:      d.ProductName,
+		PurchasePrice:    money.New(d.PurchasePrice.Amount, d.Currency),
+		LeadTimeDays:     d.LeadTimeDays,
+		MinOrderQuantity: d.MinOrderQuantity,
+		UpdatedAt:        d.UpdatedAt,
+	}
+	if d.SKU != nil {
+		p.SKU = *d.SKU
+	}
+	return p
+}
@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	model "hardware_store/internal/model/warehouse"
+	"hardware_store/internal/storage/postgres/dto"
+)
+
+func WarehouseToDTO(w model.Warehouse) dto.WarehouseDTO {
+	return dto.WarehouseDTO{
+		WarehouseID: w.WarehouseID,
+		Name:        w.Name,
+		Kind:        string(w.Kind),
+		AddressID:   w.AddressID,
+		IsDefault:   w.IsDefault,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+func WarehouseFromDTO(d dto.WarehouseDTO) model.Warehouse {
+	return model.Warehouse{
+		WarehouseID: d.WarehouseID,
+		Name:        d.Name,
+		Kind:        model.Kind(d.Kind),
+		AddressID:   d.AddressID,
+		IsDefault:   d.IsDefault,
+		CreatedAt:   d.CreatedAt,
+	}
+}
@@ -0,0 +1,227 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var orderKeyset = postgres.Keyset{Key: "created_at", ID: "order_id", Cast: "timestamptz"}
+
+const orderColumns = `order_id, client_id, warehouse_id, status, tax_mode, total, created_at, updated_at`
+
+type orderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrderRepository(db *pgxpool.Pool) *orderRepository {
+	return &orderRepository{
+		pool: db,
+	}
+}
+
+func (r *orderRepository) Insert(ctx context.Context, o order.Order) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO orders (order_id, client_id, warehouse_id, status, tax_mode, total, created_at, updated_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	d := mapper.OrderToDTO(o)
+	if _, err := exec.Exec(ctx, query, d.OrderID, d.ClientID, d.WarehouseID, d.Status, d.TaxMode, d.Total, d.CreatedAt, d.UpdatedAt); err != nil {
+		return fmt.Errorf("ошибка создания заказа: %w", err)
+	}
+
+	itemQuery := `INSERT INTO order_items (item_id, order_id, product_id, name, quantity, unit_price, discount, tax_rate)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+	for _, item := range o.Items {
+		i := mapper.OrderItemToDTO(o.OrderID, item)
+		if _, err := exec.Exec(ctx, itemQuery, i.ItemID, i.OrderID, i.ProductID, i.Name, i.Quantity, i.UnitPrice, i.Discount, i.TaxRate); err != nil {
+			return fmt.Errorf("ошибка создания позиции заказа: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) GetById(ctx context.Context, id uuid.UUID) (order.Order, error) {
+	return r.getOne(ctx, `SELECT `+orderColumns+` FROM orders WHERE order_id = $1`, id)
+}
+
+// GetByIdForUpdate блокирует строку заказа до конца текущей транзакции.
+func (r *orderRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (order.Order, error) {
+	return r.getOne(ctx, `SELECT `+orderColumns+` FROM orders WHERE order_id = $1 FOR UPDATE`, id)
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, o order.Order) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE orders SET status = $2, updated_at = $3 WHERE order_id = $1`
+
+	tag, err := exec.Exec(ctx, query, o.OrderID, string(o.Status), o.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения статуса заказа: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrOrderNotFound
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertTransition(ctx context.Context, t order.Transition) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO order_transitions (transition_id, order_id, from_status, to_status, actor_id, created_at)
+	VALUES ($1,$2,$3,$4,$5,$6)`
+
+	d := mapper.OrderTransitionToDTO(t)
+	if _, err := exec.Exec(ctx, query, d.TransitionID, d.OrderID, d.FromStatus, d.ToStatus, d.ActorID, d.CreatedAt); err != nil {
+		return fmt.Errorf("ошибка записи перехода заказа: %w", err)
+	}
+	return nil
+}
+
+// AddReturnedQuantity увеличивает количество вернувшихся единиц позиции.
+func (r *orderRepository) AddReturnedQuantity(ctx context.Context, itemID uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE order_items SET returned_quantity = returned_quantity + $2 WHERE item_id = $1`
+
+	tag, err := exec.Exec(ctx, query, itemID, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения позиции заказа: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrOrderNotFound
+	}
+	return nil
+}
+
+func (r *orderRepository) getOne(ctx context.Context, query string, id uuid.UUID) (order.Order, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.OrderDTO
+	err := exec.QueryRow(ctx, query, id).Scan(&d.OrderID, &d.ClientID, &d.WarehouseID, &d.Status, &d.TaxMode, &d.Total, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return order.Order{}, storage.ErrOrderNotFound
+		}
+		return order.Order{}, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+
+	orders := []order.Order{mapper.OrderFromDTO(d)}
+	if err := r.loadDetails(ctx, exec, orders); err != nil {
+		return order.Order{}, err
+	}
+	return orders[0], nil
+}
+
+func (r *orderRepository) GetByClient(ctx context.Context, clientID uuid.UUID, req page.Request) ([]order.Order, error) {
+	query, args := orderKeyset.Apply(`SELECT `+orderColumns+` FROM orders`,
+		[]string{"client_id = $1"}, []any{clientID}, req)
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заказов: %w", err)
+	}
+	defer row.Close()
+	var orders []order.Order
+	for row.Next() {
+		var d dto.OrderDTO
+
+		if err := row.Scan(&d.OrderID, &d.ClientID, &d.WarehouseID, &d.Status, &d.TaxMode, &d.Total, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		orders = append(orders, mapper.OrderFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	if err := r.loadDetails(ctx, r.pool, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// loadDetails дозагружает позиции и историю статусов для списка заказов.
+func (r *orderRepository) loadDetails(ctx context.Context, exec tx.Executer, orders []order.Order) error {
+	ids := make([]uuid.UUID, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.OrderID)
+	}
+	items, err := r.getItems(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	transitions, err := r.getTransitions(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		orders[i].Items = items[orders[i].OrderID]
+		orders[i].Transitions = transitions[orders[i].OrderID]
+	}
+	return nil
+}
+
+// getItems загружает позиции сразу для нескольких заказов одним запросом.
+func (r *orderRepository) getItems(ctx context.Context, exec tx.Executer, orderIDs []uuid.UUID) (map[uuid.UUID][]order.Item, error) {
+	query := `SELECT item_id, order_id, product_id, name, quantity, unit_price, discount, tax_rate, returned_quantity
+	FROM order_items
+	WHERE order_id = ANY($1)
+	ORDER BY order_id, name, item_id`
+
+	row, err := exec.Query(ctx, query, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения позиций заказа: %w", err)
+	}
+	defer row.Close()
+	items := make(map[uuid.UUID][]order.Item, len(orderIDs))
+	for row.Next() {
+		var d dto.OrderItemDTO
+
+		if err := row.Scan(&d.ItemID, &d.OrderID, &d.ProductID, &d.Name, &d.Quantity, &d.UnitPrice, &d.Discount, &d.TaxRate, &d.ReturnedQuantity); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		items[d.OrderID] = append(items[d.OrderID], mapper.OrderItemFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return items, nil
+}
+
+func (r *orderRepository) getTransitions(ctx context.Context, exec tx.Executer, orderIDs []uuid.UUID) (map[uuid.UUID][]order.Transition, error) {
+	query := `SELECT transition_id, order_id, from_status, to_status, actor_id, created_at
+	FROM order_transitions
+	WHERE order_id = ANY($1)
+	ORDER BY order_id, created_at, transition_id`
+
+	row, err := exec.Query(ctx, query, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории заказа: %w", err)
+	}
+	defer row.Close()
+	transitions := make(map[uuid.UUID][]order.Transition, len(orderIDs))
+	for row.Next() {
+		var d dto.OrderTransitionDTO
+
+		if err := row.Scan(&d.TransitionID, &d.OrderID, &d.FromStatus, &d.ToStatus, &d.ActorID, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		transitions[d.OrderID] = append(transitions[d.OrderID], mapper.OrderTransitionFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return transitions, nil
+}
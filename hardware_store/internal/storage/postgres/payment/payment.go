@@ -0,0 +1,215 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/payment"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const paymentColumns = `payment_id, order_id, provider, external_id, status, amount, refunded, confirmation_url, created_at, updated_at`
+
+const refundColumns = `refund_id, payment_id, return_id, idempotency_key, status, amount, actor_id, created_at`
+
+type paymentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPaymentRepository(db *pgxpool.Pool) *paymentRepository {
+	return &paymentRepository{
+		pool: db,
+	}
+}
+
+func (r *paymentRepository) Insert(ctx context.Context, p payment.Payment) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO payments (` + paymentColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+	d := mapper.PaymentToDTO(p)
+	_, err := exec.Exec(ctx, query, d.PaymentID, d.OrderID, d.Provider, d.ExternalID, d.Status, d.Amount, d.Refunded,
+		d.ConfirmationURL, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания платежа: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentRepository) GetById(ctx context.Context, id uuid.UUID) (payment.Payment, error) {
+	return r.getOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE payment_id = $1`, id)
+}
+
+// GetByIdForUpdate блокирует платёж до конца текущей транзакции.
+func (r *paymentRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (payment.Payment, error) {
+	return r.getOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE payment_id = $1 FOR UPDATE`, id)
+}
+
+// GetByExternalIDForUpdate находит платёж по идентификатору в шлюзе и
+// блокирует его до конца текущей транзакции.
+func (r *paymentRepository) GetByExternalIDForUpdate(ctx context.Context, provider, externalID string) (payment.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND external_id = $2 FOR UPDATE`
+	return r.getOne(ctx, query, provider, externalID)
+}
+
+func (r *paymentRepository) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]payment.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 ORDER BY created_at, payment_id`
+	return r.getMany(ctx, query, orderID)
+}
+
+// GetOpen возвращает платежи шлюза provider, которые ещё могут измениться
+// на его стороне.
+func (r *paymentRepository) GetOpen(ctx context.Context, provider string) ([]payment.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments
+	WHERE provider = $1 AND status IN ('pending', 'authorized')
+	ORDER BY created_at, payment_id`
+	return r.getMany(ctx, query, provider)
+}
+
+func (r *paymentRepository) Update(ctx context.Context, p payment.Payment) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE payments SET status = $2, refunded = $3, updated_at = $4 WHERE payment_id = $1`
+
+	tag, err := exec.Exec(ctx, query, p.PaymentID, string(p.Status), p.Refunded, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения платежа: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPaymentNotFound
+	}
+	return nil
+}
+
+func (r *paymentRepository) InsertRefund(ctx context.Context, refund payment.Refund) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO payment_refunds (` + refundColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	d := mapper.PaymentRefundToDTO(refund)
+	_, err := exec.Exec(ctx, query, d.RefundID, d.PaymentID, d.ReturnID, d.Key, d.Status, d.Amount, d.ActorID, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи возврата по платежу: %w", err)
+	}
+	return nil
+}
+
+// GetRefundByKey находит возврат по платежу с ключом идемпотентности key.
+func (r *paymentRepository) GetRefundByKey(ctx context.Context, paymentID uuid.UUID, key string) (payment.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM payment_refunds WHERE payment_id = $1 AND idempotency_key = $2`
+	list, err := r.getRefunds(ctx, query, paymentID, key)
+	if err != nil {
+		return payment.Refund{}, err
+	}
+	if len(list) == 0 {
+		return payment.Refund{}, storage.ErrRefundNotFound
+	}
+	return list[0], nil
+}
+
+// GetPendingRefunds возвращает возвраты по платежам шлюза provider, которые
+// шлюз ещё не подтвердил. Если paymentID задан, только по этому платежу.
+func (r *paymentRepository) GetPendingRefunds(ctx context.Context, provider string, paymentID *uuid.UUID) ([]payment.Refund, error) {
+	query := `SELECT f.refund_id, f.payment_id, f.return_id, f.idempotency_key, f.status, f.amount, f.actor_id, f.created_at
+	FROM payment_refunds f
+	JOIN payments p ON p.payment_id = f.payment_id
+	WHERE f.status = 'pending' AND p.provider = $1 AND ($2::uuid IS NULL OR f.payment_id = $2)
+	ORDER BY f.created_at, f.refund_id`
+	return r.getRefunds(ctx, query, provider, paymentID)
+}
+
+// CompleteRefund отмечает возврат подтверждённым шлюзом. Возвращает false,
+// если возврат уже был подтверждён.
+func (r *paymentRepository) CompleteRefund(ctx context.Context, refundID uuid.UUID) (bool, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE payment_refunds SET status = 'succeeded' WHERE refund_id = $1 AND status = 'pending'`
+
+	tag, err := exec.Exec(ctx, query, refundID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка подтверждения возврата по платежу: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// RecordEvent отмечает уведомление шлюза обработанным. Возвращает false, если
+// событие с таким идентификатором уже было обработано.
+func (r *paymentRepository) RecordEvent(ctx context.Context, provider string, e payment.Event, at time.Time) (bool, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO payment_events (provider, event_id, external_id, status, received_at)
+	VALUES ($1,$2,$3,$4,$5)
+	ON CONFLICT (provider, event_id) DO NOTHING`
+
+	tag, err := exec.Exec(ctx, query, provider, e.EventID, e.ExternalID, string(e.Status), at)
+	if err != nil {
+		return false, fmt.Errorf("ошибка записи уведомления о платеже: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *paymentRepository) getOne(ctx context.Context, query string, args ...any) (payment.Payment, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.PaymentDTO
+	err := exec.QueryRow(ctx, query, args...).Scan(&d.PaymentID, &d.OrderID, &d.Provider, &d.ExternalID, &d.Status,
+		&d.Amount, &d.Refunded, &d.ConfirmationURL, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return payment.Payment{}, storage.ErrPaymentNotFound
+		}
+		return payment.Payment{}, fmt.Errorf("ошибка получения платежа: %w", err)
+	}
+	return mapper.PaymentFromDTO(d), nil
+}
+
+func (r *paymentRepository) getMany(ctx context.Context, query string, args ...any) ([]payment.Payment, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	row, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения платежей: %w", err)
+	}
+	defer row.Close()
+	var list []payment.Payment
+	for row.Next() {
+		var d dto.PaymentDTO
+
+		if err := row.Scan(&d.PaymentID, &d.OrderID, &d.Provider, &d.ExternalID, &d.Status, &d.Amount, &d.Refunded,
+			&d.ConfirmationURL, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		list = append(list, mapper.PaymentFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return list, nil
+}
+
+func (r *paymentRepository) getRefunds(ctx context.Context, query string, args ...any) ([]payment.Refund, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	row, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения возвратов по платежу: %w", err)
+	}
+	defer row.Close()
+	var list []payment.Refund
+	for row.Next() {
+		var d dto.PaymentRefundDTO
+
+		if err := row.Scan(&d.RefundID, &d.PaymentID, &d.ReturnID, &d.Key, &d.Status, &d.Amount, &d.ActorID,
+			&d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		list = append(list, mapper.PaymentRefundFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return list, nil
+}
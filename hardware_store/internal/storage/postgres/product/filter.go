@@ -0,0 +1,91 @@
+package product
+
+import (
+	"fmt"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/storage/postgres"
+	"strings"
+)
+
+// sortColumns белый список колонок для ORDER BY, значения из запроса
+// никогда не подставляются в SQL напрямую.
+var sortColumns = map[string]string{
+	product.SortByPrice:          "price",
+	product.SortByName:           "name",
+	product.SortByLastUpdateDate: "last_update_date",
+}
+
+// sortCasts типы, к которым приводится значение ключа из курсора.
+var sortCasts = map[string]string{
+	product.SortByPrice:          "numeric",
+	product.SortByName:           "text",
+	product.SortByLastUpdateDate: "timestamptz",
+}
+
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+// add добавляет условие, в котором "?" заменяется на номер следующего параметра.
+func (b *whereBuilder) add(cond string, arg any) {
+	b.args = append(b.args, arg)
+	b.conds = append(b.conds, strings.Replace(cond, "?", fmt.Sprintf("$%d", len(b.args)), 1))
+}
+
+func (b *whereBuilder) addRaw(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+func (b *whereBuilder) String() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
+
+func buildWhere(f product.Filter) *whereBuilder {
+	b := &whereBuilder{}
+	if f.CategoryID != nil {
+		b.add("category_id = ?", *f.CategoryID)
+	}
+	if f.SupplierID != nil {
+		b.add("supplier_id = ?", *f.SupplierID)
+	}
+	if f.WarehouseID != nil {
+		b.add(`EXISTS (SELECT 1 FROM warehouse_stock ws
+		WHERE ws.product_id = product.product_id AND ws.warehouse_id = ? AND ws.quantity > 0)`, *f.WarehouseID)
+	}
+	if f.MinPrice != nil {
+		b.add("price >= ?", *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		b.add("price <= ?", *f.MaxPrice)
+	}
+	if f.InStock {
+		b.addRaw("available_stock > reserved_stock")
+	}
+	if f.Name != "" {
+		b.add(`name ILIKE '%' || ? || '%' ESCAPE '\'`, escapeLike(f.Name))
+	}
+	return b
+}
+
+// buildKeyset порядок выборки. product_id делает порядок стабильным
+// при одинаковых значениях ключа сортировки.
+func buildKeyset(f product.Filter) postgres.Keyset {
+	sortBy := f.SortBy
+	if _, ok := sortColumns[sortBy]; !ok {
+		sortBy = product.SortByName
+	}
+	return postgres.Keyset{
+		Key:  sortColumns[sortBy],
+		ID:   "product_id",
+		Cast: sortCasts[sortBy],
+		Desc: f.Order == product.OrderDesc,
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,209 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var priceKeyset = postgres.Keyset{Key: "valid_from", ID: "price_id", Cast: "timestamptz", Desc: true}
+
+const priceColumns = `price_id, product_id, price, valid_from, valid_to, reason, created_by, created_at, applied_at`
+
+// InsertPrice добавляет в историю цену, которая уже действует.
+func (r *productRepository) InsertPrice(ctx context.Context, p product.Price) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO product_prices (` + priceColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	d := mapper.ProductPriceToDTO(p)
+	_, err := exec.Exec(ctx, query, d.PriceID, d.ProductID, d.Price, d.ValidFrom, d.ValidTo, d.Reason,
+		d.CreatedBy, d.CreatedAt, d.AppliedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи истории цен: %w", err)
+	}
+	return nil
+}
+
+// SchedulePrice планирует цену. Цена, уже запланированная на тот же момент,
+// заменяется новой.
+func (r *productRepository) SchedulePrice(ctx context.Context, p product.Price) (product.Price, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO product_prices (` + priceColumns + `) VALUES ($1,$2,$3,$4,NULL,$5,$6,$7,NULL)
+	ON CONFLICT (product_id, valid_from) WHERE applied_at IS NULL DO UPDATE SET
+	price = EXCLUDED.price,
+	reason = EXCLUDED.reason,
+	created_by = EXCLUDED.created_by,
+	created_at = EXCLUDED.created_at
+	RETURNING ` + priceColumns
+
+	d := mapper.ProductPriceToDTO(p)
+	rows, err := exec.Query(ctx, query, d.PriceID, d.ProductID, d.Price, d.ValidFrom, d.Reason, d.CreatedBy, d.CreatedAt)
+	if err != nil {
+		return product.Price{}, fmt.Errorf("ошибка планирования цены: %w", err)
+	}
+	prices, err := collectPrices(rows)
+	if err != nil {
+		return product.Price{}, err
+	}
+	if len(prices) == 0 {
+		return product.Price{}, fmt.Errorf("ошибка планирования цены: %w", pgx.ErrNoRows)
+	}
+	return prices[0], nil
+}
+
+// ClosePrice завершает действующую цену товара моментом at. Если цена
+// начала действовать позже at, она завершается в момент начала.
+func (r *productRepository) ClosePrice(ctx context.Context, productID uuid.UUID, at time.Time) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product_prices SET valid_to = GREATEST(valid_from, $2)
+	WHERE product_id = $1 AND applied_at IS NOT NULL AND valid_to IS NULL`
+
+	if _, err := exec.Exec(ctx, query, productID, at); err != nil {
+		return fmt.Errorf("ошибка закрытия действующей цены: %w", err)
+	}
+	return nil
+}
+
+// ApplyPrice отмечает запланированную цену применённой и ставит её товару.
+func (r *productRepository) ApplyPrice(ctx context.Context, p product.Price, at time.Time) error {
+	exec := tx.FromContext(ctx, r.pool)
+	d := mapper.ProductPriceToDTO(p)
+
+	if _, err := exec.Exec(ctx, `UPDATE product_prices SET applied_at = $2 WHERE price_id = $1`, d.PriceID, at); err != nil {
+		return fmt.Errorf("ошибка применения цены: %w", err)
+	}
+	tag, err := exec.Exec(ctx, `UPDATE product SET price = $2, last_update_date = $3 WHERE product_id = $1`,
+		d.ProductID, d.Price, at)
+	if err != nil {
+		return fmt.Errorf("ошибка применения цены: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+// DeleteScheduledPrice отменяет ещё не применённую цену.
+func (r *productRepository) DeleteScheduledPrice(ctx context.Context, productID, priceID uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `DELETE FROM product_prices WHERE price_id = $1 AND product_id = $2 AND applied_at IS NULL`
+
+	tag, err := exec.Exec(ctx, query, priceID, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка отмены цены: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPriceChangeNotFound
+	}
+	return nil
+}
+
+// GetPrices возвращает историю цен товара от поздних к ранним вместе с
+// запланированными.
+func (r *productRepository) GetPrices(ctx context.Context, productID uuid.UUID, req page.Request) ([]product.Price, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query, args := priceKeyset.Apply(`SELECT `+priceColumns+` FROM product_prices`,
+		[]string{"product_id = $1"}, []any{productID}, req)
+
+	rows, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории цен: %w", err)
+	}
+	return collectPrices(rows)
+}
+
+// GetDuePricesForUpdate блокирует запланированные цены, срок которых
+// наступил к now, в порядке наступления. Заблокированные другой транзакцией
+// пропускаются.
+func (r *productRepository) GetDuePricesForUpdate(ctx context.Context, now time.Time, limit int) ([]product.Price, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT ` + priceColumns + ` FROM product_prices
+	WHERE applied_at IS NULL AND valid_from <= $1
+	ORDER BY valid_from, price_id
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+
+	rows, err := exec.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения запланированных цен: %w", err)
+	}
+	return collectPrices(rows)
+}
+
+// GetForRepricing блокирует товары по фильтру до конца транзакции и
+// возвращает их в порядке названий. Учитываются только категория,
+// поставщик и диапазон цен.
+func (r *productRepository) GetForRepricing(ctx context.Context, filter product.Filter) ([]product.Product, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	where := buildWhere(product.Filter{
+		CategoryID: filter.CategoryID,
+		SupplierID: filter.SupplierID,
+		MinPrice:   filter.MinPrice,
+		MaxPrice:   filter.MaxPrice,
+	})
+	query := `SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku
+	FROM product` + where.String() + `
+	ORDER BY name, product_id
+	FOR UPDATE`
+
+	rows, err := exec.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения товаров для переоценки: %w", err)
+	}
+	defer rows.Close()
+	var products []product.Product
+	for rows.Next() {
+		var d dto.ProductDTO
+		if err := rows.Scan(&d.ProductID, &d.Name, &d.CategoryID, &d.Price, &d.AvailableStock, &d.ReservedStock, &d.LastUpdateDate, &d.SupplierID, &d.ImageID, &d.SKU); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		products = append(products, mapper.ProductFromDTO(d))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return products, nil
+}
+
+// UpdatePrice меняет цену товара, не трогая остальные поля.
+func (r *productRepository) UpdatePrice(ctx context.Context, p product.Product) error {
+	exec := tx.FromContext(ctx, r.pool)
+	d := mapper.ProductToDTO(p)
+
+	tag, err := exec.Exec(ctx, `UPDATE product SET price = $2, last_update_date = $3 WHERE product_id = $1`,
+		d.ProductID, d.Price, d.LastUpdateDate)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения цены: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+func collectPrices(rows pgx.Rows) ([]product.Price, error) {
+	defer rows.Close()
+	var prices []product.Price
+	for rows.Next() {
+		var d dto.ProductPriceDTO
+		if err := rows.Scan(&d.PriceID, &d.ProductID, &d.Price, &d.ValidFrom, &d.ValidTo, &d.Reason,
+			&d.CreatedBy, &d.CreatedAt, &d.AppliedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		prices = append(prices, mapper.ProductPriceFromDTO(d))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return prices, nil
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/product"
 	"hardware_store/internal/storage"
 	"hardware_store/internal/storage/postgres/dto"
 	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
 	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -28,12 +31,16 @@ func NewProductRepository(db *pgxpool.Pool, log *slog.Logger) *productRepository
 }
 
 func (r *productRepository) Insert(ctx context.Context, product product.Product) error {
+	exec := tx.FromContext(ctx, r.pool)
 	dto := mapper.ProductToDTO(product)
 	query := `INSERT INTO product 
-	(product_id, name, category_id, price, available_stock, last_update_date, supplier_id)
-	VALUES ($1,$2,$3,$4,$5,$6,$7)`
-	_, err := r.pool.Exec(ctx, query, dto.ProductID, dto.Name, dto.CategoryID, dto.Price, dto.AvailableStock, dto.LastUpdateDate, dto.SupplierID)
+	(product_id, name, category_id, price, available_stock, last_update_date, supplier_id, sku)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+	_, err := exec.Exec(ctx, query, dto.ProductID, dto.Name, dto.CategoryID, dto.Price, dto.AvailableStock, dto.LastUpdateDate, dto.SupplierID, dto.SKU)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrDuplicateProductSKU
+		}
 		r.log.Error("failed to insert product",
 			slog.Any("error", err),
 			slog.String("product_id", dto.ProductID.String()),
@@ -44,22 +51,36 @@ func (r *productRepository) Insert(ctx context.Context, product product.Product)
 	return nil
 }
 
-func (r *productRepository) UpdateBalance(ctx context.Context, id uuid.UUID, col int) (product.Product, error) {
-	query := `UPDATE product 
-	SET available_stock = available_stock - $2
-	WHERE product_id = $1 AND available_stock >= $2
-	RETURNING product_id, name, category_id, price, available_stock, last_update_date, supplier_id, image_id`
+func (r *productRepository) Update(ctx context.Context, p product.Product) (product.Product, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product
+	SET name = $2, category_id = $3, price = $4, available_stock = $5, last_update_date = $6, supplier_id = $7, sku = $8
+	WHERE product_id = $1
+	RETURNING product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku`
 
+	in := mapper.ProductToDTO(p)
 	var dto dto.ProductDTO
 
-	err := r.pool.QueryRow(ctx, query, id, col).Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID)
+	err := exec.QueryRow(ctx, query, in.ProductID, in.Name, nullUUID(in.CategoryID), in.Price, in.AvailableStock, in.LastUpdateDate, nullUUID(in.SupplierID), in.SKU).
+		Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.ReservedStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID, &dto.SKU)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return product.Product{}, storage.ErrProductNotFound
 		}
-		return product.Product{}, fmt.Errorf("ошибка обновления товара: %w", err)
+		if isUniqueViolation(err) {
+			return product.Product{}, storage.ErrDuplicateProductSKU
+		}
+		r.log.Error("failed to update product",
+			slog.Any("error", err),
+			slog.String("product_id", in.ProductID.String()),
+		)
+		return product.Product{}, storage.ErrUpdate
 	}
-	return mapper.ProductFromDTO(dto), nil
+	products := []product.Product{mapper.ProductFromDTO(dto)}
+	if err := r.loadLocations(ctx, exec, products); err != nil {
+		return product.Product{}, err
+	}
+	return products[0], nil
 }
 
 func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -73,40 +94,248 @@ func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *productRepository) GetById(ctx context.Context, id uuid.UUID) (product.Product, error) {
-	query := `SELECT * FROM product 
+	query := `SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku
+	FROM product
 	WHERE product_id = $1`
 
 	var dto dto.ProductDTO
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID)
+	err := r.pool.QueryRow(ctx, query, id).Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.ReservedStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID, &dto.SKU)
 	if err != nil {
 		return product.Product{}, storage.ErrProductNotFound
 	}
+	products := []product.Product{mapper.ProductFromDTO(dto)}
+	if err := r.loadLocations(ctx, r.pool, products); err != nil {
+		return product.Product{}, err
+	}
+	return products[0], nil
+}
+
+// GetBySKU ищет товар по артикулу магазина.
+func (r *productRepository) GetBySKU(ctx context.Context, sku string) (product.Product, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku
+	FROM product
+	WHERE sku = $1`
+
+	var dto dto.ProductDTO
+
+	err := exec.QueryRow(ctx, query, sku).Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.ReservedStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID, &dto.SKU)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return product.Product{}, storage.ErrProductNotFound
+		}
+		return product.Product{}, fmt.Errorf("ошибка получения товара по артикулу: %w", err)
+	}
 	return mapper.ProductFromDTO(dto), nil
 }
 
-func (r *productRepository) GetAll(ctx context.Context) ([]product.Product, error) {
-	query := `SELECT * FROM product
-	WHERE available_stock > 0`
+// GetByIdForUpdate блокирует строку товара до конца текущей транзакции.
+func (r *productRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (product.Product, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku
+	FROM product
+	WHERE product_id = $1
+	FOR UPDATE`
+
+	var dto dto.ProductDTO
 
-	row, err := r.pool.Query(ctx, query)
+	err := exec.QueryRow(ctx, query, id).Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.ReservedStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID, &dto.SKU)
 	if err != nil {
-		return []product.Product{}, storage.ErrProductNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return product.Product{}, storage.ErrProductNotFound
+		}
+		return product.Product{}, fmt.Errorf("ошибка получения товара: %w", err)
+	}
+	return mapper.ProductFromDTO(dto), nil
+}
+
+// Reserve увеличивает зарезервированный остаток товара и склада warehouseID,
+// если свободного остатка хватает и в целом, и на этом складе. Строка товара
+// блокируется раньше строки склада, в том же порядке, что и при движениях.
+func (r *productRepository) Reserve(ctx context.Context, id, warehouseID uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product
+	SET reserved_stock = reserved_stock + $2
+	WHERE product_id = $1 AND available_stock - reserved_stock >= $2`
+
+	tag, err := exec.Exec(ctx, query, id, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка резервирования товара: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.stockError(ctx, exec, id)
+	}
+
+	query = `UPDATE warehouse_stock
+	SET reserved = reserved + $3
+	WHERE warehouse_id = $1 AND product_id = $2 AND quantity - reserved >= $3`
+	tag, err = exec.Exec(ctx, query, warehouseID, id, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка резервирования товара на складе: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w in warehouse %s", storage.ErrInsufficientStock, warehouseID)
+	}
+	return nil
+}
+
+// Unreserve снимает резерв с товара и со склада warehouseID.
+func (r *productRepository) Unreserve(ctx context.Context, id, warehouseID uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product
+	SET reserved_stock = reserved_stock - $2
+	WHERE product_id = $1 AND reserved_stock >= $2`
+
+	tag, err := exec.Exec(ctx, query, id, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка снятия резерва товара: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.stockError(ctx, exec, id)
+	}
+
+	query = `UPDATE warehouse_stock
+	SET reserved = reserved - $3
+	WHERE warehouse_id = $1 AND product_id = $2 AND reserved >= $3`
+	tag, err = exec.Exec(ctx, query, warehouseID, id, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка снятия резерва товара на складе: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w in warehouse %s", storage.ErrInsufficientStock, warehouseID)
+	}
+	return nil
+}
+
+// stockError различает отсутствующий товар и нехватку остатка после
+// неудачного условного обновления.
+func (r *productRepository) stockError(ctx context.Context, exec tx.Executer, id uuid.UUID) error {
+	var exists bool
+	if err := exec.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM product WHERE product_id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("ошибка проверки товара: %w", err)
+	}
+	if !exists {
+		return storage.ErrProductNotFound
+	}
+	return storage.ErrInsufficientStock
+}
+
+func (r *productRepository) GetAll(ctx context.Context, filter product.Filter) ([]product.Product, int, error) {
+	where := buildWhere(filter)
+
+	countQuery := `SELECT COUNT(*) FROM product` + where.String()
+	var total int
+	if err := r.pool.QueryRow(ctx, countQuery, where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчёта товаров: %w", err)
+	}
+
+	query, args := buildKeyset(filter).Apply(`SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku
+	FROM product`, where.conds, where.args, filter.Page)
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return []product.Product{}, 0, storage.ErrProductNotFound
 	}
 	defer row.Close()
 	var products []product.Product
 	for row.Next() {
 		var dto dto.ProductDTO
 
-		if err := row.Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID); err != nil {
-			return []product.Product{}, fmt.Errorf("ошибка сканирования строки: %w", err)
+		if err := row.Scan(&dto.ProductID, &dto.Name, &dto.CategoryID, &dto.Price, &dto.AvailableStock, &dto.ReservedStock, &dto.LastUpdateDate, &dto.SupplierID, &dto.ImageID, &dto.SKU); err != nil {
+			return []product.Product{}, 0, fmt.Errorf("ошибка сканирования строки: %w", err)
 		}
 		products = append(products, mapper.ProductFromDTO(dto))
 	}
 
 	if err = row.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+		return nil, 0, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	if err := r.loadLocations(ctx, r.pool, products); err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// Export передаёт fn товары по фильтру в порядке сортировки фильтра без
+// постраничного ограничения. Строки читаются из курсора pgx по мере
+// обработки и не накапливаются в памяти; ошибка fn прерывает выборку.
+func (r *productRepository) Export(ctx context.Context, filter product.Filter, fn func(product.ExportRow) error) error {
+	where := buildWhere(filter)
+	query, args := buildKeyset(filter).Apply(`SELECT product_id, name, category_id, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, sku,
+		(SELECT c.category FROM category c WHERE c.category_id = product.category_id),
+		(SELECT s.name FROM supplier s WHERE s.supplier_id = product.supplier_id)
+	FROM product`, where.conds, where.args, page.Request{})
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("ошибка выгрузки товаров: %w", err)
+	}
+	defer row.Close()
+	for row.Next() {
+		var d dto.ProductExportDTO
+
+		if err := row.Scan(&d.ProductID, &d.Name, &d.CategoryID, &d.Price, &d.AvailableStock, &d.ReservedStock, &d.LastUpdateDate, &d.SupplierID, &d.ImageID, &d.SKU,
+			&d.CategoryName, &d.SupplierName); err != nil {
+			return fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		if err := fn(mapper.ProductExportFromDTO(d)); err != nil {
+			return err
+		}
+	}
+	if err = row.Err(); err != nil {
+		return fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return nil
+}
+
+// loadLocations дозагружает остатки по складам одним запросом для всех товаров.
+func (r *productRepository) loadLocations(ctx context.Context, exec tx.Executer, products []product.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ProductID)
+	}
+	query := `SELECT ws.warehouse_id, ws.product_id, w.name, ws.quantity
+	FROM warehouse_stock ws
+	JOIN warehouses w ON w.warehouse_id = ws.warehouse_id
+	WHERE ws.product_id = ANY($1) AND ws.quantity > 0
+	ORDER BY ws.product_id, w.name, ws.warehouse_id`
+
+	row, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения остатков по складам: %w", err)
+	}
+	defer row.Close()
+	locations := make(map[uuid.UUID][]product.Location, len(products))
+	for row.Next() {
+		var d dto.WarehouseStockDTO
+
+		if err := row.Scan(&d.WarehouseID, &d.ProductID, &d.Name, &d.Quantity); err != nil {
+			return fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		locations[d.ProductID] = append(locations[d.ProductID], mapper.LocationFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	for i := range products {
+		products[i].Locations = locations[products[i].ProductID]
 	}
+	return nil
+}
 
-	return products, nil
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
 }
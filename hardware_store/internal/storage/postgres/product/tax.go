@@ -0,0 +1,71 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+)
+
+// SetTaxRate задаёт ставку налога товара; nil возвращает товару ставку
+// категории.
+func (r *productRepository) SetTaxRate(ctx context.Context, id uuid.UUID, rate *money.Rate) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product SET tax_rate = $2 WHERE product_id = $1`
+
+	var bp *int64
+	if rate != nil {
+		v := int64(*rate)
+		bp = &v
+	}
+	tag, err := exec.Exec(ctx, query, id, bp)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения ставки налога: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+// GetTaxRates возвращает ставки товаров и их категорий. Действующая ставка
+// не выбирается: товары, которых нет, пропускаются.
+func (r *productRepository) GetTaxRates(ctx context.Context, ids []uuid.UUID) ([]tax.ProductRate, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT p.product_id, p.tax_rate, c.tax_rate
+	FROM product p
+	LEFT JOIN category c ON c.category_id = p.category_id
+	WHERE p.product_id = ANY($1)`
+
+	rows, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ставок налога: %w", err)
+	}
+	defer rows.Close()
+	var rates []tax.ProductRate
+	for rows.Next() {
+		var id uuid.UUID
+		var override, category *int64
+		if err := rows.Scan(&id, &override, &category); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		rate := tax.ProductRate{ProductID: id}
+		if override != nil {
+			v := money.Rate(*override)
+			rate.Override = &v
+		}
+		if category != nil {
+			v := money.Rate(*category)
+			rate.CategoryRate = &v
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,199 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/promotion"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var promotionKeyset = postgres.Keyset{Key: "created_at", ID: "promotion_id", Cast: "timestamptz", Desc: true}
+
+const promotionColumns = `promotion_id, name, kind, percent, amount, buy_quantity, get_quantity, category_id, supplier_id,
+	code, usage_limit, per_client_limit, valid_from, valid_to, stackable, priority, active, created_at, updated_at`
+
+type promotionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPromotionRepository(db *pgxpool.Pool) *promotionRepository {
+	return &promotionRepository{
+		pool: db,
+	}
+}
+
+func (r *promotionRepository) Insert(ctx context.Context, p promotion.Promotion) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO promotions (` + promotionColumns + `)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)`
+
+	d := mapper.PromotionToDTO(p)
+	_, err := exec.Exec(ctx, query, d.PromotionID, d.Name, d.Kind, d.Percent, d.Amount, d.BuyQuantity, d.GetQuantity,
+		d.CategoryID, d.SupplierID, d.Code, d.UsageLimit, d.PerClientLimit, d.ValidFrom, d.ValidTo,
+		d.Stackable, d.Priority, d.Active, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return writeError(err, "ошибка создания акции")
+	}
+	return nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, p promotion.Promotion) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE promotions SET name = $2, kind = $3, percent = $4, amount = $5, buy_quantity = $6, get_quantity = $7,
+	category_id = $8, supplier_id = $9, code = $10, usage_limit = $11, per_client_limit = $12, valid_from = $13,
+	valid_to = $14, stackable = $15, priority = $16, active = $17, updated_at = $18
+	WHERE promotion_id = $1`
+
+	d := mapper.PromotionToDTO(p)
+	tag, err := exec.Exec(ctx, query, d.PromotionID, d.Name, d.Kind, d.Percent, d.Amount, d.BuyQuantity, d.GetQuantity,
+		d.CategoryID, d.SupplierID, d.Code, d.UsageLimit, d.PerClientLimit, d.ValidFrom, d.ValidTo,
+		d.Stackable, d.Priority, d.Active, d.UpdatedAt)
+	if err != nil {
+		return writeError(err, "ошибка изменения акции")
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *promotionRepository) GetById(ctx context.Context, id uuid.UUID) (promotion.Promotion, error) {
+	promos, err := r.query(ctx, `SELECT `+promotionColumns+` FROM promotions WHERE promotion_id = $1`, id)
+	if err != nil {
+		return promotion.Promotion{}, err
+	}
+	if len(promos) == 0 {
+		return promotion.Promotion{}, storage.ErrPromotionNotFound
+	}
+	return promos[0], nil
+}
+
+// GetAll возвращает акции от новых к старым.
+func (r *promotionRepository) GetAll(ctx context.Context, req page.Request) ([]promotion.Promotion, error) {
+	query, args := promotionKeyset.Apply(`SELECT `+promotionColumns+` FROM promotions`, nil, nil, req)
+	return r.query(ctx, query, args...)
+}
+
+// GetActive возвращает акции, действующие в момент now: все акции без кода
+// и акции с кодами из codes.
+func (r *promotionRepository) GetActive(ctx context.Context, now time.Time, codes []string) ([]promotion.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions
+	WHERE active
+	AND (valid_from IS NULL OR valid_from <= $1)
+	AND (valid_to IS NULL OR valid_to > $1)
+	AND (code IS NULL OR code = ANY($2))`
+	return r.query(ctx, query, now, codes)
+}
+
+// LockLimited блокирует до конца транзакции акции с лимитами, чтобы
+// параллельные заказы не превысили лимит. Строки блокируются в порядке
+// promotion_id.
+func (r *promotionRepository) LockLimited(ctx context.Context, ids []uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT promotion_id FROM promotions
+	WHERE promotion_id = ANY($1) AND (usage_limit IS NOT NULL OR per_client_limit IS NOT NULL)
+	ORDER BY promotion_id
+	FOR UPDATE`
+
+	rows, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("ошибка блокировки акций: %w", err)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ошибка блокировки акций: %w", err)
+	}
+	return nil
+}
+
+// GetUsage считает погашения акций неотменёнными заказами: всего и у
+// клиента clientID.
+func (r *promotionRepository) GetUsage(ctx context.Context, ids []uuid.UUID, clientID *uuid.UUID) (map[uuid.UUID]promotion.Usage, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT r.promotion_id, COUNT(*), COUNT(*) FILTER (WHERE r.client_id = $2)
+	FROM promotion_redemptions r
+	JOIN orders o ON o.order_id = r.order_id
+	WHERE r.promotion_id = ANY($1) AND o.status <> 'cancelled'
+	GROUP BY r.promotion_id`
+
+	rows, err := exec.Query(ctx, query, ids, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка подсчёта погашений акций: %w", err)
+	}
+	defer rows.Close()
+	usage := make(map[uuid.UUID]promotion.Usage, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		var u promotion.Usage
+		if err := rows.Scan(&id, &u.Total, &u.Client); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		usage[id] = u
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return usage, nil
+}
+
+func (r *promotionRepository) InsertRedemption(ctx context.Context, rd promotion.Redemption) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO promotion_redemptions (promotion_id, order_id, client_id, amount, created_at)
+	VALUES ($1,$2,$3,$4,$5)`
+
+	d := mapper.PromotionRedemptionToDTO(rd)
+	if _, err := exec.Exec(ctx, query, d.PromotionID, d.OrderID, d.ClientID, d.Amount, d.CreatedAt); err != nil {
+		return fmt.Errorf("ошибка записи погашения акции: %w", err)
+	}
+	return nil
+}
+
+func (r *promotionRepository) query(ctx context.Context, query string, args ...any) ([]promotion.Promotion, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	rows, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения акций: %w", err)
+	}
+	defer rows.Close()
+	var promos []promotion.Promotion
+	for rows.Next() {
+		var d dto.PromotionDTO
+		if err := rows.Scan(&d.PromotionID, &d.Name, &d.Kind, &d.Percent, &d.Amount, &d.BuyQuantity, &d.GetQuantity,
+			&d.CategoryID, &d.SupplierID, &d.Code, &d.UsageLimit, &d.PerClientLimit, &d.ValidFrom, &d.ValidTo,
+			&d.Stackable, &d.Priority, &d.Active, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		promos = append(promos, mapper.PromotionFromDTO(d))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return promos, nil
+}
+
+// writeError переводит нарушения ограничений таблицы в ошибки модели.
+func writeError(err error, msg string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505":
+			return storage.ErrDuplicatePromoCode
+		case pgErr.Code == "23503" && pgErr.ConstraintName == "promotions_category_id_fkey":
+			return storage.ErrCategoryNotFound
+		case pgErr.Code == "23503" && pgErr.ConstraintName == "promotions_supplier_id_fkey":
+			return storage.ErrSupplierNotFound
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,238 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/purchase"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var purchaseKeyset = postgres.Keyset{Key: "created_at", ID: "purchase_order_id", Cast: "timestamptz"}
+
+const purchaseColumns = `purchase_order_id, supplier_id, warehouse_id, status, note, created_at, updated_at`
+
+type purchaseRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPurchaseRepository(db *pgxpool.Pool) *purchaseRepository {
+	return &purchaseRepository{
+		pool: db,
+	}
+}
+
+func (r *purchaseRepository) Insert(ctx context.Context, po purchase.PurchaseOrder) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO purchase_orders (` + purchaseColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	d := mapper.PurchaseOrderToDTO(po)
+	_, err := exec.Exec(ctx, query, d.PurchaseOrderID, d.SupplierID, d.WarehouseID, d.Status, d.Note, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания заказа поставщику: %w", err)
+	}
+
+	lineQuery := `INSERT INTO purchase_order_lines
+	(purchase_order_id, product_id, name, quantity, expected_price, received_quantity)
+	VALUES ($1,$2,$3,$4,$5,$6)`
+	for _, line := range po.Lines {
+		l := mapper.PurchaseOrderLineToDTO(po.PurchaseOrderID, line)
+		if _, err := exec.Exec(ctx, lineQuery, l.PurchaseOrderID, l.ProductID, l.Name, l.Quantity, l.ExpectedPrice, l.ReceivedQuantity); err != nil {
+			return fmt.Errorf("ошибка создания позиции заказа поставщику: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *purchaseRepository) GetById(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error) {
+	return r.getOne(ctx, `SELECT `+purchaseColumns+` FROM purchase_orders WHERE purchase_order_id = $1`, id)
+}
+
+// GetByIdForUpdate блокирует заказ поставщику до конца текущей транзакции.
+func (r *purchaseRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (purchase.PurchaseOrder, error) {
+	return r.getOne(ctx, `SELECT `+purchaseColumns+` FROM purchase_orders WHERE purchase_order_id = $1 FOR UPDATE`, id)
+}
+
+func (r *purchaseRepository) UpdateStatus(ctx context.Context, po purchase.PurchaseOrder) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE purchase_orders SET status = $2, updated_at = $3 WHERE purchase_order_id = $1`
+
+	tag, err := exec.Exec(ctx, query, po.PurchaseOrderID, string(po.Status), po.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения статуса заказа поставщику: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPurchaseOrderNotFound
+	}
+	return nil
+}
+
+// InsertReceipt записывает приёмку и увеличивает принятое количество по
+// позициям заказа.
+func (r *purchaseRepository) InsertReceipt(ctx context.Context, rc purchase.Receipt) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO goods_receipts (receipt_id, purchase_order_id, warehouse_id, actor_id, created_at)
+	VALUES ($1,$2,$3,$4,$5)`
+
+	d := mapper.GoodsReceiptToDTO(rc)
+	if _, err := exec.Exec(ctx, query, d.ReceiptID, d.PurchaseOrderID, d.WarehouseID, d.ActorID, d.CreatedAt); err != nil {
+		return fmt.Errorf("ошибка записи приёмки: %w", err)
+	}
+
+	lineQuery := `INSERT INTO goods_receipt_lines (receipt_id, product_id, expected_quantity, received_quantity)
+	VALUES ($1,$2,$3,$4)`
+	receivedQuery := `UPDATE purchase_order_lines SET received_quantity = received_quantity + $3
+	WHERE purchase_order_id = $1 AND product_id = $2`
+	for _, l := range rc.Lines {
+		if _, err := exec.Exec(ctx, lineQuery, rc.ReceiptID, l.ProductID, l.ExpectedQuantity, l.ReceivedQuantity); err != nil {
+			return fmt.Errorf("ошибка записи позиции приёмки: %w", err)
+		}
+		if _, err := exec.Exec(ctx, receivedQuery, rc.PurchaseOrderID, l.ProductID, l.ReceivedQuantity); err != nil {
+			return fmt.Errorf("ошибка изменения позиции заказа поставщику: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *purchaseRepository) GetBySupplier(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]purchase.PurchaseOrder, error) {
+	query, args := purchaseKeyset.Apply(`SELECT `+purchaseColumns+` FROM purchase_orders`,
+		[]string{"supplier_id = $1"}, []any{supplierID}, req)
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заказов поставщику: %w", err)
+	}
+	defer row.Close()
+	var orders []purchase.PurchaseOrder
+	for row.Next() {
+		var d dto.PurchaseOrderDTO
+
+		if err := row.Scan(&d.PurchaseOrderID, &d.SupplierID, &d.WarehouseID, &d.Status, &d.Note, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		orders = append(orders, mapper.PurchaseOrderFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	if err := r.loadDetails(ctx, r.pool, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *purchaseRepository) getOne(ctx context.Context, query string, id uuid.UUID) (purchase.PurchaseOrder, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.PurchaseOrderDTO
+	err := exec.QueryRow(ctx, query, id).Scan(&d.PurchaseOrderID, &d.SupplierID, &d.WarehouseID, &d.Status, &d.Note, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return purchase.PurchaseOrder{}, storage.ErrPurchaseOrderNotFound
+		}
+		return purchase.PurchaseOrder{}, fmt.Errorf("ошибка получения заказа поставщику: %w", err)
+	}
+
+	orders := []purchase.PurchaseOrder{mapper.PurchaseOrderFromDTO(d)}
+	if err := r.loadDetails(ctx, exec, orders); err != nil {
+		return purchase.PurchaseOrder{}, err
+	}
+	return orders[0], nil
+}
+
+// loadDetails дозагружает позиции и приёмки для списка заказов поставщику.
+func (r *purchaseRepository) loadDetails(ctx context.Context, exec tx.Executer, orders []purchase.PurchaseOrder) error {
+	ids := make([]uuid.UUID, 0, len(orders))
+	for _, po := range orders {
+		ids = append(ids, po.PurchaseOrderID)
+	}
+	lines, err := r.getLines(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	receipts, err := r.getReceipts(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		orders[i].Lines = lines[orders[i].PurchaseOrderID]
+		orders[i].Receipts = receipts[orders[i].PurchaseOrderID]
+	}
+	return nil
+}
+
+func (r *purchaseRepository) getLines(ctx context.Context, exec tx.Executer, ids []uuid.UUID) (map[uuid.UUID][]purchase.Line, error) {
+	query := `SELECT purchase_order_id, product_id, name, quantity, expected_price, received_quantity
+	FROM purchase_order_lines
+	WHERE purchase_order_id = ANY($1)
+	ORDER BY purchase_order_id, product_id`
+
+	row, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения позиций заказа поставщику: %w", err)
+	}
+	defer row.Close()
+	lines := make(map[uuid.UUID][]purchase.Line, len(ids))
+	for row.Next() {
+		var d dto.PurchaseOrderLineDTO
+
+		if err := row.Scan(&d.PurchaseOrderID, &d.ProductID, &d.Name, &d.Quantity, &d.ExpectedPrice, &d.ReceivedQuantity); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		lines[d.PurchaseOrderID] = append(lines[d.PurchaseOrderID], mapper.PurchaseOrderLineFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return lines, nil
+}
+
+// getReceipts загружает приёмки вместе с их позициями.
+func (r *purchaseRepository) getReceipts(ctx context.Context, exec tx.Executer, ids []uuid.UUID) (map[uuid.UUID][]purchase.Receipt, error) {
+	query := `SELECT g.receipt_id, g.purchase_order_id, g.warehouse_id, g.actor_id, g.created_at,
+	l.product_id, l.expected_quantity, l.received_quantity
+	FROM goods_receipts g
+	JOIN goods_receipt_lines l ON l.receipt_id = g.receipt_id
+	WHERE g.purchase_order_id = ANY($1)
+	ORDER BY g.purchase_order_id, g.created_at, g.receipt_id, l.product_id`
+
+	row, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения приёмок: %w", err)
+	}
+	defer row.Close()
+	receipts := make(map[uuid.UUID][]purchase.Receipt, len(ids))
+	for row.Next() {
+		var g dto.GoodsReceiptDTO
+		var l dto.GoodsReceiptLineDTO
+
+		if err := row.Scan(&g.ReceiptID, &g.PurchaseOrderID, &g.WarehouseID, &g.ActorID, &g.CreatedAt,
+			&l.ProductID, &l.ExpectedQuantity, &l.ReceivedQuantity); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		list := receipts[g.PurchaseOrderID]
+		if n := len(list); n == 0 || list[n-1].ReceiptID != g.ReceiptID {
+			list = append(list, mapper.GoodsReceiptFromDTO(g))
+		}
+		last := &list[len(list)-1]
+		last.Lines = append(last.Lines, mapper.GoodsReceiptLineFromDTO(l))
+		receipts[g.PurchaseOrderID] = list
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return receipts, nil
+}
@@ -0,0 +1,197 @@
+package replenishment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/replenishment"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var alertKeyset = postgres.Keyset{Key: "a.created_at", ID: "a.alert_id", Cast: "timestamptz"}
+
+const alertSelect = `SELECT a.alert_id, a.product_id, p.name, a.supplier_id, s.name, a.stock, a.reorder_point,
+	a.suggested_quantity, a.status, a.created_at, a.notified_at, a.resolved_at
+	FROM stock_alerts a
+	JOIN product p ON p.product_id = a.product_id
+	LEFT JOIN supplier s ON s.supplier_id = a.supplier_id`
+
+// effectiveLevels точка заказа и объём закупки товаров с учётом значений категории.
+const effectiveLevels = `SELECT p.product_id, p.supplier_id, p.available_stock,
+	COALESCE(p.reorder_point, c.reorder_point) AS reorder_point,
+	COALESCE(p.reorder_quantity, c.reorder_quantity) AS reorder_quantity
+	FROM product p
+	LEFT JOIN category c ON c.category_id = p.category_id`
+
+type replenishmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReplenishmentRepository(db *pgxpool.Pool) *replenishmentRepository {
+	return &replenishmentRepository{
+		pool: db,
+	}
+}
+
+func (r *replenishmentRepository) GetProductPolicy(ctx context.Context, productID uuid.UUID) (replenishment.ProductPolicy, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT p.reorder_point, p.reorder_quantity, c.reorder_point, c.reorder_quantity
+	FROM product p
+	LEFT JOIN category c ON c.category_id = p.category_id
+	WHERE p.product_id = $1`
+
+	res := replenishment.ProductPolicy{ProductID: productID}
+	err := exec.QueryRow(ctx, query, productID).Scan(&res.Own.ReorderPoint, &res.Own.ReorderQuantity,
+		&res.Category.ReorderPoint, &res.Category.ReorderQuantity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return replenishment.ProductPolicy{}, storage.ErrProductNotFound
+		}
+		return replenishment.ProductPolicy{}, fmt.Errorf("ошибка получения точки заказа: %w", err)
+	}
+	return res, nil
+}
+
+func (r *replenishmentRepository) SetProductPolicy(ctx context.Context, productID uuid.UUID, p replenishment.Policy) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product SET reorder_point = $2, reorder_quantity = $3 WHERE product_id = $1`
+
+	tag, err := exec.Exec(ctx, query, productID, p.ReorderPoint, p.ReorderQuantity)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения точки заказа: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *replenishmentRepository) SetCategoryPolicy(ctx context.Context, categoryID uuid.UUID, p replenishment.Policy) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE category SET reorder_point = $2, reorder_quantity = $3 WHERE category_id = $1`
+
+	tag, err := exec.Exec(ctx, query, categoryID, p.ReorderPoint, p.ReorderQuantity)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения точки заказа категории: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// OpenAlerts создаёт оповещения по товарам, остаток которых ниже точки
+// заказа, если по ним ещё нет открытого оповещения. Без заданного объёма
+// закупки рекомендуется довести остаток до точки заказа.
+func (r *replenishmentRepository) OpenAlerts(ctx context.Context, now time.Time) (int, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `WITH levels AS (` + effectiveLevels + `)
+	INSERT INTO stock_alerts (alert_id, product_id, supplier_id, stock, reorder_point, suggested_quantity, status, created_at)
+	SELECT gen_random_uuid(), product_id, supplier_id, available_stock, reorder_point,
+	COALESCE(reorder_quantity, reorder_point - available_stock), 'open', $1
+	FROM levels
+	WHERE available_stock < reorder_point
+	ON CONFLICT (product_id) WHERE status = 'open' DO NOTHING`
+
+	tag, err := exec.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания оповещений: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ResolveRecovered закрывает открытые оповещения по товарам, остаток которых
+// снова не ниже точки заказа или точка заказа больше не задана.
+func (r *replenishmentRepository) ResolveRecovered(ctx context.Context, now time.Time) (int, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `WITH levels AS (` + effectiveLevels + `)
+	UPDATE stock_alerts a SET status = 'resolved', resolved_at = $1
+	FROM levels l
+	WHERE a.product_id = l.product_id AND a.status = 'open'
+	AND (l.reorder_point IS NULL OR l.available_stock >= l.reorder_point)`
+
+	tag, err := exec.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка закрытия оповещений: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// GetUnnotifiedForUpdate блокирует до limit открытых оповещений, о которых
+// ещё не сообщалось и которые к now не закреплены за другим отправителем.
+// Заблокированные другой транзакцией пропускаются.
+func (r *replenishmentRepository) GetUnnotifiedForUpdate(ctx context.Context, now time.Time, limit int) ([]replenishment.Alert, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := alertSelect + `
+	WHERE a.status = 'open' AND a.notified_at IS NULL
+	AND (a.notify_claimed_until IS NULL OR a.notify_claimed_until <= $1)
+	ORDER BY a.created_at, a.alert_id
+	LIMIT $2
+	FOR UPDATE OF a SKIP LOCKED`
+
+	return r.getMany(ctx, exec, query, now, limit)
+}
+
+// Claim закрепляет оповещения за отправителем до until.
+func (r *replenishmentRepository) Claim(ctx context.Context, ids []uuid.UUID, until time.Time) error {
+	exec := tx.FromContext(ctx, r.pool)
+	if _, err := exec.Exec(ctx, `UPDATE stock_alerts SET notify_claimed_until = $2 WHERE alert_id = ANY($1)`, ids, until); err != nil {
+		return fmt.Errorf("ошибка изменения оповещений: %w", err)
+	}
+	return nil
+}
+
+// ReleaseClaim снимает закрепление, чтобы оповещения ушли при следующей сверке.
+func (r *replenishmentRepository) ReleaseClaim(ctx context.Context, ids []uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	if _, err := exec.Exec(ctx, `UPDATE stock_alerts SET notify_claimed_until = NULL WHERE alert_id = ANY($1)`, ids); err != nil {
+		return fmt.Errorf("ошибка изменения оповещений: %w", err)
+	}
+	return nil
+}
+
+func (r *replenishmentRepository) MarkNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE stock_alerts SET notified_at = $2, notify_claimed_until = NULL WHERE alert_id = ANY($1)`
+	if _, err := exec.Exec(ctx, query, ids, at); err != nil {
+		return fmt.Errorf("ошибка изменения оповещений: %w", err)
+	}
+	return nil
+}
+
+func (r *replenishmentRepository) GetAlerts(ctx context.Context, status replenishment.AlertStatus, req page.Request) ([]replenishment.Alert, error) {
+	query, args := alertKeyset.Apply(alertSelect, []string{"a.status = $1"}, []any{string(status)}, req)
+	return r.getMany(ctx, r.pool, query, args...)
+}
+
+func (r *replenishmentRepository) getMany(ctx context.Context, exec tx.Executer, query string, args ...any) ([]replenishment.Alert, error) {
+	row, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения оповещений: %w", err)
+	}
+	defer row.Close()
+	var alerts []replenishment.Alert
+	for row.Next() {
+		var d dto.StockAlertDTO
+
+		if err := row.Scan(&d.AlertID, &d.ProductID, &d.ProductName, &d.SupplierID, &d.SupplierName, &d.Stock,
+			&d.ReorderPoint, &d.SuggestedQuantity, &d.Status, &d.CreatedAt, &d.NotifiedAt, &d.ResolvedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		alerts = append(alerts, mapper.StockAlertFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return alerts, nil
+}
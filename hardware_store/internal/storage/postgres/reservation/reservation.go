@@ -0,0 +1,99 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/reservation"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const reservationColumns = `reservation_id, product_id, warehouse_id, quantity, status, reference, expires_at, created_at, updated_at`
+
+type reservationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReservationRepository(db *pgxpool.Pool) *reservationRepository {
+	return &reservationRepository{
+		pool: db,
+	}
+}
+
+func (r *reservationRepository) Insert(ctx context.Context, res reservation.Reservation) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO stock_reservations (` + reservationColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	d := mapper.ReservationToDTO(res)
+	_, err := exec.Exec(ctx, query, d.ReservationID, d.ProductID, d.WarehouseID, d.Quantity, d.Status, d.Reference, d.ExpiresAt, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания резерва: %w", err)
+	}
+	return nil
+}
+
+// GetByIdForUpdate блокирует резерв до конца текущей транзакции.
+func (r *reservationRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (reservation.Reservation, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE reservation_id = $1 FOR UPDATE`
+
+	var d dto.ReservationDTO
+	err := exec.QueryRow(ctx, query, id).Scan(&d.ReservationID, &d.ProductID, &d.WarehouseID, &d.Quantity, &d.Status, &d.Reference, &d.ExpiresAt, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return reservation.Reservation{}, storage.ErrReservationNotFound
+		}
+		return reservation.Reservation{}, fmt.Errorf("ошибка получения резерва: %w", err)
+	}
+	return mapper.ReservationFromDTO(d), nil
+}
+
+func (r *reservationRepository) UpdateStatus(ctx context.Context, res reservation.Reservation) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE stock_reservations SET status = $2, updated_at = $3 WHERE reservation_id = $1`
+
+	if _, err := exec.Exec(ctx, query, res.ReservationID, string(res.Status), res.UpdatedAt); err != nil {
+		return fmt.Errorf("ошибка изменения резерва: %w", err)
+	}
+	return nil
+}
+
+// GetExpiredForUpdate блокирует до limit активных резервов, истёкших к now.
+// Резервы, заблокированные другой транзакцией, пропускаются, поэтому несколько
+// экземпляров приложения могут освобождать резервы параллельно.
+func (r *reservationRepository) GetExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]reservation.Reservation, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT ` + reservationColumns + `
+	FROM stock_reservations
+	WHERE status = 'active' AND expires_at <= $1
+	ORDER BY product_id, reservation_id
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+
+	row, err := exec.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истёкших резервов: %w", err)
+	}
+	defer row.Close()
+	var reservations []reservation.Reservation
+	for row.Next() {
+		var d dto.ReservationDTO
+
+		if err := row.Scan(&d.ReservationID, &d.ProductID, &d.WarehouseID, &d.Quantity, &d.Status, &d.Reference, &d.ExpiresAt, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		reservations = append(reservations, mapper.ReservationFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return reservations, nil
+}
@@ -0,0 +1,203 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/returns"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var returnKeyset = postgres.Keyset{Key: "created_at", ID: "return_id", Cast: "timestamptz"}
+
+const returnColumns = `return_id, order_id, client_id, warehouse_id, status, note, refund, actor_id, created_at, updated_at, refunded_at`
+
+type returnRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReturnRepository(db *pgxpool.Pool) *returnRepository {
+	return &returnRepository{
+		pool: db,
+	}
+}
+
+func (r *returnRepository) Insert(ctx context.Context, rt returns.Return) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO returns (` + returnColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	d := mapper.ReturnToDTO(rt)
+	_, err := exec.Exec(ctx, query, d.ReturnID, d.OrderID, d.ClientID, d.WarehouseID, d.Status, d.Note, d.Refund,
+		d.ActorID, d.CreatedAt, d.UpdatedAt, d.RefundedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания заявки на возврат: %w", err)
+	}
+
+	lineQuery := `INSERT INTO return_lines
+	(return_id, item_id, product_id, name, quantity, reason, comment, outcome, refund)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	for _, line := range rt.Lines {
+		l := mapper.ReturnLineToDTO(rt.ReturnID, line)
+		if _, err := exec.Exec(ctx, lineQuery, l.ReturnID, l.ItemID, l.ProductID, l.Name, l.Quantity, l.Reason,
+			l.Comment, l.Outcome, l.Refund); err != nil {
+			return fmt.Errorf("ошибка создания позиции заявки на возврат: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *returnRepository) GetById(ctx context.Context, id uuid.UUID) (returns.Return, error) {
+	return r.getOne(ctx, `SELECT `+returnColumns+` FROM returns WHERE return_id = $1`, id)
+}
+
+// GetByIdForUpdate блокирует заявку на возврат до конца текущей транзакции.
+func (r *returnRepository) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (returns.Return, error) {
+	return r.getOne(ctx, `SELECT `+returnColumns+` FROM returns WHERE return_id = $1 FOR UPDATE`, id)
+}
+
+// GetByOrder возвращает все заявки по заказу, включая закрытые.
+func (r *returnRepository) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]returns.Return, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `SELECT ` + returnColumns + ` FROM returns WHERE order_id = $1 ORDER BY created_at, return_id`
+	return r.getMany(ctx, exec, query, orderID)
+}
+
+func (r *returnRepository) GetReturns(ctx context.Context, filter returns.Filter, req page.Request) ([]returns.Return, error) {
+	var conds []string
+	var args []any
+	if filter.OrderID != uuid.Nil {
+		args = append(args, filter.OrderID)
+		conds = append(conds, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	query, args := returnKeyset.Apply(`SELECT `+returnColumns+` FROM returns`, conds, args, req)
+	return r.getMany(ctx, r.pool, query, args...)
+}
+
+func (r *returnRepository) UpdateStatus(ctx context.Context, rt returns.Return) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE returns SET status = $2, updated_at = $3, refunded_at = $4 WHERE return_id = $1`
+
+	tag, err := exec.Exec(ctx, query, rt.ReturnID, string(rt.Status), rt.UpdatedAt, rt.RefundedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения статуса заявки на возврат: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrReturnNotFound
+	}
+	return nil
+}
+
+// UpdateOutcomes записывает решения осмотра по позициям заявки.
+func (r *returnRepository) UpdateOutcomes(ctx context.Context, rt returns.Return) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE return_lines SET outcome = $3 WHERE return_id = $1 AND item_id = $2`
+
+	for _, line := range rt.Lines {
+		l := mapper.ReturnLineToDTO(rt.ReturnID, line)
+		tag, err := exec.Exec(ctx, query, l.ReturnID, l.ItemID, l.Outcome)
+		if err != nil {
+			return fmt.Errorf("ошибка записи результата осмотра: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrReturnNotFound
+		}
+	}
+	return nil
+}
+
+func (r *returnRepository) getOne(ctx context.Context, query string, id uuid.UUID) (returns.Return, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.ReturnDTO
+	err := exec.QueryRow(ctx, query, id).Scan(&d.ReturnID, &d.OrderID, &d.ClientID, &d.WarehouseID, &d.Status,
+		&d.Note, &d.Refund, &d.ActorID, &d.CreatedAt, &d.UpdatedAt, &d.RefundedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return returns.Return{}, storage.ErrReturnNotFound
+		}
+		return returns.Return{}, fmt.Errorf("ошибка получения заявки на возврат: %w", err)
+	}
+
+	list := []returns.Return{mapper.ReturnFromDTO(d)}
+	if err := r.loadLines(ctx, exec, list); err != nil {
+		return returns.Return{}, err
+	}
+	return list[0], nil
+}
+
+func (r *returnRepository) getMany(ctx context.Context, exec tx.Executer, query string, args ...any) ([]returns.Return, error) {
+	row, err := exec.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок на возврат: %w", err)
+	}
+	defer row.Close()
+	var list []returns.Return
+	for row.Next() {
+		var d dto.ReturnDTO
+
+		if err := row.Scan(&d.ReturnID, &d.OrderID, &d.ClientID, &d.WarehouseID, &d.Status, &d.Note, &d.Refund,
+			&d.ActorID, &d.CreatedAt, &d.UpdatedAt, &d.RefundedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		list = append(list, mapper.ReturnFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	if len(list) == 0 {
+		return list, nil
+	}
+
+	if err := r.loadLines(ctx, exec, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// loadLines дозагружает позиции для списка заявок одним запросом.
+func (r *returnRepository) loadLines(ctx context.Context, exec tx.Executer, list []returns.Return) error {
+	ids := make([]uuid.UUID, 0, len(list))
+	for _, rt := range list {
+		ids = append(ids, rt.ReturnID)
+	}
+	query := `SELECT return_id, item_id, product_id, name, quantity, reason, comment, outcome, refund
+	FROM return_lines
+	WHERE return_id = ANY($1)
+	ORDER BY return_id, name, item_id`
+
+	row, err := exec.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения позиций заявки на возврат: %w", err)
+	}
+	defer row.Close()
+	lines := make(map[uuid.UUID][]returns.Line, len(ids))
+	for row.Next() {
+		var d dto.ReturnLineDTO
+
+		if err := row.Scan(&d.ReturnID, &d.ItemID, &d.ProductID, &d.Name, &d.Quantity, &d.Reason, &d.Comment,
+			&d.Outcome, &d.Refund); err != nil {
+			return fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		lines[d.ReturnID] = append(lines[d.ReturnID], mapper.ReturnLineFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	for i := range list {
+		list[i].Lines = lines[list[i].ReturnID]
+	}
+	return nil
+}
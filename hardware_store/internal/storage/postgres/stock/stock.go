@@ -0,0 +1,192 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/stock"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var movementKeyset = postgres.Keyset{Key: "created_at", ID: "movement_id", Cast: "timestamptz"}
+
+type stockRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStockRepository(db *pgxpool.Pool) *stockRepository {
+	return &stockRepository{
+		pool: db,
+	}
+}
+
+// AdjustStock изменяет остаток товара на складе и общий остаток на delta и
+// возвращает новый общий остаток. Ни остаток на складе, ни общий остаток не
+// могут стать меньше зарезервированного на них количества.
+func (r *stockRepository) AdjustStock(ctx context.Context, productID, warehouseID uuid.UUID, delta int) (int, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `UPDATE product
+	SET available_stock = available_stock + $2, last_update_date = NOW()
+	WHERE product_id = $1 AND available_stock + $2 >= reserved_stock
+	RETURNING available_stock`
+
+	var balance int
+	err := exec.QueryRow(ctx, query, productID, delta).Scan(&balance)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("ошибка изменения остатка: %w", err)
+		}
+		return 0, r.stockError(ctx, exec, productID)
+	}
+
+	if err := r.adjustWarehouse(ctx, exec, productID, warehouseID, delta); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// MoveStock перемещает quantity единиц товара между складами.
+func (r *stockRepository) MoveStock(ctx context.Context, productID, from, to uuid.UUID, quantity int) error {
+	exec := tx.FromContext(ctx, r.pool)
+	if err := r.adjustWarehouse(ctx, exec, productID, from, -quantity); err != nil {
+		return err
+	}
+	return r.adjustWarehouse(ctx, exec, productID, to, quantity)
+}
+
+func (r *stockRepository) InsertTransfer(ctx context.Context, t stock.Transfer) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO stock_transfers
+	(transfer_id, product_id, from_warehouse_id, to_warehouse_id, quantity, actor_id, created_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	d := mapper.StockTransferToDTO(t)
+	_, err := exec.Exec(ctx, query, d.TransferID, d.ProductID, d.FromWarehouseID, d.ToWarehouseID, d.Quantity, d.ActorID, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи перемещения: %w", err)
+	}
+	return nil
+}
+
+// adjustWarehouse изменяет остаток товара на одном складе. Строка остатка
+// создаётся при первом поступлении товара на склад. Списать со склада можно
+// только незарезервированную часть остатка.
+func (r *stockRepository) adjustWarehouse(ctx context.Context, exec tx.Executer, productID, warehouseID uuid.UUID, delta int) error {
+	if delta > 0 {
+		query := `INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+		VALUES ($1,$2,$3)
+		ON CONFLICT (warehouse_id, product_id) DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity`
+		if _, err := exec.Exec(ctx, query, warehouseID, productID, delta); err != nil {
+			return fmt.Errorf("ошибка изменения остатка на складе: %w", err)
+		}
+		return nil
+	}
+
+	query := `UPDATE warehouse_stock
+	SET quantity = quantity + $3
+	WHERE warehouse_id = $1 AND product_id = $2 AND quantity - reserved + $3 >= 0`
+	tag, err := exec.Exec(ctx, query, warehouseID, productID, delta)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения остатка на складе: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if err := r.stockError(ctx, exec, productID); !errors.Is(err, storage.ErrInsufficientStock) {
+			return err
+		}
+		return fmt.Errorf("%w in warehouse %s", storage.ErrInsufficientStock, warehouseID)
+	}
+	return nil
+}
+
+// stockError различает отсутствующий товар и нехватку остатка после
+// неудачного условного обновления.
+func (r *stockRepository) stockError(ctx context.Context, exec tx.Executer, productID uuid.UUID) error {
+	var exists bool
+	if err := exec.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM product WHERE product_id = $1)`, productID).Scan(&exists); err != nil {
+		return fmt.Errorf("ошибка проверки товара: %w", err)
+	}
+	if !exists {
+		return storage.ErrProductNotFound
+	}
+	return storage.ErrInsufficientStock
+}
+
+func (r *stockRepository) InsertMovement(ctx context.Context, m stock.Movement) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO stock_movements
+	(movement_id, product_id, warehouse_id, movement_type, quantity, reason, actor_id, created_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	d := mapper.StockMovementToDTO(m)
+	_, err := exec.Exec(ctx, query, d.MovementID, d.ProductID, d.WarehouseID, d.MovementType, d.Quantity, d.Reason, d.ActorID, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи движения: %w", err)
+	}
+	return nil
+}
+
+func (r *stockRepository) GetMovements(ctx context.Context, productID uuid.UUID, req page.Request) ([]stock.Movement, error) {
+	query, args := movementKeyset.Apply(`SELECT movement_id, product_id, warehouse_id, movement_type, quantity, reason, actor_id, created_at
+	FROM stock_movements`, []string{"product_id = $1"}, []any{productID}, req)
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения движений: %w", err)
+	}
+	defer row.Close()
+	var movements []stock.Movement
+	for row.Next() {
+		var d dto.StockMovementDTO
+
+		if err := row.Scan(&d.MovementID, &d.ProductID, &d.WarehouseID, &d.MovementType, &d.Quantity, &d.Reason, &d.ActorID, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		movements = append(movements, mapper.StockMovementFromDTO(d))
+	}
+
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return movements, nil
+}
+
+// Reconcile пересчитывает остатки по журналу и возвращает товары,
+// у которых остаток не совпадает с суммой движений.
+func (r *stockRepository) Reconcile(ctx context.Context) ([]stock.Discrepancy, error) {
+	query := `SELECT p.product_id, p.available_stock, COALESCE(SUM(m.quantity), 0) AS ledger_stock
+	FROM product p
+	LEFT JOIN stock_movements m ON m.product_id = p.product_id
+	GROUP BY p.product_id, p.available_stock
+	HAVING p.available_stock <> COALESCE(SUM(m.quantity), 0)
+	ORDER BY p.product_id`
+
+	row, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сверки остатков: %w", err)
+	}
+	defer row.Close()
+	var res []stock.Discrepancy
+	for row.Next() {
+		var d stock.Discrepancy
+		if err := row.Scan(&d.ProductID, &d.Stock, &d.LedgerStock); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		res = append(res, d)
+	}
+
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return res, nil
+}
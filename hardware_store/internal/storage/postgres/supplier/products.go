@@ -0,0 +1,144 @@
+package supplier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/supplier"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var supplierProductKeyset = postgres.Keyset{Key: "p.name", ID: "sp.product_id", Cast: "text"}
+
+const supplierProductSelect = `SELECT sp.supplier_id, s.name, sp.product_id, p.name, sp.supplier_sku, sp.purchase_price,
+	sp.currency, sp.lead_time_days, sp.min_order_quantity, sp.updated_at
+	FROM supplier_products sp
+	JOIN supplier s ON s.supplier_id = sp.supplier_id
+	JOIN product p ON p.product_id = sp.product_id`
+
+// UpsertProduct добавляет товар в прайс-лист поставщика или обновляет его
+// условия.
+func (r *supplierRepository) UpsertProduct(ctx context.Context, p supplier.Product) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO supplier_products
+	(supplier_id, product_id, supplier_sku, purchase_price, currency, lead_time_days, min_order_quantity, updated_at)
+	SELECT $1, product_id, $3, $4, $5, $6, $7, $8 FROM product WHERE product_id = $2
+	ON CONFLICT (supplier_id, product_id) DO UPDATE SET
+	supplier_sku = EXCLUDED.supplier_sku,
+	purchase_price = EXCLUDED.purchase_price,
+	currency = EXCLUDED.currency,
+	lead_time_days = EXCLUDED.lead_time_days,
+	min_order_quantity = EXCLUDED.min_order_quantity,
+	updated_at = EXCLUDED.updated_at`
+
+	d := mapper.SupplierProductToDTO(p)
+	tag, err := exec.Exec(ctx, query, d.SupplierID, d.ProductID, d.SKU, d.PurchasePrice, d.Currency,
+		d.LeadTimeDays, d.MinOrderQuantity, d.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return storage.ErrDuplicateSupplierSKU
+		}
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "supplier_products_currency_fkey" {
+			return fmt.Errorf("%w: %s", storage.ErrCurrencyNotFound, d.Currency)
+		}
+		return fmt.Errorf("ошибка изменения прайс-листа поставщика: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *supplierRepository) DeleteProduct(ctx context.Context, supplierID, productID uuid.UUID) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `DELETE FROM supplier_products WHERE supplier_id = $1 AND product_id = $2`
+
+	tag, err := exec.Exec(ctx, query, supplierID, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления товара из прайс-листа поставщика: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrSupplierProductNotFound
+	}
+	return nil
+}
+
+func (r *supplierRepository) GetProduct(ctx context.Context, supplierID, productID uuid.UUID) (supplier.Product, error) {
+	exec := tx.FromContext(ctx, r.pool)
+	query := supplierProductSelect + ` WHERE sp.supplier_id = $1 AND sp.product_id = $2`
+
+	return r.getSupplierProduct(ctx, exec, query, supplierID, productID)
+}
+
+func (r *supplierRepository) GetProducts(ctx context.Context, supplierID uuid.UUID, req page.Request) ([]supplier.Product, error) {
+	query, args := supplierProductKeyset.Apply(supplierProductSelect,
+		[]string{"sp.supplier_id = $1"}, []any{supplierID}, req)
+	return r.getSupplierProducts(ctx, query, args...)
+}
+
+// GetOffers возвращает все предложения поставщиков по товару от дешёвого к дорогому.
+func (r *supplierRepository) GetOffers(ctx context.Context, productID uuid.UUID) ([]supplier.Product, error) {
+	query := supplierProductSelect + ` WHERE sp.product_id = $1
+	ORDER BY sp.currency, sp.purchase_price, sp.lead_time_days, sp.supplier_id`
+	return r.getSupplierProducts(ctx, query, productID)
+}
+
+// GetBestOffer выбирает поставщика товара по условиям q.
+func (r *supplierRepository) GetBestOffer(ctx context.Context, q supplier.OfferQuery) (supplier.Product, error) {
+	order := "sp.purchase_price, sp.lead_time_days"
+	if q.Prefer == supplier.PreferLeadTime {
+		order = "sp.lead_time_days, sp.purchase_price"
+	}
+	query := supplierProductSelect + `
+	WHERE sp.product_id = $1 AND sp.currency = $2 AND sp.min_order_quantity <= $3
+	AND ($4::int IS NULL OR sp.lead_time_days <= $4)
+	ORDER BY ` + order + `, sp.min_order_quantity, sp.supplier_id
+	LIMIT 1`
+
+	return r.getSupplierProduct(ctx, r.pool, query, q.ProductID, q.Currency, q.Quantity, q.MaxLeadTimeDays)
+}
+
+func (r *supplierRepository) getSupplierProduct(ctx context.Context, exec tx.Executer, query string, args ...any) (supplier.Product, error) {
+	var d dto.SupplierProductDTO
+	err := exec.QueryRow(ctx, query, args...).Scan(&d.SupplierID, &d.SupplierName, &d.ProductID, &d.ProductName, &d.SKU,
+		&d.PurchasePrice, &d.Currency, &d.LeadTimeDays, &d.MinOrderQuantity, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return supplier.Product{}, storage.ErrSupplierProductNotFound
+		}
+		return supplier.Product{}, fmt.Errorf("ошибка получения прайс-листа поставщика: %w", err)
+	}
+	return mapper.SupplierProductFromDTO(d), nil
+}
+
+func (r *supplierRepository) getSupplierProducts(ctx context.Context, query string, args ...any) ([]supplier.Product, error) {
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения прайс-листа поставщика: %w", err)
+	}
+	defer row.Close()
+	var products []supplier.Product
+	for row.Next() {
+		var d dto.SupplierProductDTO
+
+		if err := row.Scan(&d.SupplierID, &d.SupplierName, &d.ProductID, &d.ProductName, &d.SKU,
+			&d.PurchasePrice, &d.Currency, &d.LeadTimeDays, &d.MinOrderQuantity, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		products = append(products, mapper.SupplierProductFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return products, nil
+}
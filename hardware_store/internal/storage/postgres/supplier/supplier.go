@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/page"
 	"hardware_store/internal/model/supplier"
 	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
 	"hardware_store/internal/storage/postgres/dto"
 	"hardware_store/internal/storage/postgres/mapper"
 	"hardware_store/internal/storage/postgres/tx"
@@ -14,6 +16,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var supplierKeyset = postgres.Keyset{Key: "name", ID: "supplier_id", Cast: "text"}
+
 type supplierRepository struct {
 	pool *pgxpool.Pool
 }
@@ -74,10 +78,10 @@ func (r *supplierRepository) GetById(ctx context.Context, id uuid.UUID) (supplie
 	return mapper.SupplierFromDTO(dto), nil
 }
 
-func (r *supplierRepository) GetAll(ctx context.Context) ([]supplier.Supplier, error) {
-	query := `SELECT * FROM supplier`
+func (r *supplierRepository) GetAll(ctx context.Context, req page.Request) ([]supplier.Supplier, error) {
+	query, args := supplierKeyset.Apply(`SELECT supplier_id, name, address_id, phone_number FROM supplier`, nil, nil, req)
 
-	row, err := r.pool.Query(ctx, query)
+	row, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return []supplier.Supplier{}, storage.ErrSupplierNotFound
 	}
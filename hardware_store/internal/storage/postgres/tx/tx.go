@@ -19,7 +19,14 @@ func NewTxManager(pool *pgxpool.Pool) tx.Manager {
 	return &TxManager{pool: pool}
 }
 
+// WithinTransaction выполняет fn в транзакции. Если в контексте уже есть
+// транзакция, fn выполняется в ней, чтобы вложенные вызовы сервисов не
+// открывали отдельное соединение и не блокировались на своих же строках.
 func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := m.pool.Begin(ctx)
 	if err != nil {
 
@@ -0,0 +1,88 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/warehouse"
+	"hardware_store/internal/storage"
+	"hardware_store/internal/storage/postgres"
+	"hardware_store/internal/storage/postgres/dto"
+	"hardware_store/internal/storage/postgres/mapper"
+	"hardware_store/internal/storage/postgres/tx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var warehouseKeyset = postgres.Keyset{Key: "name", ID: "warehouse_id", Cast: "text"}
+
+const warehouseColumns = `warehouse_id, name, kind, address_id, is_default, created_at`
+
+type warehouseRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWarehouseRepository(db *pgxpool.Pool) *warehouseRepository {
+	return &warehouseRepository{
+		pool: db,
+	}
+}
+
+func (r *warehouseRepository) Insert(ctx context.Context, w warehouse.Warehouse) error {
+	exec := tx.FromContext(ctx, r.pool)
+	query := `INSERT INTO warehouses (` + warehouseColumns + `) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	d := mapper.WarehouseToDTO(w)
+	if _, err := exec.Exec(ctx, query, d.WarehouseID, d.Name, d.Kind, d.AddressID, d.IsDefault, d.CreatedAt); err != nil {
+		return fmt.Errorf("ошибка создания склада: %w", err)
+	}
+	return nil
+}
+
+func (r *warehouseRepository) GetById(ctx context.Context, id uuid.UUID) (warehouse.Warehouse, error) {
+	return r.getOne(ctx, `SELECT `+warehouseColumns+` FROM warehouses WHERE warehouse_id = $1`, id)
+}
+
+func (r *warehouseRepository) GetDefault(ctx context.Context) (warehouse.Warehouse, error) {
+	return r.getOne(ctx, `SELECT `+warehouseColumns+` FROM warehouses WHERE is_default`)
+}
+
+func (r *warehouseRepository) GetAll(ctx context.Context, req page.Request) ([]warehouse.Warehouse, error) {
+	query, args := warehouseKeyset.Apply(`SELECT `+warehouseColumns+` FROM warehouses`, nil, nil, req)
+
+	row, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения складов: %w", err)
+	}
+	defer row.Close()
+	var warehouses []warehouse.Warehouse
+	for row.Next() {
+		var d dto.WarehouseDTO
+
+		if err := row.Scan(&d.WarehouseID, &d.Name, &d.Kind, &d.AddressID, &d.IsDefault, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		warehouses = append(warehouses, mapper.WarehouseFromDTO(d))
+	}
+	if err = row.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+	return warehouses, nil
+}
+
+func (r *warehouseRepository) getOne(ctx context.Context, query string, args ...any) (warehouse.Warehouse, error) {
+	exec := tx.FromContext(ctx, r.pool)
+
+	var d dto.WarehouseDTO
+	err := exec.QueryRow(ctx, query, args...).Scan(&d.WarehouseID, &d.Name, &d.Kind, &d.AddressID, &d.IsDefault, &d.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return warehouse.Warehouse{}, storage.ErrWarehouseNotFound
+		}
+		return warehouse.Warehouse{}, fmt.Errorf("ошибка получения склада: %w", err)
+	}
+	return mapper.WarehouseFromDTO(d), nil
+}
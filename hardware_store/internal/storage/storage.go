@@ -2,18 +2,38 @@ package storage
 
 import (
 	"errors"
+	model "hardware_store/internal/model/error"
 )
 
 var (
-	ErrClientNotFound    = errors.New("client not found")
-	ErrImageNotFound     = errors.New("image not found")
-	ErrAddressNotFound   = errors.New("address not found")
-	ErrProductNotFound   = errors.New("product not found")
-	ErrInsufficientStock = errors.New("insufficient stock")
-	ErrSupplierNotFound  = errors.New("supplier not found")
-	ErrCategoryNotFound  = errors.New("category not found")
-	ErrClientExists      = errors.New("client exists")
-	ErrCreation          = errors.New("сreation error")
-	ErrDelete            = errors.New("delete error")
-	ErrUpdate            = errors.New("update error")
+	ErrClientNotFound          = model.ErrClientNotFound
+	ErrImageNotFound           = model.ErrImageNotFound
+	ErrAddressNotFound         = model.ErrAddressNotFound
+	ErrProductNotFound         = model.ErrProductNotFound
+	ErrInsufficientStock       = model.ErrInsufficientStock
+	ErrSupplierNotFound        = model.ErrSupplierNotFound
+	ErrCategoryNotFound        = model.ErrCategoryNotFound
+	ErrOrderNotFound           = model.ErrOrderNotFound
+	ErrCartNotFound            = model.ErrCartNotFound
+	ErrReservationNotFound     = model.ErrReservationNotFound
+	ErrWarehouseNotFound       = model.ErrWarehouseNotFound
+	ErrPurchaseOrderNotFound   = model.ErrPurchaseOrderNotFound
+	ErrSupplierProductNotFound = model.ErrSupplierProductNotFound
+	ErrDuplicateSupplierSKU    = model.ErrDuplicateSupplierSKU
+	ErrDuplicateProductSKU     = model.ErrDuplicateProductSKU
+	ErrImportNotFound          = model.ErrImportNotFound
+	ErrCurrencyNotFound        = model.ErrCurrencyNotFound
+	ErrExchangeRateNotFound    = model.ErrExchangeRateNotFound
+	ErrPriceChangeNotFound     = model.ErrPriceChangeNotFound
+	ErrPromotionNotFound       = model.ErrPromotionNotFound
+	ErrDuplicatePromoCode      = model.ErrDuplicatePromoCode
+	ErrInvoiceNotFound         = model.ErrInvoiceNotFound
+	ErrDuplicateInvoice        = model.ErrDuplicateInvoice
+	ErrReturnNotFound          = model.ErrReturnNotFound
+	ErrPaymentNotFound         = model.ErrPaymentNotFound
+	ErrRefundNotFound          = model.ErrRefundNotFound
+	ErrClientExists            = model.ErrClientExists
+	ErrCreation                = errors.New("сreation error")
+	ErrDelete                  = errors.New("delete error")
+	ErrUpdate                  = errors.New("update error")
 )
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"hardware_store/internal/model/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,42 +22,123 @@ type ClientRequest struct {
 // @Description Данные клиента включая дату регистрации и ссылку на адрес
 // swagger:model ClientResponse
 type ClientResponse struct {
-	ClientID         uuid.UUID `json:"client_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name             string    `json:"name"`
-	Surname          string    `json:"surname"`
-	Birthday         time.Time `json:"birthday"`
-	Gender           string    `json:"gender"`
-	RegistrationDate time.Time `json:"registration_date"`
-	AddressID        uuid.UUID `json:"address_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ClientID         uuid.UUID  `json:"client_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name             string     `json:"name"`
+	Surname          string     `json:"surname"`
+	Birthday         time.Time  `json:"birthday"`
+	Gender           string     `json:"gender"`
+	RegistrationDate time.Time  `json:"registration_date"`
+	AddressID        uuid.UUID  `json:"address_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID           *uuid.UUID `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
 // UpdateStockCountRequest запрос на обновление остатков
 // @Description Запрос на обновление количества товара на складе
 // swagger:model UpdateStockCountRequest
 type ProductRequest struct {
-	Name           string    `json:"name" validate:"required,min=2,max=100" example:"Холодильник Samsung RB38A7861B1"`
-	CategoryID     uuid.UUID `json:"category_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Price          float64   `json:"price" validate:"required,gt=0" example:"75990.00"`
-	AvailableStock int       `json:"available_stock" validate:"required,gte=0" example:"15"`
-	SupplierID     uuid.UUID `json:"supplier_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU            string      `json:"sku" validate:"omitempty,max=64" example:"RB38A7861B1"`
+	Name           string      `json:"name" validate:"required,min=2,max=100" example:"Холодильник Samsung RB38A7861B1"`
+	CategoryID     uuid.UUID   `json:"category_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Price          money.Money `json:"price" validate:"required,gt=0" example:"75990.00" swaggertype:"string"`
+	AvailableStock int         `json:"available_stock" validate:"required,gte=0" example:"15"`
+	SupplierID     uuid.UUID   `json:"supplier_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ProductPatchRequest частичное обновление товара
+// @Description Частичное обновление товара (JSON merge patch): переданные поля заменяются, null в category_id или supplier_id отвязывает категорию или поставщика
+// swagger:model ProductPatchRequest
+type ProductPatchRequest struct {
+	Name           *string      `json:"name" validate:"omitempty,min=2,max=100" example:"Холодильник Samsung RB38A7861B1"`
+	CategoryID     *uuid.UUID   `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Price          *money.Money `json:"price" validate:"omitempty,gt=0" example:"72990.00" swaggertype:"string"`
+	AvailableStock *int         `json:"available_stock" validate:"omitempty,gte=0" example:"10"`
+	SupplierID     *uuid.UUID   `json:"supplier_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
 type UpdateStockCountRequest struct {
-	Amount int `json:"amount" validate:"required,gt=0" example:"5"`
+	Amount      int        `json:"amount" validate:"required,gt=0" example:"5"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
 }
 
 // ProductResponse ответ с информацией о товаре
 // @Description Полная информация о товаре включая цену, остатки и информацию о поставщике
 // swagger:model ProductResponse
 type ProductResponse struct {
-	ProductID      uuid.UUID  `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
-	Name           string     `json:"name" validate:"required,min=2,max=100"`
-	CategoryID     uuid.UUID  `json:"category" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Price          float64    `json:"price" validate:"required,gt=0"`
-	AvailableStock int        `json:"available_stock" validate:"required,gte=0"`
-	LastUpdateDate time.Time  `json:"last_update_date" validate:"required"`
-	SupplierID     uuid.UUID  `json:"supplier" example:"550e8400-e29b-41d4-a716-446655440000"`
-	ImageID        *uuid.UUID `json:"image" example:"b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"`
+	ProductID      uuid.UUID                 `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	SKU            string                    `json:"sku,omitempty" example:"RB38A7861B1"`
+	Name           string                    `json:"name" validate:"required,min=2,max=100"`
+	CategoryID     uuid.UUID                 `json:"category" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Price          money.Money               `json:"price" validate:"required,gt=0" swaggertype:"string"`
+	Currency       string                    `json:"currency" example:"RUB"`
+	AvailableStock int                       `json:"available_stock" validate:"required,gte=0"`
+	ReservedStock  int                       `json:"reserved_stock" example:"2"`
+	LastUpdateDate time.Time                 `json:"last_update_date" validate:"required"`
+	SupplierID     uuid.UUID                 `json:"supplier" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ImageID        *uuid.UUID                `json:"image" example:"b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"`
+	Locations      []ProductLocationResponse `json:"locations"`
+}
+
+// ProductLocationResponse остаток товара на складе
+// @Description Часть общего остатка available_stock, находящаяся на конкретном складе
+// swagger:model ProductLocationResponse
+type ProductLocationResponse struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Name        string    `json:"name" example:"Основной склад"`
+	Quantity    int       `json:"quantity" example:"10"`
+}
+
+// ProductListQuery параметры фильтрации и сортировки списка товаров.
+// limit, offset и cursor разбираются пагинатором
+type ProductListQuery struct {
+	CategoryID  string       `form:"category_id" validate:"omitempty,uuid"`
+	SupplierID  string       `form:"supplier_id" validate:"omitempty,uuid"`
+	WarehouseID string       `form:"warehouse_id" validate:"omitempty,uuid"`
+	MinPrice    *money.Money `form:"min_price" validate:"omitempty,gte=0"`
+	MaxPrice    *money.Money `form:"max_price" validate:"omitempty,gte=0"`
+	InStock     *bool        `form:"in_stock"`
+	Name        string       `form:"name" validate:"omitempty,max=100"`
+	Sort        string       `form:"sort" validate:"omitempty,oneof=price name last_update_date"`
+	Order       string       `form:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+// PriceQuery пересчёт цен в ответе в валюту currency по курсу, действующему
+// на дату date. Без date берётся текущий курс
+type PriceQuery struct {
+	Currency string `form:"currency" validate:"omitempty,len=3,uppercase"`
+	Date     string `form:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ProductExportQuery фильтры выгрузки каталога те же, что у списка товаров.
+// Без format каталог выгружается в CSV
+type ProductExportQuery struct {
+	ProductListQuery
+	Format string `form:"format" validate:"omitempty,oneof=csv xlsx jsonl"`
+}
+
+// ProductExportItem строка выгрузки каталога в JSON Lines
+// swagger:model ProductExportItem
+type ProductExportItem struct {
+	ProductID      uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	SKU            string      `json:"sku,omitempty" example:"RB38A7861B1"`
+	Name           string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	CategoryID     *uuid.UUID  `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Category       string      `json:"category,omitempty" example:"Холодильники"`
+	SupplierID     *uuid.UUID  `json:"supplier_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Supplier       string      `json:"supplier,omitempty" example:"ООО 'ТехноСнаб'"`
+	Price          money.Money `json:"price" example:"75990.00" swaggertype:"string"`
+	AvailableStock int         `json:"available_stock" example:"15"`
+	ReservedStock  int         `json:"reserved_stock" example:"2"`
+	LastUpdateDate time.Time   `json:"last_update_date"`
+}
+
+// ListResponse страница списка
+// @Description Элементы текущей страницы. next_cursor передаётся в параметре cursor для получения следующей страницы и отсутствует на последней
+type ListResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJrIjoi...Q.mD2c..."`
+	Total      *int   `json:"total,omitempty" example:"42"`
+	Limit      int    `json:"limit" example:"20"`
+	Offset     int    `json:"offset,omitempty" example:"0"`
 }
 
 // SupplierRequest запрос на создание поставщика
@@ -112,10 +194,11 @@ type AddressResponse struct {
 }
 
 // CategoryRequest запрос на создание категории
-// @Description Запрос на создание новой категории товаров
+// @Description Запрос на создание новой категории товаров. tax_rate — ставка налога в процентах для товаров категории; без неё действует ставка по умолчанию
 // swagger:model CategoryRequest
 type CategoryRequest struct {
 	Category string `json:"category" validate:"required,min=2,max=50" example:"Холодильники"`
+	TaxRate  string `json:"tax_rate" validate:"omitempty,max=12" example:"20"`
 }
 
 // CategoryResponse ответ с информацией о категории
@@ -124,4 +207,781 @@ type CategoryRequest struct {
 type CategoryResponse struct {
 	CategoryID uuid.UUID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Category   string    `json:"category" example:"Холодильники"`
+	TaxRate    string    `json:"tax_rate,omitempty" example:"20.00"`
+}
+
+// StockMovementRequest запрос на проведение движения остатка
+// @Description Движение остатка товара. Для adjustment quantity задаётся со знаком, для остальных типов — положительное количество. Без warehouse_id движение проводится по складу по умолчанию
+// swagger:model StockMovementRequest
+type StockMovementRequest struct {
+	Type        string     `json:"type" validate:"required,oneof=receipt sale return adjustment write_off supplier_return" example:"receipt"`
+	Quantity    int        `json:"quantity" validate:"required" example:"10"`
+	Reason      string     `json:"reason" validate:"max=255" example:"Поставка по накладной №123"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+}
+
+// StockMovementResponse запись журнала движений
+// @Description Движение остатка: quantity со знаком, actor_id — пользователь, проводивший движение
+// swagger:model StockMovementResponse
+type StockMovementResponse struct {
+	MovementID  uuid.UUID  `json:"movement_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductID   uuid.UUID  `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	WarehouseID uuid.UUID  `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Type        string     `json:"type" example:"sale"`
+	Quantity    int        `json:"quantity" example:"-2"`
+	Reason      string     `json:"reason,omitempty"`
+	ActorID     *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// StockMovementResultResponse результат проведения движения
+// @Description Проведённое движение и остаток товара после него
+// swagger:model StockMovementResultResponse
+type StockMovementResultResponse struct {
+	Movement       StockMovementResponse `json:"movement"`
+	AvailableStock int                   `json:"available_stock" example:"13"`
+}
+
+// StockDiscrepancyResponse расхождение остатка с журналом
+// @Description Товар, у которого остаток не совпадает с суммой движений по журналу
+// swagger:model StockDiscrepancyResponse
+type StockDiscrepancyResponse struct {
+	ProductID      uuid.UUID `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	AvailableStock int       `json:"available_stock" example:"15"`
+	LedgerStock    int       `json:"ledger_stock" example:"12"`
+}
+
+// StockTransferRequest запрос на перемещение товара между складами
+// @Description Перемещение части остатка товара с одного склада на другой. Общий остаток товара не меняется
+// swagger:model StockTransferRequest
+type StockTransferRequest struct {
+	ProductID       uuid.UUID `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id" validate:"required" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id" validate:"required,nefield=FromWarehouseID" example:"7a2e8400-e29b-41d4-a716-446655440000"`
+	Quantity        int       `json:"quantity" validate:"required,gt=0" example:"3"`
+}
+
+// StockTransferResponse перемещение товара между складами
+// @Description Проведённое перемещение: actor_id — пользователь, проводивший перемещение
+// swagger:model StockTransferResponse
+type StockTransferResponse struct {
+	TransferID      uuid.UUID  `json:"transfer_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductID       uuid.UUID  `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	FromWarehouseID uuid.UUID  `json:"from_warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	ToWarehouseID   uuid.UUID  `json:"to_warehouse_id" example:"7a2e8400-e29b-41d4-a716-446655440000"`
+	Quantity        int        `json:"quantity" example:"3"`
+	ActorID         *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// OrderItemRequest позиция оформляемого заказа
+// swagger:model OrderItemRequest
+type OrderItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Quantity  int       `json:"quantity" validate:"required,gt=0" example:"2"`
+}
+
+// OrderRequest запрос на оформление заказа
+// @Description Клиент, позиции и промокоды заказа. Цены берутся из текущих цен товаров, скидки — из действующих акций. Без warehouse_id товары списываются со склада по умолчанию
+// swagger:model OrderRequest
+type OrderRequest struct {
+	ClientID    uuid.UUID          `json:"client_id" validate:"required" example:"333e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID *uuid.UUID         `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Items       []OrderItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+	PromoCodes  []string           `json:"promo_codes" validate:"max=5,dive,required,max=50" example:"SPRING10"`
+}
+
+// OrderItemResponse позиция заказа
+// @Description Позиция заказа с ценой на момент оформления. line_total — сумма без скидки, total — со скидкой в ценах заказа; net, tax и gross — total без налога, налог и с налогом; returned_quantity — сколько единиц вернулось по заявкам на возврат
+// swagger:model OrderItemResponse
+type OrderItemResponse struct {
+	ItemID           uuid.UUID   `json:"item_id" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+	ProductID        uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name             string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	Quantity         int         `json:"quantity" example:"2"`
+	UnitPrice        money.Money `json:"unit_price" example:"75990.00" swaggertype:"string"`
+	LineTotal        money.Money `json:"line_total" example:"151980.00" swaggertype:"string"`
+	Discount         money.Money `json:"discount" example:"15198.00" swaggertype:"string"`
+	Total            money.Money `json:"total" example:"136782.00" swaggertype:"string"`
+	TaxRate          string      `json:"tax_rate" example:"20.00"`
+	Net              money.Money `json:"net" example:"113985.00" swaggertype:"string"`
+	Tax              money.Money `json:"tax" example:"22797.00" swaggertype:"string"`
+	Gross            money.Money `json:"gross" example:"136782.00" swaggertype:"string"`
+	ReturnedQuantity int         `json:"returned_quantity" example:"0"`
+}
+
+// TaxLineResponse итог налога по одной ставке
+// @Description Сумма без налога, налог и сумма с налогом по позициям с одной ставкой
+// swagger:model TaxLineResponse
+type TaxLineResponse struct {
+	Rate  string      `json:"rate" example:"20.00"`
+	Net   money.Money `json:"net" example:"113985.00" swaggertype:"string"`
+	Tax   money.Money `json:"tax" example:"22797.00" swaggertype:"string"`
+	Gross money.Money `json:"gross" example:"136782.00" swaggertype:"string"`
+}
+
+// OrderResponse заказ
+// @Description Заказ клиента с позициями, статусом и итоговой суммой. tax_mode — включали ли цены налог при оформлении (inclusive) или налог начислен сверху (exclusive); total — сумма к оплате с налогом, tax_lines — налог по ставкам
+// swagger:model OrderResponse
+type OrderResponse struct {
+	OrderID     uuid.UUID                 `json:"order_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ClientID    uuid.UUID                 `json:"client_id" example:"333e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID uuid.UUID                 `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Status      string                    `json:"status" example:"new"`
+	Items       []OrderItemResponse       `json:"items"`
+	Discount    money.Money               `json:"discount" example:"15198.00" swaggertype:"string"`
+	TaxMode     string                    `json:"tax_mode" example:"inclusive"`
+	Net         money.Money               `json:"net" example:"113985.00" swaggertype:"string"`
+	Tax         money.Money               `json:"tax" example:"22797.00" swaggertype:"string"`
+	Total       money.Money               `json:"total" example:"136782.00" swaggertype:"string"`
+	TaxLines    []TaxLineResponse         `json:"tax_lines"`
+	Transitions []OrderTransitionResponse `json:"transitions"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+// OrderTransitionRequest запрос на смену статуса заказа
+// @Description Новый статус заказа. Допустимые переходы: new → paid|cancelled, paid → packed|cancelled, packed → shipped|cancelled, shipped → delivered, delivered → returned
+// swagger:model OrderTransitionRequest
+type OrderTransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=new paid packed shipped delivered cancelled returned" example:"paid"`
+}
+
+// OrderTransitionResponse смена статуса заказа
+// @Description Запись истории статусов заказа
+// swagger:model OrderTransitionResponse
+type OrderTransitionResponse struct {
+	From      string     `json:"from" example:"new"`
+	To        string     `json:"to" example:"paid"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CartItemRequest товар, добавляемый в корзину
+// swagger:model CartItemRequest
+type CartItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Quantity  int       `json:"quantity" validate:"required,gt=0" example:"2"`
+}
+
+// CartItemQuantityRequest новое количество товара в корзине
+// swagger:model CartItemQuantityRequest
+type CartItemQuantityRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0" example:"3"`
+}
+
+// CartCheckoutRequest промокоды, применяемые при оформлении корзины
+// swagger:model CartCheckoutRequest
+type CartCheckoutRequest struct {
+	PromoCodes  []string   `json:"promo_codes" validate:"max=5,dive,required,max=50" example:"SPRING10"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+}
+
+// CartMergeRequest слияние анонимной корзины с корзиной клиента
+// swagger:model CartMergeRequest
+type CartMergeRequest struct {
+	Token string `json:"token" validate:"required" example:"q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"`
+}
+
+// CartItemResponse позиция корзины
+// @Description Позиция корзины с текущей ценой и остатком товара
+// swagger:model CartItemResponse
+type CartItemResponse struct {
+	ProductID      uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name           string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	Quantity       int         `json:"quantity" example:"2"`
+	UnitPrice      money.Money `json:"unit_price" example:"75990.00" swaggertype:"string"`
+	LineTotal      money.Money `json:"line_total" example:"151980.00" swaggertype:"string"`
+	AvailableStock int         `json:"available_stock" example:"15"`
+	Available      bool        `json:"available" example:"true"`
+}
+
+// CartResponse корзина
+// @Description Корзина с позициями и итоговой суммой по текущим ценам. token используется для доступа к корзине
+// swagger:model CartResponse
+type CartResponse struct {
+	Token     string             `json:"token" example:"q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"`
+	ClientID  *uuid.UUID         `json:"client_id,omitempty" example:"333e8400-e29b-41d4-a716-446655440001"`
+	Items     []CartItemResponse `json:"items"`
+	Total     money.Money        `json:"total" example:"151980.00" swaggertype:"string"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// ReservationRequest запрос на резервирование товара
+// @Description Количество, склад и срок резерва. Без ttl_seconds используется срок из конфигурации, без warehouse_id при подтверждении товар списывается со склада по умолчанию
+// swagger:model ReservationRequest
+type ReservationRequest struct {
+	Quantity    int        `json:"quantity" validate:"required,gt=0" example:"1"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	TTLSeconds  int        `json:"ttl_seconds" validate:"omitempty,gt=0,lte=86400" example:"900"`
+	Reference   string     `json:"reference" validate:"omitempty,max=255" example:"cart q1w2e3r4"`
+}
+
+// ReservationResponse резерв товара
+// @Description Резерв удерживает количество товара до expires_at, пока не будет подтверждён или освобождён
+// swagger:model ReservationResponse
+type ReservationResponse struct {
+	ReservationID uuid.UUID `json:"reservation_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductID     uuid.UUID `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	WarehouseID   uuid.UUID `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Quantity      int       `json:"quantity" example:"1"`
+	Status        string    `json:"status" example:"active"`
+	Reference     string    `json:"reference,omitempty" example:"cart q1w2e3r4"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// WarehouseRequest запрос на создание склада
+// @Description Склад или торговый зал с адресом
+// swagger:model WarehouseRequest
+type WarehouseRequest struct {
+	Name    string         `json:"name" validate:"required,min=2,max=100" example:"Склад на Складской"`
+	Kind    string         `json:"kind" validate:"required,oneof=warehouse showroom" example:"warehouse"`
+	Address AddressRequest `json:"address"`
+}
+
+// WarehouseResponse склад
+// @Description Место хранения товара. Склад по умолчанию принимает движения остатков без указанного склада
+// swagger:model WarehouseResponse
+type WarehouseResponse struct {
+	WarehouseID uuid.UUID  `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Name        string     `json:"name" example:"Основной склад"`
+	Kind        string     `json:"kind" example:"warehouse"`
+	AddressID   *uuid.UUID `json:"address_uuid,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	IsDefault   bool       `json:"is_default" example:"true"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ReorderPolicyRequest точка заказа товара или категории
+// @Description Остаток ниже reorder_point вызывает оповещение с рекомендацией заказать reorder_quantity единиц. Отсутствующее поле у товара берётся из категории
+// swagger:model ReorderPolicyRequest
+type ReorderPolicyRequest struct {
+	ReorderPoint    *int `json:"reorder_point" validate:"omitempty,gte=0" example:"5"`
+	ReorderQuantity *int `json:"reorder_quantity" validate:"omitempty,gt=0" example:"20"`
+}
+
+// ReorderPolicyResponse точка заказа
+// swagger:model ReorderPolicyResponse
+type ReorderPolicyResponse struct {
+	ReorderPoint    *int `json:"reorder_point" example:"5"`
+	ReorderQuantity *int `json:"reorder_quantity" example:"20"`
+}
+
+// ProductReorderPolicyResponse точка заказа товара
+// @Description Собственная политика товара, политика его категории и действующая политика, по которой проверяется остаток
+// swagger:model ProductReorderPolicyResponse
+type ProductReorderPolicyResponse struct {
+	ProductID uuid.UUID             `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Product   ReorderPolicyResponse `json:"product"`
+	Category  ReorderPolicyResponse `json:"category"`
+	Effective ReorderPolicyResponse `json:"effective"`
+}
+
+// StockAlertQuery параметры списка оповещений о низком остатке
+type StockAlertQuery struct {
+	Status string `form:"status" validate:"omitempty,oneof=open resolved"`
+}
+
+// StockAlertResponse оповещение о низком остатке
+// @Description Товар, остаток которого опустился ниже точки заказа, и рекомендуемая закупка у его поставщика
+// swagger:model StockAlertResponse
+type StockAlertResponse struct {
+	AlertID           uuid.UUID  `json:"alert_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductID         uuid.UUID  `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ProductName       string     `json:"product_name" example:"Холодильник Samsung RB38A7861B1"`
+	SupplierID        *uuid.UUID `json:"supplier_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SupplierName      string     `json:"supplier_name,omitempty" example:"ООО 'ТехноСнаб'"`
+	Stock             int        `json:"stock" example:"3"`
+	ReorderPoint      int        `json:"reorder_point" example:"5"`
+	SuggestedQuantity int        `json:"suggested_quantity" example:"20"`
+	Status            string     `json:"status" example:"open"`
+	CreatedAt         time.Time  `json:"created_at"`
+	NotifiedAt        *time.Time `json:"notified_at,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+}
+
+// PurchaseOrderLineRequest позиция заказа поставщику
+// swagger:model PurchaseOrderLineRequest
+type PurchaseOrderLineRequest struct {
+	ProductID     uuid.UUID   `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Quantity      int         `json:"quantity" validate:"required,gt=0" example:"20"`
+	ExpectedPrice money.Money `json:"expected_price" validate:"gte=0" example:"52000.00" swaggertype:"string"`
+}
+
+// PurchaseOrderRequest запрос на создание заказа поставщику
+// @Description Черновик заказа поставщику. Без warehouse_id товар принимается на склад по умолчанию
+// swagger:model PurchaseOrderRequest
+type PurchaseOrderRequest struct {
+	SupplierID  uuid.UUID                  `json:"supplier_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	WarehouseID *uuid.UUID                 `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Note        string                     `json:"note" validate:"max=500" example:"Поставка к сезону"`
+	Lines       []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,max=200,dive"`
+}
+
+// PurchaseOrderTransitionRequest запрос на смену статуса заказа поставщику
+// @Description Ручные переходы: draft → sent|cancelled, sent → cancelled, partially_received → received (закрыть недопоставку)
+// swagger:model PurchaseOrderTransitionRequest
+type PurchaseOrderTransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft sent partially_received received cancelled" example:"sent"`
+}
+
+// GoodsReceiptLineRequest принятая позиция
+// swagger:model GoodsReceiptLineRequest
+type GoodsReceiptLineRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Quantity  int       `json:"quantity" validate:"gte=0" example:"18"`
+}
+
+// GoodsReceiptRequest приёмка товара по заказу поставщику
+// @Description Фактически принятые количества. Нулевое количество фиксирует, что позиция не пришла
+// swagger:model GoodsReceiptRequest
+type GoodsReceiptRequest struct {
+	Lines []GoodsReceiptLineRequest `json:"lines" validate:"required,min=1,max=200,dive"`
+}
+
+// PurchaseOrderLineResponse позиция заказа поставщику
+// swagger:model PurchaseOrderLineResponse
+type PurchaseOrderLineResponse struct {
+	ProductID        uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name             string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	Quantity         int         `json:"quantity" example:"20"`
+	ExpectedPrice    money.Money `json:"expected_price" example:"52000.00" swaggertype:"string"`
+	LineTotal        money.Money `json:"line_total" example:"1040000.00" swaggertype:"string"`
+	ReceivedQuantity int         `json:"received_quantity" example:"18"`
+	Outstanding      int         `json:"outstanding" example:"2"`
+}
+
+// GoodsReceiptLineResponse позиция приёмки
+// @Description discrepancy — разница между принятым и ожидаемым количеством: отрицательная при недопоставке, положительная при излишке
+// swagger:model GoodsReceiptLineResponse
+type GoodsReceiptLineResponse struct {
+	ProductID        uuid.UUID `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ExpectedQuantity int       `json:"expected_quantity" example:"20"`
+	ReceivedQuantity int       `json:"received_quantity" example:"18"`
+	Discrepancy      int       `json:"discrepancy" example:"-2"`
+}
+
+// GoodsReceiptResponse приёмка товара
+// swagger:model GoodsReceiptResponse
+type GoodsReceiptResponse struct {
+	ReceiptID   uuid.UUID                  `json:"receipt_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	WarehouseID uuid.UUID                  `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Lines       []GoodsReceiptLineResponse `json:"lines"`
+	ActorID     *uuid.UUID                 `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt   time.Time                  `json:"created_at"`
+}
+
+// PurchaseOrderResponse заказ поставщику
+// @Description Заказ поставщику с позициями, ожидаемой суммой и историей приёмок
+// swagger:model PurchaseOrderResponse
+type PurchaseOrderResponse struct {
+	PurchaseOrderID uuid.UUID                   `json:"purchase_order_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	SupplierID      uuid.UUID                   `json:"supplier_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	WarehouseID     uuid.UUID                   `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Status          string                      `json:"status" example:"draft"`
+	Note            string                      `json:"note,omitempty" example:"Поставка к сезону"`
+	Lines           []PurchaseOrderLineResponse `json:"lines"`
+	Total           money.Money                 `json:"total" example:"1040000.00" swaggertype:"string"`
+	Receipts        []GoodsReceiptResponse      `json:"receipts"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+// SupplierProductRequest условия поставки товара поставщиком
+// @Description Позиция прайс-листа: артикул поставщика, закупочная цена, срок поставки и минимальная партия. Без валюты цена считается в RUB
+// swagger:model SupplierProductRequest
+type SupplierProductRequest struct {
+	SKU              string      `json:"supplier_sku" validate:"max=64" example:"TS-RB38-001"`
+	PurchasePrice    money.Money `json:"purchase_price" validate:"gte=0" example:"48500.00" swaggertype:"string"`
+	Currency         string      `json:"currency" validate:"omitempty,len=3,uppercase" example:"RUB"`
+	LeadTimeDays     int         `json:"lead_time_days" validate:"gte=0" example:"7"`
+	MinOrderQuantity int         `json:"min_order_quantity" validate:"omitempty,gt=0" example:"5"`
+}
+
+// SupplierProductResponse позиция прайс-листа поставщика
+// swagger:model SupplierProductResponse
+type SupplierProductResponse struct {
+	SupplierID       uuid.UUID   `json:"supplier_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SupplierName     string      `json:"supplier_name" example:"ООО 'ТехноСнаб'"`
+	ProductID        uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ProductName      string      `json:"product_name" example:"Холодильник Samsung RB38A7861B1"`
+	SKU              string      `json:"supplier_sku,omitempty" example:"TS-RB38-001"`
+	PurchasePrice    money.Money `json:"purchase_price" example:"48500.00" swaggertype:"string"`
+	Currency         string      `json:"currency" example:"RUB"`
+	LeadTimeDays     int         `json:"lead_time_days" example:"7"`
+	MinOrderQuantity int         `json:"min_order_quantity" example:"5"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// BestSupplierQuery параметры выбора поставщика товара
+type BestSupplierQuery struct {
+	Quantity        int    `form:"quantity" validate:"omitempty,gt=0"`
+	Currency        string `form:"currency" validate:"omitempty,len=3,uppercase"`
+	MaxLeadTimeDays *int   `form:"max_lead_time_days" validate:"omitempty,gte=0"`
+	Prefer          string `form:"prefer" validate:"omitempty,oneof=price lead_time"`
+}
+
+// ProductImportForm параметры импорта товаров, передаются полями формы
+// вместе с файлом. mapping — JSON-объект "заголовок столбца": "поле товара"
+type ProductImportForm struct {
+	Format  string `form:"format" validate:"omitempty,oneof=csv xlsx"`
+	DryRun  bool   `form:"dry_run"`
+	Upsert  bool   `form:"upsert"`
+	Mapping string `form:"mapping" validate:"omitempty,json"`
+}
+
+// ProductImportRowError ошибка строки файла импорта
+// @Description row — номер строки в файле с единицы, включая заголовок; field отсутствует, если ошибка относится ко всей строке
+// swagger:model ProductImportRowError
+type ProductImportRowError struct {
+	Row     int    `json:"row" example:"7"`
+	Field   string `json:"field,omitempty" example:"category"`
+	Message string `json:"message" example:"category not found"`
+}
+
+// ProductImportResponse состояние импорта товаров
+// @Description Импорт обрабатывается в фоне: pending — в очереди, running — обрабатывается, completed — обработан (ошибки строк в errors), failed — файл не удалось обработать (причина в error)
+// swagger:model ProductImportResponse
+type ProductImportResponse struct {
+	ImportID    uuid.UUID               `json:"import_id" example:"9b2e8400-e29b-41d4-a716-446655440000"`
+	Status      string                  `json:"status" example:"completed"`
+	Format      string                  `json:"format" example:"csv"`
+	FileName    string                  `json:"file_name" example:"catalog.csv"`
+	DryRun      bool                    `json:"dry_run" example:"false"`
+	Upsert      bool                    `json:"upsert" example:"true"`
+	TotalRows   int                     `json:"total_rows" example:"120"`
+	CreatedRows int                     `json:"created_rows" example:"100"`
+	UpdatedRows int                     `json:"updated_rows" example:"17"`
+	FailedRows  int                     `json:"failed_rows" example:"3"`
+	Error       string                  `json:"error,omitempty" example:"missing columns: price"`
+	Errors      []ProductImportRowError `json:"errors"`
+	CreatedAt   time.Time               `json:"created_at"`
+	StartedAt   *time.Time              `json:"started_at,omitempty"`
+	FinishedAt  *time.Time              `json:"finished_at,omitempty"`
+}
+
+// CurrencyRequest запрос на добавление или изменение валюты
+// @Description Название и правила округления сумм, пересчитанных в валюту. По умолчанию два знака после запятой и округление половины вверх
+// swagger:model CurrencyRequest
+type CurrencyRequest struct {
+	Name     string `json:"name" validate:"required,max=100" example:"Доллар США"`
+	Decimals *int   `json:"decimals" validate:"omitempty,gte=0,lte=2" example:"2"`
+	Rounding string `json:"rounding" validate:"omitempty,oneof=half_up down up" example:"half_up"`
+}
+
+// CurrencyResponse валюта
+// swagger:model CurrencyResponse
+type CurrencyResponse struct {
+	Code     string `json:"code" example:"USD"`
+	Name     string `json:"name" example:"Доллар США"`
+	Decimals int    `json:"decimals" example:"2"`
+	Rounding string `json:"rounding" example:"half_up"`
+}
+
+// ExchangeRateRequest курс валюты на дату
+// @Description Сколько рублей стоит единица валюты начиная с effective_date
+// swagger:model ExchangeRateRequest
+type ExchangeRateRequest struct {
+	Currency      string `json:"currency" validate:"required,len=3,uppercase" example:"USD"`
+	EffectiveDate string `json:"effective_date" validate:"required,datetime=2006-01-02" example:"2026-10-01"`
+	Rate          string `json:"rate" validate:"required" example:"92.5031"`
+}
+
+// ExchangeRatesRequest пакет курсов, сохраняемый целиком
+// swagger:model ExchangeRatesRequest
+type ExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates" validate:"required,min=1,max=1000,dive"`
+}
+
+// ExchangeRateResponse курс валюты
+// swagger:model ExchangeRateResponse
+type ExchangeRateResponse struct {
+	RateID        uuid.UUID `json:"rate_id" example:"7a1e8400-e29b-41d4-a716-446655440000"`
+	Currency      string    `json:"currency" example:"USD"`
+	Rate          string    `json:"rate" example:"92.50310000"`
+	EffectiveDate string    `json:"effective_date" example:"2026-10-01"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ExchangeRateListQuery период действия курсов, границы включаются
+type ExchangeRateListQuery struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ExchangeRateImportResponse результат загрузки файла курсов
+// swagger:model ExchangeRateImportResponse
+type ExchangeRateImportResponse struct {
+	Imported int `json:"imported" example:"30"`
+}
+
+// ProductPriceRequest запрос на смену цены товара в будущем
+// @Description Цена в рублях начнёт действовать с valid_from. Цена, уже запланированная на тот же момент, заменяется
+// swagger:model ProductPriceRequest
+type ProductPriceRequest struct {
+	Price     money.Money `json:"price" validate:"required,gt=0" example:"69990.00" swaggertype:"string"`
+	ValidFrom time.Time   `json:"valid_from" validate:"required" example:"2026-11-01T00:00:00+03:00"`
+	Reason    string      `json:"reason" validate:"max=200" example:"Осенняя распродажа"`
+}
+
+// ProductPriceResponse период действия цены товара
+// @Description У текущей цены valid_to отсутствует. Запланированная цена отмечена scheduled и ещё не применена
+// swagger:model ProductPriceResponse
+type ProductPriceResponse struct {
+	PriceID   uuid.UUID   `json:"price_id" example:"8b1e8400-e29b-41d4-a716-446655440000"`
+	ProductID uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Price     money.Money `json:"price" example:"69990.00" swaggertype:"string"`
+	Currency  string      `json:"currency" example:"RUB"`
+	ValidFrom time.Time   `json:"valid_from"`
+	ValidTo   *time.Time  `json:"valid_to,omitempty"`
+	Scheduled bool        `json:"scheduled" example:"false"`
+	Reason    string      `json:"reason,omitempty" example:"Осенняя распродажа"`
+	CreatedBy *uuid.UUID  `json:"created_by,omitempty" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt time.Time   `json:"created_at"`
+	AppliedAt *time.Time  `json:"applied_at,omitempty"`
+}
+
+// RepricingRequest запрос на массовое изменение цен
+// @Description Меняет цены товаров категории, поставщика или диапазона цен на процент percent или на сумму amount — задаётся одно из двух, отрицательное значение снижает цену. ending округляет новую цену до ближайшей с таким числом копеек
+// swagger:model RepricingRequest
+type RepricingRequest struct {
+	CategoryID *uuid.UUID   `json:"category_id" example:"b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	SupplierID *uuid.UUID   `json:"supplier_id" example:"c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	MinPrice   *money.Money `json:"min_price" validate:"omitempty,gte=0" example:"1000.00" swaggertype:"string"`
+	MaxPrice   *money.Money `json:"max_price" validate:"omitempty,gte=0" example:"50000.00" swaggertype:"string"`
+	Percent    string       `json:"percent" validate:"omitempty,max=12" example:"7.5"`
+	Amount     *money.Money `json:"amount" example:"-100.00" swaggertype:"string"`
+	Ending     *int64       `json:"ending" validate:"omitempty,min=0,max=99" example:"90"`
+	Reason     string       `json:"reason" validate:"max=200" example:"Повышение цен поставщиком"`
+	DryRun     bool         `json:"dry_run" example:"true"`
+}
+
+// RepricingItemResponse новая цена товара
+// swagger:model RepricingItemResponse
+type RepricingItemResponse struct {
+	ProductID uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name      string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	OldPrice  money.Money `json:"old_price" example:"75990.00" swaggertype:"string"`
+	NewPrice  money.Money `json:"new_price" example:"81690.00" swaggertype:"string"`
+}
+
+// RepricingResponse результат массового изменения цен
+// @Description matched — число товаров под фильтром, items — товары, цена которых изменилась или изменится. При dry_run цены не меняются
+// swagger:model RepricingResponse
+type RepricingResponse struct {
+	DryRun  bool                    `json:"dry_run" example:"true"`
+	Matched int                     `json:"matched" example:"42"`
+	Changed int                     `json:"changed" example:"40"`
+	Items   []RepricingItemResponse `json:"items"`
+}
+
+// ProductTaxRequest собственная ставка налога товара
+// @Description Ставка в процентах, которая действует для товара вместо ставки его категории
+// swagger:model ProductTaxRequest
+type ProductTaxRequest struct {
+	TaxRate string `json:"tax_rate" validate:"required,max=12" example:"10"`
+}
+
+// ProductTaxResponse ставка налога товара
+// @Description tax_rate — действующая ставка, source — откуда она взята: product, category или default. override и category_rate — ставки товара и категории, если заданы
+// swagger:model ProductTaxResponse
+type ProductTaxResponse struct {
+	ProductID    uuid.UUID `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	TaxRate      string    `json:"tax_rate" example:"10.00"`
+	Source       string    `json:"source" example:"product"`
+	Override     string    `json:"override,omitempty" example:"10.00"`
+	CategoryRate string    `json:"category_rate,omitempty" example:"20.00"`
+}
+
+// PromotionRequest условия акции
+// @Description Вид kind задаёт обязательные параметры: percent — процент скидки с позиции, fixed — сумма скидки на все подходящие позиции заказа, buy_x_get_y — из каждых buy_quantity+get_quantity единиц товара get_quantity бесплатны. Без category_id и supplier_id акция действует на все товары. Акция с code применяется только по промокоду. Акции применяются по убыванию priority; акция с stackable=false не складывается с другими скидками на ту же позицию
+// swagger:model PromotionRequest
+type PromotionRequest struct {
+	Name           string       `json:"name" validate:"required,max=200" example:"Весенняя скидка на инструмент"`
+	Kind           string       `json:"kind" validate:"required,oneof=percent fixed buy_x_get_y" example:"percent"`
+	Percent        string       `json:"percent" validate:"omitempty,max=12" example:"10"`
+	Amount         *money.Money `json:"amount" validate:"omitempty,gt=0" example:"500.00" swaggertype:"string"`
+	BuyQuantity    int          `json:"buy_quantity" validate:"gte=0" example:"2"`
+	GetQuantity    int          `json:"get_quantity" validate:"gte=0" example:"1"`
+	CategoryID     *uuid.UUID   `json:"category_id" example:"b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	SupplierID     *uuid.UUID   `json:"supplier_id" example:"c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Code           string       `json:"code" validate:"omitempty,max=50" example:"SPRING10"`
+	UsageLimit     *int         `json:"usage_limit" validate:"omitempty,gt=0" example:"1000"`
+	PerClientLimit *int         `json:"per_client_limit" validate:"omitempty,gt=0" example:"1"`
+	ValidFrom      *time.Time   `json:"valid_from" example:"2026-03-01T00:00:00+03:00"`
+	ValidTo        *time.Time   `json:"valid_to" example:"2026-06-01T00:00:00+03:00"`
+	Stackable      *bool        `json:"stackable" example:"true"`
+	Priority       int          `json:"priority" example:"10"`
+	Active         *bool        `json:"active" example:"true"`
+}
+
+// PromotionResponse акция
+// swagger:model PromotionResponse
+type PromotionResponse struct {
+	PromotionID    uuid.UUID    `json:"promotion_id" example:"d3eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name           string       `json:"name" example:"Весенняя скидка на инструмент"`
+	Kind           string       `json:"kind" example:"percent"`
+	Percent        string       `json:"percent,omitempty" example:"10.00"`
+	Amount         *money.Money `json:"amount,omitempty" example:"500.00" swaggertype:"string"`
+	BuyQuantity    int          `json:"buy_quantity,omitempty" example:"2"`
+	GetQuantity    int          `json:"get_quantity,omitempty" example:"1"`
+	CategoryID     *uuid.UUID   `json:"category_id,omitempty" example:"b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	SupplierID     *uuid.UUID   `json:"supplier_id,omitempty" example:"c2eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Code           string       `json:"code,omitempty" example:"SPRING10"`
+	UsageLimit     *int         `json:"usage_limit,omitempty" example:"1000"`
+	PerClientLimit *int         `json:"per_client_limit,omitempty" example:"1"`
+	ValidFrom      *time.Time   `json:"valid_from,omitempty"`
+	ValidTo        *time.Time   `json:"valid_to,omitempty"`
+	Stackable      bool         `json:"stackable" example:"true"`
+	Priority       int          `json:"priority" example:"10"`
+	Active         bool         `json:"active" example:"true"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// PromotionEvaluateRequest позиции для подбора скидок
+// @Description Позиции берутся из корзины cart_token или из items. Клиент нужен для проверки лимитов промокодов на клиента; у корзины клиента он берётся из корзины
+// swagger:model PromotionEvaluateRequest
+type PromotionEvaluateRequest struct {
+	ClientID   *uuid.UUID         `json:"client_id" example:"333e8400-e29b-41d4-a716-446655440001"`
+	CartToken  string             `json:"cart_token" validate:"required_without=Items,excluded_with=Items" example:"7Jx0c2VjcmV0LXRva2Vu"`
+	Items      []OrderItemRequest `json:"items" validate:"omitempty,max=100,dive"`
+	PromoCodes []string           `json:"promo_codes" validate:"max=5,dive,required,max=50" example:"SPRING10"`
+}
+
+// AppliedDiscountResponse скидка одной акции
+// swagger:model AppliedDiscountResponse
+type AppliedDiscountResponse struct {
+	PromotionID uuid.UUID   `json:"promotion_id" example:"d3eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name        string      `json:"name" example:"Весенняя скидка на инструмент"`
+	Code        string      `json:"code,omitempty" example:"SPRING10"`
+	Amount      money.Money `json:"amount" example:"7599.00" swaggertype:"string"`
+}
+
+// PromotionLineResponse позиция со скидками
+// swagger:model PromotionLineResponse
+type PromotionLineResponse struct {
+	ProductID uuid.UUID                 `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name      string                    `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	Quantity  int                       `json:"quantity" example:"1"`
+	UnitPrice money.Money               `json:"unit_price" example:"75990.00" swaggertype:"string"`
+	Subtotal  money.Money               `json:"subtotal" example:"75990.00" swaggertype:"string"`
+	Discounts []AppliedDiscountResponse `json:"discounts"`
+	Discount  money.Money               `json:"discount" example:"7599.00" swaggertype:"string"`
+	Total     money.Money               `json:"total" example:"68391.00" swaggertype:"string"`
+}
+
+// PromotionEvaluationResponse скидки по позициям
+// @Description Скидки каждой позиции и итог по каждой применённой акции
+// swagger:model PromotionEvaluationResponse
+type PromotionEvaluationResponse struct {
+	Lines      []PromotionLineResponse   `json:"lines"`
+	Promotions []AppliedDiscountResponse `json:"promotions"`
+	Subtotal   money.Money               `json:"subtotal" example:"75990.00" swaggertype:"string"`
+	Discount   money.Money               `json:"discount" example:"7599.00" swaggertype:"string"`
+	Total      money.Money               `json:"total" example:"68391.00" swaggertype:"string"`
+}
+
+// ReturnLineRequest возвращаемая позиция заказа
+// swagger:model ReturnLineRequest
+type ReturnLineRequest struct {
+	ItemID   uuid.UUID `json:"item_id" validate:"required" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+	Quantity int       `json:"quantity" validate:"required,gt=0" example:"1"`
+	Reason   string    `json:"reason" validate:"required,oneof=defective damaged wrong_item not_as_described changed_mind other" example:"defective"`
+	Comment  string    `json:"comment" validate:"max=500" example:"Не включается компрессор"`
+}
+
+// ReturnRequest заявка на возврат по заказу
+// @Description Позиции доставленного заказа с количеством и причиной возврата. Без warehouse_id товар принимается на склад по умолчанию
+// swagger:model ReturnRequest
+type ReturnRequest struct {
+	OrderID     uuid.UUID           `json:"order_id" validate:"required" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Note        string              `json:"note" validate:"max=500" example:"Покупатель принёс товар в магазин"`
+	Lines       []ReturnLineRequest `json:"lines" validate:"required,min=1,max=100,unique=ItemID,dive"`
+}
+
+// ReturnTransitionRequest запрос на смену статуса заявки на возврат
+// @Description Ручные переходы: requested → approved|rejected|cancelled, approved → received|cancelled. В refunded заявку переводит только возврат денег по платежу
+// swagger:model ReturnTransitionRequest
+type ReturnTransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=requested approved rejected received inspected cancelled" example:"approved"`
+}
+
+// ReturnInspectionLineRequest решение осмотра по позиции
+// swagger:model ReturnInspectionLineRequest
+type ReturnInspectionLineRequest struct {
+	ItemID  uuid.UUID `json:"item_id" validate:"required" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+	Outcome string    `json:"outcome" validate:"required,oneof=restock write_off send_to_supplier" example:"restock"`
+}
+
+// ReturnInspectionRequest результаты осмотра вернувшегося товара
+// @Description Решение по каждой позиции заявки: restock — вернуть в продажу, write_off — списать, send_to_supplier — отправить поставщику
+// swagger:model ReturnInspectionRequest
+type ReturnInspectionRequest struct {
+	Lines []ReturnInspectionLineRequest `json:"lines" validate:"required,min=1,max=100,unique=ItemID,dive"`
+}
+
+// ReturnListQuery параметры списка заявок на возврат
+type ReturnListQuery struct {
+	OrderID string `form:"order_id" validate:"omitempty,uuid"`
+	Status  string `form:"status" validate:"omitempty,oneof=requested approved rejected received inspected refunded cancelled"`
+}
+
+// ReturnLineResponse позиция заявки на возврат
+// @Description outcome заполняется после осмотра, refund — сумма к возврату за позицию
+// swagger:model ReturnLineResponse
+type ReturnLineResponse struct {
+	ItemID    uuid.UUID   `json:"item_id" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+	ProductID uuid.UUID   `json:"product_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name      string      `json:"name" example:"Холодильник Samsung RB38A7861B1"`
+	Quantity  int         `json:"quantity" example:"1"`
+	Reason    string      `json:"reason" example:"defective"`
+	Comment   string      `json:"comment,omitempty" example:"Не включается компрессор"`
+	Outcome   string      `json:"outcome,omitempty" example:"send_to_supplier"`
+	Refund    money.Money `json:"refund" example:"68391.00" swaggertype:"string"`
+}
+
+// ReturnResponse заявка на возврат
+// @Description Заявка на возврат с позициями, суммой к возврату и складом приёмки
+// swagger:model ReturnResponse
+type ReturnResponse struct {
+	ReturnID    uuid.UUID            `json:"return_id" example:"3f2504e0-4f89-11d3-9a0c-0305e82c3301"`
+	OrderID     uuid.UUID            `json:"order_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ClientID    uuid.UUID            `json:"client_id" example:"333e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID uuid.UUID            `json:"warehouse_id" example:"6f1e8400-e29b-41d4-a716-446655440000"`
+	Status      string               `json:"status" example:"requested"`
+	Note        string               `json:"note,omitempty" example:"Покупатель принёс товар в магазин"`
+	Lines       []ReturnLineResponse `json:"lines"`
+	Refund      money.Money          `json:"refund" example:"68391.00" swaggertype:"string"`
+	ActorID     *uuid.UUID           `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	RefundedAt  *time.Time           `json:"refunded_at,omitempty"`
+}
+
+// PaymentRefundRequest запрос на возврат денег по платежу
+// @Description Сумма возврата или заявка на возврат товара. С return_id и без amount возвращается сумма заявки, а заявка закрывается возвратом денег
+// swagger:model PaymentRefundRequest
+type PaymentRefundRequest struct {
+	Amount   money.Money `json:"amount" validate:"gte=0" example:"1500.00" swaggertype:"string"`
+	ReturnID *uuid.UUID  `json:"return_id" example:"3f2504e0-4f89-11d3-9a0c-0305e82c3301"`
+}
+
+// PaymentResponse платёж по заказу
+// @Description Платёж в платёжном шлюзе. confirmation_url — страница оплаты для покупателя, refunded — сумма, уже возвращённая покупателю
+// swagger:model PaymentResponse
+type PaymentResponse struct {
+	PaymentID       uuid.UUID   `json:"payment_id" example:"1b4e28ba-2fa1-11d2-883f-0016d3cca427"`
+	OrderID         uuid.UUID   `json:"order_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Provider        string      `json:"provider" example:"http"`
+	ExternalID      string      `json:"external_id" example:"fake_5f0c7a1e-7f3b-4a61-9d7e-2c1f1b9c0a11"`
+	Status          string      `json:"status" example:"pending"`
+	Amount          money.Money `json:"amount" example:"68391.00" swaggertype:"string"`
+	Refunded        money.Money `json:"refunded" example:"0.00" swaggertype:"string"`
+	ConfirmationURL string      `json:"confirmation_url,omitempty" example:"http://localhost:8090/payments/fake_5f0c7a1e-7f3b-4a61-9d7e-2c1f1b9c0a11/pay"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
@@ -15,3 +15,20 @@ type NotFoundErrorResponse struct {
 type InternalErrorResponse struct {
 	Error string `json:"error" example:"internal server error"`
 }
+
+type UnauthorizedErrorResponse struct {
+	Error string `json:"error" example:"missing access token"`
+}
+
+type ForbiddenErrorResponse struct {
+	Error string `json:"error" example:"insufficient permissions"`
+}
+
+type ConflictErrorResponse struct {
+	Error string `json:"error" example:"illegal order status transition: new -> shipped"`
+	Code  string `json:"code" example:"illegal_transition"`
+}
+
+type BadGatewayErrorResponse struct {
+	Error string `json:"error" example:"payment provider error: unexpected status 503 Service Unavailable"`
+}
@@ -0,0 +1,375 @@
+package cart
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	service "hardware_store/internal/service/cart"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CartHandler struct {
+	validator *validator.Validate
+	service   service.CartService
+	logger    *slog.Logger
+}
+
+func NewCartHandler(validator *validator.Validate, service service.CartService, logger *slog.Logger) *CartHandler {
+	return &CartHandler{validator: validator, service: service, logger: logger}
+}
+
+func (h *CartHandler) Register(r *gin.RouterGroup) {
+	carts := r.Group("/carts")
+	{
+		carts.POST("", h.Create)
+		carts.GET("/:token", h.Get)
+		carts.POST("/:token/items", h.AddItem)
+		carts.PUT("/:token/items/:product_id", h.UpdateItem)
+		carts.DELETE("/:token/items/:product_id", h.RemoveItem)
+		carts.POST("/:token/checkout",
+			middleware.RequireRoles(auth.RoleCustomer, auth.RoleManager, auth.RoleCashier), h.Checkout)
+	}
+	clientCart := r.Group("/clients/:id/cart",
+		middleware.RequireRoles(auth.RoleCustomer, auth.RoleManager, auth.RoleCashier))
+	{
+		clientCart.GET("", h.GetClientCart)
+		clientCart.POST("/merge", h.Merge)
+	}
+	own := r.Group("/cart", middleware.RequireRoles(auth.RoleCustomer))
+	{
+		own.GET("", h.GetOwnCart)
+		own.POST("/merge", h.MergeOwn)
+	}
+}
+
+// Create godoc
+// @Summary Создать анонимную корзину
+// @Description Создаёт корзину без клиента. Возвращённый token нужен для всех операций с корзиной
+// @Tags carts
+// @Produce json
+// @Success 201 {object} dto.CartResponse "Корзина создана"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /carts [post]
+func (h *CartHandler) Create(c *gin.Context) {
+	created, err := h.service.CreateCart(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to create cart", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to create cart"})
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.CartDomainToWeb(created))
+}
+
+// Get godoc
+// @Summary Получить корзину
+// @Description Возвращает корзину с текущими ценами и остатками товаров
+// @Tags carts
+// @Produce json
+// @Param token path string true "Токен корзины"
+// @Success 200 {object} dto.CartResponse "Корзина"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина не найдена или истекла"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /carts/{token} [get]
+func (h *CartHandler) Get(c *gin.Context) {
+	found, err := h.service.GetCart(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.fail(c, err, "failed to fetch cart")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(found))
+}
+
+// AddItem godoc
+// @Summary Добавить товар в корзину
+// @Description Добавляет товар в корзину. Если товар уже есть, количество увеличивается. Итоговое количество не может превышать остаток
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Токен корзины"
+// @Param item body dto.CartItemRequest true "Товар и количество"
+// @Success 200 {object} dto.CartResponse "Корзина после изменения"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недостаточный остаток"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина или продукт не найдены"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /carts/{token}/items [post]
+func (h *CartHandler) AddItem(c *gin.Context) {
+	var req dto.CartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	updated, err := h.service.AddItem(c.Request.Context(), c.Param("token"), req.ProductID, req.Quantity)
+	if err != nil {
+		h.fail(c, err, "failed to add item to cart")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(updated))
+}
+
+// UpdateItem godoc
+// @Summary Изменить количество товара в корзине
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Токен корзины"
+// @Param product_id path string true "UUID продукта" format(uuid)
+// @Param item body dto.CartItemQuantityRequest true "Новое количество"
+// @Success 200 {object} dto.CartResponse "Корзина после изменения"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недостаточный остаток"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина или позиция не найдены"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /carts/{token}/items/{product_id} [put]
+func (h *CartHandler) UpdateItem(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.CartItemQuantityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	updated, err := h.service.UpdateItem(c.Request.Context(), c.Param("token"), productID, req.Quantity)
+	if err != nil {
+		h.fail(c, err, "failed to update cart item")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(updated))
+}
+
+// RemoveItem godoc
+// @Summary Удалить товар из корзины
+// @Tags carts
+// @Produce json
+// @Param token path string true "Токен корзины"
+// @Param product_id path string true "UUID продукта" format(uuid)
+// @Success 200 {object} dto.CartResponse "Корзина после изменения"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина или позиция не найдены"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /carts/{token}/items/{product_id} [delete]
+func (h *CartHandler) RemoveItem(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	updated, err := h.service.RemoveItem(c.Request.Context(), c.Param("token"), productID)
+	if err != nil {
+		h.fail(c, err, "failed to remove cart item")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(updated))
+}
+
+// Checkout godoc
+// @Summary Оформить заказ по корзине
+// @Description Создаёт заказ из корзины клиента и удаляет корзину в одной транзакции. Анонимную корзину нужно предварительно слить с корзиной клиента. Покупатель может оформить только корзину своего клиента. Без warehouse_id товары списываются со склада по умолчанию. Тело с промокодами и складом необязательно
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Токен корзины"
+// @Param checkout body dto.CartCheckoutRequest false "Промокоды и склад"
+// @Success 201 {object} dto.OrderResponse "Заказ оформлен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Корзина пуста, анонимна, товара недостаточно или промокод недействителен"
+// @Failure 409 {object} dto.ConflictErrorResponse "Лимит использований промокода исчерпан"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина, склад или продукт не найдены"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав или корзина чужого клиента"
+// @Router /carts/{token}/checkout [post]
+func (h *CartHandler) Checkout(c *gin.Context) {
+	var req dto.CartCheckoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+			return
+		}
+		if err := h.validator.Struct(req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	var warehouseID uuid.UUID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+	created, err := h.service.Checkout(c.Request.Context(), c.Param("token"), req.PromoCodes, warehouseID)
+	if err != nil {
+		h.fail(c, err, "failed to checkout cart")
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.OrderDomainToWeb(created))
+}
+
+// GetClientCart godoc
+// @Summary Получить корзину клиента
+// @Description Возвращает корзину клиента, создавая её при первом обращении. Покупателю доступна только корзина своего клиента
+// @Tags carts
+// @Produce json
+// @Param id path string true "UUID клиента" format(uuid)
+// @Success 200 {object} dto.CartResponse "Корзина клиента"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Клиент не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав или клиент чужой"
+// @Router /clients/{id}/cart [get]
+func (h *CartHandler) GetClientCart(c *gin.Context) {
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	found, err := h.service.GetClientCart(c.Request.Context(), clientID)
+	if err != nil {
+		h.fail(c, err, "failed to fetch cart")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(found))
+}
+
+// Merge godoc
+// @Summary Слить анонимную корзину с корзиной клиента
+// @Description Переносит позиции анонимной корзины в корзину клиента. Анонимная корзина удаляется. Покупателю доступна только корзина своего клиента
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID клиента" format(uuid)
+// @Param merge body dto.CartMergeRequest true "Токен анонимной корзины"
+// @Success 200 {object} dto.CartResponse "Корзина клиента после слияния"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Клиент или корзина не найдены"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав или клиент чужой"
+// @Router /clients/{id}/cart/merge [post]
+func (h *CartHandler) Merge(c *gin.Context) {
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.CartMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	merged, err := h.service.Merge(c.Request.Context(), clientID, req.Token)
+	if err != nil {
+		h.fail(c, err, "failed to merge carts")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(merged))
+}
+
+// GetOwnCart godoc
+// @Summary Получить свою корзину
+// @Description Возвращает корзину клиента, привязанного к пользователю из токена, создавая её при первом обращении
+// @Tags carts
+// @Produce json
+// @Success 200 {object} dto.CartResponse "Корзина клиента"
+// @Failure 404 {object} dto.NotFoundErrorResponse "У пользователя нет клиента"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /cart [get]
+func (h *CartHandler) GetOwnCart(c *gin.Context) {
+	claims, _ := auth.FromContext(c.Request.Context())
+	found, err := h.service.GetUserCart(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.fail(c, err, "failed to fetch cart")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(found))
+}
+
+// MergeOwn godoc
+// @Summary Слить анонимную корзину со своей корзиной после входа
+// @Description Вызывается сразу после входа через auth-service: переносит позиции анонимной корзины в корзину клиента, привязанного к пользователю из токена. Анонимная корзина удаляется
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param merge body dto.CartMergeRequest true "Токен анонимной корзины"
+// @Success 200 {object} dto.CartResponse "Корзина клиента после слияния"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "У пользователя нет клиента или корзина не найдена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /cart/merge [post]
+func (h *CartHandler) MergeOwn(c *gin.Context) {
+	var req dto.CartMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	claims, _ := auth.FromContext(c.Request.Context())
+	merged, err := h.service.MergeUserCart(c.Request.Context(), claims.UserID, req.Token)
+	if err != nil {
+		h.fail(c, err, "failed to merge carts")
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CartDomainToWeb(merged))
+}
+
+func (h *CartHandler) fail(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, model.ErrAccessDenied):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrCartNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "cart not found"})
+	case errors.Is(err, model.ErrClientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "client not found"})
+	case errors.Is(err, model.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrInsufficientStock), errors.Is(err, model.ErrInvalidCart),
+		errors.Is(err, model.ErrInvalidOrder), errors.Is(err, model.ErrInvalidPromoCode):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrPromoCodeExhausted):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "promo_code_exhausted"})
+	default:
+		h.logger.Error(msg, logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
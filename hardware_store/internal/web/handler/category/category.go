@@ -1,9 +1,15 @@
 package category
 
 import (
+	"errors"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
 	service "hardware_store/internal/service/category"
 	"hardware_store/internal/web/dto"
 	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -14,23 +20,25 @@ import (
 type CategoryHandler struct {
 	validator *validator.Validate
 	service   service.CategoryService
+	paginator *pagination.Paginator
 }
 
 func NewCategoryHandler(validator *validator.Validate,
-	service service.CategoryService) *CategoryHandler {
+	service service.CategoryService, paginator *pagination.Paginator) *CategoryHandler {
 	return &CategoryHandler{
 		validator: validator,
 		service:   service,
+		paginator: paginator,
 	}
 }
 func (h *CategoryHandler) Register(r *gin.RouterGroup) {
 	category := r.Group("/categories")
 	{
-		category.POST("", h.Create)
-		category.DELETE("/:id", h.Delete)
+		category.POST("", middleware.RequireRoles(auth.RoleManager), h.Create)
+		category.DELETE("/:id", middleware.RequireRoles(auth.RoleAdmin), h.Delete)
 		category.GET("/:id", h.Get)
 		category.GET("", h.List)
-		category.POST("/:id", h.Update)
+		category.POST("/:id", middleware.RequireRoles(auth.RoleManager), h.Update)
 
 	}
 }
@@ -45,6 +53,9 @@ func (h *CategoryHandler) Register(r *gin.RouterGroup) {
 // @Success 200 {object} dto.CategoryResponse "Категория успешно создана"
 // @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации полей или некорректный формат запроса"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при сохранении категории"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
 	var req dto.CategoryRequest
@@ -57,9 +68,18 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
+	taxRate, err := parseTaxRate(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
 	newID := uuid.New()
-	categor := mapper.CategoryRequestToDomain(req, newID)
-	err := h.service.CreateCategory(c.Request.Context(), categor)
+	categor := mapper.CategoryRequestToDomain(req, newID, taxRate)
+	err = h.service.CreateCategory(c.Request.Context(), categor)
+	if errors.Is(err, model.ErrInvalidTaxRate) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to create category"})
 		return
@@ -77,6 +97,9 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Категория не найдена"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при удалении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -121,23 +144,38 @@ func (h *CategoryHandler) Get(c *gin.Context) {
 
 // List godoc
 // @Summary Получить список категорий
-// @Description Возвращает список всех категорий в системе
+// @Description Возвращает страницу категорий, упорядоченных по названию
 // @Tags categories
 // @Produce json
-// @Success 200 {array} dto.CategoryResponse "Список категорий успешно получен"
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.CategoryResponse] "Список категорий успешно получен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры пагинации"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при получении списка"
 // @Router /categories [get]
 func (h *CategoryHandler) List(c *gin.Context) {
-	cat, err := h.service.GetCategories(c.Request.Context())
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	cat, err := h.service.GetCategories(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "category not found"})
 		return
 	}
-	var categories []dto.CategoryResponse
+	res := dto.ListResponse[dto.CategoryResponse]{
+		Items:  make([]dto.CategoryResponse, 0, len(cat)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
 	for _, category := range cat {
-		categories = append(categories, mapper.CategoryDomainToWeb(category))
+		res.Items = append(res.Items, mapper.CategoryDomainToWeb(category))
+	}
+	if n := len(cat); n > 0 {
+		res.NextCursor = h.paginator.Next(req, n, "", "", cat[n-1].Category, cat[n-1].CategoryID)
 	}
-	c.JSON(http.StatusOK, categories)
+	c.JSON(http.StatusOK, res)
 }
 
 // Update godoc
@@ -152,6 +190,9 @@ func (h *CategoryHandler) List(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный UUID или ошибки валидации данных"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Категория не найдена"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /categories/{id} [post]
 func (h *CategoryHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -169,10 +210,32 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 		return
 	}
 
-	cat, err := h.service.UpdateCategory(c.Request.Context(), mapper.CategoryRequestToDomain(req, id))
+	taxRate, err := parseTaxRate(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	cat, err := h.service.UpdateCategory(c.Request.Context(), mapper.CategoryRequestToDomain(req, id, taxRate))
+	if errors.Is(err, model.ErrInvalidTaxRate) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "category not found"})
 		return
 	}
 	c.JSON(http.StatusOK, mapper.CategoryDomainToWeb(cat))
 }
+
+// parseTaxRate разбирает ставку налога категории; пустая ставка — nil.
+func parseTaxRate(req dto.CategoryRequest) (*money.Rate, error) {
+	if req.TaxRate == "" {
+		return nil, nil
+	}
+	rate, err := money.ParseRate(req.TaxRate)
+	if err != nil {
+		return nil, errors.New("tax_rate: " + err.Error())
+	}
+	return &rate, nil
+}
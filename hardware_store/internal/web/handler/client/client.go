@@ -1,14 +1,17 @@
 package client
 
 import (
-	"fmt"
+	"errors"
 	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/auth"
 	"hardware_store/internal/model/client"
+	model "hardware_store/internal/model/error"
 	service "hardware_store/internal/service/client"
 	"hardware_store/internal/web/dto"
 	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,32 +22,34 @@ import (
 type ClientHandler struct {
 	validator *validator.Validate
 	service   service.ClientService
+	paginator *pagination.Paginator
 }
 
-func NewClientHandler(validator *validator.Validate, service service.ClientService) *ClientHandler {
-	return &ClientHandler{validator: validator, service: service}
+func NewClientHandler(validator *validator.Validate, service service.ClientService, paginator *pagination.Paginator) *ClientHandler {
+	return &ClientHandler{validator: validator, service: service, paginator: paginator}
 }
 
 func (h *ClientHandler) Register(r *gin.RouterGroup) {
 	clients := r.Group("/clients")
 	{
 		clients.POST("", h.Create)
-		clients.DELETE("/:id", h.Delete)
-		clients.GET("/search", h.Get)
-		clients.PUT("/:id", h.Update)
-		clients.GET("", h.List)
+		clients.DELETE("/:id", middleware.RequireRoles(auth.RoleAdmin), h.Delete)
+		clients.GET("/search", middleware.RequireRoles(auth.RoleManager, auth.RoleAdmin), h.Get)
+		clients.PUT("/:id", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.Update)
+		clients.GET("", middleware.RequireRoles(auth.RoleManager, auth.RoleAdmin), h.List)
 	}
 }
 
 // Create godoc
 // @Summary Создание клиента
-// @Description Создаёт нового клиента вместе с адресом
+// @Description Создаёт нового клиента вместе с адресом. Если запрос выполнен с токеном покупателя, клиент привязывается к его пользователю, у пользователя может быть только один клиент
 // @Tags clients
 // @Accept json
 // @Produce json
 // @Param client body dto.ClientRequest true "Данные клиента"
 // @Success 201 {object} dto.ClientResponse "Клиент успешно создан"
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный запрос"
+// @Failure 409 {object} dto.ConflictErrorResponse "У пользователя уже есть клиент"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
 // @Router /clients [post]
 func (h *ClientHandler) Create(c *gin.Context) {
@@ -81,6 +86,11 @@ func (h *ClientHandler) Create(c *gin.Context) {
 		RegistrationDate: dateRegistration,
 		AddressID:        addrID,
 	}
+	// Клиент, созданный покупателем, привязывается к его пользователю:
+	// по этой связи проверяется доступ к корзине клиента.
+	if claims, ok := auth.FromContext(c.Request.Context()); ok && !claims.HasAnyRole(auth.RoleManager, auth.RoleCashier) {
+		cl.UserID = &claims.UserID
+	}
 	addr := address.Address{
 		AddressID: addrID,
 		Country:   req.Address.Country,
@@ -88,6 +98,10 @@ func (h *ClientHandler) Create(c *gin.Context) {
 		Street:    req.Address.Street}
 
 	err = h.service.CreateClient(c.Request.Context(), cl, addr)
+	if errors.Is(err, model.ErrClientExists) {
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: "user already has a client", Code: "client_exists"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "failed to create client",
@@ -109,6 +123,9 @@ func (h *ClientHandler) Create(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Клиент не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /clients/{id} [delete]
 func (h *ClientHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
@@ -138,6 +155,9 @@ func (h *ClientHandler) Delete(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Клиент не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /clients/search [get]
 func (h *ClientHandler) Get(c *gin.Context) {
 	name := c.Query("name")
@@ -168,6 +188,9 @@ func (h *ClientHandler) Get(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Клиент не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /clients/{id} [put]
 func (h *ClientHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
@@ -203,54 +226,43 @@ func (h *ClientHandler) Update(c *gin.Context) {
 
 // List godoc
 // @Summary Получить список всех клиентов
-// @Description Возвращает список всех клиентов в системе
+// @Description Возвращает страницу клиентов в порядке регистрации
 // @Tags clients
 // @Accept json
 // @Produce json
-// @Success 200 {array} dto.ClientResponse "Список клиентов"
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param offset query int false "Смещение, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.ClientResponse] "Список клиентов"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры пагинации"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /clients [get]
 func (h *ClientHandler) List(c *gin.Context) {
-	limit, err := parsePaginationParam(c.Query("limit"), "limit", 100)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
-		return
-	}
-	offset, err := parsePaginationParam(c.Query("offset"), "offset", 100)
+	req, err := h.paginator.Parse(c, "", "")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var res []dto.ClientResponse
-	clients, err := h.service.GetClients(c.Request.Context(), int(limit), offset)
+	clients, err := h.service.GetClients(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch clients"})
 		return
 	}
-	for _, client := range clients {
-		res = append(res, mapper.ClientDomainToWeb(client))
-	}
-	c.JSON(http.StatusOK, res)
-}
-
-func parsePaginationParam(s string, paramName string, max int) (int, error) {
-	if s == "" {
-		return 0, nil
-	}
-
-	n, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, fmt.Errorf("invalid %s format: must be an integer", paramName)
+	res := dto.ListResponse[dto.ClientResponse]{
+		Items:  make([]dto.ClientResponse, 0, len(clients)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
 	}
-
-	if n < 0 {
-		return 0, fmt.Errorf("%s must be >= 0", paramName)
+	for _, client := range clients {
+		res.Items = append(res.Items, mapper.ClientDomainToWeb(client))
 	}
-
-	if max > 0 && n > max {
-		return 0, fmt.Errorf("%s must be <= %d", paramName, max)
+	if n := len(clients); n > 0 {
+		last := clients[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.RegistrationDate.Format(time.RFC3339Nano), last.ClientID)
 	}
-
-	return n, nil
+	c.JSON(http.StatusOK, res)
 }
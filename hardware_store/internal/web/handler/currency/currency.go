@@ -0,0 +1,261 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	"hardware_store/internal/model/currency"
+	model "hardware_store/internal/model/error"
+	service "hardware_store/internal/service/currency"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CurrencyHandler struct {
+	validator *validator.Validate
+	service   service.CurrencyService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewCurrencyHandler(validator *validator.Validate, service service.CurrencyService,
+	paginator *pagination.Paginator, logger *slog.Logger) *CurrencyHandler {
+	return &CurrencyHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *CurrencyHandler) Register(r *gin.RouterGroup) {
+	currencies := r.Group("/currencies")
+	{
+		currencies.GET("", h.List)
+		currencies.PUT("/:code", middleware.RequireRoles(auth.RoleAdmin), h.Save)
+		currencies.GET("/:code/rates", h.ListRates)
+	}
+	rates := r.Group("/exchange-rates", middleware.RequireRoles(auth.RoleAdmin))
+	{
+		rates.PUT("", h.SetRates)
+		rates.POST("/import", h.ImportRates)
+	}
+}
+
+// List godoc
+// @Summary Получить список валют
+// @Description Возвращает валюты, в которые можно пересчитывать цены, с правилами округления
+// @Tags currencies
+// @Produce json
+// @Success 200 {array} dto.CurrencyResponse "Валюты"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /currencies [get]
+func (h *CurrencyHandler) List(c *gin.Context) {
+	currencies, err := h.service.GetCurrencies(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to fetch currencies", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch currencies"})
+		return
+	}
+	res := make([]dto.CurrencyResponse, 0, len(currencies))
+	for _, cur := range currencies {
+		res = append(res, mapper.CurrencyDomainToWeb(cur))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Save godoc
+// @Summary Добавить или изменить валюту
+// @Description Сохраняет название валюты и правила округления сумм после пересчёта в неё
+// @Tags currencies
+// @Accept json
+// @Produce json
+// @Param code path string true "Код валюты ISO 4217" example(USD)
+// @Param currency body dto.CurrencyRequest true "Данные валюты"
+// @Success 200 {object} dto.CurrencyResponse "Валюта сохранена"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или некорректный код валюты"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /currencies/{code} [put]
+func (h *CurrencyHandler) Save(c *gin.Context) {
+	var req dto.CurrencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	cur := mapper.CurrencyRequestToDomain(req, strings.ToUpper(c.Param("code")))
+	if err := h.service.SaveCurrency(c.Request.Context(), cur); err != nil {
+		h.writeError(c, err, "failed to save currency", slog.String("code", cur.Code))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.CurrencyDomainToWeb(cur))
+}
+
+// ListRates godoc
+// @Summary Получить историю курсов валюты
+// @Description Возвращает курсы валюты к рублю от последнего к первому, при необходимости за период
+// @Tags currencies
+// @Produce json
+// @Param code path string true "Код валюты ISO 4217" example(USD)
+// @Param from query string false "Начало периода" format(date)
+// @Param to query string false "Конец периода" format(date)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.ExchangeRateResponse] "Курсы валюты"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Валюта не найдена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /currencies/{code}/rates [get]
+func (h *CurrencyHandler) ListRates(c *gin.Context) {
+	code := strings.ToUpper(c.Param("code"))
+	var query dto.ExchangeRateListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var filter currency.RateFilter
+	if query.From != "" {
+		from, _ := time.Parse(time.DateOnly, query.From)
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, _ := time.Parse(time.DateOnly, query.To)
+		filter.To = &to
+	}
+	rates, err := h.service.GetRates(c.Request.Context(), code, filter, req)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch exchange rates", slog.String("code", code))
+		return
+	}
+	res := dto.ListResponse[dto.ExchangeRateResponse]{
+		Items:  make([]dto.ExchangeRateResponse, 0, len(rates)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, r := range rates {
+		res.Items = append(res.Items, mapper.ExchangeRateDomainToWeb(r))
+	}
+	if n := len(rates); n > 0 {
+		last := rates[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.EffectiveDate.Format(time.DateOnly), last.RateID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// SetRates godoc
+// @Summary Загрузить курсы валют
+// @Description Сохраняет курсы валют к рублю. Курс той же валюты на ту же дату заменяется. Пакет сохраняется целиком: при ошибке в любом курсе не сохраняется ни один
+// @Tags currencies
+// @Accept json
+// @Produce json
+// @Param rates body dto.ExchangeRatesRequest true "Курсы валют"
+// @Success 200 {object} dto.ExchangeRateImportResponse "Курсы сохранены"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, неизвестная валюта или курс базовой валюты"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /exchange-rates [put]
+func (h *CurrencyHandler) SetRates(c *gin.Context) {
+	var req dto.ExchangeRatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	rates := make([]currency.ExchangeRate, 0, len(req.Rates))
+	for i, r := range req.Rates {
+		rate, err := currency.ParseRate(r.Rate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: fmt.Sprintf("rates[%d]: %v", i, err)})
+			return
+		}
+		date, _ := time.Parse(time.DateOnly, r.EffectiveDate)
+		rates = append(rates, currency.ExchangeRate{Currency: r.Currency, Rate: rate, EffectiveDate: date})
+	}
+	if err := h.service.SetRates(c.Request.Context(), rates); err != nil {
+		h.writeError(c, err, "failed to save exchange rates", slog.Int("rates", len(rates)))
+		return
+	}
+	c.JSON(http.StatusOK, dto.ExchangeRateImportResponse{Imported: len(rates)})
+}
+
+// ImportRates godoc
+// @Summary Загрузить курсы валют из файла
+// @Description Загружает CSV со столбцами currency, date и rate; дата в формате YYYY-MM-DD или DD.MM.YYYY, разделитель — запятая или точка с запятой. Файл сохраняется целиком: при ошибке в любой строке не сохраняется ни один курс
+// @Tags currencies
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Файл CSV с курсами"
+// @Success 200 {object} dto.ExchangeRateImportResponse "Курсы сохранены"
+// @Failure 400 {object} dto.ValidationErrorResponse "Нет файла, ошибка в строке или неизвестная валюта"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /exchange-rates/import [post]
+func (h *CurrencyHandler) ImportRates(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read file"})
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read file"})
+		return
+	}
+
+	n, err := h.service.ImportRates(c.Request.Context(), data)
+	if err != nil {
+		h.writeError(c, err, "failed to import exchange rates", slog.String("file_name", header.Filename))
+		return
+	}
+	c.JSON(http.StatusOK, dto.ExchangeRateImportResponse{Imported: n})
+}
+
+func (h *CurrencyHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrCurrencyNotFound) && c.Request.Method == http.MethodGet:
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "currency not found"})
+	case errors.Is(err, model.ErrCurrencyNotFound), errors.Is(err, model.ErrInvalidCurrency),
+		errors.Is(err, model.ErrInvalidExchangeRate):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle currencies", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
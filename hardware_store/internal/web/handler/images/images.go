@@ -2,10 +2,12 @@ package images
 
 import (
 	"errors"
+	"hardware_store/internal/model/auth"
 	model "hardware_store/internal/model/error"
 	"hardware_store/internal/service/images"
 
 	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/middleware"
 	"io"
 	"net/http"
 
@@ -26,11 +28,11 @@ func NewImageHandler(validator *validator.Validate, service images.ImageService)
 func (h *ImageHandler) Register(r *gin.RouterGroup) {
 	clients := r.Group("/images")
 	{
-		clients.DELETE("/:id", h.Delete)
+		clients.DELETE("/:id", middleware.RequireRoles(auth.RoleManager), h.Delete)
 		clients.GET("/:id", h.Get)
-		clients.PUT("/:id", h.Update)
+		clients.PUT("/:id", middleware.RequireRoles(auth.RoleManager), h.Update)
 	}
-	r.POST("/products/:id/image", h.Create)
+	r.POST("/products/:id/image", middleware.RequireRoles(auth.RoleManager), h.Create)
 	r.GET("/products/:id/image", h.GetImage)
 }
 
@@ -45,6 +47,9 @@ func (h *ImageHandler) Register(r *gin.RouterGroup) {
 // @Success 201 {object} dto.ImageResponse "Изображение успешно загружено"
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID продукта или некорректные данные изображения"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при сохранении изображения"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /products/{id}/image [post]
 func (h *ImageHandler) Create(c *gin.Context) {
 	prodyctId, err := uuid.Parse(c.Param("id"))
@@ -88,6 +93,9 @@ func (h *ImageHandler) Create(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID изображения"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Изображение не найдено"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при удалении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /images/{id} [delete]
 func (h *ImageHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -183,6 +191,9 @@ func (h *ImageHandler) GetImage(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID или некорректные данные изображения"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Изображение не найдено"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /images/{id} [put]
 func (h *ImageHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
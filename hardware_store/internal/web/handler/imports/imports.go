@@ -0,0 +1,152 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/imports"
+	service "hardware_store/internal/service/imports"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ImportHandler struct {
+	validator *validator.Validate
+	service   service.ImportService
+	logger    *slog.Logger
+}
+
+func NewImportHandler(validator *validator.Validate, service service.ImportService, logger *slog.Logger) *ImportHandler {
+	return &ImportHandler{validator: validator, service: service, logger: logger}
+}
+
+func (h *ImportHandler) Register(r *gin.RouterGroup) {
+	imports := r.Group("/products/imports", middleware.RequireRoles(auth.RoleManager))
+	{
+		imports.POST("", h.Create)
+		imports.GET("/:id", h.Get)
+	}
+}
+
+// Create godoc
+// @Summary Импортировать товары из файла
+// @Description Загружает CSV или XLSX с товарами и ставит его в очередь на обработку. Столбцы сопоставляются полям sku, name, category, supplier, price, available_stock по заголовкам или через mapping; категория и поставщик задаются UUID или названием. При dry_run строки только проверяются, при upsert товары с существующим артикулом обновляются
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Файл CSV или XLSX, первая строка — заголовок"
+// @Param format formData string false "Формат файла, по умолчанию определяется по расширению" Enums(csv, xlsx)
+// @Param dry_run formData bool false "Только проверить строки" default(false)
+// @Param upsert formData bool false "Обновлять товары с существующим артикулом" default(false)
+// @Param mapping formData string false "JSON-объект: заголовок столбца → поле товара"
+// @Success 202 {object} dto.ProductImportResponse "Импорт поставлен в очередь"
+// @Failure 400 {object} dto.ValidationErrorResponse "Нет файла, неизвестный формат или некорректное сопоставление столбцов"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/imports [post]
+func (h *ImportHandler) Create(c *gin.Context) {
+	var form dto.ProductImportForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid form: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(form); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+		return
+	}
+
+	job := imports.Job{
+		Format:   imports.Format(form.Format),
+		FileName: filepath.Base(header.Filename),
+		DryRun:   form.DryRun,
+		Upsert:   form.Upsert,
+	}
+	if job.Format == "" {
+		job.Format = imports.Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), "."))
+	}
+	if form.Mapping != "" {
+		if err := json.Unmarshal([]byte(form.Mapping), &job.Mapping); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "mapping must be an object of strings"})
+			return
+		}
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read file"})
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read file"})
+		return
+	}
+
+	job, err = h.service.StartImport(c.Request.Context(), job, data)
+	if err != nil {
+		h.writeError(c, err, "failed to start import", slog.String("file_name", header.Filename))
+		return
+	}
+	c.JSON(http.StatusAccepted, mapper.ProductImportDomainToWeb(job))
+}
+
+// Get godoc
+// @Summary Получить состояние импорта товаров
+// @Description Возвращает статус импорта, счётчики созданных, обновлённых и ошибочных строк и отчёт об ошибках по строкам
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID импорта" format(uuid)
+// @Success 200 {object} dto.ProductImportResponse "Состояние импорта"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Импорт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/imports/{id} [get]
+func (h *ImportHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	job, err := h.service.GetImport(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch import", slog.String("import_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductImportDomainToWeb(job))
+}
+
+func (h *ImportHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrImportNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "import not found"})
+	case errors.Is(err, model.ErrInvalidImport):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle product import", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
@@ -0,0 +1,99 @@
+package invoice
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/invoice"
+	service "hardware_store/internal/service/invoice"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/middleware"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InvoiceHandler struct {
+	service service.InvoiceService
+	logger  *slog.Logger
+}
+
+func NewInvoiceHandler(service service.InvoiceService, logger *slog.Logger) *InvoiceHandler {
+	return &InvoiceHandler{service: service, logger: logger}
+}
+
+func (h *InvoiceHandler) Register(r *gin.RouterGroup) {
+	orders := r.Group("/orders", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier))
+	{
+		orders.GET("/:id/invoice.pdf", h.Invoice)
+		orders.GET("/:id/receipt.pdf", h.Receipt)
+	}
+}
+
+// Invoice godoc
+// @Summary Счёт на оплату заказа
+// @Description Возвращает PDF-счёт по заказу с реквизитами магазина, покупателем, позициями, НДС по ставкам и итогами. Счёт выпускается при первом запросе под следующим номером за год, дальше отдаётся сохранённым. Номер — в имени файла
+// @Tags orders
+// @Produce application/pdf
+// @Param id path string true "UUID заказа" format(uuid)
+// @Success 200 {file} file "PDF-документ"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ или клиент не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Заказ отменён"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id}/invoice.pdf [get]
+func (h *InvoiceHandler) Invoice(c *gin.Context) {
+	h.serve(c, invoice.KindInvoice)
+}
+
+// Receipt godoc
+// @Summary Квитанция об оплате заказа
+// @Description Возвращает PDF-квитанцию по оплаченному заказу. Квитанции нумеруются отдельно от счетов; выпущенная квитанция отдаётся сохранённой
+// @Tags orders
+// @Produce application/pdf
+// @Param id path string true "UUID заказа" format(uuid)
+// @Success 200 {file} file "PDF-документ"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ или клиент не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Заказ ещё не оплачен или отменён"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id}/receipt.pdf [get]
+func (h *InvoiceHandler) Receipt(c *gin.Context) {
+	h.serve(c, invoice.KindReceipt)
+}
+
+func (h *InvoiceHandler) serve(c *gin.Context, kind invoice.Kind) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	inv, err := h.service.GetDocument(c.Request.Context(), id, kind)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "order not found"})
+		case errors.Is(err, model.ErrClientNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "client not found"})
+		case errors.Is(err, model.ErrInvoiceNotIssuable):
+			c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "invoice_not_issuable"})
+		default:
+			h.logger.Error("Failed to get order document", logger.Err(err),
+				slog.String("order_id", id.String()), slog.String("kind", string(kind)))
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to get " + string(kind)})
+		}
+		return
+	}
+	c.Header("Content-Disposition", `inline; filename="`+inv.FileName()+`"`)
+	c.Data(http.StatusOK, "application/pdf", inv.Document)
+}
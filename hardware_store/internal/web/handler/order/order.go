@@ -0,0 +1,233 @@
+package order
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/order"
+	service "hardware_store/internal/service/order"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type OrderHandler struct {
+	validator *validator.Validate
+	service   service.OrderService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewOrderHandler(validator *validator.Validate, service service.OrderService,
+	paginator *pagination.Paginator, logger *slog.Logger) *OrderHandler {
+	return &OrderHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *OrderHandler) Register(r *gin.RouterGroup) {
+	orders := r.Group("/orders", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier))
+	{
+		orders.POST("", h.Create)
+		orders.GET("/:id", h.Get)
+		orders.POST("/:id/transitions", h.Transition)
+	}
+	r.GET("/clients/:id/orders", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.List)
+}
+
+// Create godoc
+// @Summary Оформить заказ
+// @Description Проверяет клиента, фиксирует текущие цены товаров, применяет акции и промокоды и списывает остатки по всем позициям со склада заказа в одной транзакции. Если какого-то товара на складе не хватает или промокод недействителен, заказ не создаётся
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param order body dto.OrderRequest true "Клиент и позиции заказа"
+// @Success 201 {object} dto.OrderResponse "Заказ оформлен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, недостаточный остаток или недействительный промокод"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Клиент, склад или продукт не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Лимит использований промокода исчерпан"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders [post]
+func (h *OrderHandler) Create(c *gin.Context) {
+	var req dto.OrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	created, err := h.service.CreateOrder(c.Request.Context(), mapper.OrderRequestToDomain(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrClientNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "client not found"})
+		case errors.Is(err, model.ErrWarehouseNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+		case errors.Is(err, model.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, model.ErrInsufficientStock), errors.Is(err, model.ErrInvalidOrder),
+			errors.Is(err, model.ErrInvalidPromoCode):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, model.ErrPromoCodeExhausted):
+			c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "promo_code_exhausted"})
+		default:
+			h.logger.Error("Failed to create order",
+				logger.Err(err),
+				slog.String("client_id", req.ClientID.String()),
+			)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to create order"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.OrderDomainToWeb(created))
+}
+
+// Get godoc
+// @Summary Получить заказ
+// @Description Возвращает заказ с позициями по его UUID
+// @Tags orders
+// @Produce json
+// @Param id path string true "UUID заказа" format(uuid)
+// @Success 200 {object} dto.OrderResponse "Заказ"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id} [get]
+func (h *OrderHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	o, err := h.service.GetOrder(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, model.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "order not found"})
+			return
+		}
+		h.logger.Error("Failed to fetch order", logger.Err(err), slog.String("order_id", id.String()))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch order"})
+		return
+	}
+	c.JSON(http.StatusOK, mapper.OrderDomainToWeb(o))
+}
+
+// Transition godoc
+// @Summary Сменить статус заказа
+// @Description Переводит заказ в новый статус по таблице переходов. Отмена и возврат возвращают товары на склад; единицы, уже вернувшиеся по заявкам на возврат, повторно не приходуются. Недопустимый переход отклоняется с кодом illegal_transition
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID заказа" format(uuid)
+// @Param transition body dto.OrderTransitionRequest true "Новый статус"
+// @Success 200 {object} dto.OrderResponse "Статус заказа изменён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Недопустимый переход статуса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id}/transitions [post]
+func (h *OrderHandler) Transition(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.OrderTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	o, err := h.service.ChangeStatus(c.Request.Context(), id, order.Status(req.Status))
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "order not found"})
+		case errors.Is(err, model.ErrIllegalTransition):
+			c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "illegal_transition"})
+		case errors.Is(err, model.ErrInvalidOrder):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error("Failed to change order status",
+				logger.Err(err),
+				slog.String("order_id", id.String()),
+				slog.String("status", req.Status),
+			)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to change order status"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, mapper.OrderDomainToWeb(o))
+}
+
+// List godoc
+// @Summary Получить заказы клиента
+// @Description Возвращает заказы клиента в порядке оформления
+// @Tags orders
+// @Produce json
+// @Param id path string true "UUID клиента" format(uuid)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.OrderResponse] "Заказы клиента"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный UUID или параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /clients/{id}/orders [get]
+func (h *OrderHandler) List(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	orders, err := h.service.GetClientOrders(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to fetch orders", logger.Err(err), slog.String("client_id", id.String()))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch orders"})
+		return
+	}
+	res := dto.ListResponse[dto.OrderResponse]{
+		Items:  make([]dto.OrderResponse, 0, len(orders)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, o := range orders {
+		res.Items = append(res.Items, mapper.OrderDomainToWeb(o))
+	}
+	if n := len(orders); n > 0 {
+		last := orders[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.OrderID)
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,268 @@
+package payment
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	provider "hardware_store/internal/payment"
+	service "hardware_store/internal/service/payment"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// maxWebhookSize предельный размер уведомления платёжного шлюза.
+const maxWebhookSize = 1 << 20
+
+// idempotencyKeyHeader заголовок с ключом идемпотентности возврата денег.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type PaymentHandler struct {
+	validator *validator.Validate
+	service   service.PaymentService
+	logger    *slog.Logger
+}
+
+func NewPaymentHandler(validator *validator.Validate, service service.PaymentService, logger *slog.Logger) *PaymentHandler {
+	return &PaymentHandler{validator: validator, service: service, logger: logger}
+}
+
+func (h *PaymentHandler) Register(r *gin.RouterGroup) {
+	staff := middleware.RequireRoles(auth.RoleManager, auth.RoleCashier)
+	orders := r.Group("/orders", staff)
+	{
+		orders.POST("/:id/payments", h.Create)
+		orders.GET("/:id/payments", h.ListByOrder)
+	}
+	r.POST("/payments/webhook", h.Webhook)
+	payments := r.Group("/payments", staff)
+	{
+		payments.GET("/:id", h.Get)
+		payments.POST("/:id/capture", h.Capture)
+		payments.POST("/:id/refunds", h.Refund)
+	}
+}
+
+// Create godoc
+// @Summary Создать платёж по заказу
+// @Description Создаёт в платёжном шлюзе платёж на сумму нового заказа и возвращает ссылку на страницу оплаты. Пока у заказа есть незавершённый платёж, возвращается он. Когда шлюз сообщит о списании денег, заказ станет оплаченным
+// @Tags payments
+// @Produce json
+// @Param id path string true "UUID заказа" format(uuid)
+// @Success 201 {object} dto.PaymentResponse "Платёж создан"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID или нулевая сумма заказа"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Заказ не ожидает оплаты"
+// @Failure 502 {object} dto.BadGatewayErrorResponse "Ошибка платёжного шлюза"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id}/payments [post]
+func (h *PaymentHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	p, err := h.service.CreatePayment(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to create payment", slog.String("order_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.PaymentDomainToWeb(p))
+}
+
+// ListByOrder godoc
+// @Summary Получить платежи по заказу
+// @Description Возвращает все платежи заказа в порядке создания, включая отклонённые
+// @Tags payments
+// @Produce json
+// @Param id path string true "UUID заказа" format(uuid)
+// @Success 200 {array} dto.PaymentResponse "Платежи заказа"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /orders/{id}/payments [get]
+func (h *PaymentHandler) ListByOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	list, err := h.service.GetOrderPayments(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch payments", slog.String("order_id", id.String()))
+		return
+	}
+	res := make([]dto.PaymentResponse, 0, len(list))
+	for _, p := range list {
+		res = append(res, mapper.PaymentDomainToWeb(p))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Получить платёж
+// @Description Возвращает платёж с текущим статусом и суммой возвратов
+// @Tags payments
+// @Produce json
+// @Param id path string true "UUID платежа" format(uuid)
+// @Success 200 {object} dto.PaymentResponse "Платёж"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Платёж не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /payments/{id} [get]
+func (h *PaymentHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	p, err := h.service.GetPayment(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch payment", slog.String("payment_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PaymentDomainToWeb(p))
+}
+
+// Capture godoc
+// @Summary Списать заблокированные деньги
+// @Description Подтверждает платёж, деньги по которому заблокированы, на всю сумму. Нужен, если отключено автоматическое списание. Заказ становится оплаченным
+// @Tags payments
+// @Produce json
+// @Param id path string true "UUID платежа" format(uuid)
+// @Success 200 {object} dto.PaymentResponse "Деньги списаны"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Платёж не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Деньги по платежу не заблокированы"
+// @Failure 502 {object} dto.BadGatewayErrorResponse "Ошибка платёжного шлюза"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /payments/{id}/capture [post]
+func (h *PaymentHandler) Capture(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	p, err := h.service.Capture(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to capture payment", slog.String("payment_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PaymentDomainToWeb(p))
+}
+
+// Refund godoc
+// @Summary Вернуть деньги по платежу
+// @Description Возвращает покупателю часть списанных денег или сумму проверенной заявки на возврат товара; заявка при этом закрывается возвратом денег. Общая сумма возвратов не может превышать сумму платежа. Повторный запрос с тем же ключом Idempotency-Key не возвращает деньги второй раз; для заявки на возврат без заголовка ключ выводится из заявки, иначе заголовок обязателен. Если шлюз не ответил, возврат завершит повтор запроса с тем же ключом, а другие возвраты по платежу до тех пор отклоняются
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID платежа" format(uuid)
+// @Param Idempotency-Key header string false "Ключ идемпотентности возврата, до 128 байт"
+// @Param refund body dto.PaymentRefundRequest true "Сумма или заявка на возврат"
+// @Success 200 {object} dto.PaymentResponse "Деньги возвращены"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, нет ключа идемпотентности, ключ занят другим возвратом или сумма больше остатка"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Платёж или заявка не найдены"
+// @Failure 409 {object} dto.ConflictErrorResponse "Деньги не списаны, заявка не проверена или предыдущий возврат не подтверждён шлюзом"
+// @Failure 502 {object} dto.BadGatewayErrorResponse "Ошибка платёжного шлюза"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /payments/{id}/refunds [post]
+func (h *PaymentHandler) Refund(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.PaymentRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	refund := mapper.PaymentRefundRequestToDomain(req)
+	refund.Key = c.GetHeader(idempotencyKeyHeader)
+	p, err := h.service.Refund(c.Request.Context(), id, refund)
+	if err != nil {
+		h.writeError(c, err, "failed to refund payment", slog.String("payment_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PaymentDomainToWeb(p))
+}
+
+// Webhook godoc
+// @Summary Уведомление платёжного шлюза
+// @Description Принимает подписанное уведомление об изменении платежа. Подпись HMAC-SHA256 тела передаётся в заголовке X-Payment-Signature. Повторная доставка того же события не меняет данных. Когда деньги списаны, новый заказ становится оплаченным
+// @Tags payments
+// @Accept json
+// @Param X-Payment-Signature header string true "Подпись тела уведомления"
+// @Success 204 "Уведомление принято"
+// @Failure 400 {object} dto.ValidationErrorResponse "Неверная подпись или формат уведомления"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Платёж не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /payments/webhook [post]
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+
+	err = h.service.HandleWebhook(c.Request.Context(), body, c.GetHeader(provider.SignatureHeader))
+	if err != nil {
+		h.writeError(c, err, "failed to handle payment webhook", slog.Int("size", len(body)))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PaymentHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "payment not found"})
+	case errors.Is(err, model.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "order not found"})
+	case errors.Is(err, model.ErrReturnNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "return not found"})
+	case errors.Is(err, model.ErrIllegalTransition):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "illegal_transition"})
+	case errors.Is(err, model.ErrInvalidPayment), errors.Is(err, model.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrPaymentProvider):
+		h.logger.Warn("Payment provider error", logger.Err(err), attr)
+		c.JSON(http.StatusBadGateway, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle payment", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
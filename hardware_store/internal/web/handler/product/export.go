@@ -0,0 +1,224 @@
+package product
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/product"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/xlsx"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// exportColumns заголовок CSV и XLSX. Названия столбцов совпадают с полями
+// импорта, поэтому выгруженный файл можно загрузить обратно.
+var exportColumns = []string{"sku", "product_id", "name", "category_id", "category",
+	"supplier_id", "supplier", "price", "available_stock", "reserved_stock", "last_update_date"}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer) (catalogWriter, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":   {"text/csv; charset=utf-8", "csv", newCSVWriter},
+	"xlsx":  {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", newXLSXWriter},
+	"jsonl": {"application/x-ndjson", "jsonl", newJSONLWriter},
+}
+
+// Export godoc
+// @Summary Выгрузить каталог
+// @Description Выгружает товары по тем же фильтрам, что и список, в CSV, XLSX или JSON Lines вместе с названиями категорий и поставщиков. Файл передаётся потоком без постраничной разбивки; если выгрузка прервалась после начала передачи, файл обрывается
+// @Tags products
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Param format query string false "Формат файла" Enums(csv, xlsx, jsonl) default(csv)
+// @Param category_id query string false "UUID категории" format(uuid)
+// @Param supplier_id query string false "UUID поставщика" format(uuid)
+// @Param warehouse_id query string false "UUID склада, на котором есть товар" format(uuid)
+// @Param min_price query number false "Минимальная цена"
+// @Param max_price query number false "Максимальная цена"
+// @Param in_stock query bool false "Только товары в наличии" default(true)
+// @Param name query string false "Подстрока названия"
+// @Param sort query string false "Поле сортировки" Enums(price, name, last_update_date)
+// @Param order query string false "Направление сортировки" Enums(asc, desc)
+// @Success 200 {file} file "Файл каталога"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/export [get]
+func (h *ProductHandler) Export(c *gin.Context) {
+	var query dto.ProductExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && query.MinPrice.Cmp(*query.MaxPrice) > 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "min_price must be <= max_price"})
+		return
+	}
+	if query.Format == "" {
+		query.Format = "csv"
+	}
+	format := exportFormats[query.Format]
+
+	// Заголовки ответа отправляются с первой строкой, чтобы ошибку запроса
+	// до начала выгрузки можно было вернуть обычным ответом 500.
+	var w catalogWriter
+	start := func() error {
+		c.Header("Content-Type", format.contentType)
+		c.Header("Content-Disposition", `attachment; filename="products-`+time.Now().Format("20060102")+"."+format.extension+`"`)
+		c.Status(http.StatusOK)
+		var err error
+		w, err = format.newWriter(c.Writer)
+		return err
+	}
+
+	filter := mapper.ProductListQueryToFilter(query.ProductListQuery, page.Request{})
+	err := h.service.ExportProducts(c.Request.Context(), filter, func(row product.ExportRow) error {
+		if w == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return w.Write(row)
+	})
+	if err != nil && w == nil {
+		h.logger.Error("Failed to export products", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to export products"})
+		return
+	}
+	if err == nil && w == nil {
+		err = start()
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		h.logger.Error("Product export interrupted", logger.Err(err))
+		_ = c.Error(err)
+	}
+}
+
+// catalogWriter пишет строки каталога в одном из форматов выгрузки.
+type catalogWriter interface {
+	Write(row product.ExportRow) error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// newCSVWriter начинает файл с BOM, чтобы Excel распознал UTF-8.
+func newCSVWriter(w io.Writer) (catalogWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: cw}, nil
+}
+
+func (w *csvWriter) Write(row product.ExportRow) error {
+	return w.w.Write([]string{
+		row.SKU,
+		row.ProductID.String(),
+		row.Name,
+		optionalUUID(row.CategoryID),
+		row.CategoryName,
+		optionalUUID(row.SupplierID),
+		row.SupplierName,
+		row.Price.String(),
+		strconv.Itoa(row.AvailableStock),
+		strconv.Itoa(row.ReservedStock),
+		row.LastUpdateDate.Format(time.RFC3339),
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type xlsxWriter struct {
+	w *xlsx.Writer
+}
+
+func newXLSXWriter(w io.Writer) (catalogWriter, error) {
+	xw, err := xlsx.NewWriter(w, "Products")
+	if err != nil {
+		return nil, err
+	}
+	header := make([]any, 0, len(exportColumns))
+	for _, col := range exportColumns {
+		header = append(header, col)
+	}
+	if err := xw.WriteRow(header...); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{w: xw}, nil
+}
+
+func (w *xlsxWriter) Write(row product.ExportRow) error {
+	return w.w.WriteRow(
+		row.SKU,
+		row.ProductID.String(),
+		row.Name,
+		optionalUUID(row.CategoryID),
+		row.CategoryName,
+		optionalUUID(row.SupplierID),
+		row.SupplierName,
+		xlsx.Number(row.Price.String()),
+		row.AvailableStock,
+		row.ReservedStock,
+		row.LastUpdateDate.Format(time.RFC3339),
+	)
+}
+
+func (w *xlsxWriter) Close() error {
+	return w.w.Close()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) (catalogWriter, error) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &jsonlWriter{enc: enc}, nil
+}
+
+func (w *jsonlWriter) Write(row product.ExportRow) error {
+	return w.enc.Encode(mapper.ProductExportRowToWeb(row))
+}
+
+func (w *jsonlWriter) Close() error {
+	return nil
+}
+
+func optionalUUID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
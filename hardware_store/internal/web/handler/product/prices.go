@@ -0,0 +1,193 @@
+package product
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SchedulePrice godoc
+// @Summary Запланировать смену цены
+// @Description Планирует новую цену товара с момента valid_from в будущем. Когда момент наступит, фоновая задача применит цену и закроет действующую. Цена, уже запланированная на тот же момент, заменяется. Цена задаётся в рублях (RUB)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param price body dto.ProductPriceRequest true "Новая цена и момент её вступления в силу"
+// @Success 201 {object} dto.ProductPriceResponse "Цена запланирована"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или момент в прошлом"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/prices [post]
+func (h *ProductHandler) SchedulePrice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ProductPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	price, err := h.service.SchedulePrice(c.Request.Context(), mapper.ProductPriceRequestToDomain(req, id))
+	if err != nil {
+		h.writePriceError(c, err, "failed to schedule price", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.ProductPriceDomainToWeb(price))
+}
+
+// CancelPrice godoc
+// @Summary Отменить запланированную цену
+// @Description Удаляет запланированную цену, пока она не вступила в силу. Применённые цены остаются в истории
+// @Tags products
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param price_id path string true "UUID запланированной цены" format(uuid)
+// @Success 204 "Цена отменена"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Запланированная цена не найдена или уже применена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/prices/{price_id} [delete]
+func (h *ProductHandler) CancelPrice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	priceID, err := uuid.Parse(c.Param("price_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	if err := h.service.CancelScheduledPrice(c.Request.Context(), id, priceID); err != nil {
+		h.writePriceError(c, err, "failed to cancel price", slog.String("price_id", priceID.String()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListPrices godoc
+// @Summary Получить историю цен товара
+// @Description Возвращает периоды действия цен товара от поздних к ранним, включая запланированные. По истории можно установить, какая цена действовала в момент покупки
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.ProductPriceResponse] "История цен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID или параметры пагинации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/prices [get]
+func (h *ProductHandler) ListPrices(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	prices, err := h.service.GetPriceHistory(c.Request.Context(), id, req)
+	if err != nil {
+		h.writePriceError(c, err, "failed to fetch price history", slog.String("product_id", id.String()))
+		return
+	}
+	res := dto.ListResponse[dto.ProductPriceResponse]{
+		Items:  make([]dto.ProductPriceResponse, 0, len(prices)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, p := range prices {
+		res.Items = append(res.Items, mapper.ProductPriceDomainToWeb(p))
+	}
+	if n := len(prices); n > 0 {
+		last := prices[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.ValidFrom.Format(time.RFC3339Nano), last.PriceID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Reprice godoc
+// @Summary Массово изменить цены
+// @Description Меняет цены всех товаров, подходящих под фильтр по категории, поставщику или диапазону цен, на процент или сумму с округлением до окончания в копейках. Цены меняются одной транзакцией и попадают в историю цен; если новая цена хотя бы одного товара не положительна, не меняется ни одна. С dry_run возвращает новые цены, не меняя их
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Param repricing body dto.RepricingRequest true "Фильтр товаров и изменение цены"
+// @Success 200 {object} dto.RepricingResponse "Новые цены товаров"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, пустой фильтр или неположительная новая цена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /pricing/bulk [post]
+func (h *ProductHandler) Reprice(c *gin.Context) {
+	var req dto.RepricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var percent *money.Rate
+	if req.Percent != "" {
+		rate, err := money.ParseRate(req.Percent)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "percent: " + err.Error()})
+			return
+		}
+		percent = &rate
+	}
+
+	res, err := h.service.Reprice(c.Request.Context(), mapper.RepricingRequestToDomain(req, percent))
+	if err != nil {
+		h.writePriceError(c, err, "failed to reprice products", slog.Bool("dry_run", req.DryRun))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.RepricingDomainToWeb(res))
+}
+
+func (h *ProductHandler) writePriceError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrPriceChangeNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrInvalidPriceChange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle product prices", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
@@ -1,14 +1,20 @@
 package product
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
 	model "hardware_store/internal/model/error"
 	"hardware_store/internal/model/product"
+	currencyservice "hardware_store/internal/service/currency"
 	service "hardware_store/internal/service/product"
 
 	"hardware_store/internal/web/dto"
 	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
 	"log/slog"
 	"net/http"
 	"time"
@@ -21,34 +27,51 @@ import (
 type ProductHandler struct {
 	validator *validator.Validate
 	service   service.ProductService
+	currency  currencyservice.CurrencyService
+	paginator *pagination.Paginator
 	logger    *slog.Logger
 }
 
-func NewProductHandler(validator *validator.Validate, service service.ProductService, logger *slog.Logger) *ProductHandler {
-	return &ProductHandler{validator: validator, service: service, logger: logger}
+func NewProductHandler(validator *validator.Validate, service service.ProductService, currency currencyservice.CurrencyService,
+	paginator *pagination.Paginator, logger *slog.Logger) *ProductHandler {
+	return &ProductHandler{validator: validator, service: service, currency: currency, paginator: paginator, logger: logger}
 }
 
 func (h *ProductHandler) Register(r *gin.RouterGroup) {
 	clients := r.Group("/products")
 	{
-		clients.POST("", h.Create)
-		clients.DELETE("/:id", h.Delete)
+		clients.POST("", middleware.RequireRoles(auth.RoleManager), h.Create)
+		clients.GET("/export", middleware.RequireRoles(auth.RoleManager), h.Export)
+		clients.DELETE("/:id", middleware.RequireRoles(auth.RoleManager), h.Delete)
 		clients.GET("/:id", h.Get)
-		clients.PUT("/:id/stock", h.Update)
+		clients.PUT("/:id", middleware.RequireRoles(auth.RoleManager), h.Replace)
+		clients.PATCH("/:id", middleware.RequireRoles(auth.RoleManager), h.Patch)
+		clients.PUT("/:id/stock", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.Update)
+		clients.GET("/:id/prices", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.ListPrices)
+		clients.POST("/:id/prices", middleware.RequireRoles(auth.RoleManager), h.SchedulePrice)
+		clients.DELETE("/:id/prices/:price_id", middleware.RequireRoles(auth.RoleManager), h.CancelPrice)
+		clients.GET("/:id/tax", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.GetTax)
+		clients.PUT("/:id/tax", middleware.RequireRoles(auth.RoleManager), h.SetTax)
+		clients.DELETE("/:id/tax", middleware.RequireRoles(auth.RoleManager), h.ClearTax)
 		clients.GET("", h.List)
 	}
+	r.POST("/pricing/bulk", middleware.RequireRoles(auth.RoleManager), h.Reprice)
 }
 
 // Create godoc
 // @Summary Создать новый продукт
-// @Description Создаёт новый продукт в системе на основе переданных данных
+// @Description Создаёт новый продукт в системе на основе переданных данных. Цена задаётся в рублях (RUB): каталог, корзины и заказы считаются в рублях, другие валюты доступны только при выдаче через параметр currency
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param product body dto.ProductRequest true "Данные продукта для создания"
 // @Success 201 {object} dto.ProductResponse "Продукт успешно создан"
 // @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации полей или некорректный формат запроса"
+// @Failure 409 {object} dto.ConflictErrorResponse "Артикул уже занят другим товаром"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при сохранении продукта"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /products [post]
 func (h *ProductHandler) Create(c *gin.Context) {
 
@@ -73,6 +96,7 @@ func (h *ProductHandler) Create(c *gin.Context) {
 	updDate := time.Now()
 	product := product.Product{
 		ProductID:      productID,
+		SKU:            req.SKU,
 		Name:           req.Name,
 		CategoryID:     req.CategoryID,
 		Price:          req.Price,
@@ -82,6 +106,10 @@ func (h *ProductHandler) Create(c *gin.Context) {
 	}
 
 	err := h.service.CreateProduct(c.Request.Context(), product)
+	if errors.Is(err, model.ErrDuplicateProductSKU) {
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "duplicate_sku"})
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to create product",
 			logger.Err(err),
@@ -109,6 +137,9 @@ func (h *ProductHandler) Create(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при удалении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /products/{id} [delete]
 func (h *ProductHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
@@ -133,8 +164,10 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 // @Tags products
 // @Produce json
 // @Param id path string true "UUID продукта" format(uuid)
+// @Param currency query string false "Валюта, в которую пересчитывается цена; без параметра цена возвращается в рублях (RUB)" example(USD)
+// @Param date query string false "Дата курса пересчёта, по умолчанию сегодня" format(date)
 // @Success 200 {object} dto.ProductResponse "Продукт успешно получен"
-// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID, неизвестная валюта или нет курса на дату"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при получении продукта"
 // @Router /products/{id} [get]
@@ -146,18 +179,29 @@ func (h *ProductHandler) Get(c *gin.Context) {
 		return
 	}
 
+	convert, ok := h.priceConverter(c)
+	if !ok {
+		return
+	}
+
 	product, err := h.service.GetProduct(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
 		return
 	}
+	if convert != nil {
+		if product.Price, err = convert(product.Price); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
 
 	c.JSON(http.StatusOK, mapper.ProductDomainToWeb(product))
 }
 
 // Update godoc
 // @Summary Обновить количество товара на складе
-// @Description Обновляет доступное количество товара на складе для указанного продукта
+// @Description Списывает amount единиц товара продажей со склада warehouse_id, без warehouse_id — со склада по умолчанию
 // @Tags products
 // @Accept json
 // @Produce json
@@ -165,8 +209,11 @@ func (h *ProductHandler) Get(c *gin.Context) {
 // @Param stock body dto.UpdateStockCountRequest true "Новое количество товара"
 // @Success 200 {object} dto.ProductResponse "Количество товара успешно обновлено"
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат запроса, отрицательное количество или недостаточный остаток"
-// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт или склад не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /products/{id}/stock [put]
 func (h *ProductHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
@@ -181,12 +228,20 @@ func (h *ProductHandler) Update(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format" + err.Error()})
 		return
 	}
-	product, err := h.service.UpdateProduct(c.Request.Context(), id, req.Amount)
+	var warehouseID uuid.UUID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+	product, err := h.service.UpdateProduct(c.Request.Context(), id, req.Amount, warehouseID)
 	if err != nil {
 		if errors.Is(err, model.ErrProductNotFound) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
 			return
 		}
+		if errors.Is(err, model.ErrWarehouseNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+			return
+		}
 		if errors.Is(err, model.ErrInsufficientStock) {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 			return
@@ -201,24 +256,251 @@ func (h *ProductHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, mapper.ProductDomainToWeb(product))
 }
 
+// Replace godoc
+// @Summary Обновить товар
+// @Description Полностью заменяет артикул, название, категорию, цену, остаток и поставщика товара. Цена задаётся в рублях (RUB)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param product body dto.ProductRequest true "Новые данные товара"
+// @Success 200 {object} dto.ProductResponse "Товар успешно обновлён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, несуществующая категория или поставщик"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Артикул уже занят другим товаром"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id} [put]
+func (h *ProductHandler) Replace(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ProductRequest
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		h.logger.Error("Invalid JSON format", logger.Err(err))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format" + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	product, err := h.service.ReplaceProduct(c.Request.Context(), mapper.ProductRequestToDomain(req, id, time.Now()))
+	if err != nil {
+		h.writeUpdateError(c, id, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductDomainToWeb(product))
+}
+
+// Patch godoc
+// @Summary Частично обновить товар
+// @Description Обновляет только переданные поля товара по семантике JSON merge patch (RFC 7396)
+// @Tags products
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param product body dto.ProductPatchRequest true "Изменяемые поля товара"
+// @Success 200 {object} dto.ProductResponse "Товар успешно обновлён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, несуществующая категория или поставщик"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт или склад не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id} [patch]
+func (h *ProductHandler) Patch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	patch, err := h.decodePatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	product, err := h.service.PatchProduct(c.Request.Context(), id, patch)
+	if err != nil {
+		h.writeUpdateError(c, id, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductDomainToWeb(product))
+}
+
+// decodePatch разбирает тело как JSON merge patch. null допустим только
+// для category_id и supplier_id, для остальных полей это ошибка.
+func (h *ProductHandler) decodePatch(c *gin.Context) (product.Patch, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return product.Patch{}, errors.New("failed to read request body")
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return product.Patch{}, errors.New("invalid JSON format: body must be an object")
+	}
+	var req dto.ProductPatchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return product.Patch{}, errors.New("invalid JSON format: " + err.Error())
+	}
+	for _, field := range []string{"name", "price", "available_stock"} {
+		if v, ok := raw[field]; ok && bytes.Equal(bytes.TrimSpace(v), []byte("null")) {
+			return product.Patch{}, errors.New(field + " cannot be null")
+		}
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return product.Patch{}, err
+	}
+
+	patch := mapper.ProductPatchToDomain(req)
+	if _, ok := raw["category_id"]; ok && req.CategoryID == nil {
+		patch.CategoryID = &uuid.Nil
+	}
+	if _, ok := raw["supplier_id"]; ok && req.SupplierID == nil {
+		patch.SupplierID = &uuid.Nil
+	}
+	return patch, nil
+}
+
+func (h *ProductHandler) writeUpdateError(c *gin.Context, id uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrCategoryNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "category not found"})
+	case errors.Is(err, model.ErrSupplierNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "supplier not found"})
+	case errors.Is(err, model.ErrDuplicateProductSKU):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "duplicate_sku"})
+	default:
+		h.logger.Error("Failed to update product",
+			logger.Err(err),
+			slog.String("product_id", id.String()),
+		)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to update product"})
+	}
+}
+
 // List godoc
 // @Summary Получить список продуктов
-// @Description Возвращает список всех продуктов в системе
+// @Description Возвращает страницу товаров с фильтрацией и сортировкой. Без in_stock возвращаются только товары в наличии, in_stock=false снимает это ограничение
 // @Tags products
 // @Produce json
-// @Success 200 {array} dto.ProductResponse "Список продуктов успешно получен"
+// @Param category_id query string false "UUID категории" format(uuid)
+// @Param supplier_id query string false "UUID поставщика" format(uuid)
+// @Param warehouse_id query string false "UUID склада, на котором есть товар" format(uuid)
+// @Param min_price query number false "Минимальная цена в рублях"
+// @Param max_price query number false "Максимальная цена в рублях"
+// @Param in_stock query bool false "Только товары в наличии" default(true)
+// @Param name query string false "Подстрока названия"
+// @Param sort query string false "Поле сортировки" Enums(price, name, last_update_date)
+// @Param order query string false "Направление сортировки" Enums(asc, desc)
+// @Param currency query string false "Валюта, в которую пересчитываются цены; без параметра цены возвращаются в рублях (RUB)" example(USD)
+// @Param date query string false "Дата курса пересчёта, по умолчанию сегодня" format(date)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param offset query int false "Смещение, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.ProductResponse] "Список продуктов успешно получен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса, неизвестная валюта или нет курса на дату"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при получении списка"
 // @Router /products [get]
 func (h *ProductHandler) List(c *gin.Context) {
-	var res []dto.ProductResponse
-	products, err := h.service.GetProducts(c.Request.Context())
+	var query dto.ProductListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && query.MinPrice.Cmp(*query.MaxPrice) > 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "min_price must be <= max_price"})
+		return
+	}
+	req, err := h.paginator.Parse(c, query.Sort, query.Order)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	convert, ok := h.priceConverter(c)
+	if !ok {
+		return
+	}
+
+	filter := mapper.ProductListQueryToFilter(query, req)
+	products, total, err := h.service.GetProducts(c.Request.Context(), filter)
 	if err != nil {
+		h.logger.Error("Failed to fetch products", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch products"})
 		return
 	}
 
+	res := dto.ListResponse[dto.ProductResponse]{
+		Items:  make([]dto.ProductResponse, 0, len(products)),
+		Total:  &total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
 	for _, product := range products {
-		res = append(res, mapper.ProductDomainToWeb(product))
+		item := mapper.ProductDomainToWeb(product)
+		if convert != nil {
+			price, err := convert(product.Price)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+				return
+			}
+			item.Price, item.Currency = price, price.Currency
+		}
+		res.Items = append(res.Items, item)
+	}
+	if n := len(products); n > 0 {
+		last := products[n-1]
+		res.NextCursor = h.paginator.Next(req, n, query.Sort, query.Order, product.SortKey(last, query.Sort), last.ProductID)
 	}
 	c.JSON(http.StatusOK, res)
 }
+
+// priceConverter разбирает параметры currency и date. Если валюта не
+// запрошена, возвращает nil; при ошибке ответ уже записан.
+func (h *ProductHandler) priceConverter(c *gin.Context) (currencyservice.Converter, bool) {
+	var query dto.PriceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return nil, false
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	if query.Currency == "" {
+		return nil, true
+	}
+	date := time.Now()
+	if query.Date != "" {
+		date, _ = time.Parse(time.DateOnly, query.Date)
+	}
+
+	convert, err := h.currency.NewConverter(c.Request.Context(), query.Currency, date)
+	switch {
+	case errors.Is(err, model.ErrCurrencyNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "unknown currency " + query.Currency})
+		return nil, false
+	case errors.Is(err, model.ErrExchangeRateNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return nil, false
+	case err != nil:
+		h.logger.Error("Failed to load exchange rates", logger.Err(err), slog.String("currency", query.Currency))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to convert prices"})
+		return nil, false
+	}
+	return convert, true
+}
@@ -0,0 +1,128 @@
+package product
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetTax godoc
+// @Summary Получить ставку налога товара
+// @Description Возвращает действующую ставку налога товара и откуда она взята: собственная ставка товара, ставка категории или ставка по умолчанию
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Success 200 {object} dto.ProductTaxResponse "Ставка налога"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/tax [get]
+func (h *ProductHandler) GetTax(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	rate, err := h.service.GetTaxRate(c.Request.Context(), id)
+	if err != nil {
+		h.writeTaxError(c, err, "failed to get tax rate", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductTaxDomainToWeb(rate))
+}
+
+// SetTax godoc
+// @Summary Задать ставку налога товара
+// @Description Задаёт товару собственную ставку налога, которая действует вместо ставки его категории. Ставка применяется к заказам, оформленным после изменения
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param tax body dto.ProductTaxRequest true "Ставка налога в процентах"
+// @Success 200 {object} dto.ProductTaxResponse "Ставка налога задана"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или ставка вне диапазона 0–100"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/tax [put]
+func (h *ProductHandler) SetTax(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ProductTaxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	rate, err := money.ParseRate(req.TaxRate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "tax_rate: " + err.Error()})
+		return
+	}
+
+	res, err := h.service.SetTaxRate(c.Request.Context(), id, &rate)
+	if err != nil {
+		h.writeTaxError(c, err, "failed to set tax rate", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductTaxDomainToWeb(res))
+}
+
+// ClearTax godoc
+// @Summary Снять ставку налога товара
+// @Description Снимает собственную ставку налога товара: для него снова действует ставка категории или ставка по умолчанию
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Success 200 {object} dto.ProductTaxResponse "Действующая ставка после снятия"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/tax [delete]
+func (h *ProductHandler) ClearTax(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	res, err := h.service.SetTaxRate(c.Request.Context(), id, nil)
+	if err != nil {
+		h.writeTaxError(c, err, "failed to clear tax rate", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductTaxDomainToWeb(res))
+}
+
+func (h *ProductHandler) writeTaxError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrInvalidTaxRate):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle product tax rate", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
@@ -0,0 +1,276 @@
+package promotion
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/money"
+	"hardware_store/internal/model/promotion"
+	cartservice "hardware_store/internal/service/cart"
+	service "hardware_store/internal/service/promotion"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type PromotionHandler struct {
+	validator *validator.Validate
+	service   service.PromotionService
+	cart      cartservice.CartService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewPromotionHandler(validator *validator.Validate, service service.PromotionService, cart cartservice.CartService,
+	paginator *pagination.Paginator, logger *slog.Logger) *PromotionHandler {
+	return &PromotionHandler{validator: validator, service: service, cart: cart, paginator: paginator, logger: logger}
+}
+
+func (h *PromotionHandler) Register(r *gin.RouterGroup) {
+	promotions := r.Group("/promotions")
+	{
+		promotions.POST("/evaluate", h.Evaluate)
+		promotions.POST("", middleware.RequireRoles(auth.RoleManager), h.Create)
+		promotions.GET("", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.List)
+		promotions.GET("/:id", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.Get)
+		promotions.PUT("/:id", middleware.RequireRoles(auth.RoleManager), h.Replace)
+	}
+}
+
+// Create godoc
+// @Summary Создать акцию
+// @Description Создаёт правило скидки: процент, фиксированную сумму или «купи X — получи Y» на товары категории, поставщика или все товары, при необходимости по промокоду с лимитами использований и сроком действия
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotion body dto.PromotionRequest true "Условия акции"
+// @Success 201 {object} dto.PromotionResponse "Акция создана"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, параметры не соответствуют виду акции, категория или поставщик не найдены"
+// @Failure 409 {object} dto.ConflictErrorResponse "Промокод уже используется другой акцией"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /promotions [post]
+func (h *PromotionHandler) Create(c *gin.Context) {
+	p, ok := h.bind(c, uuid.Nil)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.CreatePromotion(c.Request.Context(), p)
+	if err != nil {
+		h.writeError(c, err, "failed to create promotion", slog.String("name", p.Name))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.PromotionDomainToWeb(created))
+}
+
+// Replace godoc
+// @Summary Изменить акцию
+// @Description Заменяет условия акции. Чтобы завершить акцию досрочно, передайте active=false. Оформленные заказы сохраняют полученные скидки
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID акции" format(uuid)
+// @Param promotion body dto.PromotionRequest true "Условия акции"
+// @Success 200 {object} dto.PromotionResponse "Акция изменена"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, параметры не соответствуют виду акции, категория или поставщик не найдены"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Акция не найдена"
+// @Failure 409 {object} dto.ConflictErrorResponse "Промокод уже используется другой акцией"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) Replace(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	p, ok := h.bind(c, id)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.UpdatePromotion(c.Request.Context(), p)
+	if err != nil {
+		h.writeError(c, err, "failed to update promotion", slog.String("promotion_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PromotionDomainToWeb(updated))
+}
+
+// Get godoc
+// @Summary Получить акцию
+// @Description Возвращает условия акции по её UUID
+// @Tags promotions
+// @Produce json
+// @Param id path string true "UUID акции" format(uuid)
+// @Success 200 {object} dto.PromotionResponse "Акция"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Акция не найдена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	p, err := h.service.GetPromotion(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch promotion", slog.String("promotion_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PromotionDomainToWeb(p))
+}
+
+// List godoc
+// @Summary Получить список акций
+// @Description Возвращает акции от новых к старым, включая завершённые
+// @Tags promotions
+// @Produce json
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.PromotionResponse] "Акции"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /promotions [get]
+func (h *PromotionHandler) List(c *gin.Context) {
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	promos, err := h.service.GetPromotions(c.Request.Context(), req)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch promotions", slog.Int("limit", req.Limit))
+		return
+	}
+	res := dto.ListResponse[dto.PromotionResponse]{
+		Items:  make([]dto.PromotionResponse, 0, len(promos)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, p := range promos {
+		res.Items = append(res.Items, mapper.PromotionDomainToWeb(p))
+	}
+	if n := len(promos); n > 0 {
+		last := promos[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.PromotionID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Evaluate godoc
+// @Summary Рассчитать скидки
+// @Description Подбирает к корзине или списку позиций действующие акции и введённые промокоды и возвращает скидки по каждой позиции. Цены берутся из текущих цен товаров. Промокоды не погашаются: это происходит при оформлении заказа
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param evaluation body dto.PromotionEvaluateRequest true "Корзина или позиции и промокоды"
+// @Success 200 {object} dto.PromotionEvaluationResponse "Скидки по позициям"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недействительный промокод"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Корзина или продукт не найдены"
+// @Failure 409 {object} dto.ConflictErrorResponse "Лимит использований промокода исчерпан"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /promotions/evaluate [post]
+func (h *PromotionHandler) Evaluate(c *gin.Context) {
+	var req dto.PromotionEvaluateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	basket := promotion.Basket{ClientID: req.ClientID, Codes: req.PromoCodes}
+	for _, item := range req.Items {
+		basket.Lines = append(basket.Lines, promotion.Line{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	if req.CartToken != "" {
+		found, err := h.cart.GetCart(c.Request.Context(), req.CartToken)
+		if err != nil {
+			h.writeError(c, err, "failed to fetch cart", slog.String("operation", "evaluate"))
+			return
+		}
+		if basket.ClientID == nil {
+			basket.ClientID = found.ClientID
+		}
+		for _, item := range found.Items {
+			basket.Lines = append(basket.Lines, promotion.Line{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+
+	eval, err := h.service.Evaluate(c.Request.Context(), basket)
+	if err != nil {
+		h.writeError(c, err, "failed to evaluate promotions", slog.Int("lines", len(basket.Lines)))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PromotionEvaluationDomainToWeb(eval))
+}
+
+// bind разбирает и проверяет условия акции из тела запроса.
+func (h *PromotionHandler) bind(c *gin.Context, id uuid.UUID) (promotion.Promotion, bool) {
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return promotion.Promotion{}, false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return promotion.Promotion{}, false
+	}
+	var percent money.Rate
+	if req.Percent != "" {
+		var err error
+		if percent, err = money.ParseRate(req.Percent); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "percent: " + err.Error()})
+			return promotion.Promotion{}, false
+		}
+	}
+	return mapper.PromotionRequestToDomain(req, id, percent), true
+}
+
+func (h *PromotionHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrPromotionNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "promotion not found"})
+	case errors.Is(err, model.ErrCartNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "cart not found"})
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrInvalidPromotion), errors.Is(err, model.ErrInvalidPromoCode),
+		errors.Is(err, model.ErrInvalidOrder), errors.Is(err, model.ErrCategoryNotFound),
+		errors.Is(err, model.ErrSupplierNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrDuplicatePromoCode):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "duplicate_promo_code"})
+	case errors.Is(err, model.ErrPromoCodeExhausted):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "promo_code_exhausted"})
+	default:
+		h.logger.Error("Failed to handle promotions", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
@@ -0,0 +1,257 @@
+package purchase
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/purchase"
+	service "hardware_store/internal/service/purchase"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type PurchaseHandler struct {
+	validator *validator.Validate
+	service   service.PurchaseService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewPurchaseHandler(validator *validator.Validate, service service.PurchaseService,
+	paginator *pagination.Paginator, logger *slog.Logger) *PurchaseHandler {
+	return &PurchaseHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *PurchaseHandler) Register(r *gin.RouterGroup) {
+	orders := r.Group("/purchase-orders", middleware.RequireRoles(auth.RoleManager))
+	{
+		orders.POST("", h.Create)
+		orders.GET("/:id", h.Get)
+		orders.POST("/:id/transitions", h.Transition)
+		orders.POST("/:id/receipts", h.Receive)
+	}
+	r.GET("/suppliers/:id/purchase-orders", middleware.RequireRoles(auth.RoleManager), h.List)
+}
+
+// Create godoc
+// @Summary Создать заказ поставщику
+// @Description Создаёт черновик заказа поставщику с ожидаемыми ценами и количествами
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param order body dto.PurchaseOrderRequest true "Поставщик, склад приёмки и позиции"
+// @Success 201 {object} dto.PurchaseOrderResponse "Заказ поставщику создан"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Поставщик, склад или продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /purchase-orders [post]
+func (h *PurchaseHandler) Create(c *gin.Context) {
+	var req dto.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	created, err := h.service.CreatePurchaseOrder(c.Request.Context(), mapper.PurchaseOrderRequestToDomain(req))
+	if err != nil {
+		h.writeError(c, err, "failed to create purchase order", slog.String("supplier_id", req.SupplierID.String()))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.PurchaseOrderDomainToWeb(created))
+}
+
+// Get godoc
+// @Summary Получить заказ поставщику
+// @Description Возвращает заказ поставщику с позициями и приёмками
+// @Tags purchase-orders
+// @Produce json
+// @Param id path string true "UUID заказа поставщику" format(uuid)
+// @Success 200 {object} dto.PurchaseOrderResponse "Заказ поставщику"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ поставщику не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /purchase-orders/{id} [get]
+func (h *PurchaseHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	po, err := h.service.GetPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch purchase order", slog.String("purchase_order_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PurchaseOrderDomainToWeb(po))
+}
+
+// Transition godoc
+// @Summary Сменить статус заказа поставщику
+// @Description Отправляет, отменяет или закрывает частично принятый заказ поставщику. Недопустимый переход отклоняется с кодом illegal_transition
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID заказа поставщику" format(uuid)
+// @Param transition body dto.PurchaseOrderTransitionRequest true "Новый статус"
+// @Success 200 {object} dto.PurchaseOrderResponse "Статус изменён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ поставщику не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Недопустимый переход статуса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /purchase-orders/{id}/transitions [post]
+func (h *PurchaseHandler) Transition(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.PurchaseOrderTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	po, err := h.service.ChangeStatus(c.Request.Context(), id, purchase.Status(req.Status))
+	if err != nil {
+		h.writeError(c, err, "failed to change purchase order status", slog.String("purchase_order_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.PurchaseOrderDomainToWeb(po))
+}
+
+// Receive godoc
+// @Summary Принять товар по заказу поставщику
+// @Description Приходует принятые позиции на склад заказа и записывает приёмку с расхождениями между ожидаемым и принятым количеством в одной транзакции. Заказ становится частично или полностью принятым
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID заказа поставщику" format(uuid)
+// @Param receipt body dto.GoodsReceiptRequest true "Принятые количества"
+// @Success 201 {object} dto.PurchaseOrderResponse "Товар принят"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации, товар не из заказа или ничего не принято"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ поставщику не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Заказ не ожидает поставки"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /purchase-orders/{id}/receipts [post]
+func (h *PurchaseHandler) Receive(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.GoodsReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	po, err := h.service.Receive(c.Request.Context(), id, mapper.GoodsReceiptRequestToDomain(req))
+	if err != nil {
+		h.writeError(c, err, "failed to receive goods", slog.String("purchase_order_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.PurchaseOrderDomainToWeb(po))
+}
+
+// List godoc
+// @Summary Получить заказы поставщику
+// @Description Возвращает заказы поставщику в порядке создания
+// @Tags purchase-orders
+// @Produce json
+// @Param id path string true "UUID поставщика" format(uuid)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.PurchaseOrderResponse] "Заказы поставщику"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный UUID или параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /suppliers/{id}/purchase-orders [get]
+func (h *PurchaseHandler) List(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	orders, err := h.service.GetSupplierPurchaseOrders(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to fetch purchase orders", logger.Err(err), slog.String("supplier_id", id.String()))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch purchase orders"})
+		return
+	}
+	res := dto.ListResponse[dto.PurchaseOrderResponse]{
+		Items:  make([]dto.PurchaseOrderResponse, 0, len(orders)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, po := range orders {
+		res.Items = append(res.Items, mapper.PurchaseOrderDomainToWeb(po))
+	}
+	if n := len(orders); n > 0 {
+		last := orders[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.PurchaseOrderID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *PurchaseHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrPurchaseOrderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "purchase order not found"})
+	case errors.Is(err, model.ErrSupplierNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "supplier not found"})
+	case errors.Is(err, model.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrIllegalTransition):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "illegal_transition"})
+	case errors.Is(err, model.ErrInvalidPurchaseOrder), errors.Is(err, model.ErrInvalidMovement):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle purchase order", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
@@ -0,0 +1,218 @@
+package replenishment
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/replenishment"
+	service "hardware_store/internal/service/replenishment"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ReplenishmentHandler struct {
+	validator *validator.Validate
+	service   service.ReplenishmentService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewReplenishmentHandler(validator *validator.Validate, service service.ReplenishmentService,
+	paginator *pagination.Paginator, logger *slog.Logger) *ReplenishmentHandler {
+	return &ReplenishmentHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *ReplenishmentHandler) Register(r *gin.RouterGroup) {
+	managers := middleware.RequireRoles(auth.RoleManager)
+	r.GET("/products/:id/reorder-policy", managers, h.GetProductPolicy)
+	r.PUT("/products/:id/reorder-policy", managers, h.SetProductPolicy)
+	r.PUT("/categories/:id/reorder-policy", managers, h.SetCategoryPolicy)
+	r.GET("/stock/alerts", managers, h.ListAlerts)
+}
+
+// GetProductPolicy godoc
+// @Summary Получить точку заказа товара
+// @Description Возвращает собственную точку заказа товара, точку заказа его категории и действующую
+// @Tags replenishment
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Success 200 {object} dto.ProductReorderPolicyResponse "Точка заказа"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/reorder-policy [get]
+func (h *ReplenishmentHandler) GetProductPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	p, err := h.service.GetProductPolicy(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch reorder policy", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductReorderPolicyDomainToWeb(p))
+}
+
+// SetProductPolicy godoc
+// @Summary Задать точку заказа товара
+// @Description Заменяет точку заказа и объём закупки товара. Отсутствующие поля берутся из категории товара
+// @Tags replenishment
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param policy body dto.ReorderPolicyRequest true "Точка заказа"
+// @Success 200 {object} dto.ProductReorderPolicyResponse "Точка заказа изменена"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/reorder-policy [put]
+func (h *ReplenishmentHandler) SetProductPolicy(c *gin.Context) {
+	id, req, ok := h.bindPolicy(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.service.SetProductPolicy(c.Request.Context(), id, mapper.ReorderPolicyRequestToDomain(req))
+	if err != nil {
+		h.writeError(c, err, "failed to update reorder policy", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ProductReorderPolicyDomainToWeb(p))
+}
+
+// SetCategoryPolicy godoc
+// @Summary Задать точку заказа категории
+// @Description Задаёт точку заказа и объём закупки по умолчанию для товаров категории, у которых они не заданы
+// @Tags replenishment
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID категории" format(uuid)
+// @Param policy body dto.ReorderPolicyRequest true "Точка заказа"
+// @Success 200 {object} dto.ReorderPolicyResponse "Точка заказа изменена"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Категория не найдена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /categories/{id}/reorder-policy [put]
+func (h *ReplenishmentHandler) SetCategoryPolicy(c *gin.Context) {
+	id, req, ok := h.bindPolicy(c)
+	if !ok {
+		return
+	}
+
+	policy := mapper.ReorderPolicyRequestToDomain(req)
+	if err := h.service.SetCategoryPolicy(c.Request.Context(), id, policy); err != nil {
+		h.writeError(c, err, "failed to update reorder policy", slog.String("category_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ReorderPolicyDomainToWeb(policy))
+}
+
+// ListAlerts godoc
+// @Summary Получить оповещения о низком остатке
+// @Description Возвращает оповещения о товарах, остаток которых опустился ниже точки заказа, с рекомендуемым поставщиком и объёмом закупки
+// @Tags replenishment
+// @Produce json
+// @Param status query string false "Статус оповещения" Enums(open, resolved) default(open)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.StockAlertResponse] "Оповещения"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /stock/alerts [get]
+func (h *ReplenishmentHandler) ListAlerts(c *gin.Context) {
+	var query dto.StockAlertQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	status := replenishment.AlertOpen
+	if query.Status != "" {
+		status = replenishment.AlertStatus(query.Status)
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	alerts, err := h.service.GetAlerts(c.Request.Context(), status, req)
+	if err != nil {
+		h.logger.Error("Failed to fetch stock alerts", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch stock alerts"})
+		return
+	}
+	res := dto.ListResponse[dto.StockAlertResponse]{
+		Items:  make([]dto.StockAlertResponse, 0, len(alerts)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, a := range alerts {
+		res.Items = append(res.Items, mapper.StockAlertDomainToWeb(a))
+	}
+	if n := len(alerts); n > 0 {
+		last := alerts[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.AlertID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ReplenishmentHandler) bindPolicy(c *gin.Context) (uuid.UUID, dto.ReorderPolicyRequest, bool) {
+	var req dto.ReorderPolicyRequest
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return id, req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return id, req, false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return id, req, false
+	}
+	return id, req, true
+}
+
+func (h *ReplenishmentHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "category not found"})
+	case errors.Is(err, model.ErrInvalidReorderPolicy):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle reorder policy", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
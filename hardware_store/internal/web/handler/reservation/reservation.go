@@ -0,0 +1,157 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/reservation"
+	service "hardware_store/internal/service/product"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ReservationHandler struct {
+	validator *validator.Validate
+	service   service.ProductService
+	logger    *slog.Logger
+}
+
+func NewReservationHandler(validator *validator.Validate, service service.ProductService, logger *slog.Logger) *ReservationHandler {
+	return &ReservationHandler{validator: validator, service: service, logger: logger}
+}
+
+func (h *ReservationHandler) Register(r *gin.RouterGroup) {
+	r.POST("/products/:id/reservations", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.Create)
+	reservations := r.Group("/reservations", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier))
+	{
+		reservations.POST("/:id/release", h.Release)
+		reservations.POST("/:id/confirm", h.Confirm)
+	}
+}
+
+// Create godoc
+// @Summary Зарезервировать товар
+// @Description Удерживает количество товара на ограниченный срок. Зарезервированный товар нельзя продать, пока резерв не подтверждён или не освобождён. Истёкшие резервы освобождаются автоматически
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param reservation body dto.ReservationRequest true "Параметры резерва"
+// @Success 201 {object} dto.ReservationResponse "Резерв создан"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недостаточный свободный остаток"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт или склад не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/reservations [post]
+func (h *ReservationHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var warehouseID uuid.UUID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	created, err := h.service.Reserve(c.Request.Context(), id, req.Quantity, warehouseID, ttl, req.Reference)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+		case errors.Is(err, model.ErrWarehouseNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+		case errors.Is(err, model.ErrInsufficientStock), errors.Is(err, model.ErrAmountIsNegative):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error("Failed to reserve product", logger.Err(err), slog.String("product_id", id.String()))
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to reserve product"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.ReservationDomainToWeb(created))
+}
+
+// Release godoc
+// @Summary Освободить резерв
+// @Description Снимает активный резерв и возвращает количество в свободный остаток
+// @Tags reservations
+// @Produce json
+// @Param id path string true "UUID резерва" format(uuid)
+// @Success 200 {object} dto.ReservationResponse "Резерв освобождён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Резерв не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Резерв уже закрыт или истёк"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /reservations/{id}/release [post]
+func (h *ReservationHandler) Release(c *gin.Context) {
+	h.close(c, h.service.ReleaseReservation, "failed to release reservation")
+}
+
+// Confirm godoc
+// @Summary Подтвердить резерв
+// @Description Превращает резерв в продажу: снимает резерв и списывает остаток в журнал движений
+// @Tags reservations
+// @Produce json
+// @Param id path string true "UUID резерва" format(uuid)
+// @Success 200 {object} dto.ReservationResponse "Резерв подтверждён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Резерв не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Резерв уже закрыт или истёк"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /reservations/{id}/confirm [post]
+func (h *ReservationHandler) Confirm(c *gin.Context) {
+	h.close(c, h.service.ConfirmReservation, "failed to confirm reservation")
+}
+
+func (h *ReservationHandler) close(c *gin.Context,
+	fn func(ctx context.Context, id uuid.UUID) (reservation.Reservation, error), msg string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	closed, err := fn(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrReservationNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "reservation not found"})
+		case errors.Is(err, model.ErrReservationClosed):
+			c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "reservation_closed"})
+		default:
+			h.logger.Error(msg, logger.Err(err), slog.String("reservation_id", id.String()))
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ReservationDomainToWeb(closed))
+}
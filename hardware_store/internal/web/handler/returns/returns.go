@@ -0,0 +1,260 @@
+package returns
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/returns"
+	service "hardware_store/internal/service/returns"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ReturnHandler struct {
+	validator *validator.Validate
+	service   service.ReturnService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewReturnHandler(validator *validator.Validate, service service.ReturnService,
+	paginator *pagination.Paginator, logger *slog.Logger) *ReturnHandler {
+	return &ReturnHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *ReturnHandler) Register(r *gin.RouterGroup) {
+	rs := r.Group("/returns", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier))
+	{
+		rs.POST("", h.Create)
+		rs.GET("", h.List)
+		rs.GET("/:id", h.Get)
+		rs.POST("/:id/transitions", h.Transition)
+		rs.POST("/:id/inspection", h.Inspect)
+	}
+}
+
+// Create godoc
+// @Summary Создать заявку на возврат
+// @Description Регистрирует возврат позиций доставленного заказа с причинами. Сумма к возврату — доля оплаченной суммы позиции после скидок и с налогом. Позицию нельзя вернуть больше, чем осталось незаявленным в других заявках
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param return body dto.ReturnRequest true "Заказ, склад приёмки и позиции"
+// @Success 201 {object} dto.ReturnResponse "Заявка создана"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или позиция не из заказа"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заказ или склад не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Заказ ещё не доставлен или уже возвращён"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /returns [post]
+func (h *ReturnHandler) Create(c *gin.Context) {
+	var req dto.ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	created, err := h.service.CreateReturn(c.Request.Context(), mapper.ReturnRequestToDomain(req))
+	if err != nil {
+		h.writeError(c, err, "failed to create return", slog.String("order_id", req.OrderID.String()))
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.ReturnDomainToWeb(created))
+}
+
+// Get godoc
+// @Summary Получить заявку на возврат
+// @Description Возвращает заявку на возврат с позициями и результатами осмотра
+// @Tags returns
+// @Produce json
+// @Param id path string true "UUID заявки на возврат" format(uuid)
+// @Success 200 {object} dto.ReturnResponse "Заявка на возврат"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заявка не найдена"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /returns/{id} [get]
+func (h *ReturnHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	r, err := h.service.GetReturn(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch return", slog.String("return_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ReturnDomainToWeb(r))
+}
+
+// List godoc
+// @Summary Получить заявки на возврат
+// @Description Возвращает заявки на возврат в порядке создания, при необходимости только по заказу или в одном статусе
+// @Tags returns
+// @Produce json
+// @Param order_id query string false "UUID заказа" format(uuid)
+// @Param status query string false "Статус заявки" Enums(requested, approved, rejected, received, inspected, refunded, cancelled)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.ReturnResponse] "Заявки на возврат"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /returns [get]
+func (h *ReturnHandler) List(c *gin.Context) {
+	var query dto.ReturnListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	list, err := h.service.GetReturns(c.Request.Context(), mapper.ReturnListQueryToFilter(query), req)
+	if err != nil {
+		h.writeError(c, err, "failed to fetch returns", slog.String("order_id", query.OrderID))
+		return
+	}
+	res := dto.ListResponse[dto.ReturnResponse]{
+		Items:  make([]dto.ReturnResponse, 0, len(list)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, r := range list {
+		res.Items = append(res.Items, mapper.ReturnDomainToWeb(r))
+	}
+	if n := len(list); n > 0 {
+		last := list[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.ReturnID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Transition godoc
+// @Summary Сменить статус заявки на возврат
+// @Description Одобряет, отклоняет или отменяет заявку и отмечает получение товара. Возврат денег закрывает заявку только через возврат по платежу. Недопустимый переход отклоняется с кодом illegal_transition
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID заявки на возврат" format(uuid)
+// @Param transition body dto.ReturnTransitionRequest true "Новый статус"
+// @Success 200 {object} dto.ReturnResponse "Статус изменён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заявка не найдена"
+// @Failure 409 {object} dto.ConflictErrorResponse "Недопустимый переход статуса"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /returns/{id}/transitions [post]
+func (h *ReturnHandler) Transition(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ReturnTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	r, err := h.service.ChangeStatus(c.Request.Context(), id, returns.Status(req.Status))
+	if err != nil {
+		h.writeError(c, err, "failed to change return status", slog.String("return_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ReturnDomainToWeb(r))
+}
+
+// Inspect godoc
+// @Summary Записать результаты осмотра
+// @Description Принимает решение по каждой позиции полученной заявки и в одной транзакции проводит движения остатков: возврат на склад заявки, а при списании или отправке поставщику — сразу и расход. Заявка становится проверенной
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID заявки на возврат" format(uuid)
+// @Param inspection body dto.ReturnInspectionRequest true "Решения по позициям"
+// @Success 200 {object} dto.ReturnResponse "Осмотр записан"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или решения не по всем позициям"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Заявка не найдена"
+// @Failure 409 {object} dto.ConflictErrorResponse "Товар по заявке ещё не получен"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /returns/{id}/inspection [post]
+func (h *ReturnHandler) Inspect(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.ReturnInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	r, err := h.service.Inspect(c.Request.Context(), id, mapper.ReturnInspectionRequestToDomain(req))
+	if err != nil {
+		h.writeError(c, err, "failed to inspect return", slog.String("return_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ReturnDomainToWeb(r))
+}
+
+func (h *ReturnHandler) writeError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrReturnNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "return not found"})
+	case errors.Is(err, model.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "order not found"})
+	case errors.Is(err, model.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+	case errors.Is(err, model.ErrIllegalTransition):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "illegal_transition"})
+	case errors.Is(err, model.ErrInvalidReturn), errors.Is(err, model.ErrInvalidOrder), errors.Is(err, model.ErrInvalidMovement):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle return", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
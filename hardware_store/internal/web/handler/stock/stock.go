@@ -0,0 +1,232 @@
+package stock
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/model/stock"
+	service "hardware_store/internal/service/stock"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type StockHandler struct {
+	validator *validator.Validate
+	service   service.StockService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewStockHandler(validator *validator.Validate, service service.StockService,
+	paginator *pagination.Paginator, logger *slog.Logger) *StockHandler {
+	return &StockHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *StockHandler) Register(r *gin.RouterGroup) {
+	r.POST("/products/:id/stock/movements", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.Create)
+	r.GET("/products/:id/stock/movements", middleware.RequireRoles(auth.RoleManager, auth.RoleCashier), h.List)
+	r.GET("/stock/reconciliation", middleware.RequireRoles(auth.RoleManager), h.Reconcile)
+	r.POST("/stock/transfers", middleware.RequireRoles(auth.RoleManager), h.Transfer)
+}
+
+// Create godoc
+// @Summary Провести движение остатка
+// @Description Проводит приход, продажу, возврат, корректировку или списание и записывает его в журнал. Кассир может проводить только продажи и возвраты
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param movement body dto.StockMovementRequest true "Движение остатка"
+// @Success 201 {object} dto.StockMovementResultResponse "Движение проведено"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недостаточный остаток"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт или склад не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/stock/movements [post]
+func (h *StockHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.StockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	movement := mapper.StockMovementRequestToDomain(req, id)
+	if !canPost(c, movement.Type) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "insufficient permissions"})
+		return
+	}
+
+	recorded, balance, err := h.service.RecordMovement(c.Request.Context(), movement)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+		case errors.Is(err, model.ErrWarehouseNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+		case errors.Is(err, model.ErrInsufficientStock), errors.Is(err, model.ErrInvalidMovement):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error("Failed to record stock movement",
+				logger.Err(err),
+				slog.String("product_id", id.String()),
+			)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to record stock movement"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, dto.StockMovementResultResponse{
+		Movement:       mapper.StockMovementDomainToWeb(recorded),
+		AvailableStock: balance,
+	})
+}
+
+// List godoc
+// @Summary Получить историю движений товара
+// @Description Возвращает журнал движений остатка товара в хронологическом порядке
+// @Tags stock
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.StockMovementResponse] "История движений"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный UUID или параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /products/{id}/stock/movements [get]
+func (h *StockHandler) List(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	movements, err := h.service.GetMovements(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to fetch stock movements", logger.Err(err), slog.String("product_id", id.String()))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch stock movements"})
+		return
+	}
+	res := dto.ListResponse[dto.StockMovementResponse]{
+		Items:  make([]dto.StockMovementResponse, 0, len(movements)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, m := range movements {
+		res.Items = append(res.Items, mapper.StockMovementDomainToWeb(m))
+	}
+	if n := len(movements); n > 0 {
+		last := movements[n-1]
+		res.NextCursor = h.paginator.Next(req, n, "", "", last.CreatedAt.Format(time.RFC3339Nano), last.MovementID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Reconcile godoc
+// @Summary Сверить остатки с журналом
+// @Description Пересчитывает остатки по журналу движений и возвращает товары с расхождениями. Пустой список означает, что остатки сходятся
+// @Tags stock
+// @Produce json
+// @Success 200 {array} dto.StockDiscrepancyResponse "Товары с расхождениями"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /stock/reconciliation [get]
+func (h *StockHandler) Reconcile(c *gin.Context) {
+	discrepancies, err := h.service.Reconcile(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to reconcile stock", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to reconcile stock"})
+		return
+	}
+	res := make([]dto.StockDiscrepancyResponse, 0, len(discrepancies))
+	for _, d := range discrepancies {
+		res = append(res, mapper.StockDiscrepancyDomainToWeb(d))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// canPost кассир проводит только продажи и возвраты, остальные движения
+// доступны менеджеру.
+func canPost(c *gin.Context, t stock.MovementType) bool {
+	if t == stock.MovementSale || t == stock.MovementReturn {
+		return true
+	}
+	claims, _ := auth.FromContext(c.Request.Context())
+	return claims.HasAnyRole(auth.RoleManager)
+}
+
+// Transfer godoc
+// @Summary Переместить товар между складами
+// @Description Атомарно списывает товар со склада-источника и зачисляет на склад-получатель. Общий остаток товара не меняется. Если на источнике не хватает товара, перемещение не проводится
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param transfer body dto.StockTransferRequest true "Перемещение"
+// @Success 201 {object} dto.StockTransferResponse "Перемещение проведено"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или недостаточный остаток на складе-источнике"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Продукт или склад не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /stock/transfers [post]
+func (h *StockHandler) Transfer(c *gin.Context) {
+	var req dto.StockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	t, err := h.service.Transfer(c.Request.Context(), mapper.StockTransferRequestToDomain(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+		case errors.Is(err, model.ErrWarehouseNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+		case errors.Is(err, model.ErrInsufficientStock), errors.Is(err, model.ErrInvalidTransfer):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error("Failed to transfer stock",
+				logger.Err(err),
+				slog.String("product_id", req.ProductID.String()),
+			)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to transfer stock"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, mapper.StockTransferDomainToWeb(t))
+}
@@ -0,0 +1,224 @@
+package supplier
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListProducts godoc
+// @Summary Получить прайс-лист поставщика
+// @Description Возвращает страницу товаров поставщика с закупочными ценами, упорядоченных по названию товара
+// @Tags suppliers
+// @Produce json
+// @Param id path string true "UUID поставщика" format(uuid)
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.SupplierProductResponse] "Прайс-лист поставщика"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID или параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /suppliers/{id}/products [get]
+func (h *SupplierHandler) ListProducts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	products, err := h.service.GetSupplierProducts(c.Request.Context(), id, req)
+	if err != nil {
+		h.writeProductError(c, err, "failed to fetch supplier products", slog.String("supplier_id", id.String()))
+		return
+	}
+	res := dto.ListResponse[dto.SupplierProductResponse]{
+		Items:  make([]dto.SupplierProductResponse, 0, len(products)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, p := range products {
+		res.Items = append(res.Items, mapper.SupplierProductDomainToWeb(p))
+	}
+	if n := len(products); n > 0 {
+		res.NextCursor = h.paginator.Next(req, n, "", "", products[n-1].ProductName, products[n-1].ProductID)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// SetProduct godoc
+// @Summary Задать условия поставки товара
+// @Description Добавляет товар в прайс-лист поставщика или заменяет его закупочную цену, срок поставки и минимальную партию
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param id path string true "UUID поставщика" format(uuid)
+// @Param product_id path string true "UUID продукта" format(uuid)
+// @Param product body dto.SupplierProductRequest true "Условия поставки"
+// @Success 200 {object} dto.SupplierProductResponse "Прайс-лист обновлён"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации или неизвестная валюта"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Поставщик или продукт не найден"
+// @Failure 409 {object} dto.ConflictErrorResponse "Артикул уже занят другим товаром поставщика"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /suppliers/{id}/products/{product_id} [put]
+func (h *SupplierHandler) SetProduct(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var req dto.SupplierProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	p, err := h.service.SetSupplierProduct(c.Request.Context(),
+		mapper.SupplierProductRequestToDomain(req, supplierID, productID))
+	if err != nil {
+		h.writeProductError(c, err, "failed to update supplier product", slog.String("supplier_id", supplierID.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.SupplierProductDomainToWeb(p))
+}
+
+// RemoveProduct godoc
+// @Summary Убрать товар из прайс-листа
+// @Description Удаляет товар из прайс-листа поставщика
+// @Tags suppliers
+// @Param id path string true "UUID поставщика" format(uuid)
+// @Param product_id path string true "UUID продукта" format(uuid)
+// @Success 204 "Товар убран из прайс-листа"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Товара нет в прайс-листе поставщика"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /suppliers/{id}/products/{product_id} [delete]
+func (h *SupplierHandler) RemoveProduct(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	if err := h.service.RemoveSupplierProduct(c.Request.Context(), supplierID, productID); err != nil {
+		h.writeProductError(c, err, "failed to remove supplier product", slog.String("supplier_id", supplierID.String()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListOffers godoc
+// @Summary Получить поставщиков товара
+// @Description Возвращает все предложения поставщиков по товару, сгруппированные по валюте и упорядоченные от самого дешёвого к самому дорогому
+// @Tags suppliers
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Success 200 {array} dto.SupplierProductResponse "Предложения поставщиков"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /products/{id}/suppliers [get]
+func (h *SupplierHandler) ListOffers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	offers, err := h.service.GetProductOffers(c.Request.Context(), id)
+	if err != nil {
+		h.writeProductError(c, err, "failed to fetch product suppliers", slog.String("product_id", id.String()))
+		return
+	}
+	res := make([]dto.SupplierProductResponse, 0, len(offers))
+	for _, p := range offers {
+		res = append(res, mapper.SupplierProductDomainToWeb(p))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// BestOffer godoc
+// @Summary Выбрать поставщика товара
+// @Description Возвращает лучшее предложение по товару среди поставщиков, готовых продать нужное количество в указанной валюте. По умолчанию выбирается самое дешёвое, при prefer=lead_time — самое быстрое
+// @Tags suppliers
+// @Produce json
+// @Param id path string true "UUID продукта" format(uuid)
+// @Param quantity query int false "Требуемое количество" default(1)
+// @Param currency query string false "Валюта закупки" default(RUB)
+// @Param max_lead_time_days query int false "Максимальный срок поставки в днях"
+// @Param prefer query string false "Приоритет выбора" Enums(price, lead_time) default(price)
+// @Success 200 {object} dto.SupplierProductResponse "Лучшее предложение"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры запроса"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Нет подходящего предложения"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /products/{id}/best-supplier [get]
+func (h *SupplierHandler) BestOffer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+	var q dto.BestSupplierQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	offer, err := h.service.GetBestOffer(c.Request.Context(), mapper.BestSupplierQueryToDomain(q, id))
+	if err != nil {
+		h.writeProductError(c, err, "failed to find best supplier", slog.String("product_id", id.String()))
+		return
+	}
+	c.JSON(http.StatusOK, mapper.SupplierProductDomainToWeb(offer))
+}
+
+func (h *SupplierHandler) writeProductError(c *gin.Context, err error, msg string, attr slog.Attr) {
+	switch {
+	case errors.Is(err, model.ErrSupplierNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "supplier not found"})
+	case errors.Is(err, model.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "product not found"})
+	case errors.Is(err, model.ErrSupplierProductNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, model.ErrDuplicateSupplierSKU):
+		c.JSON(http.StatusConflict, dto.ConflictErrorResponse{Error: err.Error(), Code: "duplicate_sku"})
+	case errors.Is(err, model.ErrInvalidSupplierProduct), errors.Is(err, model.ErrCurrencyNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle supplier products", logger.Err(err), attr)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: msg})
+	}
+}
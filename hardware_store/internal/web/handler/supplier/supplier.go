@@ -2,10 +2,14 @@ package supplier
 
 import (
 	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/auth"
 	"hardware_store/internal/model/supplier"
 	service "hardware_store/internal/service/supplier"
 	"hardware_store/internal/web/dto"
 	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,25 +20,34 @@ import (
 type SupplierHandler struct {
 	validator *validator.Validate
 	service   service.SupplierService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
 }
 
 func NewSupplierHandler(validator *validator.Validate,
-	service service.SupplierService) *SupplierHandler {
+	service service.SupplierService, paginator *pagination.Paginator, logger *slog.Logger) *SupplierHandler {
 	return &SupplierHandler{
 		validator: validator,
 		service:   service,
+		paginator: paginator,
+		logger:    logger,
 	}
 }
 
 func (h *SupplierHandler) Register(c *gin.RouterGroup) {
 	supplier := c.Group("suppliers")
 	{
-		supplier.POST("", h.Create)
-		supplier.DELETE(":id", h.Delete)
+		supplier.POST("", middleware.RequireRoles(auth.RoleManager), h.Create)
+		supplier.DELETE(":id", middleware.RequireRoles(auth.RoleAdmin), h.Delete)
 		supplier.GET("/:id", h.Get)
 		supplier.GET("", h.List)
-		supplier.PUT("/:id", h.Update)
+		supplier.PUT("/:id", middleware.RequireRoles(auth.RoleManager), h.Update)
+		supplier.GET("/:id/products", h.ListProducts)
+		supplier.PUT("/:id/products/:product_id", middleware.RequireRoles(auth.RoleManager), h.SetProduct)
+		supplier.DELETE("/:id/products/:product_id", middleware.RequireRoles(auth.RoleManager), h.RemoveProduct)
 	}
+	c.GET("/products/:id/suppliers", h.ListOffers)
+	c.GET("/products/:id/best-supplier", h.BestOffer)
 }
 
 // Create godoc
@@ -47,6 +60,9 @@ func (h *SupplierHandler) Register(c *gin.RouterGroup) {
 // @Success 201 {object} dto.SupplierResponse "Поставщик успешно создан"
 // @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации полей или некорректный формат запроса"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при сохранении поставщика"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /suppliers [post]
 func (h *SupplierHandler) Create(c *gin.Context) {
 	var req dto.SupplierRequest
@@ -89,6 +105,9 @@ func (h *SupplierHandler) Create(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Поставщик не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при удалении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /suppliers/{id} [delete]
 func (h *SupplierHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -130,20 +149,36 @@ func (h *SupplierHandler) Get(c *gin.Context) {
 
 // List godoc
 // @Summary Получить список поставщиков
-// @Description Возвращает список всех поставщиков в системе
+// @Description Возвращает страницу поставщиков, упорядоченных по названию
 // @Tags suppliers
 // @Produce json
-// @Success 200 {array} dto.SupplierResponse "Список поставщиков успешно получен"
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.SupplierResponse] "Список поставщиков успешно получен"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры пагинации"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при получении списка"
 // @Router /suppliers [get]
 func (h *SupplierHandler) List(c *gin.Context) {
-	suppliers, err := h.service.GetSuppliers(c.Request.Context())
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	suppliers, err := h.service.GetSuppliers(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "supplier not found"})
+		return
+	}
+	res := dto.ListResponse[dto.SupplierResponse]{
+		Items:  make([]dto.SupplierResponse, 0, len(suppliers)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
 	}
-	var res []dto.SupplierResponse
 	for _, sup := range suppliers {
-		res = append(res, mapper.SupplierDomainToWeb(sup))
+		res.Items = append(res.Items, mapper.SupplierDomainToWeb(sup))
+	}
+	if n := len(suppliers); n > 0 {
+		res.NextCursor = h.paginator.Next(req, n, "", "", suppliers[n-1].Name, suppliers[n-1].SupplierID)
 	}
 	c.JSON(http.StatusOK, res)
 }
@@ -160,6 +195,9 @@ func (h *SupplierHandler) List(c *gin.Context) {
 // @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID или ошибки валидации данных"
 // @Failure 404 {object} dto.NotFoundErrorResponse "Поставщик не найден"
 // @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера при обновлении"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
 // @Router /suppliers/{id} [put]
 func (h *SupplierHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
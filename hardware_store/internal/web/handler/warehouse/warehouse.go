@@ -0,0 +1,146 @@
+package warehouse
+
+import (
+	"errors"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	service "hardware_store/internal/service/warehouse"
+	"hardware_store/internal/web/dto"
+	"hardware_store/internal/web/mapper"
+	"hardware_store/internal/web/middleware"
+	"hardware_store/internal/web/pagination"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type WarehouseHandler struct {
+	validator *validator.Validate
+	service   service.WarehouseService
+	paginator *pagination.Paginator
+	logger    *slog.Logger
+}
+
+func NewWarehouseHandler(validator *validator.Validate, service service.WarehouseService,
+	paginator *pagination.Paginator, logger *slog.Logger) *WarehouseHandler {
+	return &WarehouseHandler{validator: validator, service: service, paginator: paginator, logger: logger}
+}
+
+func (h *WarehouseHandler) Register(r *gin.RouterGroup) {
+	warehouses := r.Group("/warehouses")
+	{
+		warehouses.POST("", middleware.RequireRoles(auth.RoleManager), h.Create)
+		warehouses.GET("/:id", h.Get)
+		warehouses.GET("", h.List)
+	}
+}
+
+// Create godoc
+// @Summary Создать склад
+// @Description Создаёт склад или торговый зал вместе с его адресом
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param warehouse body dto.WarehouseRequest true "Данные склада"
+// @Success 201 {object} dto.WarehouseResponse "Склад создан"
+// @Failure 400 {object} dto.ValidationErrorResponse "Ошибки валидации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Failure 401 {object} dto.UnauthorizedErrorResponse "Требуется авторизация"
+// @Failure 403 {object} dto.ForbiddenErrorResponse "Недостаточно прав"
+// @Router /warehouses [post]
+func (h *WarehouseHandler) Create(c *gin.Context) {
+	var req dto.WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid JSON format: " + err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	addr := mapper.AddressWebToDomain(req.Address, uuid.New())
+	w := mapper.WarehouseRequestToDomain(req, uuid.New(), time.Now())
+	if err := h.service.CreateWarehouse(c.Request.Context(), w, addr); err != nil {
+		h.logger.Error("Failed to create warehouse", logger.Err(err), slog.String("name", req.Name))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to create warehouse"})
+		return
+	}
+	w.AddressID = &addr.AddressID
+	c.JSON(http.StatusCreated, mapper.WarehouseDomainToWeb(w))
+}
+
+// Get godoc
+// @Summary Получить склад
+// @Description Возвращает склад по его UUID
+// @Tags warehouses
+// @Produce json
+// @Param id path string true "UUID склада" format(uuid)
+// @Success 200 {object} dto.WarehouseResponse "Склад"
+// @Failure 400 {object} dto.ValidationErrorResponse "Невалидный формат UUID"
+// @Failure 404 {object} dto.NotFoundErrorResponse "Склад не найден"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /warehouses/{id} [get]
+func (h *WarehouseHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid UUID format"})
+		return
+	}
+
+	w, err := h.service.GetWarehouse(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, model.ErrWarehouseNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "warehouse not found"})
+			return
+		}
+		h.logger.Error("Failed to fetch warehouse", logger.Err(err), slog.String("warehouse_id", id.String()))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch warehouse"})
+		return
+	}
+	c.JSON(http.StatusOK, mapper.WarehouseDomainToWeb(w))
+}
+
+// List godoc
+// @Summary Получить список складов
+// @Description Возвращает страницу складов, упорядоченных по названию
+// @Tags warehouses
+// @Produce json
+// @Param limit query int false "Размер страницы (до 100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} dto.ListResponse[dto.WarehouseResponse] "Список складов"
+// @Failure 400 {object} dto.ValidationErrorResponse "Некорректные параметры пагинации"
+// @Failure 500 {object} dto.InternalErrorResponse "Внутренняя ошибка сервера"
+// @Router /warehouses [get]
+func (h *WarehouseHandler) List(c *gin.Context) {
+	req, err := h.paginator.Parse(c, "", "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	warehouses, err := h.service.GetWarehouses(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to fetch warehouses", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to fetch warehouses"})
+		return
+	}
+	res := dto.ListResponse[dto.WarehouseResponse]{
+		Items:  make([]dto.WarehouseResponse, 0, len(warehouses)),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for _, w := range warehouses {
+		res.Items = append(res.Items, mapper.WarehouseDomainToWeb(w))
+	}
+	if n := len(warehouses); n > 0 {
+		res.NextCursor = h.paginator.Next(req, n, "", "", warehouses[n-1].Name, warehouses[n-1].WarehouseID)
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -2,13 +2,28 @@ package mapper
 
 import (
 	"hardware_store/internal/model/category"
+	"hardware_store/internal/model/money"
 	"hardware_store/internal/model/supplier"
 	"time"
 
 	"hardware_store/internal/model/address"
+	"hardware_store/internal/model/cart"
 	"hardware_store/internal/model/client"
+	"hardware_store/internal/model/currency"
 	"hardware_store/internal/model/images"
+	"hardware_store/internal/model/imports"
+	"hardware_store/internal/model/order"
+	"hardware_store/internal/model/page"
+	"hardware_store/internal/model/payment"
 	"hardware_store/internal/model/product"
+	"hardware_store/internal/model/promotion"
+	"hardware_store/internal/model/purchase"
+	"hardware_store/internal/model/replenishment"
+	"hardware_store/internal/model/reservation"
+	"hardware_store/internal/model/returns"
+	"hardware_store/internal/model/stock"
+	"hardware_store/internal/model/tax"
+	"hardware_store/internal/model/warehouse"
 	"hardware_store/internal/web/dto"
 
 	"github.com/google/uuid"
@@ -57,6 +72,7 @@ func ClientDomainToWeb(client client.Client) dto.ClientResponse {
 		Gender:           client.Gender,
 		RegistrationDate: client.RegistrationDate,
 		AddressID:        client.AddressID,
+		UserID:           client.UserID,
 	}
 }
 
@@ -68,27 +84,179 @@ func ProductRequestToDomain(
 ) product.Product {
 	return product.Product{
 		ProductID:      productID,
+		SKU:            req.SKU,
 		Name:           req.Name,
 		CategoryID:     req.CategoryID,
-		Price:          req.Price,
+		Price:          money.New(req.Price.Amount, money.DefaultCurrency),
 		AvailableStock: req.AvailableStock,
 		LastUpdateDate: lastUpdate,
 		SupplierID:     req.SupplierID,
 	}
 }
+func ProductPatchToDomain(req dto.ProductPatchRequest) product.Patch {
+	patch := product.Patch{
+		Name:           req.Name,
+		CategoryID:     req.CategoryID,
+		AvailableStock: req.AvailableStock,
+		SupplierID:     req.SupplierID,
+	}
+	if req.Price != nil {
+		price := money.New(req.Price.Amount, money.DefaultCurrency)
+		patch.Price = &price
+	}
+	return patch
+}
+
+// ProductListQueryToFilter ожидает провалидированный запрос.
+// Без in_stock в выборку попадают только товары в наличии.
+func ProductListQueryToFilter(q dto.ProductListQuery, req page.Request) product.Filter {
+	f := product.Filter{
+		MinPrice: q.MinPrice,
+		MaxPrice: q.MaxPrice,
+		InStock:  q.InStock == nil || *q.InStock,
+		Name:     q.Name,
+		SortBy:   q.Sort,
+		Order:    q.Order,
+		Page:     req,
+	}
+	if id, err := uuid.Parse(q.CategoryID); err == nil {
+		f.CategoryID = &id
+	}
+	if id, err := uuid.Parse(q.SupplierID); err == nil {
+		f.SupplierID = &id
+	}
+	if id, err := uuid.Parse(q.WarehouseID); err == nil {
+		f.WarehouseID = &id
+	}
+	return f
+}
+
+func ProductExportRowToWeb(r product.ExportRow) dto.ProductExportItem {
+	item := dto.ProductExportItem{
+		ProductID:      r.ProductID,
+		SKU:            r.SKU,
+		Name:           r.Name,
+		Category:       r.CategoryName,
+		Supplier:       r.SupplierName,
+		Price:          r.Price,
+		AvailableStock: r.AvailableStock,
+		ReservedStock:  r.ReservedStock,
+		LastUpdateDate: r.LastUpdateDate,
+	}
+	if r.CategoryID != uuid.Nil {
+		item.CategoryID = &r.CategoryID
+	}
+	if r.SupplierID != uuid.Nil {
+		item.SupplierID = &r.SupplierID
+	}
+	return item
+}
+
 func ProductDomainToWeb(p product.Product) dto.ProductResponse {
-	return dto.ProductResponse{
+	res := dto.ProductResponse{
 		ProductID:      p.ProductID,
+		SKU:            p.SKU,
 		Name:           p.Name,
 		CategoryID:     p.CategoryID,
 		Price:          p.Price,
+		Currency:       p.Price.Currency,
 		AvailableStock: p.AvailableStock,
+		ReservedStock:  p.ReservedStock,
 		LastUpdateDate: p.LastUpdateDate,
 		SupplierID:     p.SupplierID,
 		ImageID:        p.ImageID,
+		Locations:      make([]dto.ProductLocationResponse, 0, len(p.Locations)),
+	}
+	for _, l := range p.Locations {
+		res.Locations = append(res.Locations, dto.ProductLocationResponse{
+			WarehouseID: l.WarehouseID,
+			Name:        l.Name,
+			Quantity:    l.Quantity,
+		})
+	}
+	return res
+}
+
+func ProductPriceRequestToDomain(req dto.ProductPriceRequest, productID uuid.UUID) product.Price {
+	return product.Price{
+		ProductID: productID,
+		Price:     money.New(req.Price.Amount, money.DefaultCurrency),
+		ValidFrom: req.ValidFrom,
+		Reason:    req.Reason,
 	}
 }
 
+func ProductPriceDomainToWeb(p product.Price) dto.ProductPriceResponse {
+	return dto.ProductPriceResponse{
+		PriceID:   p.PriceID,
+		ProductID: p.ProductID,
+		Price:     p.Price,
+		Currency:  p.Price.Currency,
+		ValidFrom: p.ValidFrom,
+		ValidTo:   p.ValidTo,
+		Scheduled: p.Scheduled(),
+		Reason:    p.Reason,
+		CreatedBy: p.CreatedBy,
+		CreatedAt: p.CreatedAt,
+		AppliedAt: p.AppliedAt,
+	}
+}
+
+// RepricingRequestToDomain percent передаётся разобранным: ошибку его
+// формата сообщает обработчик.
+func RepricingRequestToDomain(req dto.RepricingRequest, percent *money.Rate) product.Repricing {
+	r := product.Repricing{
+		Filter: product.Filter{
+			CategoryID: req.CategoryID,
+			SupplierID: req.SupplierID,
+			MinPrice:   req.MinPrice,
+			MaxPrice:   req.MaxPrice,
+		},
+		Percent: percent,
+		Ending:  req.Ending,
+		Reason:  req.Reason,
+		DryRun:  req.DryRun,
+	}
+	if req.Amount != nil {
+		amount := money.New(req.Amount.Amount, money.DefaultCurrency)
+		r.Amount = &amount
+	}
+	return r
+}
+
+func RepricingDomainToWeb(res product.RepricingResult) dto.RepricingResponse {
+	out := dto.RepricingResponse{
+		DryRun:  res.DryRun,
+		Matched: res.Matched,
+		Changed: len(res.Items),
+		Items:   make([]dto.RepricingItemResponse, 0, len(res.Items)),
+	}
+	for _, item := range res.Items {
+		out.Items = append(out.Items, dto.RepricingItemResponse{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			OldPrice:  item.OldPrice,
+			NewPrice:  item.NewPrice,
+		})
+	}
+	return out
+}
+
+func ProductTaxDomainToWeb(r tax.ProductRate) dto.ProductTaxResponse {
+	res := dto.ProductTaxResponse{
+		ProductID: r.ProductID,
+		TaxRate:   r.Rate.String(),
+		Source:    string(r.Source),
+	}
+	if r.Override != nil {
+		res.Override = r.Override.String()
+	}
+	if r.CategoryRate != nil {
+		res.CategoryRate = r.CategoryRate.String()
+	}
+	return res
+}
+
 // === Image mappers ===
 func ImageRequestToDomain(req dto.ImageRequest, imageID uuid.UUID) images.Images {
 	return images.Images{
@@ -104,18 +272,23 @@ func ImageDomainToWeb(img images.Images) dto.ImageResponse {
 }
 
 // === Category mappers ===
-func CategoryRequestToDomain(req dto.CategoryRequest, id uuid.UUID) category.Category {
+func CategoryRequestToDomain(req dto.CategoryRequest, id uuid.UUID, taxRate *money.Rate) category.Category {
 	return category.Category{
 		CategoryID: id,
 		Category:   req.Category,
+		TaxRate:    taxRate,
 	}
 }
 
 func CategoryDomainToWeb(category category.Category) dto.CategoryResponse {
-	return dto.CategoryResponse{
+	res := dto.CategoryResponse{
 		CategoryID: category.CategoryID,
 		Category:   category.Category,
 	}
+	if category.TaxRate != nil {
+		res.TaxRate = category.TaxRate.String()
+	}
+	return res
 }
 
 // === Supplier mappers ===
@@ -136,3 +309,604 @@ func SupplierDomainToWeb(s supplier.Supplier) dto.SupplierResponse {
 		PhoneNumber: s.PhoneNumber,
 	}
 }
+
+// === Stock mappers ===
+
+func StockMovementRequestToDomain(req dto.StockMovementRequest, productID uuid.UUID) stock.Movement {
+	m := stock.Movement{
+		ProductID: productID,
+		Type:      stock.MovementType(req.Type),
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+	}
+	if req.WarehouseID != nil {
+		m.WarehouseID = *req.WarehouseID
+	}
+	return m
+}
+
+func StockMovementDomainToWeb(m stock.Movement) dto.StockMovementResponse {
+	return dto.StockMovementResponse{
+		MovementID:  m.MovementID,
+		ProductID:   m.ProductID,
+		WarehouseID: m.WarehouseID,
+		Type:        string(m.Type),
+		Quantity:    m.Quantity,
+		Reason:      m.Reason,
+		ActorID:     m.ActorID,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func StockDiscrepancyDomainToWeb(d stock.Discrepancy) dto.StockDiscrepancyResponse {
+	return dto.StockDiscrepancyResponse{
+		ProductID:      d.ProductID,
+		AvailableStock: d.Stock,
+		LedgerStock:    d.LedgerStock,
+	}
+}
+
+func StockTransferRequestToDomain(req dto.StockTransferRequest) stock.Transfer {
+	return stock.Transfer{
+		ProductID:       req.ProductID,
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+		Quantity:        req.Quantity,
+	}
+}
+
+func StockTransferDomainToWeb(t stock.Transfer) dto.StockTransferResponse {
+	return dto.StockTransferResponse{
+		TransferID:      t.TransferID,
+		ProductID:       t.ProductID,
+		FromWarehouseID: t.FromWarehouseID,
+		ToWarehouseID:   t.ToWarehouseID,
+		Quantity:        t.Quantity,
+		ActorID:         t.ActorID,
+		CreatedAt:       t.CreatedAt,
+	}
+}
+
+// === Order mappers ===
+
+func OrderRequestToDomain(req dto.OrderRequest) order.Order {
+	o := order.Order{
+		ClientID:   req.ClientID,
+		Items:      make([]order.Item, 0, len(req.Items)),
+		PromoCodes: req.PromoCodes,
+	}
+	if req.WarehouseID != nil {
+		o.WarehouseID = *req.WarehouseID
+	}
+	for _, item := range req.Items {
+		o.Items = append(o.Items, order.Item{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return o
+}
+
+func OrderDomainToWeb(o order.Order) dto.OrderResponse {
+	res := dto.OrderResponse{
+		OrderID:     o.OrderID,
+		ClientID:    o.ClientID,
+		WarehouseID: o.WarehouseID,
+		Status:      string(o.Status),
+		Items:       make([]dto.OrderItemResponse, 0, len(o.Items)),
+		Discount:    o.Discount(),
+		TaxMode:     string(o.TaxMode),
+		Total:       o.Total,
+		Transitions: make([]dto.OrderTransitionResponse, 0, len(o.Transitions)),
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+	lines := o.TaxLines()
+	total := tax.Total(lines)
+	res.Net, res.Tax = total.Net, total.Tax
+	res.TaxLines = TaxLinesDomainToWeb(tax.Summarize(lines))
+	for _, t := range o.Transitions {
+		res.Transitions = append(res.Transitions, dto.OrderTransitionResponse{
+			From:      string(t.From),
+			To:        string(t.To),
+			ActorID:   t.ActorID,
+			CreatedAt: t.CreatedAt,
+		})
+	}
+	for i, item := range o.Items {
+		res.Items = append(res.Items, dto.OrderItemResponse{
+			ItemID:           item.ItemID,
+			ProductID:        item.ProductID,
+			Name:             item.Name,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			LineTotal:        item.LineTotal(),
+			Discount:         item.Discount,
+			Total:            item.Total(),
+			TaxRate:          item.TaxRate.String(),
+			Net:              lines[i].Net,
+			Tax:              lines[i].Tax,
+			Gross:            lines[i].Gross,
+			ReturnedQuantity: item.ReturnedQuantity,
+		})
+	}
+	return res
+}
+
+func TaxLinesDomainToWeb(lines []tax.Line) []dto.TaxLineResponse {
+	res := make([]dto.TaxLineResponse, 0, len(lines))
+	for _, l := range lines {
+		res = append(res, dto.TaxLineResponse{Rate: l.Rate.String(), Net: l.Net, Tax: l.Tax, Gross: l.Gross})
+	}
+	return res
+}
+
+// === Cart mappers ===
+
+func CartDomainToWeb(c cart.Cart) dto.CartResponse {
+	res := dto.CartResponse{
+		Token:     c.Token,
+		ClientID:  c.ClientID,
+		Items:     make([]dto.CartItemResponse, 0, len(c.Items)),
+		Total:     c.Total(),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	for _, item := range c.Items {
+		res.Items = append(res.Items, dto.CartItemResponse{
+			ProductID:      item.ProductID,
+			Name:           item.Name,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			LineTotal:      item.LineTotal(),
+			AvailableStock: item.AvailableStock,
+			Available:      item.Available(),
+		})
+	}
+	return res
+}
+
+// === Reservation mappers ===
+
+func ReservationDomainToWeb(r reservation.Reservation) dto.ReservationResponse {
+	return dto.ReservationResponse{
+		ReservationID: r.ReservationID,
+		ProductID:     r.ProductID,
+		WarehouseID:   r.WarehouseID,
+		Quantity:      r.Quantity,
+		Status:        string(r.Status),
+		Reference:     r.Reference,
+		ExpiresAt:     r.ExpiresAt,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// === Warehouse mappers ===
+
+func WarehouseRequestToDomain(req dto.WarehouseRequest, warehouseID uuid.UUID, createdAt time.Time) warehouse.Warehouse {
+	return warehouse.Warehouse{
+		WarehouseID: warehouseID,
+		Name:        req.Name,
+		Kind:        warehouse.Kind(req.Kind),
+		CreatedAt:   createdAt,
+	}
+}
+
+func WarehouseDomainToWeb(w warehouse.Warehouse) dto.WarehouseResponse {
+	return dto.WarehouseResponse{
+		WarehouseID: w.WarehouseID,
+		Name:        w.Name,
+		Kind:        string(w.Kind),
+		AddressID:   w.AddressID,
+		IsDefault:   w.IsDefault,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+// === Replenishment mappers ===
+
+func ReorderPolicyRequestToDomain(req dto.ReorderPolicyRequest) replenishment.Policy {
+	return replenishment.Policy{
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+	}
+}
+
+func ReorderPolicyDomainToWeb(p replenishment.Policy) dto.ReorderPolicyResponse {
+	return dto.ReorderPolicyResponse{
+		ReorderPoint:    p.ReorderPoint,
+		ReorderQuantity: p.ReorderQuantity,
+	}
+}
+
+func ProductReorderPolicyDomainToWeb(p replenishment.ProductPolicy) dto.ProductReorderPolicyResponse {
+	return dto.ProductReorderPolicyResponse{
+		ProductID: p.ProductID,
+		Product:   ReorderPolicyDomainToWeb(p.Own),
+		Category:  ReorderPolicyDomainToWeb(p.Category),
+		Effective: ReorderPolicyDomainToWeb(p.Effective()),
+	}
+}
+
+func StockAlertDomainToWeb(a replenishment.Alert) dto.StockAlertResponse {
+	return dto.StockAlertResponse{
+		AlertID:           a.AlertID,
+		ProductID:         a.ProductID,
+		ProductName:       a.ProductName,
+		SupplierID:        a.SupplierID,
+		SupplierName:      a.SupplierName,
+		Stock:             a.Stock,
+		ReorderPoint:      a.ReorderPoint,
+		SuggestedQuantity: a.SuggestedQuantity,
+		Status:            string(a.Status),
+		CreatedAt:         a.CreatedAt,
+		NotifiedAt:        a.NotifiedAt,
+		ResolvedAt:        a.ResolvedAt,
+	}
+}
+
+// === Purchase order mappers ===
+
+func PurchaseOrderRequestToDomain(req dto.PurchaseOrderRequest) purchase.PurchaseOrder {
+	po := purchase.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		Note:       req.Note,
+		Lines:      make([]purchase.Line, 0, len(req.Lines)),
+	}
+	if req.WarehouseID != nil {
+		po.WarehouseID = *req.WarehouseID
+	}
+	for _, l := range req.Lines {
+		po.Lines = append(po.Lines, purchase.Line{
+			ProductID:     l.ProductID,
+			Quantity:      l.Quantity,
+			ExpectedPrice: l.ExpectedPrice,
+		})
+	}
+	return po
+}
+
+func GoodsReceiptRequestToDomain(req dto.GoodsReceiptRequest) []purchase.ReceiptLine {
+	lines := make([]purchase.ReceiptLine, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		lines = append(lines, purchase.ReceiptLine{ProductID: l.ProductID, ReceivedQuantity: l.Quantity})
+	}
+	return lines
+}
+
+func PurchaseOrderDomainToWeb(po purchase.PurchaseOrder) dto.PurchaseOrderResponse {
+	res := dto.PurchaseOrderResponse{
+		PurchaseOrderID: po.PurchaseOrderID,
+		SupplierID:      po.SupplierID,
+		WarehouseID:     po.WarehouseID,
+		Status:          string(po.Status),
+		Note:            po.Note,
+		Lines:           make([]dto.PurchaseOrderLineResponse, 0, len(po.Lines)),
+		Total:           po.Total(),
+		Receipts:        make([]dto.GoodsReceiptResponse, 0, len(po.Receipts)),
+		CreatedAt:       po.CreatedAt,
+		UpdatedAt:       po.UpdatedAt,
+	}
+	for _, l := range po.Lines {
+		res.Lines = append(res.Lines, dto.PurchaseOrderLineResponse{
+			ProductID:        l.ProductID,
+			Name:             l.Name,
+			Quantity:         l.Quantity,
+			ExpectedPrice:    l.ExpectedPrice,
+			LineTotal:        l.LineTotal(),
+			ReceivedQuantity: l.ReceivedQuantity,
+			Outstanding:      l.Outstanding(),
+		})
+	}
+	for _, r := range po.Receipts {
+		receipt := dto.GoodsReceiptResponse{
+			ReceiptID:   r.ReceiptID,
+			WarehouseID: r.WarehouseID,
+			Lines:       make([]dto.GoodsReceiptLineResponse, 0, len(r.Lines)),
+			ActorID:     r.ActorID,
+			CreatedAt:   r.CreatedAt,
+		}
+		for _, l := range r.Lines {
+			receipt.Lines = append(receipt.Lines, dto.GoodsReceiptLineResponse{
+				ProductID:        l.ProductID,
+				ExpectedQuantity: l.ExpectedQuantity,
+				ReceivedQuantity: l.ReceivedQuantity,
+				Discrepancy:      l.Discrepancy(),
+			})
+		}
+		res.Receipts = append(res.Receipts, receipt)
+	}
+	return res
+}
+
+// === Supplier product mappers ===
+
+func SupplierProductRequestToDomain(req dto.SupplierProductRequest, supplierID, productID uuid.UUID) supplier.Product {
+	return supplier.Product{
+		SupplierID:       supplierID,
+		ProductID:        productID,
+		SKU:              req.SKU,
+		PurchasePrice:    money.New(req.PurchasePrice.Amount, req.Currency),
+		LeadTimeDays:     req.LeadTimeDays,
+		MinOrderQuantity: req.MinOrderQuantity,
+	}
+}
+
+func SupplierProductDomainToWeb(p supplier.Product) dto.SupplierProductResponse {
+	return dto.SupplierProductResponse{
+		SupplierID:       p.SupplierID,
+		SupplierName:     p.SupplierName,
+		ProductID:        p.ProductID,
+		ProductName:      p.ProductName,
+		SKU:              p.SKU,
+		PurchasePrice:    p.PurchasePrice,
+		Currency:         p.PurchasePrice.Currency,
+		LeadTimeDays:     p.LeadTimeDays,
+		MinOrderQuantity: p.MinOrderQuantity,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+func BestSupplierQueryToDomain(q dto.BestSupplierQuery, productID uuid.UUID) supplier.OfferQuery {
+	return supplier.OfferQuery{
+		ProductID:       productID,
+		Quantity:        q.Quantity,
+		Currency:        q.Currency,
+		MaxLeadTimeDays: q.MaxLeadTimeDays,
+		Prefer:          supplier.Preference(q.Prefer),
+	}
+}
+
+// === Product import mappers ===
+
+func ProductImportDomainToWeb(j imports.Job) dto.ProductImportResponse {
+	res := dto.ProductImportResponse{
+		ImportID:    j.ImportID,
+		Status:      string(j.Status),
+		Format:      string(j.Format),
+		FileName:    j.FileName,
+		DryRun:      j.DryRun,
+		Upsert:      j.Upsert,
+		TotalRows:   j.TotalRows,
+		CreatedRows: j.CreatedRows,
+		UpdatedRows: j.UpdatedRows,
+		FailedRows:  j.FailedRows,
+		Error:       j.Error,
+		Errors:      make([]dto.ProductImportRowError, 0, len(j.Errors)),
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+	for _, e := range j.Errors {
+		res.Errors = append(res.Errors, dto.ProductImportRowError{
+			Row:     e.Row,
+			Field:   e.Field,
+			Message: e.Message,
+		})
+	}
+	return res
+}
+
+// === Currency mappers ===
+
+func CurrencyRequestToDomain(req dto.CurrencyRequest, code string) currency.Currency {
+	c := currency.Currency{
+		Code:     code,
+		Name:     req.Name,
+		Decimals: 2,
+		Rounding: currency.Rounding(req.Rounding),
+	}
+	if req.Decimals != nil {
+		c.Decimals = *req.Decimals
+	}
+	if c.Rounding == "" {
+		c.Rounding = currency.RoundHalfUp
+	}
+	return c
+}
+
+func CurrencyDomainToWeb(c currency.Currency) dto.CurrencyResponse {
+	return dto.CurrencyResponse{
+		Code:     c.Code,
+		Name:     c.Name,
+		Decimals: c.Decimals,
+		Rounding: string(c.Rounding),
+	}
+}
+
+func ExchangeRateDomainToWeb(r currency.ExchangeRate) dto.ExchangeRateResponse {
+	return dto.ExchangeRateResponse{
+		RateID:        r.RateID,
+		Currency:      r.Currency,
+		Rate:          currency.FormatRate(r.Rate),
+		EffectiveDate: r.EffectiveDate.Format(time.DateOnly),
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+// === Promotion mappers ===
+
+// PromotionRequestToDomain percent передаётся разобранным: ошибку его
+// формата сообщает обработчик. Без stackable и active акция складывается с
+// другими и действует.
+func PromotionRequestToDomain(req dto.PromotionRequest, id uuid.UUID, percent money.Rate) promotion.Promotion {
+	p := promotion.Promotion{
+		PromotionID:    id,
+		Name:           req.Name,
+		Kind:           promotion.Kind(req.Kind),
+		Percent:        percent,
+		BuyQuantity:    req.BuyQuantity,
+		GetQuantity:    req.GetQuantity,
+		CategoryID:     req.CategoryID,
+		SupplierID:     req.SupplierID,
+		Code:           req.Code,
+		UsageLimit:     req.UsageLimit,
+		PerClientLimit: req.PerClientLimit,
+		ValidFrom:      req.ValidFrom,
+		ValidTo:        req.ValidTo,
+		Stackable:      req.Stackable == nil || *req.Stackable,
+		Priority:       req.Priority,
+		Active:         req.Active == nil || *req.Active,
+	}
+	if req.Amount != nil {
+		p.Amount = money.New(req.Amount.Amount, money.DefaultCurrency)
+	}
+	return p
+}
+
+func PromotionDomainToWeb(p promotion.Promotion) dto.PromotionResponse {
+	res := dto.PromotionResponse{
+		PromotionID:    p.PromotionID,
+		Name:           p.Name,
+		Kind:           string(p.Kind),
+		CategoryID:     p.CategoryID,
+		SupplierID:     p.SupplierID,
+		Code:           p.Code,
+		UsageLimit:     p.UsageLimit,
+		PerClientLimit: p.PerClientLimit,
+		ValidFrom:      p.ValidFrom,
+		ValidTo:        p.ValidTo,
+		Stackable:      p.Stackable,
+		Priority:       p.Priority,
+		Active:         p.Active,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+	switch p.Kind {
+	case promotion.KindPercent:
+		res.Percent = p.Percent.String()
+	case promotion.KindFixed:
+		amount := p.Amount
+		res.Amount = &amount
+	case promotion.KindBuyXGetY:
+		res.BuyQuantity = p.BuyQuantity
+		res.GetQuantity = p.GetQuantity
+	}
+	return res
+}
+
+func PromotionEvaluationDomainToWeb(e promotion.Evaluation) dto.PromotionEvaluationResponse {
+	res := dto.PromotionEvaluationResponse{
+		Lines:      make([]dto.PromotionLineResponse, 0, len(e.Lines)),
+		Promotions: appliedDiscountsToWeb(e.Promotions),
+		Subtotal:   e.Subtotal,
+		Discount:   e.Discount,
+		Total:      e.Total,
+	}
+	for _, l := range e.Lines {
+		res.Lines = append(res.Lines, dto.PromotionLineResponse{
+			ProductID: l.ProductID,
+			Name:      l.Name,
+			Quantity:  l.Quantity,
+			UnitPrice: l.UnitPrice,
+			Subtotal:  l.Subtotal(),
+			Discounts: appliedDiscountsToWeb(l.Discounts),
+			Discount:  l.Discount,
+			Total:     l.Total,
+		})
+	}
+	return res
+}
+
+func appliedDiscountsToWeb(applied []promotion.Applied) []dto.AppliedDiscountResponse {
+	res := make([]dto.AppliedDiscountResponse, 0, len(applied))
+	for _, a := range applied {
+		res = append(res, dto.AppliedDiscountResponse{
+			PromotionID: a.PromotionID,
+			Name:        a.Name,
+			Code:        a.Code,
+			Amount:      a.Amount,
+		})
+	}
+	return res
+}
+
+// === Return mappers ===
+
+func ReturnRequestToDomain(req dto.ReturnRequest) returns.Return {
+	r := returns.Return{
+		OrderID: req.OrderID,
+		Note:    req.Note,
+		Lines:   make([]returns.Line, 0, len(req.Lines)),
+	}
+	if req.WarehouseID != nil {
+		r.WarehouseID = *req.WarehouseID
+	}
+	for _, l := range req.Lines {
+		r.Lines = append(r.Lines, returns.Line{
+			ItemID:   l.ItemID,
+			Quantity: l.Quantity,
+			Reason:   returns.Reason(l.Reason),
+			Comment:  l.Comment,
+		})
+	}
+	return r
+}
+
+func ReturnInspectionRequestToDomain(req dto.ReturnInspectionRequest) map[uuid.UUID]returns.Outcome {
+	outcomes := make(map[uuid.UUID]returns.Outcome, len(req.Lines))
+	for _, l := range req.Lines {
+		outcomes[l.ItemID] = returns.Outcome(l.Outcome)
+	}
+	return outcomes
+}
+
+func ReturnListQueryToFilter(q dto.ReturnListQuery) returns.Filter {
+	f := returns.Filter{Status: returns.Status(q.Status)}
+	if id, err := uuid.Parse(q.OrderID); err == nil {
+		f.OrderID = id
+	}
+	return f
+}
+
+func ReturnDomainToWeb(r returns.Return) dto.ReturnResponse {
+	res := dto.ReturnResponse{
+		ReturnID:    r.ReturnID,
+		OrderID:     r.OrderID,
+		ClientID:    r.ClientID,
+		WarehouseID: r.WarehouseID,
+		Status:      string(r.Status),
+		Note:        r.Note,
+		Lines:       make([]dto.ReturnLineResponse, 0, len(r.Lines)),
+		Refund:      r.Refund,
+		ActorID:     r.ActorID,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		RefundedAt:  r.RefundedAt,
+	}
+	for _, l := range r.Lines {
+		res.Lines = append(res.Lines, dto.ReturnLineResponse{
+			ItemID:    l.ItemID,
+			ProductID: l.ProductID,
+			Name:      l.Name,
+			Quantity:  l.Quantity,
+			Reason:    string(l.Reason),
+			Comment:   l.Comment,
+			Outcome:   string(l.Outcome),
+			Refund:    l.Refund,
+		})
+	}
+	return res
+}
+
+// === Payment mappers ===
+
+func PaymentRefundRequestToDomain(req dto.PaymentRefundRequest) payment.Refund {
+	return payment.Refund{
+		Amount:   req.Amount,
+		ReturnID: req.ReturnID,
+	}
+}
+
+func PaymentDomainToWeb(p payment.Payment) dto.PaymentResponse {
+	return dto.PaymentResponse{
+		PaymentID:       p.PaymentID,
+		OrderID:         p.OrderID,
+		Provider:        p.Provider,
+		ExternalID:      p.ExternalID,
+		Status:          string(p.Status),
+		Amount:          p.Amount,
+		Refunded:        p.Refunded,
+		ConfirmationURL: p.ConfirmationURL,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/logger"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"hardware_store/internal/web/dto"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	UserIDKey = "user_id"
+	AppIDKey  = "app_id"
+
+	tokenIssuer = "auth-service"
+)
+
+type TokenValidator interface {
+	Validate(ctx context.Context, token string) (auth.Claims, error)
+}
+
+type AuthMiddleware struct {
+	validator TokenValidator
+	secretKey []byte
+	public    map[string]struct{}
+	logger    *slog.Logger
+}
+
+func NewAuthMiddleware(cfg *config.Config, validator TokenValidator, logger *slog.Logger) *AuthMiddleware {
+	public := make(map[string]struct{}, len(cfg.Auth.PublicRoutes))
+	for _, route := range cfg.Auth.PublicRoutes {
+		public[strings.TrimSpace(route)] = struct{}{}
+	}
+	return &AuthMiddleware{
+		validator: validator,
+		secretKey: []byte(cfg.Auth.JWTSecretKey),
+		public:    public,
+		logger:    logger,
+	}
+}
+
+// Handler пропускает без токена только маршруты из auth.public_routes,
+// остальные запросы независимо от метода требуют валидный Bearer-токен.
+// Если токен передан, он проверяется всегда.
+func (m *AuthMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			if m.isPublic(c) {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "missing access token"})
+			return
+		}
+
+		claims, err := m.validate(c.Request.Context(), token)
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidToken) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid access token"})
+				return
+			}
+			m.logger.Error("Failed to validate token", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "auth service unavailable"})
+			return
+		}
+
+		c.Set(UserIDKey, claims.UserID)
+		c.Set(AppIDKey, claims.AppID)
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
+
+func (m *AuthMiddleware) validate(ctx context.Context, token string) (auth.Claims, error) {
+	claims, err := m.validator.Validate(ctx, token)
+	if err == nil || errors.Is(err, model.ErrInvalidToken) {
+		return claims, err
+	}
+	if len(m.secretKey) == 0 {
+		return auth.Claims{}, err
+	}
+
+	m.logger.Warn("auth-service is unavailable, falling back to local token validation", logger.Err(err))
+	return m.validateLocal(token)
+}
+
+func (m *AuthMiddleware) validateLocal(tokenString string) (auth.Claims, error) {
+	const op = "middleware.validateLocal"
+	var claims auth.TokenClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return m.secretKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(tokenIssuer))
+	if err != nil || !token.Valid {
+		return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+	}
+	if claims.UserID == uuid.Nil {
+		return auth.Claims{}, fmt.Errorf("%s: %w", op, model.ErrInvalidToken)
+	}
+	return auth.Claims{UserID: claims.UserID, AppID: claims.AppID, Roles: claims.Roles}, nil
+}
+
+// RequireRoles разрешает запрос только пользователям с одной из ролей.
+// Должен стоять после AuthMiddleware.Handler.
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "missing access token"})
+			return
+		}
+		if !claims.HasAnyRole(roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ErrorResponse{Error: "insufficient permissions"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (m *AuthMiddleware) isPublic(c *gin.Context) bool {
+	path := c.FullPath()
+	if _, ok := m.public[path]; ok {
+		return true
+	}
+	_, ok := m.public[c.Request.Method+" "+path]
+	return ok
+}
+
+func bearerToken(header string) (string, bool) {
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(prefix):])
+	return token, token != ""
+}
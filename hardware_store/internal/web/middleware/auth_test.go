@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/auth"
+	model "hardware_store/internal/model/error"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeValidator принимает токены из карты, остальные считает невалидными.
+type fakeValidator map[string]auth.Claims
+
+func (f fakeValidator) Validate(_ context.Context, token string) (auth.Claims, error) {
+	claims, ok := f[token]
+	if !ok {
+		return auth.Claims{}, model.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Auth: config.AuthConfig{PublicRoutes: []string{"GET /products"}}}
+	m := NewAuthMiddleware(cfg, fakeValidator{
+		"manager":  {UserID: uuid.New(), Roles: []string{auth.RoleManager}},
+		"customer": {UserID: uuid.New(), Roles: []string{auth.RoleCustomer}},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	r := gin.New()
+	api := r.Group("", m.Handler())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	api.GET("/products", ok)
+	api.POST("/products", ok)
+	api.GET("/clients", RequireRoles(auth.RoleManager, auth.RoleAdmin), ok)
+	api.HEAD("/clients", RequireRoles(auth.RoleManager, auth.RoleAdmin), ok)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{name: "public route without token", method: http.MethodGet, path: "/products", want: http.StatusOK},
+		{name: "public route is method scoped", method: http.MethodPost, path: "/products", want: http.StatusUnauthorized},
+		{name: "get without token", method: http.MethodGet, path: "/clients", want: http.StatusUnauthorized},
+		{name: "head without token", method: http.MethodHead, path: "/clients", want: http.StatusUnauthorized},
+		{name: "invalid token", method: http.MethodGet, path: "/products", token: "forged", want: http.StatusUnauthorized},
+		{name: "role missing", method: http.MethodGet, path: "/clients", token: "customer", want: http.StatusForbidden},
+		{name: "role granted", method: http.MethodGet, path: "/clients", token: "manager", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hardware_store/internal/config"
+	"hardware_store/internal/model/page"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Paginator разбирает параметры limit, offset и cursor и выпускает
+// подписанные курсоры для следующей страницы.
+type Paginator struct {
+	key          []byte
+	defaultLimit int
+	maxLimit     int
+}
+
+// payload содержимое курсора. Сортировка и отпечаток выборки сохраняются в
+// курсоре, чтобы нельзя было продолжить выборку с другим порядком строк,
+// по другому маршруту или с другими фильтрами.
+type payload struct {
+	Sort  string    `json:"s,omitempty"`
+	Order string    `json:"o,omitempty"`
+	Scope string    `json:"sc"`
+	Key   string    `json:"k"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func NewPaginator(cfg *config.Config, log *slog.Logger) *Paginator {
+	key := []byte(cfg.Pagination.CursorSecret)
+	if len(key) == 0 {
+		log.Warn("pagination.cursor_secret is not set, cursors will not survive restart")
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &Paginator{
+		key:          key,
+		defaultLimit: cfg.Pagination.DefaultLimit,
+		maxLimit:     cfg.Pagination.MaxLimit,
+	}
+}
+
+// Parse читает параметры страницы из запроса. sort и order должны совпадать
+// с сортировкой, под которую был выпущен курсор.
+func (p *Paginator) Parse(c *gin.Context, sort, order string) (page.Request, error) {
+	limit, err := parseParam(c.Query("limit"), "limit", p.maxLimit)
+	if err != nil {
+		return page.Request{}, err
+	}
+	if limit == 0 {
+		limit = p.defaultLimit
+	}
+	offset, err := parseParam(c.Query("offset"), "offset", 0)
+	if err != nil {
+		return page.Request{}, err
+	}
+	req := page.Request{Limit: limit, Offset: offset, Scope: scope(c)}
+
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := p.decode(token, sort, order, req.Scope)
+		if err != nil {
+			return page.Request{}, err
+		}
+		req.After = &cursor
+		req.Offset = 0
+	}
+	return req, nil
+}
+
+// Next возвращает курсор следующей страницы или пустую строку,
+// если текущая страница неполная.
+func (p *Paginator) Next(req page.Request, count int, sort, order, key string, id uuid.UUID) string {
+	if req.Limit == 0 || count < req.Limit {
+		return ""
+	}
+	data, err := json.Marshal(payload{Sort: sort, Order: order, Scope: req.Scope, Key: key, ID: id})
+	if err != nil {
+		return ""
+	}
+	body := base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(p.sign(body))
+}
+
+func (p *Paginator) decode(token, sort, order, scope string) (page.Cursor, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return page.Cursor{}, ErrInvalidCursor
+	}
+	gotSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotSig, p.sign(body)) {
+		return page.Cursor{}, ErrInvalidCursor
+	}
+	data, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return page.Cursor{}, ErrInvalidCursor
+	}
+	var pl payload
+	if err := json.Unmarshal(data, &pl); err != nil {
+		return page.Cursor{}, ErrInvalidCursor
+	}
+	if pl.Sort != sort || pl.Order != order {
+		return page.Cursor{}, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+	}
+	if pl.Scope != scope {
+		return page.Cursor{}, fmt.Errorf("%w: cursor was issued for a different list", ErrInvalidCursor)
+	}
+	return page.Cursor{Key: pl.Key, ID: pl.ID}, nil
+}
+
+// scope отпечаток выборки: метод, шаблон маршрута, значения параметров пути
+// и все параметры запроса, кроме параметров самой страницы.
+func scope(c *gin.Context) string {
+	var b strings.Builder
+	b.WriteString(c.Request.Method)
+	b.WriteByte(' ')
+	b.WriteString(c.FullPath())
+	for _, param := range c.Params {
+		b.WriteString("\n" + param.Key + "=" + param.Value)
+	}
+	query := c.Request.URL.Query()
+	query.Del("cursor")
+	query.Del("limit")
+	query.Del("offset")
+	b.WriteString("\n" + query.Encode())
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func (p *Paginator) sign(body string) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func parseParam(s string, paramName string, max int) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s format: must be an integer", paramName)
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("%s must be >= 0", paramName)
+	}
+
+	if max > 0 && n > max {
+		return 0, fmt.Errorf("%s must be <= %d", paramName, max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"errors"
+	"hardware_store/internal/config"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaginator() *Paginator {
+	cfg := &config.Config{Pagination: config.PaginationConfig{CursorSecret: "secret", DefaultLimit: 2, MaxLimit: 100}}
+	return NewPaginator(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// parse разбирает параметры страницы так, как это сделал бы обработчик
+// маршрута route для запроса target.
+func parse(t *testing.T, p *Paginator, route, target, sort, order string) (string, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var next string
+	var parseErr error
+	r.GET(route, func(c *gin.Context) {
+		req, err := p.Parse(c, sort, order)
+		parseErr = err
+		if err == nil {
+			next = p.Next(req, req.Limit, sort, order, "2024-01-01T00:00:00Z", uuid.New())
+		}
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	return next, parseErr
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	p := newPaginator()
+	cursor, err := parse(t, p, "/orders", "/orders?status=new", "", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+
+	next, err := parse(t, p, "/orders", "/orders?status=new&limit=2&cursor="+url.QueryEscape(cursor), "", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, next)
+}
+
+func TestCursorRejectedOutsideItsList(t *testing.T) {
+	p := newPaginator()
+	clientID := uuid.NewString()
+	cursor, err := parse(t, p, "/clients/:id/orders", "/clients/"+clientID+"/orders?status=new", "", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+
+	tests := []struct {
+		name   string
+		route  string
+		target string
+		sort   string
+	}{
+		{name: "other endpoint", route: "/products", target: "/products"},
+		{name: "other path parameter", route: "/clients/:id/orders", target: "/clients/" + uuid.NewString() + "/orders?status=new"},
+		{name: "other filter", route: "/clients/:id/orders", target: "/clients/" + clientID + "/orders?status=paid"},
+		{name: "other sort", route: "/clients/:id/orders", target: "/clients/" + clientID + "/orders?status=new", sort: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, p, tt.route, tt.target+sep(tt.target)+"cursor="+url.QueryEscape(cursor), tt.sort, "")
+			assert.True(t, errors.Is(err, ErrInvalidCursor), "got %v", err)
+		})
+	}
+}
+
+func TestCursorTampered(t *testing.T) {
+	p := newPaginator()
+	cursor, err := parse(t, p, "/orders", "/orders", "", "")
+	require.NoError(t, err)
+
+	_, err = parse(t, p, "/orders", "/orders?cursor="+url.QueryEscape("x"+cursor), "", "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func sep(target string) string {
+	if u, _ := url.Parse(target); u.RawQuery != "" {
+		return "&"
+	}
+	return "?"
+}
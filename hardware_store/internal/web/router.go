@@ -1,11 +1,25 @@
 package web
 
 import (
+	"hardware_store/internal/web/handler/cart"
 	"hardware_store/internal/web/handler/category"
 	"hardware_store/internal/web/handler/client"
+	"hardware_store/internal/web/handler/currency"
 	"hardware_store/internal/web/handler/images"
+	"hardware_store/internal/web/handler/imports"
+	"hardware_store/internal/web/handler/invoice"
+	"hardware_store/internal/web/handler/order"
+	"hardware_store/internal/web/handler/payment"
 	"hardware_store/internal/web/handler/product"
+	"hardware_store/internal/web/handler/promotion"
+	"hardware_store/internal/web/handler/purchase"
+	"hardware_store/internal/web/handler/replenishment"
+	"hardware_store/internal/web/handler/reservation"
+	"hardware_store/internal/web/handler/returns"
+	"hardware_store/internal/web/handler/stock"
 	"hardware_store/internal/web/handler/supplier"
+	"hardware_store/internal/web/handler/warehouse"
+	"hardware_store/internal/web/middleware"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -16,17 +30,36 @@ import (
 
 func NewRouter(client *client.ClientHandler, product *product.ProductHandler,
 	image *images.ImageHandler,
-	category *category.CategoryHandler, supplier *supplier.SupplierHandler) *gin.Engine {
+	category *category.CategoryHandler, supplier *supplier.SupplierHandler,
+	stock *stock.StockHandler, order *order.OrderHandler, cart *cart.CartHandler,
+	reservation *reservation.ReservationHandler, warehouse *warehouse.WarehouseHandler,
+	replenishment *replenishment.ReplenishmentHandler, purchase *purchase.PurchaseHandler,
+	imports *imports.ImportHandler, currency *currency.CurrencyHandler,
+	promotion *promotion.PromotionHandler, invoice *invoice.InvoiceHandler,
+	returns *returns.ReturnHandler, payment *payment.PaymentHandler, auth *middleware.AuthMiddleware) *gin.Engine {
 	r := gin.Default()
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	api := r.Group("/api/v1")
+	api := r.Group("/api/v1", auth.Handler())
 	{
 		client.Register(api)
 		product.Register(api)
 		image.Register(api)
 		category.Register(api)
 		supplier.Register(api)
+		stock.Register(api)
+		order.Register(api)
+		cart.Register(api)
+		reservation.Register(api)
+		warehouse.Register(api)
+		replenishment.Register(api)
+		purchase.Register(api)
+		imports.Register(api)
+		currency.Register(api)
+		promotion.Register(api)
+		invoice.Register(api)
+		returns.Register(api)
+		payment.Register(api)
 	}
 	return r
 }
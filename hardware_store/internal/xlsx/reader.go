@@ -0,0 +1,294 @@
+// Package xlsx читает и записывает табличные данные в формате Office Open XML
+// (XLSX). Поддерживается только то, что нужно для импорта и экспорта
+// каталога: значения ячеек первого листа без стилей и формул.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidFile = errors.New("invalid xlsx file")
+	ErrTooManyRows = errors.New("too many rows")
+)
+
+// Размеры листа Excel: столбцы A..XFD и строки 1..1048576. Ссылки за этими
+// пределами отклоняются до того, как под них выделяется память.
+const (
+	MaxColumns = 16384
+	MaxRows    = 1048576
+)
+
+// Распакованный размер всех прочитанных частей книги вместе не может
+// превышать размер файла больше чем в maxExpansion раз, так что память на
+// разбор ограничена пропорционально лимиту на размер загрузки. Маленьким
+// файлам разрешено распаковаться до minUnpackedSize.
+const (
+	maxExpansion    = 20
+	minUnpackedSize = 1 << 20
+)
+
+// Read возвращает не больше maxRows строк первого листа книги, вместе с
+// заголовком; maxRows <= 0 означает MaxRows. Если строк на листе больше,
+// возвращается ErrTooManyRows. Пустые ячейки внутри строки заполняются
+// пустыми строками, хвостовые пустые ячейки отбрасываются.
+func Read(data []byte, maxRows int) ([][]string, error) {
+	if maxRows <= 0 || maxRows > MaxRows {
+		maxRows = MaxRows
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	b := &budget{left: max(int64(len(data))*maxExpansion, minUnpackedSize)}
+
+	sheetPath, err := b.firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = b.readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidFile, sheetPath)
+	}
+	return b.readSheet(f, shared, maxRows)
+}
+
+type workbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// firstSheet находит файл первого листа по связям книги.
+func (b *budget) firstSheet(files map[string]*zip.File) (string, error) {
+	var wb workbook
+	if err := b.decodeFile(files["xl/workbook.xml"], &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", fmt.Errorf("%w: workbook has no sheets", ErrInvalidFile)
+	}
+	var rels relationships
+	if err := b.decodeFile(files["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("%w: sheet relationship not found", ErrInvalidFile)
+}
+
+type richText struct {
+	T  string `xml:"t"`
+	Rs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Rs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Rs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+func (b *budget) readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := b.decodeFile(f, &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, 0, len(sst.Items))
+	for _, si := range sst.Items {
+		shared = append(shared, si.String())
+	}
+	return shared, nil
+}
+
+type cell struct {
+	Ref    string    `xml:"r,attr"`
+	Type   string    `xml:"t,attr"`
+	Value  string    `xml:"v"`
+	Inline *richText `xml:"is"`
+}
+
+func (b *budget) readSheet(f *zip.File, shared []string, maxRows int) ([][]string, error) {
+	var sheet struct {
+		Rows []struct {
+			Num   int    `xml:"r,attr"`
+			Cells []cell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := b.decodeFile(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, r := range sheet.Rows {
+		if r.Num > MaxRows {
+			return nil, fmt.Errorf("%w: row number %d is out of range", ErrInvalidFile, r.Num)
+		}
+		if r.Num > maxRows || len(rows) >= maxRows {
+			return nil, fmt.Errorf("%w: sheet has more than %d rows", ErrTooManyRows, maxRows)
+		}
+		// Пропущенные пустые строки сохраняются, чтобы номера строк в
+		// отчёте совпадали с номерами в таблице.
+		for r.Num > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var row []string
+		for i, c := range r.Cells {
+			col := i
+			if c.Ref != "" {
+				var err error
+				if col, err = columnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			} else if col >= MaxColumns {
+				return nil, fmt.Errorf("%w: row %d has more than %d columns", ErrInvalidFile, len(rows)+1, MaxColumns)
+			}
+			value, err := c.value(shared)
+			if err != nil {
+				return nil, err
+			}
+			// Строка дополняется только до заполненных ячеек: пустая ячейка
+			// в дальнем столбце не должна раздувать строку.
+			if value == "" {
+				continue
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			row[col] = value
+		}
+		for len(row) > 0 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (c cell) value(shared []string) (string, error) {
+	switch c.Type {
+	case "s":
+		i, err := strconv.Atoi(c.Value)
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("%w: bad shared string index in %s", ErrInvalidFile, c.Ref)
+		}
+		return shared[i], nil
+	case "inlineStr":
+		if c.Inline == nil {
+			return "", nil
+		}
+		return c.Inline.String(), nil
+	case "b":
+		if c.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return c.Value, nil
+	}
+}
+
+// columnIndex переводит ссылку на ячейку вида "AB12" в номер столбца с нуля.
+// Столбцы правее XFD отклоняются.
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		n++
+		if col > MaxColumns {
+			return 0, fmt.Errorf("%w: column of cell %q is out of range", ErrInvalidFile, ref)
+		}
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("%w: bad cell reference %q", ErrInvalidFile, ref)
+	}
+	return col - 1, nil
+}
+
+// budget — остаток распакованных байт, общий для всех частей книги.
+type budget struct {
+	left int64
+}
+
+func (b *budget) decodeFile(f *zip.File, v any) error {
+	if f == nil {
+		return fmt.Errorf("%w: missing workbook part", ErrInvalidFile)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(&budgetReader{r: rc, b: b}).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidFile, f.Name, err)
+	}
+	return nil
+}
+
+var errUnpackedTooLarge = errors.New("unpacked workbook is too large")
+
+// budgetReader списывает прочитанные байты с общего остатка и возвращает
+// ошибку, как только распакованные части вместе его превысят.
+type budgetReader struct {
+	r io.Reader
+	b *budget
+}
+
+func (r *budgetReader) Read(p []byte) (int, error) {
+	// Байт сверх остатка читается, чтобы отличить часть, которая ровно
+	// укладывается в остаток, от части, которая его превышает.
+	if int64(len(p)) > r.b.left+1 {
+		p = p[:r.b.left+1]
+	}
+	n, err := r.r.Read(p)
+	if int64(n) > r.b.left {
+		r.b.left = -1
+		return 0, errUnpackedTooLarge
+	}
+	r.b.left -= int64(n)
+	return n, err
+}
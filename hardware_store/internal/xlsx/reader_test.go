@@ -0,0 +1,210 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// book собирает минимальную книгу с листом sheetData и общими строками
+// shared; части из skip в архив не попадают.
+func book(t *testing.T, sheetData string, shared []string, skip ...string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"[Content_Types].xml":        contentTypes,
+		"xl/workbook.xml":            strings.Replace(workbookXML, "%s", "Sheet1", 1),
+		"xl/_rels/workbook.xml.rels": workbookRels,
+		"xl/worksheets/sheet1.xml":   sheetStart + sheetData + sheetEnd,
+	}
+	if shared != nil {
+		var sst strings.Builder
+		sst.WriteString(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+		for _, s := range shared {
+			sst.WriteString("<si><t>" + s + "</t></si>")
+		}
+		sst.WriteString("</sst>")
+		parts["xl/sharedStrings.xml"] = sst.String()
+	}
+	for _, name := range skip {
+		delete(parts, name)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range parts {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		sheet  string
+		shared []string
+		want   [][]string
+	}{
+		{
+			name:   "shared and inline strings",
+			sheet:  `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="inlineStr"><is><t>Цена</t></is></c></row>`,
+			shared: []string{"Артикул"},
+			want:   [][]string{{"Артикул", "Цена"}},
+		},
+		{
+			name:  "numbers and booleans",
+			sheet: `<row r="1"><c r="A1"><v>19.99</v></c><c r="B1" t="b"><v>1</v></c><c r="C1" t="b"><v>0</v></c></row>`,
+			want:  [][]string{{"19.99", "TRUE", "FALSE"}},
+		},
+		{
+			name:  "gap between cells padded",
+			sheet: `<row r="1"><c r="A1"><v>1</v></c><c r="C1"><v>3</v></c></row>`,
+			want:  [][]string{{"1", "", "3"}},
+		},
+		{
+			name:  "skipped rows kept",
+			sheet: `<row r="1"><c r="A1"><v>1</v></c></row><row r="3"><c r="A3"><v>3</v></c></row>`,
+			want:  [][]string{{"1"}, nil, {"3"}},
+		},
+		{
+			name:  "cells without references",
+			sheet: `<row><c><v>1</v></c><c><v>2</v></c></row>`,
+			want:  [][]string{{"1", "2"}},
+		},
+		{
+			name:  "far empty cell not padded",
+			sheet: `<row r="1"><c r="A1"><v>1</v></c><c r="XFD1"/></row>`,
+			want:  [][]string{{"1"}},
+		},
+		{
+			name:  "last column",
+			sheet: `<row r="1"><c r="XFC1"><v>1</v></c></row>`,
+			want:  [][]string{append(make([]string, MaxColumns-2), "1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(book(t, tt.sheet, tt.shared), 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		maxRows int
+		wantErr error
+	}{
+		{name: "not a zip", data: []byte("name,price\n"), wantErr: ErrInvalidFile},
+		{
+			name:    "missing workbook",
+			data:    book(t, "", nil, "xl/workbook.xml"),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "missing sheet",
+			data:    book(t, "", nil, "xl/worksheets/sheet1.xml"),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "bad shared string index",
+			data:    book(t, `<row r="1"><c r="A1" t="s"><v>5</v></c></row>`, []string{"a"}),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "bad cell reference",
+			data:    book(t, `<row r="1"><c r="12"><v>1</v></c></row>`, nil),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "column beyond XFD",
+			data:    book(t, `<row r="1"><c r="XFE1"><v>1</v></c></row>`, nil),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "huge column",
+			data:    book(t, `<row r="1"><c r="ZZZZZZZZZZZZZZZZZZZZ1"><v>1</v></c></row>`, nil),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "row beyond sheet",
+			data:    book(t, `<row r="999999999"><c r="A999999999"><v>1</v></c></row>`, nil),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "row beyond limit",
+			data:    book(t, `<row r="1"><c r="A1"><v>1</v></c></row><row r="100"><c r="A100"><v>1</v></c></row>`, nil),
+			maxRows: 10,
+			wantErr: ErrTooManyRows,
+		},
+		{
+			name:    "too many rows",
+			data:    book(t, `<row><c><v>1</v></c></row><row><c><v>2</v></c></row><row><c><v>3</v></c></row>`, nil),
+			maxRows: 2,
+			wantErr: ErrTooManyRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.data, tt.maxRows)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadUnpackedSize(t *testing.T) {
+	padding := func(n int) string { return strings.Repeat(" ", n) }
+	row := `<row r="1"><c r="A1" t="s"><v>0</v></c></row>`
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "part within budget", data: book(t, row+padding(minUnpackedSize/2), []string{"a"})},
+		{name: "single part beyond budget", data: book(t, row+padding(minUnpackedSize), []string{"a"}), wantErr: ErrInvalidFile},
+		{
+			name:    "parts together beyond budget",
+			data:    book(t, row+padding(minUnpackedSize*2/3), []string{"a" + padding(minUnpackedSize*2/3)}),
+			wantErr: ErrInvalidFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Less(t, len(tt.data)*maxExpansion, minUnpackedSize, "the test book must compress well")
+			_, err := Read(tt.data, 0)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{"A1", 0},
+		{"Z7", 25},
+		{"AA1", 26},
+		{"AZ1", 51},
+		{"XFD1048576", MaxColumns - 1},
+	}
+	for _, tt := range tests {
+		got, err := columnIndex(tt.ref)
+		require.NoError(t, err, tt.ref)
+		assert.Equal(t, tt.want, got, tt.ref)
+	}
+}
@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, `Каталог <"A&B">`)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow("sku", "name", "price", "stock", "weight"))
+	require.NoError(t, w.WriteRow("A-1", `Молоток <5 кг> & "ручка"`, Number("75990.00"), 15, 1.25))
+	require.NoError(t, w.WriteRow("A-2", "", nil, int64(-3)))
+	require.NoError(t, w.WriteRow())
+	require.NoError(t, w.WriteRow("  пробелы  "))
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close(), "repeated close is a no-op")
+
+	got, err := Read(buf.Bytes(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"sku", "name", "price", "stock", "weight"},
+		{"A-1", `Молоток <5 кг> & "ручка"`, "75990.00", "15", "1.25"},
+		{"A-2", "", "", "-3"},
+		nil,
+		{"  пробелы  "},
+	}, got)
+}
+
+func TestWriterErrors(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Sheet1")
+	require.NoError(t, err)
+	assert.Error(t, w.WriteRow(true), "unsupported cell type")
+	require.NoError(t, w.Close())
+	assert.Error(t, w.WriteRow("late"), "write after close")
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{MaxColumns - 1, "XFD"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, columnName(tt.i), tt.i)
+		i, err := columnIndex(tt.want + "1")
+		require.NoError(t, err)
+		assert.Equal(t, tt.i, i, tt.want)
+	}
+}
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_movements (
+    movement_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    movement_type TEXT NOT NULL CHECK (
+        movement_type IN ('receipt', 'sale', 'return', 'adjustment', 'write_off')
+    ),
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    reason TEXT,
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS stock_movements_product_idx ON stock_movements (product_id, created_at, movement_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Текущие остатки переносятся в журнал как начальная корректировка,
+-- чтобы сверка сходилась для уже существующих товаров
+INSERT INTO stock_movements (movement_id, product_id, movement_type, quantity, reason)
+SELECT gen_random_uuid(), product_id, 'adjustment', available_stock, 'opening balance'
+FROM product
+WHERE available_stock > 0;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_movements;
+-- +goose StatementEnd
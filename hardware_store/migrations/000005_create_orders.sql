@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS orders (
+    order_id UUID PRIMARY KEY,
+    client_id UUID NOT NULL,
+    status TEXT NOT NULL,
+    total NUMERIC(12, 2) NOT NULL CHECK (total >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (client_id) REFERENCES client(client_id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Название и цена копируются в позицию, поэтому product_id может стать NULL
+-- после удаления товара без потери истории заказа
+CREATE TABLE IF NOT EXISTS order_items (
+    item_id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    product_id UUID,
+    name TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(10, 2) NOT NULL CHECK (unit_price >= 0),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS orders_client_idx ON orders (client_id, created_at, order_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS order_items_order_idx ON order_items (order_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_items;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS orders;
+-- +goose StatementEnd
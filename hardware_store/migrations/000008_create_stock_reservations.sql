@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- available_stock остаётся остатком на складе, reserved_stock — его часть,
+-- удерживаемая активными резервами
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS reserved_stock INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT product_reserved_stock_check CHECK (
+        reserved_stock >= 0
+        AND reserved_stock <= available_stock
+    );
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    reservation_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status TEXT NOT NULL CHECK (status IN ('active', 'confirmed', 'released', 'expired')),
+    reference TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS stock_reservations_active_idx ON stock_reservations (expires_at)
+WHERE status = 'active';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_reservations;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product
+    DROP CONSTRAINT IF EXISTS product_reserved_stock_check,
+    DROP COLUMN IF EXISTS reserved_stock;
+-- +goose StatementEnd
@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS warehouses (
+    warehouse_id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('warehouse', 'showroom')),
+    address_id UUID,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (address_id) REFERENCES address(address_id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS warehouses_default_idx ON warehouses (is_default) WHERE is_default;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Склад по умолчанию принимает движения без указания склада,
+-- в него переносятся текущие остатки
+INSERT INTO warehouses (warehouse_id, name, kind, is_default)
+VALUES (gen_random_uuid(), 'Основной склад', 'warehouse', TRUE);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- product.available_stock остаётся суммой остатков по всем складам
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    PRIMARY KEY (warehouse_id, product_id),
+    FOREIGN KEY (warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS warehouse_stock_product_idx ON warehouse_stock (product_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT w.warehouse_id, p.product_id, p.available_stock
+FROM product p
+CROSS JOIN warehouses w
+WHERE w.is_default AND p.available_stock > 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_id UUID
+REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT;
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE stock_movements
+SET warehouse_id = (SELECT warehouse_id FROM warehouses WHERE is_default);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_movements ALTER COLUMN warehouse_id SET NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    transfer_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    from_warehouse_id UUID NOT NULL,
+    to_warehouse_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (from_warehouse_id <> to_warehouse_id),
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (from_warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT,
+    FOREIGN KEY (to_warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_transfers;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS warehouse_id;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS warehouse_stock;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS warehouses;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Точка заказа товара: при остатке ниже reorder_point создаётся оповещение,
+-- reorder_quantity — рекомендуемый объём закупки. Значения категории
+-- используются для товаров, у которых собственные не заданы
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS reorder_point INTEGER CHECK (reorder_point >= 0),
+    ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER CHECK (reorder_quantity > 0);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE category
+    ADD COLUMN IF NOT EXISTS reorder_point INTEGER CHECK (reorder_point >= 0),
+    ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER CHECK (reorder_quantity > 0);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    alert_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    supplier_id UUID,
+    stock INTEGER NOT NULL,
+    reorder_point INTEGER NOT NULL,
+    suggested_quantity INTEGER NOT NULL CHECK (suggested_quantity > 0),
+    status TEXT NOT NULL CHECK (status IN ('open', 'resolved')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (supplier_id) REFERENCES supplier(supplier_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- По товару может быть только одно открытое оповещение
+CREATE UNIQUE INDEX IF NOT EXISTS stock_alerts_open_idx ON stock_alerts (product_id)
+WHERE status = 'open';
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS stock_alerts_status_created_idx ON stock_alerts (status, created_at, alert_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_alerts;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE category
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point;
+-- +goose StatementEnd
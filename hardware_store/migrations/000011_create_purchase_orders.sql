@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    purchase_order_id UUID PRIMARY KEY,
+    supplier_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    status TEXT NOT NULL CHECK (
+        status IN ('draft', 'sent', 'partially_received', 'received', 'cancelled')
+    ),
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (supplier_id) REFERENCES supplier(supplier_id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    FOREIGN KEY (warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- received_quantity накапливается по всем приёмкам и может превышать quantity
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    purchase_order_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    expected_price NUMERIC(10, 2) NOT NULL CHECK (expected_price >= 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    PRIMARY KEY (purchase_order_id, product_id),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(purchase_order_id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    receipt_id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(purchase_order_id) ON DELETE CASCADE,
+    FOREIGN KEY (warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- expected_quantity — сколько оставалось получить по позиции до приёмки,
+-- discrepancy — расхождение принятого количества с ожидаемым
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    receipt_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    expected_quantity INTEGER NOT NULL CHECK (expected_quantity >= 0),
+    received_quantity INTEGER NOT NULL CHECK (received_quantity >= 0),
+    discrepancy INTEGER GENERATED ALWAYS AS (received_quantity - expected_quantity) STORED,
+    PRIMARY KEY (receipt_id, product_id),
+    FOREIGN KEY (receipt_id) REFERENCES goods_receipts(receipt_id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS purchase_orders_supplier_idx ON purchase_orders (supplier_id, created_at, purchase_order_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS goods_receipts_order_idx ON goods_receipts (purchase_order_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS goods_receipt_lines;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS goods_receipts;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS purchase_order_lines;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS purchase_orders;
+-- +goose StatementEnd
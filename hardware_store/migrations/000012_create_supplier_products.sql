@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Прайс-лист поставщика: товар может закупаться у нескольких поставщиков.
+-- product.supplier_id остаётся основным поставщиком товара
+CREATE TABLE IF NOT EXISTS supplier_products (
+    supplier_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    supplier_sku TEXT,
+    purchase_price NUMERIC(12, 2) NOT NULL CHECK (purchase_price >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    min_order_quantity INTEGER NOT NULL DEFAULT 1 CHECK (min_order_quantity > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (supplier_id, product_id),
+    FOREIGN KEY (supplier_id) REFERENCES supplier(supplier_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS supplier_products_sku_idx ON supplier_products (supplier_id, supplier_sku)
+WHERE supplier_sku IS NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS supplier_products_product_idx ON supplier_products (product_id, purchase_price, lead_time_days);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS supplier_products;
+-- +goose StatementEnd
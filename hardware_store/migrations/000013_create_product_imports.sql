@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE product ADD COLUMN IF NOT EXISTS sku TEXT;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS product_sku_idx ON product (sku) WHERE sku IS NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- payload хранит загруженный файл до окончания обработки и затем очищается
+CREATE TABLE IF NOT EXISTS product_imports (
+    import_id UUID PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    format TEXT NOT NULL CHECK (format IN ('csv', 'xlsx')),
+    file_name TEXT NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    upsert BOOLEAN NOT NULL DEFAULT FALSE,
+    mapping TEXT,
+    payload BYTEA,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    updated_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS product_imports_pending_idx ON product_imports (created_at) WHERE status = 'pending';
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_import_errors (
+    import_id UUID NOT NULL,
+    row_number INTEGER NOT NULL,
+    field TEXT,
+    message TEXT NOT NULL,
+    FOREIGN KEY (import_id) REFERENCES product_imports(import_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS product_import_errors_import_idx ON product_import_errors (import_id, row_number);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_import_errors;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_imports;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP INDEX IF EXISTS product_sku_idx;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product DROP COLUMN IF EXISTS sku;
+-- +goose StatementEnd
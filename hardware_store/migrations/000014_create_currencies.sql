@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Валюты, в которых заданы цены. decimals и rounding определяют, как
+-- округляется сумма после пересчёта в валюту
+CREATE TABLE IF NOT EXISTS currencies (
+    code CHAR(3) PRIMARY KEY CHECK (code ~ '^[A-Z]{3}$'),
+    name TEXT NOT NULL,
+    decimals SMALLINT NOT NULL DEFAULT 2 CHECK (decimals BETWEEN 0 AND 2),
+    rounding TEXT NOT NULL DEFAULT 'half_up' CHECK (rounding IN ('half_up', 'down', 'up'))
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT INTO currencies (code, name) VALUES
+    ('RUB', 'Российский рубль'),
+    ('USD', 'Доллар США'),
+    ('EUR', 'Евро'),
+    ('CNY', 'Китайский юань')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT INTO currencies (code, name)
+SELECT DISTINCT currency, currency FROM supplier_products
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE supplier_products ADD CONSTRAINT supplier_products_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Курс — сколько рублей стоит единица валюты. Курс действует с effective_date
+-- до следующей записи по той же валюте
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    rate_id UUID PRIMARY KEY,
+    currency_code CHAR(3) NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    effective_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (currency_code, effective_date),
+    FOREIGN KEY (currency_code) REFERENCES currencies(code) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exchange_rates;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE supplier_products DROP CONSTRAINT IF EXISTS supplier_products_currency_fkey;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS currencies;
+-- +goose StatementEnd
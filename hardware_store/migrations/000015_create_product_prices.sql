@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История цен товара. Цена действует с valid_from до valid_to; у текущей цены
+-- valid_to пуст. Запланированная цена ждёт valid_from с пустым applied_at и
+-- становится текущей, когда её применит фоновая задача
+CREATE TABLE IF NOT EXISTS product_prices (
+    price_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    price NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    reason TEXT,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMPTZ,
+    CHECK (valid_to IS NULL OR valid_to >= valid_from),
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS product_prices_product_idx ON product_prices (product_id, valid_from DESC);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS product_prices_scheduled_idx ON product_prices (product_id, valid_from)
+WHERE applied_at IS NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS product_prices_due_idx ON product_prices (valid_from) WHERE applied_at IS NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Текущие цены становятся началом истории
+INSERT INTO product_prices (price_id, product_id, price, valid_from, reason, applied_at)
+SELECT gen_random_uuid(), product_id, price, last_update_date, 'initial price', NOW()
+FROM product;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_prices;
+-- +goose StatementEnd
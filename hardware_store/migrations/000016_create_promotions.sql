@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Акция даёт скидку на товары категории или поставщика, а без них — на все
+-- товары. percent хранится в сотых долях процента: 1000 — 10%. Акция с кодом
+-- применяется только по промокоду, без кода — ко всем заказам. Лимиты
+-- считают погашения неотменённых заказов
+CREATE TABLE IF NOT EXISTS promotions (
+    promotion_id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('percent', 'fixed', 'buy_x_get_y')),
+    percent INTEGER CHECK (percent > 0 AND percent <= 10000),
+    amount NUMERIC(10, 2) CHECK (amount > 0),
+    buy_quantity INTEGER CHECK (buy_quantity > 0),
+    get_quantity INTEGER CHECK (get_quantity > 0),
+    category_id UUID,
+    supplier_id UUID,
+    code TEXT UNIQUE,
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    per_client_limit INTEGER CHECK (per_client_limit > 0),
+    valid_from TIMESTAMPTZ,
+    valid_to TIMESTAMPTZ,
+    stackable BOOLEAN NOT NULL DEFAULT TRUE,
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from),
+    FOREIGN KEY (category_id) REFERENCES category(category_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (supplier_id) REFERENCES supplier(supplier_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS promotions_created_idx ON promotions (created_at, promotion_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Погашение акции заказом: сколько скидки акция дала заказу
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    promotion_id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    client_id UUID NOT NULL,
+    amount NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (promotion_id, order_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS promotion_redemptions_client_idx ON promotion_redemptions (promotion_id, client_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS discount NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (discount >= 0);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS promotion_redemptions;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS promotions;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ставки налога хранятся в сотых долях процента: 2000 — 20%. Ставка товара
+-- переопределяет ставку категории; без обеих действует ставка по умолчанию
+-- из настроек
+ALTER TABLE category ADD COLUMN IF NOT EXISTS tax_rate INTEGER CHECK (tax_rate >= 0 AND tax_rate <= 10000);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product ADD COLUMN IF NOT EXISTS tax_rate INTEGER CHECK (tax_rate >= 0 AND tax_rate <= 10000);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Режим цен и ставки фиксируются при оформлении заказа, чтобы смена настроек
+-- не меняла налог в уже оформленных заказах. Старые заказы считаются
+-- оформленными по ценам с налогом по ставке 0%
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_mode TEXT NOT NULL DEFAULT 'inclusive'
+    CHECK (tax_mode IN ('inclusive', 'exclusive'));
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_rate INTEGER NOT NULL DEFAULT 0
+    CHECK (tax_rate >= 0 AND tax_rate <= 10000);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_rate;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_mode;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product DROP COLUMN IF EXISTS tax_rate;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE category DROP COLUMN IF EXISTS tax_rate;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Последний выданный номер документа вида kind за год. Номер берётся в той
+-- же транзакции, что и запись документа, поэтому откат не оставляет пропусков
+CREATE TABLE IF NOT EXISTS invoice_counters (
+    kind TEXT NOT NULL CHECK (kind IN ('invoice', 'receipt')),
+    year INTEGER NOT NULL,
+    last_number INTEGER NOT NULL CHECK (last_number > 0),
+    PRIMARY KEY (kind, year)
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Выпущенный документ по заказу: счёт на оплату или квитанция об оплате.
+-- document хранит PDF для повторной печати без изменений
+CREATE TABLE IF NOT EXISTS invoices (
+    invoice_id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('invoice', 'receipt')),
+    year INTEGER NOT NULL,
+    number INTEGER NOT NULL CHECK (number > 0),
+    total NUMERIC(12, 2) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    document BYTEA NOT NULL,
+    UNIQUE (order_id, kind),
+    UNIQUE (kind, year, number),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invoices;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invoice_counters;
+-- +goose StatementEnd
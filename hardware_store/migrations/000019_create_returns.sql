@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Возврат поставщику уменьшает остаток: товар, принятый от покупателя,
+-- уходит со склада обратно поставщику
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_movement_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_movement_type_check CHECK (
+    movement_type IN ('receipt', 'sale', 'return', 'adjustment', 'write_off', 'supplier_return')
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- returned_quantity — сколько единиц позиции уже вернулось по заявкам на
+-- возврат; при возврате всего заказа на склад приходуется только остаток
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS returned_quantity INTEGER NOT NULL DEFAULT 0
+    CHECK (returned_quantity >= 0 AND returned_quantity <= quantity);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS returns (
+    return_id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    client_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    status TEXT NOT NULL CHECK (
+        status IN ('requested', 'approved', 'rejected', 'received', 'inspected', 'refunded', 'cancelled')
+    ),
+    note TEXT,
+    refund NUMERIC(12, 2) NOT NULL CHECK (refund >= 0),
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    refunded_at TIMESTAMPTZ,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE RESTRICT,
+    FOREIGN KEY (client_id) REFERENCES client(client_id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    FOREIGN KEY (warehouse_id) REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- outcome заполняется при осмотре товара, refund — доля оплаченной суммы
+-- позиции заказа за возвращаемое количество
+CREATE TABLE IF NOT EXISTS return_lines (
+    return_id UUID NOT NULL,
+    item_id UUID NOT NULL,
+    product_id UUID,
+    name TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    reason TEXT NOT NULL CHECK (
+        reason IN ('defective', 'damaged', 'wrong_item', 'not_as_described', 'changed_mind', 'other')
+    ),
+    comment TEXT,
+    outcome TEXT CHECK (outcome IN ('restock', 'write_off', 'send_to_supplier')),
+    refund NUMERIC(12, 2) NOT NULL CHECK (refund >= 0),
+    PRIMARY KEY (return_id, item_id),
+    FOREIGN KEY (return_id) REFERENCES returns(return_id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES order_items(item_id) ON DELETE RESTRICT,
+    FOREIGN KEY (product_id) REFERENCES product(product_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS returns_order_idx ON returns (order_id);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS returns_status_idx ON returns (status, created_at, return_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS return_lines;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS returns;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN IF EXISTS returned_quantity;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_movement_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_movement_type_check CHECK (
+    movement_type IN ('receipt', 'sale', 'return', 'adjustment', 'write_off')
+);
+-- +goose StatementEnd
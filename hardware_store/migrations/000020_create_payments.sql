@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+-- external_id — идентификатор платежа в шлюзе provider; refunded — сумма,
+-- уже возвращённая покупателю
+CREATE TABLE IF NOT EXISTS payments (
+    payment_id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (
+        status IN ('pending', 'authorized', 'captured', 'failed', 'cancelled')
+    ),
+    amount NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    refunded NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (refunded >= 0 AND refunded <= amount),
+    confirmation_url TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, external_id),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS payments_order_idx ON payments (order_id, created_at);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Незавершённые платежи сверяются со шлюзом при запуске приложения
+CREATE INDEX IF NOT EXISTS payments_open_idx ON payments (provider, created_at)
+    WHERE status IN ('pending', 'authorized');
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    refund_id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL,
+    return_id UUID,
+    amount NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (payment_id) REFERENCES payments(payment_id) ON DELETE CASCADE,
+    FOREIGN KEY (return_id) REFERENCES returns(return_id) ON DELETE SET NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS payment_refunds_payment_idx ON payment_refunds (payment_id, created_at);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Обработанные уведомления шлюзов: повторная доставка того же события
+-- пропускается
+CREATE TABLE IF NOT EXISTS payment_events (
+    provider TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payment_events;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payment_refunds;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payments;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- user_id — пользователь auth-service, которому принадлежит клиент. Пусто у
+-- клиентов, заведённых сотрудниками магазина
+ALTER TABLE client
+    ADD COLUMN IF NOT EXISTS user_id UUID,
+    ADD CONSTRAINT client_user_id_key UNIQUE (user_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE client DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
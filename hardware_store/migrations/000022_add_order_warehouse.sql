@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- warehouse_id — склад, с которого списан товар заказа или резерва; на него
+-- же товар возвращается при отмене. Существующие записи относятся к складу
+-- по умолчанию, через который до сих пор проходили все продажи
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS warehouse_id UUID
+REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT;
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE orders
+SET warehouse_id = (SELECT warehouse_id FROM warehouses WHERE is_default);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE orders ALTER COLUMN warehouse_id SET NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS warehouse_id UUID
+REFERENCES warehouses(warehouse_id) ON DELETE RESTRICT;
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE stock_reservations
+SET warehouse_id = (SELECT warehouse_id FROM warehouses WHERE is_default);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE stock_reservations ALTER COLUMN warehouse_id SET NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stock_reservations DROP COLUMN IF EXISTS warehouse_id;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS warehouse_id;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- notify_claimed_until — до какого момента пачка оповещений закреплена за
+-- отправителем. Отправка идёт вне транзакции, и если отправитель упал, не
+-- отметив оповещения, после этого момента их заберёт следующая сверка
+ALTER TABLE stock_alerts ADD COLUMN IF NOT EXISTS notify_claimed_until TIMESTAMPTZ;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stock_alerts DROP COLUMN IF EXISTS notify_claimed_until;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Валюта цены товара. Пока каталог, корзины и заказы считаются в рублях,
+-- поэтому API записывает только RUB; в другие валюты цены пересчитываются
+-- при выдаче по параметру currency
+ALTER TABLE product ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product ADD CONSTRAINT product_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product_prices ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product_prices ADD CONSTRAINT product_prices_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE product_prices DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- reserved — часть остатка склада, удерживаемая активными резервами на этом
+-- складе; продать или переместить со склада можно только quantity - reserved
+ALTER TABLE warehouse_stock ADD COLUMN IF NOT EXISTS reserved INTEGER NOT NULL DEFAULT 0
+CHECK (reserved >= 0);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Резервы, созданные до появления колонки, переносятся на их склады. Если на
+-- складе резерва нет строки остатка, она создаётся с нулевым количеством:
+-- такой склад не сможет отгрузить товар, пока резерв не будет снят
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity, reserved)
+SELECT warehouse_id, product_id, 0, SUM(quantity)
+FROM stock_reservations
+WHERE status = 'active'
+GROUP BY warehouse_id, product_id
+ON CONFLICT (warehouse_id, product_id) DO UPDATE SET reserved = EXCLUDED.reserved;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE warehouse_stock DROP COLUMN IF EXISTS reserved;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Цены каталога всегда хранятся в рублях (DefaultCurrency): API принимает их
+-- только в рублях и пересчитывает в другие валюты при выдаче, так что столбец
+-- валюты ничего не хранил
+ALTER TABLE product_prices DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE product ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product ADD CONSTRAINT product_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product_prices ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE product_prices ADD CONSTRAINT product_prices_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Номер документа выдаётся и запись сохраняется короткой транзакцией, а PDF
+-- набирается после неё, чтобы не держать блокировку счётчика номеров.
+-- Пока PDF не записан, document пуст
+ALTER TABLE invoices ALTER COLUMN document DROP NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM invoices WHERE document IS NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE invoices ALTER COLUMN document SET NOT NULL;
+-- +goose StatementEnd
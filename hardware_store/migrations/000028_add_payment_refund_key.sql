@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- idempotency_key — ключ повторного запроса возврата в пределах платежа.
+-- Возврат записывается в статусе pending до запроса к шлюзу и становится
+-- succeeded, когда шлюз вернул деньги
+ALTER TABLE payment_refunds
+    ADD COLUMN IF NOT EXISTS idempotency_key TEXT,
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'succeeded' CHECK (status IN ('pending', 'succeeded'));
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE payment_refunds SET idempotency_key = 'refund-' || refund_id WHERE idempotency_key IS NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE payment_refunds ALTER COLUMN idempotency_key SET NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE payment_refunds
+    ADD CONSTRAINT payment_refunds_key_unique UNIQUE (payment_id, idempotency_key);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Неподтверждённые возвраты повторяются при сверке со шлюзом
+CREATE INDEX IF NOT EXISTS payment_refunds_pending_idx ON payment_refunds (created_at)
+    WHERE status = 'pending';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS payment_refunds_pending_idx;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM payment_refunds WHERE status = 'pending';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE payment_refunds
+    DROP CONSTRAINT IF EXISTS payment_refunds_key_unique,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS idempotency_key;
+-- +goose StatementEnd